@@ -0,0 +1,65 @@
+// File: examples/arity_test.go
+package examples
+
+import (
+	"strings"
+	"testing"
+
+	"arcsyn.io/propx"
+)
+
+// TestForAll3AdditionAssociativity demonstrates how to use ForAll3 to test
+// properties that involve three parameters, without a pair of pairs.
+func TestForAll3AdditionAssociativity(t *testing.T) {
+	small := propx.Int(propx.Size{Min: -100, Max: 100})
+
+	propx.ForAll3(t, propx.Default(), small, small, small)(func(t *testing.T, a, b, c int) {
+		// Property: (a + b) + c == a + (b + c) (associativity)
+		if (a+b)+c != a+(b+c) {
+			t.Errorf("addition is not associative for (%d, %d, %d): (%d + %d) + %d = %d, but %d + (%d + %d) = %d",
+				a, b, c, a, b, c, (a+b)+c, a, b, c, a+(b+c))
+		}
+	})
+}
+
+// TestForAll3MixedTypes demonstrates ForAll3 with arguments of different types.
+func TestForAll3MixedTypes(t *testing.T) {
+	propx.ForAll3(t, propx.Default(),
+		propx.StringAlpha(propx.Size{Min: 0, Max: 10}),
+		propx.IntRange(0, 5),
+		propx.Bool(),
+	)(func(t *testing.T, s string, n int, upper bool) {
+		// Property: repeating a string n times multiplies its length by n,
+		// whatever its case
+		if upper {
+			s = strings.ToUpper(s)
+		}
+		if got := strings.Repeat(s, n); len(got) != n*len(s) {
+			t.Errorf("strings.Repeat(%q, %d) has length %d, expected %d", s, n, len(got), n*len(s))
+		}
+	})
+}
+
+// TestForAll4StringConcatenation demonstrates ForAll4 with four strings.
+func TestForAll4StringConcatenation(t *testing.T) {
+	word := propx.StringAlpha(propx.Size{Min: 1, Max: 10})
+
+	propx.ForAll4(t, propx.Default(), word, word, word, word)(func(t *testing.T, a, b, c, d string) {
+		// Property: concatenation length is the sum of individual lengths
+		if got := len(a + b + c + d); got != len(a)+len(b)+len(c)+len(d) {
+			t.Errorf("concatenation length mismatch for (%q, %q, %q, %q): got length %d", a, b, c, d, got)
+		}
+	})
+}
+
+// TestForAll5BooleanLogic demonstrates ForAll5 with five booleans.
+func TestForAll5BooleanLogic(t *testing.T) {
+	b := propx.Bool()
+
+	propx.ForAll5(t, propx.Default(), b, b, b, b, b)(func(t *testing.T, p, q, r, s, u bool) {
+		// Property: De Morgan's law over five operands
+		if !(p && q && r && s && u) != (!p || !q || !r || !s || !u) {
+			t.Errorf("De Morgan's law fails for (%t, %t, %t, %t, %t)", p, q, r, s, u)
+		}
+	})
+}
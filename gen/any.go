@@ -0,0 +1,33 @@
+// File: gen/any.go
+package gen
+
+import "math/rand"
+
+// ToAny lifts a typed generator into a Generator[any] (type erasure).
+// Values and shrink candidates are the same as g's, boxed as interface{}.
+//
+// Example:
+//
+//	values := gen.AnyOf(gen.ToAny(gen.Int(gen.Size{})), gen.ToAny(gen.Bool()))
+func ToAny[T any](g Generator[T]) Generator[any] {
+	return From(func(r *rand.Rand, sz Size) (any, Shrinker[any]) {
+		v, s := g.Generate(r, sz)
+		return v, func(accept bool) (any, bool) {
+			if s == nil {
+				return nil, false
+			}
+			nv, ok := s(accept)
+			if !ok {
+				return nil, false
+			}
+			return nv, true
+		}
+	})
+}
+
+// AnyOf chooses uniformly among type-erased generators, allowing heterogeneous
+// values (e.g. ints and strings) in a single generator.
+// Shrinking is delegated to the generator that produced the value.
+func AnyOf(gs ...Generator[any]) Generator[any] {
+	return OneOf(gs...)
+}
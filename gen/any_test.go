@@ -0,0 +1,133 @@
+package gen
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestToAny(t *testing.T) {
+	g := ToAny(IntRange(1, 100))
+	r := rand.New(rand.NewSource(123))
+
+	value, shrink := g.Generate(r, Size{})
+
+	v, ok := value.(int)
+	if !ok {
+		t.Fatalf("ToAny().Generate() = %T, expected int", value)
+	}
+	if v < 1 || v > 100 {
+		t.Errorf("ToAny().Generate() = %d, expected value in range [1, 100]", v)
+	}
+
+	// shrinking is preserved through the type erasure
+	next, ok := shrink(false)
+	if !ok {
+		t.Fatal("ToAny() shrinker returned false on first call")
+	}
+	if _, isInt := next.(int); !isInt {
+		t.Errorf("ToAny() shrink candidate = %T, expected int", next)
+	}
+}
+
+func TestToAny_NonShrinking(t *testing.T) {
+	g := ToAny(Const("fixed"))
+	r := rand.New(rand.NewSource(123))
+
+	value, shrink := g.Generate(r, Size{})
+	if value != "fixed" {
+		t.Errorf("ToAny(Const()).Generate() = %v, expected 'fixed'", value)
+	}
+	if _, ok := shrink(true); ok {
+		t.Error("ToAny(Const()) shrinker should not propose candidates")
+	}
+}
+
+func TestAnyOf(t *testing.T) {
+	g := AnyOf(ToAny(IntRange(0, 10)), ToAny(StringAlpha(Size{Min: 1, Max: 5})), ToAny(Bool()))
+	r := rand.New(rand.NewSource(123))
+
+	kinds := map[string]bool{}
+	for i := 0; i < 100; i++ {
+		value, shrink := g.Generate(r, Size{})
+		switch value.(type) {
+		case int:
+			kinds["int"] = true
+		case string:
+			kinds["string"] = true
+		case bool:
+			kinds["bool"] = true
+		default:
+			t.Fatalf("AnyOf().Generate() = %T, expected int, string or bool", value)
+		}
+		if shrink == nil {
+			t.Fatal("AnyOf().Generate() returned nil shrinker")
+		}
+	}
+	if len(kinds) != 3 {
+		t.Errorf("AnyOf() produced kinds %v, expected all three", kinds)
+	}
+}
+
+func TestAnyOf_ShrinksTowardSimplestBranch(t *testing.T) {
+	SetShrinkStrategy(ShrinkStrategyBFS)
+	g := AnyOf(ToAny(IntRange(0, 100)), ToAny(StringAlpha(Size{Min: 1, Max: 10})))
+
+	// find a seed whose value comes from the string branch
+	var (
+		start  any
+		shrink Shrinker[any]
+	)
+	for seed := int64(0); ; seed++ {
+		start, shrink = g.Generate(rand.New(rand.NewSource(seed)), Size{})
+		if _, ok := start.(string); ok {
+			break
+		}
+	}
+
+	// property fails for every value: the minimum must be the int branch's 0
+	min := start
+	for i := 0; i < 1000; i++ {
+		next, ok := shrink(true)
+		if !ok {
+			break
+		}
+		min = next
+	}
+	if min != 0 {
+		t.Errorf("AnyOf() shrunk %#v to %#v, expected int 0", start, min)
+	}
+}
+
+func TestOneOf_ShrinksWithinBranch(t *testing.T) {
+	SetShrinkStrategy(ShrinkStrategyBFS)
+	g := OneOf(IntRange(10, 20), IntRange(1000, 2000))
+
+	var (
+		start  int
+		shrink Shrinker[int]
+	)
+	for seed := int64(0); ; seed++ {
+		start, shrink = g.Generate(rand.New(rand.NewSource(seed)), Size{})
+		if start >= 1000 {
+			break
+		}
+	}
+
+	// property fails only for values >= 1000: the simpler branch is rejected and
+	// shrinking continues within the chosen branch toward its lower bound
+	min := start
+	accept := true
+	for i := 0; i < 1000; i++ {
+		next, ok := shrink(accept)
+		if !ok {
+			break
+		}
+		accept = next >= 1000
+		if accept {
+			min = next
+		}
+	}
+	if min != 1000 {
+		t.Errorf("OneOf() shrunk %d to %d, expected 1000", start, min)
+	}
+}
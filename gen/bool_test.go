@@ -178,7 +178,7 @@ func boolShrinkInit(start bool) (bool, Shrinker[bool]) {
 		if len(queue) == 0 {
 			return false, false
 		}
-		if shrinkStrategy == "dfs" {
+		if GetShrinkStrategy() == "dfs" {
 			v := queue[len(queue)-1]
 			queue = queue[:len(queue)-1]
 			return v, true
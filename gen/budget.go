@@ -0,0 +1,65 @@
+// File: gen/budget.go
+package gen
+
+import "fmt"
+
+// Budget caps the total number of elements generated for one example across
+// nested collections (slices of slices of strings, ...). It travels in
+// Size.Budget: collection generators spend their length on it and pass it on
+// to their element generators, so a single example cannot allocate without
+// bound. A nil *Budget is unlimited.
+type Budget struct {
+	limit   int
+	used    int
+	stopped bool
+}
+
+// NewBudget returns a budget allowing limit elements; limit <= 0 returns nil
+// (unlimited).
+func NewBudget(limit int) *Budget {
+	if limit <= 0 {
+		return nil
+	}
+	return &Budget{limit: limit}
+}
+
+// BudgetExceededError is the panic value raised by Spend when a budget is
+// exhausted.
+type BudgetExceededError struct {
+	Limit int
+	Used  int
+}
+
+// Error implements error.
+func (e *BudgetExceededError) Error() string {
+	return fmt.Sprintf("generated size budget exceeded: %d elements requested, limit %d", e.Used, e.Limit)
+}
+
+// Spend charges n elements to the budget and panics with a
+// *BudgetExceededError when the limit is exceeded, before the caller
+// allocates them. It is a no-op on a nil or stopped budget.
+func (b *Budget) Spend(n int) {
+	if b == nil || b.stopped {
+		return
+	}
+	b.used += n
+	if b.used > b.limit {
+		panic(&BudgetExceededError{Limit: b.limit, Used: b.used})
+	}
+}
+
+// Used returns the number of elements charged so far.
+func (b *Budget) Used() int {
+	if b == nil {
+		return 0
+	}
+	return b.used
+}
+
+// Stop disables further charges. Runners stop the budget once the example is
+// generated, so values regenerated while shrinking are not charged again.
+func (b *Budget) Stop() {
+	if b != nil {
+		b.stopped = true
+	}
+}
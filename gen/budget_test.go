@@ -0,0 +1,57 @@
+package gen
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+func TestBudget_Spend(t *testing.T) {
+	b := NewBudget(10)
+	b.Spend(4)
+	b.Spend(6)
+	if b.Used() != 10 {
+		t.Errorf("Used() = %d, expected 10", b.Used())
+	}
+
+	defer func() {
+		p := recover()
+		var be *BudgetExceededError
+		if err, ok := p.(error); !ok || !errors.As(err, &be) || be.Limit != 10 || be.Used != 11 {
+			t.Errorf("Spend() over the limit panicked with %v, expected *BudgetExceededError{10, 11}", p)
+		}
+	}()
+	b.Spend(1)
+}
+
+func TestBudget_NilAndStopped(t *testing.T) {
+	if NewBudget(0) != nil {
+		t.Error("NewBudget(0) should be nil (unlimited)")
+	}
+	var nilBudget *Budget
+	nilBudget.Spend(1 << 30)
+	nilBudget.Stop()
+
+	b := NewBudget(1)
+	b.Stop()
+	b.Spend(100) // must not panic
+}
+
+func TestBudget_NestedCollections(t *testing.T) {
+	g := SliceOf(SliceOf(StringAlpha(Size{Min: 50, Max: 50}), Size{Min: 10, Max: 10}), Size{Min: 10, Max: 10})
+	r := rand.New(rand.NewSource(1))
+
+	// 10 + 10*10 + 100*50 = 5110 elements
+	b := NewBudget(10_000)
+	g.Generate(r, Size{Budget: b})
+	if b.Used() != 5110 {
+		t.Errorf("Used() = %d, expected 5110", b.Used())
+	}
+
+	defer func() {
+		if _, ok := recover().(*BudgetExceededError); !ok {
+			t.Error("nested generation over the budget did not panic with *BudgetExceededError")
+		}
+	}()
+	g.Generate(r, Size{Budget: NewBudget(1000)})
+}
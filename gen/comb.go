@@ -9,13 +9,28 @@ import (
 // Basic helpers
 // -------------------------
 
-// Const always returns the same value (without shrinking).
+// Const always returns the same value (without shrinking): its shrinker is
+// never nil and always reports ok=false, returning v itself.
 func Const[T any](v T) Generator[T] {
 	return From(func(_ *rand.Rand, _ Size) (T, Shrinker[T]) {
-		return v, func(bool) (T, bool) { var z T; return z, false }
+		return v, noShrink(v)
 	})
 }
 
+// noShrink returns a shrinker without candidates: it always returns (v, false).
+func noShrink[T any](v T) Shrinker[T] {
+	return func(bool) (T, bool) { return v, false }
+}
+
+// orNoShrink returns s, or a shrinker without candidates when s is nil, so
+// combinators can drive sub-shrinkers without nil checks.
+func orNoShrink[T any](s Shrinker[T], v T) Shrinker[T] {
+	if s == nil {
+		return noShrink(v)
+	}
+	return s
+}
+
 // OneOf chooses uniformly from one of the generators.
 func OneOf[T any](gs ...Generator[T]) Generator[T] {
 	return Weighted(func(_ T) float64 { return 1.0 }, gs...)
@@ -23,8 +38,11 @@ func OneOf[T any](gs ...Generator[T]) Generator[T] {
 
 // Weighted chooses a generator based on dynamic weights (by value).
 // The strategy here captures which index was selected to be able to "shrink"
-// reusing the shrinker of the chosen generator. Optionally, in shrinking
-// it also tries to migrate to neighbors (other indices) — controlled by `tryNeighbors`.
+// reusing the shrinker of the chosen generator. Shrinking first tries the
+// "simpler" generators (those earlier in the list, first to last) by drawing a
+// fresh value from each; when one still fails it becomes the current branch.
+// Then it shrinks within the current branch. This lets a union of different
+// shapes (e.g. ints and strings, via AnyOf) minimize toward the first branch.
 func Weighted[T any](weight func(T) float64, gs ...Generator[T]) Generator[T] {
 	if len(gs) == 0 {
 		panic("gen.Weighted: needs at least one generator")
@@ -37,43 +55,37 @@ func Weighted[T any](weight func(T) float64, gs ...Generator[T]) Generator[T] {
 		idx := r.Intn(len(gs))
 		val, shrink := gs[idx].Generate(r, sz)
 
-		// queue of neighbors (other generators) to try during shrinking
-		neighbors := make([]int, 0, len(gs)-1)
-		for i := range gs {
-			if i != idx {
-				neighbors = append(neighbors, i)
-			}
-		}
+		// values drawn while shrinking come from a private source, so shrinking
+		// never touches r (which may be shared with other examples)
+		sr := rand.New(rand.NewSource(r.Int63())) // #nosec G404 -- Using math/rand for deterministic property-based testing
+
+		simpler := 0    // next earlier branch to try (always < idx)
+		migrating := -1 // branch of the last proposed migration, if any
+		var migShrink Shrinker[T]
 
 		return val, func(accept bool) (T, bool) {
-			// if the last candidate was accepted (failed), we continue shrinking the same generator
-			if accept {
-				if next, ok := shrink(true); ok {
-					return next, true
-				}
-				// exhausted internal shrink → try to migrate to a neighbor
-				for len(neighbors) > 0 {
-					j := neighbors[0]
-					neighbors = neighbors[1:]
-					nv, ns := gs[j].Generate(r, sz)
-					// update "context" for the new generator
-					idx, val, shrink = j, nv, ns
-					return val, true
+			// verdict on a migration: adopt the branch if it still fails
+			if migrating >= 0 {
+				if accept {
+					idx, shrink = migrating, migShrink
+					simpler = 0
 				}
-				var z T
-				return z, false
+				migrating = -1
+				accept = false
 			}
-			// candidate was rejected → try another from the same shrinker
-			if next, ok := shrink(false); ok {
-				return next, true
+			// (1) simpler branches first
+			if simpler < idx {
+				j := simpler
+				simpler++
+				nv, ns := gs[j].Generate(sr, sz)
+				migrating, migShrink = j, ns
+				return nv, true
 			}
-			// or migrate to a neighbor
-			for len(neighbors) > 0 {
-				j := neighbors[0]
-				neighbors = neighbors[1:]
-				nv, ns := gs[j].Generate(r, sz)
-				idx, val, shrink = j, nv, ns
-				return val, true
+			// (2) shrink within the current branch
+			if shrink != nil {
+				if next, ok := shrink(accept); ok {
+					return next, true
+				}
 			}
 			var z T
 			return z, false
@@ -86,9 +98,11 @@ func Weighted[T any](weight func(T) float64, gs ...Generator[T]) Generator[T] {
 // -------------------------
 
 // Map applies f: A -> B preserving shrinking (maps A's candidates).
+// When ga does not shrink (e.g. Const, or a nil shrinker), neither does Map.
 func Map[A, B any](ga Generator[A], f func(A) B) Generator[B] {
 	return From(func(r *rand.Rand, sz Size) (B, Shrinker[B]) {
 		a, sa := ga.Generate(r, sz)
+		sa = orNoShrink(sa, a)
 		b := f(a)
 		return b, func(accept bool) (B, bool) {
 			na, ok := sa(accept)
@@ -104,59 +118,79 @@ func Map[A, B any](ga Generator[A], f func(A) B) Generator[B] {
 // Filter keeps only values that satisfy pred.
 // Implements "rebase" in shrink: when accepting, shrinks on top of the new minimum
 // ensuring that the next candidates also satisfy the predicate.
+// When g does not shrink, or no value passed pred within maxTries, the
+// shrinker proposes no candidates.
+// The values rejected while generating are reported to Size.Filters, under
+// the filter's Label if it has one.
 func Filter[T any](g Generator[T], pred func(T) bool, maxTries int) Generator[T] {
 	if maxTries <= 0 {
 		maxTries = 1000
 	}
-	return From(func(r *rand.Rand, sz Size) (T, Shrinker[T]) {
-		if r == nil {
-			r = rand.New(rand.NewSource(rand.Int63())) // #nosec G404 -- Using math/rand for deterministic property-based testing
-		}
-		// generate a value that passes the pred
-		var v T
-		var s Shrinker[T]
-		okv := false
-		for tries := 0; tries < maxTries; tries++ {
-			v, s = g.Generate(r, sz)
-			if pred(v) {
-				okv = true
-				break
-			}
-		}
-		if !okv {
-			var z T
-			return z, func(bool) (T, bool) { return z, false }
+	return filter[T]{g: g, pred: pred, maxTries: maxTries}
+}
+
+// filter implements Filter; label is set by Label.
+type filter[T any] struct {
+	g        Generator[T]
+	pred     func(T) bool
+	maxTries int
+	label    string
+}
+
+// Generate implements Generator.
+func (f filter[T]) Generate(r *rand.Rand, sz Size) (T, Shrinker[T]) {
+	if r == nil {
+		r = rand.New(rand.NewSource(rand.Int63())) // #nosec G404 -- Using math/rand for deterministic property-based testing
+	}
+	// generate a value that passes the pred
+	var v T
+	var s Shrinker[T]
+	okv := false
+	for tries := 0; tries < f.maxTries; tries++ {
+		v, s = f.g.Generate(r, sz)
+		if f.pred(v) {
+			okv = true
+			break
 		}
+		sz.Filters.reject(f.label, v, tries == f.maxTries-1)
+	}
+	if !okv {
+		var z T
+		return z, noShrink(z)
+	}
+	s = orNoShrink(s, v)
 
-		// shrinker: whenever we accept, we need to "rebase" and continue
-		// ensuring pred on the next candidates.
-		return v, func(accept bool) (T, bool) {
-			for {
-				nv, ok := s(accept)
-				if !ok {
-					var z T
-					return z, false
-				}
-				if pred(nv) {
-					return nv, true
-				}
-				// candidate doesn't satisfy pred → reject and try next
-				accept = false
+	// shrinker: whenever we accept, we need to "rebase" and continue
+	// ensuring pred on the next candidates.
+	return v, func(accept bool) (T, bool) {
+		for {
+			nv, ok := s(accept)
+			if !ok {
+				var z T
+				return z, false
+			}
+			if f.pred(nv) {
+				return nv, true
 			}
+			// candidate doesn't satisfy pred → reject and try next
+			accept = false
 		}
-	})
+	}
 }
 
 // Bind (flatMap): the output generator depends on the value generated in A.
 // Shrinking: first tries to shrink in B; when exhausted, shrinks in A and regenerates B.
+// Either side may be non-shrinking; that side is simply skipped.
 func Bind[A, B any](ga Generator[A], f func(A) Generator[B]) Generator[B] {
 	return From(func(r *rand.Rand, sz Size) (B, Shrinker[B]) {
 		if r == nil {
 			r = rand.New(rand.NewSource(rand.Int63())) // #nosec G404 -- Using math/rand for deterministic property-based testing
 		}
 		a, sa := ga.Generate(r, sz)
+		sa = orNoShrink(sa, a)
 		gb := f(a)
 		b, sb := gb.Generate(r, sz)
+		sb = orNoShrink(sb, b)
 
 		state := 0 // 0 => shrink B; 1 => shrink A (and regenerate B)
 
@@ -180,6 +214,7 @@ func Bind[A, B any](ga Generator[A], f func(A) Generator[B]) Generator[B] {
 				a = na
 				gb = f(a)
 				b, sb = gb.Generate(r, sz)
+				sb = orNoShrink(sb, b)
 				return b, true
 			default:
 				var z B
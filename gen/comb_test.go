@@ -120,3 +120,52 @@ func TestBind(t *testing.T) {
 		t.Error("Bind().Generate() returned nil shrinker")
 	}
 }
+
+// nilShrinkGen produces v with a nil shrinker, like a hand-written generator
+// that does not support shrinking.
+func nilShrinkGen[T any](v T) Generator[T] {
+	return From(func(_ *rand.Rand, _ Size) (T, Shrinker[T]) { return v, nil })
+}
+
+func TestConst_ShrinksToItself(t *testing.T) {
+	_, shrink := Const(42).Generate(nil, Size{})
+
+	for _, accept := range []bool{false, true, false} {
+		if v, ok := shrink(accept); ok || v != 42 {
+			t.Errorf("Const(42) shrink(%v) = (%d, %v), expected (42, false)", accept, v, ok)
+		}
+	}
+}
+
+func TestCombinators_NonShrinkingSubGenerators(t *testing.T) {
+	tests := []struct {
+		name string
+		g    Generator[int]
+	}{
+		{"Map(Const)", Map(Const(1), func(x int) int { return x + 1 })},
+		{"Map(nil shrinker)", Map(nilShrinkGen(1), func(x int) int { return x + 1 })},
+		{"Filter(Const)", Filter(Const(2), func(x int) bool { return x%2 == 0 }, 10)},
+		{"Filter(nil shrinker)", Filter(nilShrinkGen(2), func(x int) bool { return x%2 == 0 }, 10)},
+		{"Filter(never passes)", Filter(Const(1), func(int) bool { return false }, 3)},
+		{"Bind(Const, Const)", Bind(Const(1), func(x int) Generator[int] { return Const(x) })},
+		{"Bind(nil, nil)", Bind(nilShrinkGen(1), func(x int) Generator[int] { return nilShrinkGen(x) })},
+		{"OneOf(Const, nil shrinker)", OneOf(Const(1), nilShrinkGen(2))},
+		{"Map(PairOf(Const, nil shrinker))", Map(PairOf(Const(1), nilShrinkGen(2)), func(p Pair[int, int]) int { return p.First + p.Second })},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := rand.New(rand.NewSource(123))
+			_, shrink := tt.g.Generate(r, Size{})
+			if shrink == nil {
+				t.Fatal("Generate() returned nil shrinker")
+			}
+			// must terminate without panicking regardless of the verdicts
+			for i, accept := 0, false; i < 10; i, accept = i+1, !accept {
+				if _, ok := shrink(accept); !ok {
+					return
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package gen
+
+import "math/rand"
+
+// SliceContaining generates []T holding must at least once, at a random
+// position, with the other elements drawn from g. Useful for search and
+// membership code, where the "present" case is rare with random slices.
+// - size.Min/Max control the length including must (default Min=0, Max=16);
+// the length is always at least 1.
+// Shrink: shrinks the other elements like SliceOf, then moves must toward
+// the front; must is never removed.
+func SliceContaining[T any](g Generator[T], must T, size Size) Generator[[]T] {
+	return From(func(r *rand.Rand, sz Size) ([]T, Shrinker[[]T]) {
+		if r == nil {
+			r = rand.New(rand.NewSource(rand.Int63())) // #nosec G404 -- Using math/rand for deterministic property-based testing
+		}
+		if size.Min == 0 && size.Max == 0 {
+			size.Min, size.Max = 0, 16
+		}
+		if sz.Min != 0 || sz.Max != 0 {
+			size = sz
+		}
+		if size.Max < size.Min {
+			size.Max = size.Min
+		}
+
+		sz.Budget.Spend(1) // must itself
+		if size.Max <= 1 {
+			v := []T{must}
+			return v, noShrink(v)
+		}
+
+		// the other elements, must's position among them
+		rest := sz.elem()
+		rest.Min, rest.Max = size.Min-1, size.Max-1
+		if rest.Min < 0 {
+			rest.Min = 0
+		}
+		withMust := Map(PairOf(SliceOf(g, rest), IntRange(0, rest.Max)), func(p Pair[[]T, int]) []T {
+			pos := p.Second % (len(p.First) + 1)
+			out := make([]T, 0, len(p.First)+1)
+			out = append(out, p.First[:pos]...)
+			out = append(out, must)
+			return append(out, p.First[pos:]...)
+		})
+		return withMust.Generate(r, rest)
+	})
+}
@@ -0,0 +1,86 @@
+package gen
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func countOf(xs []int, x int) int {
+	n := 0
+	for _, v := range xs {
+		if v == x {
+			n++
+		}
+	}
+	return n
+}
+
+func TestSliceContaining_AlwaysContains(t *testing.T) {
+	g := SliceContaining(IntRange(0, 9), 42, Size{Min: 0, Max: 10})
+	r := rand.New(rand.NewSource(1))
+	positions := map[int]bool{}
+	for i := 0; i < 300; i++ {
+		xs, _ := g.Generate(r, Size{})
+		if len(xs) < 1 || len(xs) > 10 {
+			t.Fatalf("SliceContaining() length %d outside [1, 10]", len(xs))
+		}
+		if countOf(xs, 42) != 1 {
+			t.Fatalf("SliceContaining() = %v, expected 42 exactly once", xs)
+		}
+		for p, v := range xs {
+			if v == 42 {
+				positions[p] = true
+			}
+		}
+	}
+	if len(positions) < 5 {
+		t.Errorf("must appeared at only %d distinct positions", len(positions))
+	}
+}
+
+func TestSliceContaining_TinySizes(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	xs, shrink := SliceContaining(IntRange(0, 9), 7, Size{Min: 1, Max: 1}).Generate(r, Size{})
+	if len(xs) != 1 || xs[0] != 7 {
+		t.Errorf("SliceContaining() with Max=1 = %v, expected [7]", xs)
+	}
+	if _, ok := shrink(false); ok {
+		t.Error("SliceContaining() with Max=1 proposed a shrink candidate")
+	}
+}
+
+func TestSliceContaining_ShrinkKeepsMust(t *testing.T) {
+	g := SliceContaining(IntRange(0, 100), -1, Size{Max: 12})
+	r := rand.New(rand.NewSource(3))
+	for i := 0; i < 30; i++ {
+		xs, shrink := g.Generate(r, Size{})
+		// fails while the slice holds -1 and a value >= 50: minimal has length 2
+		fails := func(ys []int) bool {
+			big := false
+			for _, y := range ys {
+				big = big || y >= 50
+			}
+			return big && countOf(ys, -1) > 0
+		}
+		if !fails(xs) {
+			continue
+		}
+		min, accept := xs, false
+		for j := 0; j < 2000; j++ {
+			next, ok := shrink(accept)
+			if !ok {
+				break
+			}
+			if countOf(next, -1) != 1 {
+				t.Fatalf("shrink candidate %v lost must", next)
+			}
+			accept = fails(next)
+			if accept {
+				min = next
+			}
+		}
+		if len(min) != 2 || !fails(min) {
+			t.Errorf("SliceContaining() %v shrunk to %v, expected -1 and one value >= 50", xs, min)
+		}
+	}
+}
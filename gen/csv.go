@@ -0,0 +1,209 @@
+package gen
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+)
+
+// csvPlain and csvTricky are the building blocks of field text: plain
+// characters (including spaces, kept as is by readers) and the sequences that
+// force quoting (comma, quote, line feed). Carriage returns are left out:
+// encoding/csv drops a "\r" before "\n" even inside quoted fields, which
+// would break round trips.
+var (
+	csvPlain  = []string{"a", "b", "z", "0", "9", "é", " "}
+	csvTricky = []string{",", "\"", "\n", "\"\"", ",\n"}
+)
+
+// csvField is one field of a record; quote forces quoting even when the text
+// does not need it.
+type csvField struct {
+	text  string
+	quote bool
+}
+
+// csvDoc is the plain-data description of a CSV document.
+type csvDoc struct {
+	records [][]csvField
+}
+
+// CSV generates RFC 4180 documents of records with cols fields each, covering
+// the escaping cases parsers get wrong: quoted fields (needed or not),
+// embedded commas, embedded line breaks and doubled quotes. Records end with
+// CRLF. Every document, including shrink candidates, reads back with
+// encoding/csv (default Reader settings) into the generated fields.
+// - size.Min/Max control the number of records (default Min=0, Max=8).
+// Panics if cols < 1.
+// Shrink: removes records (blocks, then single, R->L), then empties, halves
+// and trims fields (at either end) and drops unneeded quotes, toward the empty document.
+func CSV(cols int, size Size) Generator[string] {
+	if cols < 1 {
+		panic(fmt.Sprintf("gen.CSV: cols=%d, must be >= 1", cols))
+	}
+	return From(func(r *rand.Rand, sz Size) (string, Shrinker[string]) {
+		if r == nil {
+			r = rand.New(rand.NewSource(rand.Int63())) // #nosec G404 -- Using math/rand for deterministic property-based testing
+		}
+		if size.Min == 0 && size.Max == 0 {
+			size.Min, size.Max = 0, 8
+		}
+		if sz.Min != 0 || sz.Max != 0 {
+			size = sz
+		}
+		if size.Max < size.Min {
+			size.Max = size.Min
+		}
+
+		n := size.Min
+		if size.Max > size.Min {
+			n += r.Intn(size.Max - size.Min + 1)
+		}
+		sz.Budget.Spend(n * cols)
+		cur := csvDoc{records: make([][]csvField, n)}
+		for i := range cur.records {
+			cur.records[i] = make([]csvField, cols)
+			for j := range cur.records[i] {
+				cur.records[i][j] = generateCSVField(r)
+			}
+		}
+
+		queue := make([]csvDoc, 0, 32)
+		tried := map[string]struct{}{cur.render(): {}}
+		var queued map[string]struct{}
+		var last *csvDoc
+
+		push := func(d csvDoc) {
+			k := d.render()
+			if _, ok := tried[k]; ok {
+				return
+			}
+			if _, ok := queued[k]; ok {
+				return
+			}
+			queued[k] = struct{}{}
+			queue = append(queue, d)
+		}
+
+		grow := func(base csvDoc) {
+			queue = queue[:0]
+			queued = map[string]struct{}{}
+			L := len(base.records)
+			// (1) remove records: blocks (half, quarter, ...), then single (R->L)
+			for chunk := L / 2; chunk >= 1; chunk /= 2 {
+				for i := 0; i+chunk <= L; i += chunk {
+					push(base.without(i, i+chunk))
+				}
+			}
+			for i := L - 1; i >= 0; i-- {
+				push(base.without(i, i+1))
+			}
+			// (2) simpler fields (R->L)
+			for i := L - 1; i >= 0; i-- {
+				for j := cols - 1; j >= 0; j-- {
+					f := base.records[i][j]
+					edit := func(nf csvField) {
+						d := base.clone()
+						d.records[i][j] = nf
+						push(d)
+					}
+					if f.text != "" {
+						edit(csvField{text: "", quote: f.quote})
+						rs := []rune(f.text)
+						edit(csvField{text: string(rs[:len(rs)/2]), quote: f.quote})
+						edit(csvField{text: string(rs[:len(rs)-1]), quote: f.quote})
+						edit(csvField{text: string(rs[1:]), quote: f.quote})
+					}
+					if f.quote {
+						edit(csvField{text: f.text})
+					}
+				}
+			}
+		}
+		grow(cur)
+
+		pop := func() (csvDoc, bool) {
+			if len(queue) == 0 {
+				return csvDoc{}, false
+			}
+			var v csvDoc
+			if GetShrinkStrategy() == ShrinkStrategyDFS {
+				v = queue[len(queue)-1]
+				queue = queue[:len(queue)-1]
+			} else {
+				v = queue[0]
+				queue = queue[1:]
+			}
+			tried[v.render()] = struct{}{}
+			return v, true
+		}
+
+		return cur.render(), func(accept bool) (string, bool) {
+			if accept && last != nil {
+				cur = *last
+				grow(cur)
+			}
+			nxt, ok := pop()
+			if !ok {
+				return "", false
+			}
+			last = &nxt
+			return nxt.render(), true
+		}
+	})
+}
+
+// generateCSVField draws 0..6 pieces, quoting one in four fields that do not
+// need it.
+func generateCSVField(r *rand.Rand) csvField {
+	var b strings.Builder
+	for i, n := 0, r.Intn(7); i < n; i++ {
+		if r.Intn(3) == 0 {
+			b.WriteString(csvTricky[r.Intn(len(csvTricky))])
+		} else {
+			b.WriteString(csvPlain[r.Intn(len(csvPlain))])
+		}
+	}
+	return csvField{text: b.String(), quote: r.Intn(4) == 0}
+}
+
+// render writes the document, quoting the fields that need it (or ask for it)
+// and doubling their quotes.
+func (d csvDoc) render() string {
+	var b strings.Builder
+	for _, rec := range d.records {
+		for j, f := range rec {
+			if j > 0 {
+				b.WriteByte(',')
+			}
+			// a record made of one empty field would be an empty line, which
+			// readers skip
+			if f.quote || strings.ContainsAny(f.text, ",\"\n") || len(rec) == 1 && f.text == "" {
+				b.WriteByte('"')
+				b.WriteString(strings.ReplaceAll(f.text, `"`, `""`))
+				b.WriteByte('"')
+			} else {
+				b.WriteString(f.text)
+			}
+		}
+		b.WriteString("\r\n")
+	}
+	return b.String()
+}
+
+// without returns a copy of the document without records [i, j).
+func (d csvDoc) without(i, j int) csvDoc {
+	out := csvDoc{records: make([][]csvField, 0, len(d.records)-(j-i))}
+	out.records = append(out.records, d.records[:i]...)
+	out.records = append(out.records, d.records[j:]...)
+	return out
+}
+
+// clone returns a deep copy of the document.
+func (d csvDoc) clone() csvDoc {
+	out := csvDoc{records: make([][]csvField, len(d.records))}
+	for i, rec := range d.records {
+		out.records[i] = append([]csvField(nil), rec...)
+	}
+	return out
+}
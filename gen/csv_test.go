@@ -0,0 +1,162 @@
+package gen
+
+import (
+	"encoding/csv"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// readCSV parses s with the default encoding/csv Reader settings.
+func readCSV(t *testing.T, s string) [][]string {
+	t.Helper()
+	recs, err := csv.NewReader(strings.NewReader(s)).ReadAll()
+	if err != nil {
+		t.Fatalf("encoding/csv failed on %q: %v", s, err)
+	}
+	return recs
+}
+
+func TestCSV_RoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 500; i++ {
+		cols := 1 + i%4
+		d := csvDoc{records: make([][]csvField, r.Intn(6))}
+		want := make([][]string, len(d.records))
+		for j := range d.records {
+			d.records[j] = make([]csvField, cols)
+			want[j] = make([]string, cols)
+			for k := range d.records[j] {
+				d.records[j][k] = generateCSVField(r)
+				want[j][k] = d.records[j][k].text
+			}
+		}
+		got := readCSV(t, d.render())
+		if len(want) == 0 {
+			want = nil
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("render() = %q read back as %q, expected %q", d.render(), got, want)
+		}
+	}
+}
+
+func TestCSV_Shape(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	g := CSV(3, Size{Min: 2, Max: 5})
+	for i := 0; i < 200; i++ {
+		s, _ := g.Generate(r, Size{})
+		recs := readCSV(t, s)
+		if len(recs) < 2 || len(recs) > 5 {
+			t.Fatalf("CSV() has %d records, expected 2..5: %q", len(recs), s)
+		}
+		for _, rec := range recs {
+			if len(rec) != 3 {
+				t.Fatalf("CSV() record %q has %d fields, expected 3", rec, len(rec))
+			}
+		}
+		if !strings.HasSuffix(s, "\r\n") {
+			t.Fatalf("CSV() = %q, expected records ending in CRLF", s)
+		}
+	}
+}
+
+func TestCSV_Coverage(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	g := CSV(2, Size{})
+	seen := map[string]bool{}
+	for i := 0; i < 300; i++ {
+		s, _ := g.Generate(r, Size{})
+		for _, rec := range readCSV(t, s) {
+			for _, f := range rec {
+				seen["comma"] = seen["comma"] || strings.Contains(f, ",")
+				seen["quote"] = seen["quote"] || strings.Contains(f, `""`)
+				seen["newline"] = seen["newline"] || strings.Contains(f, "\n")
+				seen["empty"] = seen["empty"] || f == ""
+			}
+		}
+		seen["needless quotes"] = seen["needless quotes"] || strings.Contains(s, `"a`) || strings.Contains(s, `"0`)
+	}
+	for _, c := range []string{"comma", "quote", "newline", "empty", "needless quotes"} {
+		if !seen[c] {
+			t.Errorf("CSV() never produced a field with %s", c)
+		}
+	}
+}
+
+func TestCSV_SingleColumn(t *testing.T) {
+	d := csvDoc{records: [][]csvField{{{}}, {{text: "a"}}}}
+	got := readCSV(t, d.render())
+	if !reflect.DeepEqual(got, [][]string{{""}, {"a"}}) {
+		t.Errorf("render() = %q read back as %q, expected the empty record kept", d.render(), got)
+	}
+}
+
+func TestCSV_PanicsOnNoColumns(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("CSV(0) did not panic")
+		}
+	}()
+	CSV(0, Size{})
+}
+
+func TestCSV_ShrinkTowardEmpty(t *testing.T) {
+	r := rand.New(rand.NewSource(4))
+	s, shrink := CSV(2, Size{Min: 3, Max: 6}).Generate(r, Size{})
+
+	min := s
+	for i := 0; i < 1000; i++ {
+		nxt, ok := shrink(true)
+		if !ok {
+			break
+		}
+		readCSV(t, nxt)
+		min = nxt
+	}
+	if min != "" {
+		t.Errorf("shrinking %q accepting everything ended at %q, expected the empty document", s, min)
+	}
+}
+
+func TestCSV_ShrinkKeepsFailure(t *testing.T) {
+	// fails while some field holds a quote: minimal is one record with a
+	// lone quote in a field
+	fails := func(s string) bool {
+		for _, rec := range readCSV(t, s) {
+			for _, f := range rec {
+				if strings.Contains(f, `"`) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	r := rand.New(rand.NewSource(5))
+	g := CSV(3, Size{Min: 4, Max: 8})
+	var s string
+	var shrink Shrinker[string]
+	for {
+		s, shrink = g.Generate(r, Size{})
+		if fails(s) {
+			break
+		}
+	}
+
+	min, accept := s, false
+	for i := 0; i < 5000; i++ {
+		nxt, ok := shrink(accept)
+		if !ok {
+			break
+		}
+		accept = fails(nxt)
+		if accept {
+			min = nxt
+		}
+	}
+	recs := readCSV(t, min)
+	if len(recs) != 1 || strings.Join(recs[0], "") != `"` {
+		t.Errorf("shrinking %q ended at %q (%q), expected a single record with a lone quote", s, min, recs)
+	}
+}
@@ -0,0 +1,182 @@
+// File: gen/decimal.go
+package gen
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+)
+
+// decimalSpec is the plain-data description of a decimal: its sign, the
+// digits of the integer part (without leading zeros, "" for zero), the scale
+// digits of the fraction, and whether the integer part is padded with
+// leading zeros to its full width.
+type decimalSpec struct {
+	neg  bool
+	intd string
+	frac string
+	pad  bool
+}
+
+// Decimal generates decimal numbers as strings that fit a fixed-point
+// DECIMAL(precision, scale) column: at most precision-scale integer digits
+// and exactly scale fractional digits (precision=5, scale=2 → "123.45",
+// "-0.07", "999.99"). The integer part is "0" when it is zero; with scale 0
+// there is no decimal point. Besides uniformly random digit counts, examples
+// favor the edge cases of fixed-point parsers: zero (also "-0.00"), the
+// largest magnitude ("999.99"), the smallest non-zero one ("0.01"), and
+// integer parts padded with leading zeros to the full width ("001.50").
+// Panics if precision < 1, scale < 0 or scale > precision.
+// Shrink: toward "0" (or "0.00" for scale 2): zero, drops the sign and the
+// padding, shortens the integer part, then zeroes digits (R->L).
+func Decimal(precision, scale int) Generator[string] {
+	if precision < 1 || scale < 0 || scale > precision {
+		panic(fmt.Sprintf("gen.Decimal: precision=%d, scale=%d; need precision >= 1 and 0 <= scale <= precision", precision, scale))
+	}
+	width := precision - scale
+	return From(func(r *rand.Rand, _ Size) (string, Shrinker[string]) {
+		if r == nil {
+			r = rand.New(rand.NewSource(rand.Int63())) // #nosec G404 -- Using math/rand for deterministic property-based testing
+		}
+		digits := func(n int) string {
+			b := make([]byte, n)
+			for i := range b {
+				b[i] = byte('0' + r.Intn(10))
+			}
+			return string(b)
+		}
+
+		cur := decimalSpec{neg: r.Intn(2) == 0, frac: strings.Repeat("0", scale)}
+		switch r.Intn(8) {
+		case 0: // zero
+		case 1: // largest magnitude
+			cur.intd, cur.frac = strings.Repeat("9", width), strings.Repeat("9", scale)
+		case 2: // smallest non-zero magnitude
+			if scale > 0 {
+				cur.frac = cur.frac[:scale-1] + "1"
+			} else {
+				cur.intd = "1"
+			}
+		default:
+			cur.intd, cur.frac = digits(r.Intn(width+1)), digits(scale)
+			cur.pad = r.Intn(5) == 0
+		}
+		cur.intd = strings.TrimLeft(cur.intd, "0")
+
+		queue := make([]decimalSpec, 0, 16)
+		tried := map[string]struct{}{cur.render(width): {}}
+		var queued map[string]struct{}
+		last := cur
+
+		push := func(d decimalSpec) {
+			k := d.render(width)
+			if _, ok := tried[k]; ok {
+				return
+			}
+			if _, ok := queued[k]; ok {
+				return
+			}
+			queued[k] = struct{}{}
+			queue = append(queue, d)
+		}
+
+		grow := func(base decimalSpec) {
+			queue = queue[:0]
+			queued = map[string]struct{}{}
+			// (1) zero
+			push(decimalSpec{frac: strings.Repeat("0", scale)})
+			// (2) drop the sign, then the padding
+			if base.neg {
+				d := base
+				d.neg = false
+				push(d)
+			}
+			if base.pad {
+				d := base
+				d.pad = false
+				push(d)
+			}
+			// (3) shorter integer part: its first digit, then without it
+			if n := len(base.intd); n > 1 {
+				d := base
+				d.intd = base.intd[:1]
+				push(d)
+				d.intd = strings.TrimLeft(base.intd[1:], "0")
+				push(d)
+			}
+			// (4) zero the fraction, then single digits (R->L)
+			if strings.Trim(base.frac, "0") != "" {
+				d := base
+				d.frac = strings.Repeat("0", scale)
+				push(d)
+			}
+			for i := len(base.frac) - 1; i >= 0; i-- {
+				if base.frac[i] != '0' {
+					d := base
+					d.frac = base.frac[:i] + "0" + base.frac[i+1:]
+					push(d)
+				}
+			}
+			for i := len(base.intd) - 1; i >= 0; i-- {
+				if base.intd[i] != '0' {
+					d := base
+					d.intd = strings.TrimLeft(base.intd[:i]+"0"+base.intd[i+1:], "0")
+					push(d)
+				}
+			}
+		}
+		grow(cur)
+
+		pop := func() (decimalSpec, bool) {
+			if len(queue) == 0 {
+				return decimalSpec{}, false
+			}
+			var v decimalSpec
+			if GetShrinkStrategy() == ShrinkStrategyDFS {
+				v = queue[len(queue)-1]
+				queue = queue[:len(queue)-1]
+			} else {
+				v = queue[0]
+				queue = queue[1:]
+			}
+			tried[v.render(width)] = struct{}{}
+			return v, true
+		}
+
+		return cur.render(width), func(accept bool) (string, bool) {
+			if accept && last != cur {
+				cur = last
+				grow(cur)
+			}
+			nxt, ok := pop()
+			if !ok {
+				return "", false
+			}
+			last = nxt
+			return nxt.render(width), true
+		}
+	})
+}
+
+// render writes the decimal; width is the number of integer digits padding
+// fills up to.
+func (d decimalSpec) render(width int) string {
+	var b strings.Builder
+	if d.neg {
+		b.WriteByte('-')
+	}
+	switch {
+	case d.pad && width > len(d.intd):
+		b.WriteString(strings.Repeat("0", width-len(d.intd)))
+		b.WriteString(d.intd)
+	case d.intd == "":
+		b.WriteByte('0')
+	default:
+		b.WriteString(d.intd)
+	}
+	if d.frac != "" {
+		b.WriteByte('.')
+		b.WriteString(d.frac)
+	}
+	return b.String()
+}
@@ -0,0 +1,137 @@
+package gen
+
+import (
+	"fmt"
+	"math/big"
+	"math/rand"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// checkDecimal fails the test unless s fits DECIMAL(precision, scale).
+func checkDecimal(t *testing.T, s string, precision, scale int) {
+	t.Helper()
+	frac := ""
+	if scale > 0 {
+		frac = fmt.Sprintf(`\.\d{%d}`, scale)
+	}
+	if !regexp.MustCompile(`^-?\d+` + frac + `$`).MatchString(s) {
+		t.Fatalf("Decimal(%d, %d) = %q, expected scale %d", precision, scale, s, scale)
+	}
+	intPart := strings.TrimLeft(strings.SplitN(strings.TrimPrefix(s, "-"), ".", 2)[0], "0")
+	if len(intPart) > precision-scale {
+		t.Fatalf("Decimal(%d, %d) = %q has more than %d integer digits", precision, scale, s, precision-scale)
+	}
+	// padded integer part (at least "0"), point and fraction
+	wide := max(precision-scale, 1)
+	if scale > 0 {
+		wide += 1 + scale
+	}
+	if len(strings.TrimPrefix(s, "-")) > wide {
+		t.Fatalf("Decimal(%d, %d) = %q is wider than its padded form", precision, scale, s)
+	}
+}
+
+func TestDecimal(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	g := Decimal(5, 2)
+	seen := map[string]bool{}
+	neg, padded := false, false
+	for i := 0; i < 500; i++ {
+		s, shrink := g.Generate(r, Size{})
+		checkDecimal(t, s, 5, 2)
+		seen[s] = true
+		neg = neg || strings.HasPrefix(s, "-")
+		padded = padded || strings.HasPrefix(strings.TrimPrefix(s, "-"), "00")
+		if shrink == nil {
+			t.Fatal("Decimal().Generate() returned nil shrinker")
+		}
+	}
+	for _, edge := range []string{"0.00", "999.99", "0.01"} {
+		if !seen[edge] && !seen["-"+edge] {
+			t.Errorf("Decimal(5, 2) never produced %q", edge)
+		}
+	}
+	if !neg || !padded || len(seen) < 200 {
+		t.Errorf("Decimal(5, 2): negatives %v, padded %v, %d distinct values", neg, padded, len(seen))
+	}
+}
+
+func TestDecimal_Scales(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	for _, ps := range [][2]int{{1, 0}, {3, 0}, {4, 4}, {18, 6}, {38, 10}} {
+		g := Decimal(ps[0], ps[1])
+		for i := 0; i < 100; i++ {
+			s, _ := g.Generate(r, Size{})
+			checkDecimal(t, s, ps[0], ps[1])
+		}
+	}
+	if s, _ := Decimal(4, 4).Generate(r, Size{}); !strings.HasPrefix(strings.TrimPrefix(s, "-"), "0.") {
+		t.Errorf("Decimal(4, 4) = %q, expected a zero integer part", s)
+	}
+}
+
+func TestDecimal_PanicsOnInvalidArgs(t *testing.T) {
+	for _, ps := range [][2]int{{0, 0}, {2, 3}, {5, -1}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Decimal(%d, %d) did not panic", ps[0], ps[1])
+				}
+			}()
+			Decimal(ps[0], ps[1])
+		}()
+	}
+}
+
+func TestDecimal_ShrinkTowardZero(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	g := Decimal(7, 3)
+	for i := 0; i < 20; i++ {
+		s, shrink := g.Generate(r, Size{})
+		min := s
+		for j := 0; j < 200; j++ {
+			next, ok := shrink(true)
+			if !ok {
+				break
+			}
+			checkDecimal(t, next, 7, 3)
+			min = next
+		}
+		if s != "0.000" && min != "0.000" {
+			t.Errorf("shrinking %q accepting everything ended at %q, expected \"0.000\"", s, min)
+		}
+	}
+}
+
+func TestDecimal_ShrinkKeepsFailure(t *testing.T) {
+	// fails while the value is at least 100: minimal keeps one significant
+	// integer digit in the hundreds and a zero fraction
+	atLeast100 := func(s string) bool {
+		v, _ := new(big.Rat).SetString(s)
+		return v.Cmp(big.NewRat(100, 1)) >= 0
+	}
+	r := rand.New(rand.NewSource(4))
+	g := Decimal(6, 2)
+	var s string
+	var shrink Shrinker[string]
+	for s == "" || !atLeast100(s) {
+		s, shrink = g.Generate(r, Size{})
+	}
+
+	min, accept := s, false
+	for i := 0; i < 1000; i++ {
+		next, ok := shrink(accept)
+		if !ok {
+			break
+		}
+		accept = atLeast100(next)
+		if accept {
+			min = next
+		}
+	}
+	if !regexp.MustCompile(`^[1-9]00\.00$`).MatchString(min) {
+		t.Errorf("shrinking %q ended at %q, expected d00.00", s, min)
+	}
+}
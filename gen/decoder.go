@@ -0,0 +1,311 @@
+// File: gen/decoder.go
+package gen
+
+import (
+	"bytes"
+	"math/bits"
+	"math/rand"
+)
+
+// maxDecoderBytes bounds the random stream of one Decode example; reads past
+// it return zeros, so a decoding loop that never ends on its own still stops.
+const maxDecoderBytes = 1 << 16
+
+// Decoder is a byte stream that decoding functions read their choices from.
+// Every read consumes bytes in order, and reads past the end of the stream
+// return zeros, so shorter streams and smaller bytes decode to simpler
+// values: 0, false, min, empty. This is the model of Go's fuzzer and of
+// Hypothesis: a value is the decoding of a byte stream, and shrinking the
+// stream shrinks any value the same way. See Decode.
+//
+// Decoder is a prototype alongside Generator: its methods and the shrinking
+// of Decode may change.
+type Decoder struct {
+	data []byte
+	pos  int
+
+	// r, when set, extends data with random bytes as they are read
+	r *rand.Rand
+}
+
+// NewDecoder returns a Decoder reading data, then zeros. Use it to decode a
+// fuzzing input with the function given to Decode:
+//
+//	f.Fuzz(func(t *testing.T, data []byte) {
+//	    v := decodeOrder(gen.NewDecoder(data))
+//	    // ...
+//	})
+func NewDecoder(data []byte) *Decoder {
+	return &Decoder{data: data}
+}
+
+// Byte reads one byte.
+func (d *Decoder) Byte() byte {
+	if d.pos >= len(d.data) {
+		if d.r == nil || d.pos >= maxDecoderBytes {
+			d.pos++
+			return 0
+		}
+		d.data = append(d.data, byte(d.r.Intn(256)))
+	}
+	b := d.data[d.pos]
+	d.pos++
+	return b
+}
+
+// Bytes reads n bytes.
+func (d *Decoder) Bytes(n int) []byte {
+	out := make([]byte, n)
+	for i := range out {
+		out[i] = d.Byte()
+	}
+	return out
+}
+
+// Uint64 reads 8 bytes as a big-endian number, so smaller leading bytes give
+// smaller numbers.
+func (d *Decoder) Uint64() uint64 {
+	var v uint64
+	for i := 0; i < 8; i++ {
+		v = v<<8 | uint64(d.Byte())
+	}
+	return v
+}
+
+// Bool reads one byte; odd bytes are true.
+func (d *Decoder) Bool() bool {
+	return d.Byte()&1 == 1
+}
+
+// IntRange reads an int in [min, max] (bounds swapped if reversed) from one
+// byte more than the span needs (at most 8), scaling the bytes onto the
+// range: smaller bytes give smaller values, and zeros decode to min.
+func (d *Decoder) IntRange(min, max int) int {
+	if min > max {
+		min, max = max, min
+	}
+	span := uint64(max) - uint64(min) + 1 // 0: the whole int range
+	n := 8
+	if span != 0 {
+		n = (bits.Len64(span-1)+7)/8 + 1
+		if n > 8 {
+			n = 8
+		}
+	}
+	var u uint64
+	for i := 0; i < n; i++ {
+		u = u<<8 | uint64(d.Byte())
+	}
+	if span == 0 {
+		return min + int(u)
+	}
+	v, _ := bits.Mul64(u<<(64-8*n), span)
+	return min + int(v)
+}
+
+// Float64 reads a float64 in [0, 1); zeros decode to 0.
+func (d *Decoder) Float64() float64 {
+	return float64(d.Uint64()>>11) / (1 << 53)
+}
+
+// Consumed returns the number of bytes read so far, including those read
+// past the end of the stream.
+func (d *Decoder) Consumed() int {
+	return d.pos
+}
+
+// Decode builds a generator from a decoding function: each example runs f on
+// a fresh random byte stream (reproducible from the runner's seed), and f
+// reads its choices from it with the Decoder methods. f must be
+// deterministic given the bytes it reads; like Do, later reads may depend on
+// earlier ones. The same f decodes fuzzing inputs through NewDecoder.
+//
+// Example usage:
+//
+//	order := gen.Decode(func(d *gen.Decoder) []int {
+//	    items := make([]int, d.IntRange(0, 10))
+//	    for i := range items {
+//	        items[i] = d.IntRange(1, 99)
+//	    }
+//	    return items
+//	})
+//
+// Decode is a prototype alongside Generate: it trades the per-type shrinkers
+// of the other generators for one shrinker that works on bytes, so values
+// shrink the same way whatever their type.
+// Shrink: shrinks the bytes f read and decodes them again, skipping
+// candidates that decode to a value already tried:
+//
+//	(1) shorter streams: empty, halves, then without the last byte
+//	(2) remove blocks of 8, 4, 2 and 1 bytes (R->L)
+//	(3) zero blocks of 8, 4, 2 and 1 bytes (L->R)
+//	(4) halve, then decrement, each byte (L->R)
+//
+// A candidate decoding to the current value with a shorter (or, as long,
+// lexicographically smaller) stream replaces the current stream without
+// running the property.
+// Accepting every candidate converges to the decoding of the empty stream.
+func Decode[T any](f func(d *Decoder) T) Generator[T] {
+	return From(func(r *rand.Rand, _ Size) (T, Shrinker[T]) {
+		if r == nil {
+			r = rand.New(rand.NewSource(rand.Int63())) // #nosec G404 -- Using math/rand for deterministic property-based testing
+		}
+		d := &Decoder{r: r}
+		val := f(d)
+		return val, createDecodeShrinker(f, d.data[:min(d.pos, len(d.data))])
+	})
+}
+
+// decodeRun is one decoding: the bytes f read (trailing zeros trimmed, as
+// reads past the end return zeros anyway) and the value.
+type decodeRun[T any] struct {
+	data  []byte
+	value T
+}
+
+// runDecode decodes data with f.
+func runDecode[T any](f func(d *Decoder) T, data []byte) decodeRun[T] {
+	d := NewDecoder(data)
+	v := f(d)
+	used := data[:min(d.pos, len(data))]
+	for len(used) > 0 && used[len(used)-1] == 0 {
+		used = used[:len(used)-1]
+	}
+	return decodeRun[T]{data: used, value: v}
+}
+
+// createDecodeShrinker creates the byte-stream shrinker of Decode.
+func createDecodeShrinker[T any](f func(d *Decoder) T, data []byte) Shrinker[T] {
+	cur := runDecode(f, append([]byte(nil), data...))
+	// a value the empty stream decodes to has nothing to shrink
+	if empty := runDecode(f, nil); sig([]T{empty.value}) == sig([]T{cur.value}) {
+		cur = empty
+	}
+	queue := make([][]byte, 0, 64)
+	triedData := map[string]struct{}{string(cur.data): {}}
+	triedValue := map[string]struct{}{sig([]T{cur.value}): {}}
+	var queued map[string]struct{}
+	var last *decodeRun[T]
+
+	push := func(b []byte) {
+		k := string(b)
+		if _, ok := triedData[k]; ok {
+			return
+		}
+		if _, ok := queued[k]; ok {
+			return
+		}
+		queued[k] = struct{}{}
+		queue = append(queue, b)
+	}
+	edit := func(base []byte, fn func(b []byte) []byte) {
+		push(fn(append([]byte(nil), base...)))
+	}
+
+	grow := func(base []byte) {
+		queue = queue[:0]
+		queued = map[string]struct{}{}
+		L := len(base)
+		if L == 0 {
+			return
+		}
+		// (1) shorter streams
+		push(nil)
+		for k := L / 2; k >= 1; k /= 2 {
+			push(base[:k:k])
+		}
+		push(base[: L-1 : L-1])
+		// (2) remove blocks (R->L)
+		for _, n := range []int{8, 4, 2, 1} {
+			for i := L - n; i >= 0; i-- {
+				edit(base, func(b []byte) []byte { return append(b[:i], b[i+n:]...) })
+			}
+		}
+		// (3) zero blocks (L->R)
+		for _, n := range []int{8, 4, 2, 1} {
+			for i := 0; i+n <= L; i++ {
+				if allZero(base[i : i+n]) {
+					continue
+				}
+				edit(base, func(b []byte) []byte {
+					clear(b[i : i+n])
+					return b
+				})
+			}
+		}
+		// (4) smaller bytes (L->R)
+		for i, c := range base {
+			if c > 1 {
+				edit(base, func(b []byte) []byte { b[i] = c / 2; return b })
+			}
+			if c > 0 {
+				edit(base, func(b []byte) []byte { b[i] = c - 1; return b })
+			}
+		}
+	}
+	grow(cur.data)
+
+	// pop decodes queued streams until one gives a new value
+	pop := func() (decodeRun[T], bool) {
+		for len(queue) > 0 {
+			var b []byte
+			if GetShrinkStrategy() == ShrinkStrategyDFS {
+				b = queue[len(queue)-1]
+				queue = queue[:len(queue)-1]
+			} else {
+				b = queue[0]
+				queue = queue[1:]
+			}
+			triedData[string(b)] = struct{}{}
+			run := runDecode(f, b)
+			triedData[string(run.data)] = struct{}{}
+			k := sig([]T{run.value})
+			// a simpler stream for the current value needs no property run:
+			// continue from it
+			if k == sig([]T{cur.value}) && shortlexLess(run.data, cur.data) {
+				cur = run
+				grow(cur.data)
+				continue
+			}
+			if _, ok := triedValue[k]; ok {
+				continue
+			}
+			triedValue[k] = struct{}{}
+			return run, true
+		}
+		return decodeRun[T]{}, false
+	}
+
+	return func(accept bool) (T, bool) {
+		if accept && last != nil {
+			cur = *last
+			grow(cur.data)
+		}
+		nxt, ok := pop()
+		if !ok {
+			var zero T
+			return zero, false
+		}
+		last = &nxt
+		return nxt.value, true
+	}
+}
+
+// shortlexLess reports whether a is shorter than b, or as long and
+// lexicographically smaller.
+func shortlexLess(a, b []byte) bool {
+	if len(a) != len(b) {
+		return len(a) < len(b)
+	}
+	return bytes.Compare(a, b) < 0
+}
+
+// allZero reports whether every byte of b is 0.
+func allZero(b []byte) bool {
+	for _, c := range b {
+		if c != 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,121 @@
+package gen
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// decodeItems decodes 0 to 10 items in [1, 99].
+func decodeItems(d *Decoder) []int {
+	items := make([]int, d.IntRange(0, 10))
+	for i := range items {
+		items[i] = d.IntRange(1, 99)
+	}
+	return items
+}
+
+func TestDecoder(t *testing.T) {
+	d := NewDecoder([]byte{0, 0, 0, 0, 0, 0, 1, 2, 7})
+	if v := d.Uint64(); v != 0x0102 {
+		t.Errorf("Uint64() = %#x, expected big-endian 0x102", v)
+	}
+	if !d.Bool() {
+		t.Error("Bool() on an odd byte = false")
+	}
+	if d.Consumed() != 9 {
+		t.Errorf("Consumed() = %d, expected 9", d.Consumed())
+	}
+	// past the end every read is zero; IntRange(3, 9) reads 2 bytes
+	if v := d.IntRange(3, 9); v != 3 {
+		t.Errorf("IntRange(3, 9) past the end = %d, expected 3", v)
+	}
+	if d.Bool() || d.Float64() != 0 || d.Byte() != 0 {
+		t.Error("reads past the end are not zero")
+	}
+	if d.Consumed() != 9+2+1+8+1 {
+		t.Errorf("Consumed() = %d, expected reads past the end to count", d.Consumed())
+	}
+
+	d = NewDecoder([]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff})
+	if v := d.IntRange(9, 3); v < 3 || v > 9 {
+		t.Errorf("IntRange(9, 3) = %d, expected a value in [3, 9]", v)
+	}
+	if v := d.IntRange(math.MinInt, math.MaxInt); v != math.MaxInt {
+		t.Errorf("IntRange over every int = %d, expected MaxInt for all-ones bytes", v)
+	}
+}
+
+func TestDecode(t *testing.T) {
+	g := Decode(decodeItems)
+	a, _ := g.Generate(rand.New(rand.NewSource(1)), Size{})
+	b, _ := g.Generate(rand.New(rand.NewSource(1)), Size{})
+	if !reflect.DeepEqual(a, b) {
+		t.Errorf("Decode() with the same seed gave %v and %v", a, b)
+	}
+
+	r := rand.New(rand.NewSource(2))
+	lengths := map[int]bool{}
+	for i := 0; i < 200; i++ {
+		items, _ := g.Generate(r, Size{})
+		for _, v := range items {
+			if v < 1 || v > 99 {
+				t.Fatalf("Decode() = %v, item out of [1, 99]", items)
+			}
+		}
+		lengths[len(items)] = true
+	}
+	if len(lengths) != 11 {
+		t.Errorf("Decode() produced %d distinct lengths, expected 11", len(lengths))
+	}
+}
+
+func TestDecode_ShrinkTowardEmptyStream(t *testing.T) {
+	SetShrinkStrategy("bfs")
+	r := rand.New(rand.NewSource(3))
+	g := Decode(decodeItems)
+	for i := 0; i < 20; i++ {
+		items, shrink := g.Generate(r, Size{})
+		seen := map[string]bool{}
+		min := minimize(items, shrink, func(c []int) bool {
+			if seen[sig(c)] {
+				t.Fatalf("shrinking %v proposed %v twice", items, c)
+			}
+			seen[sig(c)] = true
+			return true
+		})
+		if len(min) != 0 {
+			t.Errorf("shrinking %v accepting everything ended at %v, expected the empty stream's []", items, min)
+		}
+	}
+}
+
+func TestDecode_ShrinkKeepsFailure(t *testing.T) {
+	// fails while some item is at least 50: byte shrinking reaches the
+	// minimal value [50], whatever the type
+	for _, strategy := range []string{"bfs", "dfs"} {
+		SetShrinkStrategy(strategy)
+		r := rand.New(rand.NewSource(4))
+		g := Decode(decodeItems)
+		for i := 0; i < 20; i++ {
+			items, shrink := g.Generate(r, Size{})
+			fails := func(c []int) bool {
+				for _, v := range c {
+					if v >= 50 {
+						return true
+					}
+				}
+				return false
+			}
+			if !fails(items) {
+				continue
+			}
+			min := minimize(items, shrink, fails)
+			if !reflect.DeepEqual(min, []int{50}) {
+				t.Errorf("%s: shrinking %v ended at %v, expected [50]", strategy, items, min)
+			}
+		}
+	}
+	SetShrinkStrategy("bfs")
+}
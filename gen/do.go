@@ -0,0 +1,132 @@
+// File: gen/do.go
+package gen
+
+import "math/rand"
+
+// Draw records the values pulled by a Do body so they can be shrunk.
+// Use DrawFrom to pull a value from a generator.
+type Draw struct {
+	r  *rand.Rand
+	sz Size
+
+	// replay holds fixed values for the first len(replay) draws; seeds
+	// holds the seeds used by the draws of the previous run
+	replay []any
+	seeds  []int64
+	draws  []drawn
+}
+
+// drawn is one recorded draw: its value, the seed it was generated from and,
+// when it was generated (not replayed), the shrinker for that value.
+type drawn struct {
+	value  any
+	seed   int64
+	shrink Shrinker[any]
+}
+
+// DrawFrom pulls a value from g inside a Do body and records it for
+// shrinking. (It is a function rather than a Draw method because Go methods
+// cannot have type parameters.)
+func DrawFrom[T any](d *Draw, g Generator[T]) T {
+	i := len(d.draws)
+	if i < len(d.replay) {
+		v := d.replay[i].(T)
+		d.draws = append(d.draws, drawn{value: v, seed: d.seeds[i]})
+		return v
+	}
+	// each draw has its own seed, so a regenerated draw at the same position
+	// sees the same random stream as before
+	seed := d.r.Int63()
+	if i < len(d.seeds) {
+		seed = d.seeds[i]
+	}
+	v, s := g.Generate(rand.New(rand.NewSource(seed)), d.sz) // #nosec G404 -- Using math/rand for deterministic property-based testing
+	s = orNoShrink(s, v)
+	d.draws = append(d.draws, drawn{value: v, seed: seed, shrink: func(accept bool) (any, bool) {
+		return s(accept)
+	}})
+	return v
+}
+
+// doRun is one execution of a Do body.
+type doRun[T any] struct {
+	value T
+	draws []drawn
+}
+
+// Do builds a generator imperatively: f pulls values with DrawFrom, and later
+// draws may depend on earlier ones, without nesting Bind calls.
+// f must be deterministic given the values it draws.
+//
+// Example usage:
+//
+//	sorted := gen.Do(func(d *gen.Draw) gen.Pair[int, int] {
+//	    lo := gen.DrawFrom(d, gen.IntRange(0, 100))
+//	    hi := gen.DrawFrom(d, gen.IntRange(lo, 200))
+//	    return gen.Pair[int, int]{First: lo, Second: hi}
+//	})
+//
+// Shrink: the recorded draws are shrunk one at a time, first to last, each
+// with the shrinker of its own generator. Earlier draws are replayed as they
+// are; draws after the one being shrunk are regenerated (their generators may
+// depend on it, like Bind) from the seed they were first drawn with, so
+// draws that do not depend on it come out unchanged.
+func Do[T any](f func(d *Draw) T) Generator[T] {
+	return From(func(r *rand.Rand, sz Size) (T, Shrinker[T]) {
+		if r == nil {
+			r = rand.New(rand.NewSource(rand.Int63())) // #nosec G404 -- Using math/rand for deterministic property-based testing
+		}
+		d := &Draw{r: r, sz: sz}
+		cur := doRun[T]{value: f(d), draws: d.draws}
+
+		// draws beyond the previous run take seeds from a private source
+		sr := rand.New(rand.NewSource(r.Int63())) // #nosec G404 -- Using math/rand for deterministic property-based testing
+		execute := func(replay []any, seeds []int64) doRun[T] {
+			d := &Draw{r: sr, sz: sz, replay: replay, seeds: seeds}
+			v := f(d)
+			return doRun[T]{value: v, draws: d.draws}
+		}
+
+		idx := 0                 // draw being shrunk
+		var active Shrinker[any] // shrinker of draw idx
+		var pending *doRun[T]    // last proposed run
+
+		return cur.value, func(accept bool) (T, bool) {
+			if accept && pending != nil {
+				cur = *pending
+			}
+			pending = nil
+
+			for idx < len(cur.draws) {
+				if active == nil {
+					active = cur.draws[idx].shrink
+					if active == nil {
+						idx++
+						accept = false
+						continue
+					}
+				}
+				nv, ok := active(accept)
+				if !ok {
+					idx++
+					active = nil
+					accept = false
+					continue
+				}
+				replay := make([]any, 0, idx+1)
+				seeds := make([]int64, 0, len(cur.draws))
+				for j, dr := range cur.draws {
+					if j < idx {
+						replay = append(replay, dr.value)
+					}
+					seeds = append(seeds, dr.seed)
+				}
+				run := execute(append(replay, nv), seeds)
+				pending = &run
+				return run.value, true
+			}
+			var z T
+			return z, false
+		}
+	})
+}
@@ -0,0 +1,129 @@
+package gen
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// orderedPair draws lo, then hi in [lo, 200].
+func orderedPair() Generator[Pair[int, int]] {
+	return Do(func(d *Draw) Pair[int, int] {
+		lo := DrawFrom(d, IntRange(0, 100))
+		hi := DrawFrom(d, IntRange(lo, 200))
+		return Pair[int, int]{First: lo, Second: hi}
+	})
+}
+
+func TestDo(t *testing.T) {
+	g := orderedPair()
+	r := rand.New(rand.NewSource(123))
+
+	for i := 0; i < 100; i++ {
+		p, shrink := g.Generate(r, Size{})
+		if p.First < 0 || p.First > 100 || p.Second < p.First || p.Second > 200 {
+			t.Fatalf("Do().Generate() = %+v, expected 0 <= lo <= hi <= 200", p)
+		}
+		if shrink == nil {
+			t.Fatal("Do().Generate() returned nil shrinker")
+		}
+	}
+}
+
+func TestDo_ShrinksEachDraw(t *testing.T) {
+	g := orderedPair()
+	r := rand.New(rand.NewSource(7))
+
+	// the property fails while hi >= 50; accept failing candidates that do
+	// not grow either component
+	fails := func(p Pair[int, int]) bool { return p.Second >= 50 }
+	for i := 0; i < 20; i++ {
+		p, shrink := g.Generate(r, Size{})
+		if !fails(p) {
+			continue
+		}
+		min, accept := p, false
+		for j := 0; j < 2000; j++ {
+			next, ok := shrink(accept)
+			if !ok {
+				break
+			}
+			if next.Second < next.First {
+				t.Fatalf("shrink candidate %+v breaks lo <= hi", next)
+			}
+			accept = fails(next) && next.First <= min.First && next.Second <= min.Second
+			if accept {
+				min = next
+			}
+		}
+		// hi depends on lo and is regenerated when lo shrinks, so lo only
+		// shrinks as far as regenerated values keep failing; hi, drawn last,
+		// always reaches the boundary
+		if want := max(50, min.First); min.Second != want || min.First > p.First {
+			t.Errorf("Do() shrunk %+v to %+v, expected hi=%d and lo <= %d", p, min, want, p.First)
+		}
+	}
+}
+
+func TestDo_IndependentDrawsShrinkFully(t *testing.T) {
+	g := Do(func(d *Draw) Pair[int, int] {
+		a := DrawFrom(d, IntRange(0, 100))
+		b := DrawFrom(d, IntRange(0, 100))
+		return Pair[int, int]{First: a, Second: b}
+	})
+	r := rand.New(rand.NewSource(11))
+
+	// only b matters: a must shrink to 0 without disturbing b
+	fails := func(p Pair[int, int]) bool { return p.Second >= 20 }
+	for i := 0; i < 20; i++ {
+		p, shrink := g.Generate(r, Size{})
+		if !fails(p) {
+			continue
+		}
+		min, accept := p, false
+		for j := 0; j < 2000; j++ {
+			next, ok := shrink(accept)
+			if !ok {
+				break
+			}
+			accept = fails(next) && next.First <= min.First && next.Second <= min.Second
+			if accept {
+				min = next
+			}
+		}
+		if min != (Pair[int, int]{First: 0, Second: 20}) {
+			t.Errorf("Do() shrunk %+v to %+v, expected {0 20}", p, min)
+		}
+	}
+}
+
+func TestDo_VariableNumberOfDraws(t *testing.T) {
+	g := Do(func(d *Draw) []int {
+		n := DrawFrom(d, IntRange(0, 5))
+		out := make([]int, n)
+		for i := range out {
+			out[i] = DrawFrom(d, IntRange(0, 9))
+		}
+		return out
+	})
+	r := rand.New(rand.NewSource(3))
+
+	for i := 0; i < 20; i++ {
+		_, shrink := g.Generate(r, Size{})
+		// accepting everything must terminate without panicking
+		for j := 0; j < 500; j++ {
+			if _, ok := shrink(true); !ok {
+				break
+			}
+		}
+	}
+}
+
+func TestDo_NoDraws(t *testing.T) {
+	v, shrink := Do(func(*Draw) string { return "x" }).Generate(nil, Size{})
+	if v != "x" {
+		t.Errorf("Do().Generate() = %q, expected 'x'", v)
+	}
+	if _, ok := shrink(false); ok {
+		t.Error("Do() without draws should not shrink")
+	}
+}
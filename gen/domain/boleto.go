@@ -0,0 +1,162 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"arcsyn.io/propx/gen"
+)
+
+// boletoLen is the number of digits of a boleto line (linha digitável).
+const boletoLen = 47
+
+// boletoBanks are the codes of common issuing banks, Banco do Brasil first.
+var boletoBanks = []string{"001", "033", "104", "237", "341", "260", "077", "336", "422", "748", "756"}
+
+// Boleto generates valid Brazilian bank slip lines (linha digitável de
+// boleto de cobrança), unmasked: 47 digits encoding a bank code, the currency
+// code 9, a due date factor (0000 for none), an amount in cents (often 0 or
+// up to R$ 1,000.00, sometimes up to the 10-digit maximum) and a 25-digit free
+// field, with the three field check digits (mod 10) and the general check
+// digit of the barcode (mod 11). Use MaskBoleto for the printed form:
+//
+//	gen.Map(domain.Boleto(), domain.MaskBoleto)
+//
+// Shrink: toward "00190000090000000000000000000000500000000000000", Banco do
+// Brasil with no due date, amount or free field data (check digits are
+// recomputed, so every candidate is valid).
+func Boleto() gen.Generator[string] {
+	return gen.Do(drawBoleto)
+}
+
+// BoletoInvalid generates boleto lines that ValidBoleto must reject: a valid
+// line with one digit changed, so the line is well formed (47 digits) and
+// only a check digit catches the error (a typo in the due date or amount can
+// go unnoticed by the general check digit; such changes are not generated).
+// Shrink: toward the first digit of the canonical Boleto line, changed by 1.
+func BoletoInvalid() gen.Generator[string] {
+	return gen.Do(func(d *gen.Draw) string {
+		line := drawBoleto(d)
+		i := gen.DrawFrom(d, gen.IntRange(0, boletoLen-1))
+		off := gen.DrawFrom(d, gen.IntRange(1, 9))
+		for {
+			b := []byte(line)
+			b[i] = '0' + (b[i]-'0'+byte(off))%10
+			// a changed due date or amount can keep the general check digit
+			// at 1 (it stands for three remainders); a later offset does not
+			if bad := string(b); !ValidBoleto(bad) {
+				return bad
+			}
+			off = off%9 + 1
+		}
+	})
+}
+
+// drawBoleto draws the fields of a boleto and returns its line.
+func drawBoleto(d *gen.Draw) string {
+	banks := make([]gen.Generator[string], len(boletoBanks))
+	for i, b := range boletoBanks {
+		banks[i] = gen.Const(b)
+	}
+	// OneOf, not an index: it reliably shrinks to the first bank
+	bank := gen.DrawFrom(d, gen.OneOf(banks...))
+	factor := gen.DrawFrom(d, gen.Frequency([]gen.Freq[int]{
+		{Weight: 1, Gen: gen.Const(0)},
+		{Weight: 3, Gen: gen.IntRange(1000, 9999)},
+	}))
+	amount := gen.DrawFrom(d, gen.Frequency([]gen.Freq[int64]{
+		{Weight: 1, Gen: gen.Const(int64(0))},
+		{Weight: 6, Gen: gen.Int64Range(1, 100000)},
+		{Weight: 1, Gen: gen.Int64Range(1, 9999999999)},
+	}))
+	free := gen.DrawFrom(d, gen.String("0123456789", gen.Size{Min: 25, Max: 25}))
+	free = (free + strings.Repeat("0", 25))[:25]
+	data := fmt.Sprintf("%s9%04d%010d%s", bank, factor, amount, free)
+	return boletoLine(data[:4] + string(boletoGeneralDigit(data)) + data[4:])
+}
+
+// ValidBoleto checks if a string is a valid boleto line, masked or not: 47
+// digits whose three field check digits and general check digit match.
+func ValidBoleto(s string) bool {
+	raw := UnmaskBoleto(s)
+	if len(raw) != boletoLen {
+		return false
+	}
+	for _, f := range [][2]int{{0, 9}, {10, 20}, {21, 31}} {
+		if raw[f[1]] != boletoFieldDigit(raw[f[0]:f[1]]) {
+			return false
+		}
+	}
+	barcode := boletoBarcode(raw)
+	return barcode[4] == boletoGeneralDigit(barcode[:4]+barcode[5:])
+}
+
+// MaskBoleto formats a raw boleto line with the dots and spaces of the
+// printed form, "00190.00009 00000.000000 00000.000000 5 00000000000000".
+func MaskBoleto(raw string) string {
+	raw = UnmaskBoleto(raw)
+	if len(raw) != boletoLen {
+		panic(errors.New("MaskBoleto: needs 47 digits"))
+	}
+	return raw[0:5] + "." + raw[5:10] + " " + raw[10:15] + "." + raw[15:21] + " " +
+		raw[21:26] + "." + raw[26:32] + " " + raw[32:33] + " " + raw[33:47]
+}
+
+// UnmaskBoleto removes all non-digit characters from a boleto line.
+func UnmaskBoleto(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	for _, r := range s {
+		if r <= unicode.MaxASCII && unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// boletoLine builds the line of a 44-digit barcode: three fields of free data
+// (the first led by the bank and currency codes), each with its check digit,
+// then the general check digit, the due date factor and the amount.
+func boletoLine(barcode string) string {
+	f1 := barcode[0:4] + barcode[19:24]
+	f2 := barcode[24:34]
+	f3 := barcode[34:44]
+	return f1 + string(boletoFieldDigit(f1)) +
+		f2 + string(boletoFieldDigit(f2)) +
+		f3 + string(boletoFieldDigit(f3)) +
+		barcode[4:19]
+}
+
+// boletoBarcode rebuilds the 44-digit barcode from a raw 47-digit line.
+func boletoBarcode(line string) string {
+	return line[0:4] + line[32:47] + line[4:9] + line[10:20] + line[21:31]
+}
+
+// boletoFieldDigit computes the mod-10 check digit of a line field: digits
+// weighted 2, 1, 2, ... from the right, two-digit products replaced by the
+// sum of their digits.
+func boletoFieldDigit(field string) byte {
+	sum := 0
+	for i := 0; i < len(field); i++ {
+		p := int(field[len(field)-1-i]-'0') * (2 - i%2)
+		sum += p/10 + p%10
+	}
+	return byte('0' + (10-sum%10)%10)
+}
+
+// boletoGeneralDigit computes the mod-11 general check digit of the 43
+// barcode digits around it: weights 2 to 9 repeating from the right, and 1
+// where the remainder would give 0, 10 or 11.
+func boletoGeneralDigit(data string) byte {
+	sum := 0
+	for i := 0; i < len(data); i++ {
+		sum += int(data[len(data)-1-i]-'0') * (2 + i%8)
+	}
+	dv := 11 - sum%11
+	if dv >= 10 {
+		dv = 1
+	}
+	return byte('0' + dv)
+}
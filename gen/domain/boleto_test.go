@@ -0,0 +1,125 @@
+package domain
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"arcsyn.io/propx/gen"
+)
+
+// a Banco do Brasil boleto and its barcode
+const (
+	boletoSample        = "00190500954014481606906809350314337370000000100"
+	boletoSampleMasked  = "00190.50095 40144.816069 06809.350314 3 37370000000100"
+	boletoSampleBarcode = "00193373700000001000500940144816060680935031"
+)
+
+func TestValidBoleto(t *testing.T) {
+	for _, s := range []string{boletoSample, boletoSampleMasked, "00190000090000000000000000000000500000000000000"} {
+		if !ValidBoleto(s) {
+			t.Errorf("ValidBoleto(%q) = false, expected true", s)
+		}
+	}
+	invalid := []string{
+		"",
+		boletoSample[:46],
+		boletoSample + "0",
+		"10190500954014481606906809350314337370000000100", // field 1 data
+		"00190500964014481606906809350314337370000000100", // field 1 check digit
+		"00190500954014481606906809350314437370000000100", // general check digit
+		"00190500954014481606906809350314337370000000101", // amount
+		"00000000000000000000000000000000000000000000000", // general check digit is never 0
+	}
+	for _, s := range invalid {
+		if ValidBoleto(s) {
+			t.Errorf("ValidBoleto(%q) = true, expected false", s)
+		}
+	}
+	if got := boletoLine(boletoSampleBarcode); got != boletoSample {
+		t.Errorf("boletoLine(%q) = %q, expected %q", boletoSampleBarcode, got, boletoSample)
+	}
+	if got := boletoBarcode(boletoSample); got != boletoSampleBarcode {
+		t.Errorf("boletoBarcode(%q) = %q, expected %q", boletoSample, got, boletoSampleBarcode)
+	}
+}
+
+func TestMaskBoleto(t *testing.T) {
+	if got := MaskBoleto(boletoSample); got != boletoSampleMasked {
+		t.Errorf("MaskBoleto() = %q, expected %q", got, boletoSampleMasked)
+	}
+	if got := UnmaskBoleto(boletoSampleMasked); got != boletoSample {
+		t.Errorf("UnmaskBoleto() = %q, expected %q", got, boletoSample)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("MaskBoleto() of 46 digits did not panic")
+		}
+	}()
+	MaskBoleto(boletoSample[:46])
+}
+
+func TestBoletoGenerators(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	banks := map[string]bool{}
+	var noDue, zero bool
+	for i := 0; i < 500; i++ {
+		line, shrink := Boleto().Generate(r, gen.Size{})
+		if shrink == nil {
+			t.Fatal("Boleto().Generate() returned nil shrinker")
+		}
+		if len(line) != 47 || !ValidBoleto(line) || !ValidBoleto(MaskBoleto(line)) {
+			t.Fatalf("Boleto() = %q is invalid", line)
+		}
+		banks[line[:3]] = true
+		noDue = noDue || line[33:37] == "0000"
+		zero = zero || line[37:] == "0000000000"
+
+		bad, _ := BoletoInvalid().Generate(r, gen.Size{})
+		if len(UnmaskBoleto(bad)) != 47 || ValidBoleto(bad) {
+			t.Fatalf("BoletoInvalid() = %q is valid or malformed", bad)
+		}
+	}
+	if len(banks) < 5 || !noDue || !zero {
+		t.Errorf("%d banks, no due date %v, zero amount %v; expected several banks and both", len(banks), noDue, zero)
+	}
+}
+
+func TestBoleto_ShrinkToCanonical(t *testing.T) {
+	for _, strategy := range []string{"bfs", "dfs"} {
+		gen.SetShrinkStrategy(strategy)
+		r := rand.New(rand.NewSource(2))
+		for i := 0; i < 20; i++ {
+			line, shrink := Boleto().Generate(r, gen.Size{})
+			min, accept := line, false
+			for j := 0; j < 5000; j++ {
+				next, ok := shrink(accept)
+				if !ok {
+					break
+				}
+				if !ValidBoleto(next) {
+					t.Fatalf("%s: shrinking %q proposed invalid %q", strategy, line, next)
+				}
+				if accept = boletoSimpler(next, min); accept {
+					min = next
+				}
+			}
+			if want := "00190000090000000000000000000000500000000000000"; min != want {
+				t.Errorf("%s: Boleto() %q shrunk to %q, expected %q", strategy, line, min, want)
+			}
+		}
+	}
+	gen.SetShrinkStrategy("bfs")
+}
+
+// boletoSimpler reports whether the barcode data (without check digits) of
+// line a has fewer non-zero digits than that of b, or as many and is smaller.
+func boletoSimpler(a, b string) bool {
+	da, db := boletoBarcode(a), boletoBarcode(b)
+	da, db = da[:4]+da[5:], db[:4]+db[5:]
+	na, nb := len(strings.ReplaceAll(da, "0", "")), len(strings.ReplaceAll(db, "0", ""))
+	if na != nb {
+		return na < nb
+	}
+	return da < db
+}
@@ -0,0 +1,128 @@
+package domain
+
+import (
+	"math/rand"
+	"net/netip"
+
+	"arcsyn.io/propx/gen"
+)
+
+// CIDR generates valid CIDR blocks (e.g. "192.168.0.0/24" or "2001:db8::/32")
+// whose network address has all host bits zeroed.
+// ipv6 selects IPv6 blocks; otherwise IPv4 blocks are generated.
+// Shrink: narrows the block (prefix length toward a single host) and moves
+// the base address toward zero.
+func CIDR(ipv6 bool) gen.Generator[string] {
+	return gen.From(func(r *rand.Rand, _ gen.Size) (string, gen.Shrinker[string]) {
+		if r == nil {
+			r = rand.New(rand.NewSource(rand.Int63())) // #nosec G404 -- Using math/rand for deterministic property-based testing
+		}
+		cur := generateCIDR(r, ipv6)
+		return cur.String(), createCIDRShrinker(cur)
+	})
+}
+
+// ValidCIDR reports whether s is a CIDR block whose host bits are all zero.
+func ValidCIDR(s string) bool {
+	p, err := netip.ParsePrefix(s)
+	if err != nil {
+		return false
+	}
+	return p == p.Masked()
+}
+
+// generateCIDR draws a random address and prefix length and masks the host bits.
+func generateCIDR(r *rand.Rand, ipv6 bool) netip.Prefix {
+	var addr netip.Addr
+	if ipv6 {
+		var b [16]byte
+		r.Read(b[:])
+		addr = netip.AddrFrom16(b)
+	} else {
+		var b [4]byte
+		r.Read(b[:])
+		addr = netip.AddrFrom4(b)
+	}
+	bits := r.Intn(addr.BitLen() + 1)
+	return netip.PrefixFrom(addr, bits).Masked()
+}
+
+// createCIDRShrinker creates a shrinker for CIDR blocks.
+func createCIDRShrinker(initial netip.Prefix) gen.Shrinker[string] {
+	queue := make([]netip.Prefix, 0, 32)
+	seen := make(map[netip.Prefix]struct{}, 64)
+	var last netip.Prefix
+	cur := initial
+
+	push := func(p netip.Prefix) {
+		p = p.Masked()
+		if _, ok := seen[p]; ok {
+			return
+		}
+		seen[p] = struct{}{}
+		queue = append(queue, p)
+	}
+
+	growNeighbors := func(base netip.Prefix) {
+		queue = queue[:0]
+		addr, bits, maxBits := base.Addr(), base.Bits(), base.Addr().BitLen()
+
+		// (1) narrow the block: single host, bisection, then one more bit
+		if bits < maxBits {
+			push(netip.PrefixFrom(addr, maxBits))
+			push(netip.PrefixFrom(addr, bits+(maxBits-bits+1)/2))
+			push(netip.PrefixFrom(addr, bits+1))
+		}
+
+		// (2) move the address toward zero: all zero, zero each byte L->R, halve each byte
+		raw := addr.AsSlice()
+		zero := make([]byte, len(raw))
+		push(netip.PrefixFrom(addrFromSlice(zero), bits))
+		for i := range raw {
+			if raw[i] == 0 {
+				continue
+			}
+			b := append([]byte(nil), raw...)
+			b[i] = 0
+			push(netip.PrefixFrom(addrFromSlice(b), bits))
+			b[i] = raw[i] / 2
+			push(netip.PrefixFrom(addrFromSlice(b), bits))
+		}
+	}
+
+	popNext := func() (netip.Prefix, bool) {
+		if len(queue) == 0 {
+			return netip.Prefix{}, false
+		}
+		if gen.GetShrinkStrategy() == gen.ShrinkStrategyDFS {
+			v := queue[len(queue)-1]
+			queue = queue[:len(queue)-1]
+			return v, true
+		}
+		v := queue[0]
+		queue = queue[1:]
+		return v, true
+	}
+
+	seen[cur] = struct{}{}
+	growNeighbors(cur)
+
+	return func(accept bool) (string, bool) {
+		if accept && last.IsValid() && last != cur {
+			cur = last
+			growNeighbors(cur)
+		}
+		nxt, ok := popNext()
+		if !ok {
+			return "", false
+		}
+		last = nxt
+		return nxt.String(), true
+	}
+}
+
+// addrFromSlice builds an IPv4 or IPv6 address from its 4- or 16-byte form.
+func addrFromSlice(b []byte) netip.Addr {
+	addr, _ := netip.AddrFromSlice(b)
+	return addr
+}
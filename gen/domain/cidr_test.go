@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"math/rand"
+	"net/netip"
+	"testing"
+
+	"arcsyn.io/propx/gen"
+)
+
+func TestCIDR(t *testing.T) {
+	for _, ipv6 := range []bool{false, true} {
+		g := CIDR(ipv6)
+		r := rand.New(rand.NewSource(123))
+
+		for i := 0; i < 50; i++ {
+			value, shrink := g.Generate(r, gen.Size{})
+
+			if !ValidCIDR(value) {
+				t.Errorf("CIDR(%v).Generate() = %q, expected valid CIDR", ipv6, value)
+			}
+			p := netip.MustParsePrefix(value)
+			if p.Addr().Is6() != ipv6 {
+				t.Errorf("CIDR(%v).Generate() = %q, wrong address family", ipv6, value)
+			}
+			if shrink == nil {
+				t.Error("CIDR().Generate() returned nil shrinker")
+			}
+		}
+	}
+}
+
+func TestCIDR_ShrinkProducesValidBlocks(t *testing.T) {
+	g := CIDR(false)
+	r := rand.New(rand.NewSource(42))
+	_, shrink := g.Generate(r, gen.Size{})
+
+	// accept every candidate: shrinking converges to the zero address, single host
+	min := ""
+	for i := 0; i < 1000; i++ {
+		next, ok := shrink(true)
+		if !ok {
+			break
+		}
+		if !ValidCIDR(next) {
+			t.Fatalf("shrink candidate %q is not a valid CIDR", next)
+		}
+		min = next
+	}
+	if min != "" && min != "0.0.0.0/32" {
+		t.Errorf("CIDR() shrunk to %q, expected '0.0.0.0/32'", min)
+	}
+}
+
+func TestValidCIDR(t *testing.T) {
+	tests := []struct {
+		in   string
+		want bool
+	}{
+		{"192.168.0.0/24", true},
+		{"10.0.0.0/8", true},
+		{"0.0.0.0/0", true},
+		{"2001:db8::/32", true},
+		{"192.168.0.1/24", false}, // host bits set
+		{"192.168.0.0", false},    // missing prefix
+		{"192.168.0.0/33", false}, // prefix too long
+		{"not-a-cidr", false},
+	}
+	for _, tt := range tests {
+		if got := ValidCIDR(tt.in); got != tt.want {
+			t.Errorf("ValidCIDR(%q) = %v, expected %v", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,391 @@
+package domain
+
+import (
+	"math/rand"
+	"strconv"
+	"strings"
+
+	"arcsyn.io/propx/gen"
+)
+
+// cronField describes one field of a cron expression.
+type cronField struct {
+	min, max int
+	names    []string // names of min, min+1, ... (month and day of week)
+}
+
+// cronFields are the fields of a 6-field expression; 5-field expressions
+// start at the minute.
+var cronFields = [...]cronField{
+	{min: 0, max: 59}, // second
+	{min: 0, max: 59}, // minute
+	{min: 0, max: 23}, // hour
+	{min: 1, max: 31}, // day of month
+	{min: 1, max: 12, names: []string{"JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}},
+	{min: 0, max: 7, names: []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}}, // 0 and 7 are Sunday
+}
+
+// cronCorruptions are the ways CronInvalid breaks an expression.
+const (
+	cronTooFewFields  = iota // four fields
+	cronTooManyFields        // seven fields
+	cronOutOfRange           // a value past the field's maximum ("60" minutes)
+	cronReversedRange        // a range whose start is after its end ("59-0")
+	cronZeroStep             // "*/0"
+	cronEmptyElement         // a list with an empty element ("1,")
+	cronUnknownName          // a name that is not a month or weekday ("FOO")
+	cronCorruptions
+)
+
+// cronElem is one element of a field list: "*", a value or a range, with an
+// optional step.
+type cronElem struct {
+	star   bool
+	lo, hi int
+	step   int  // 0 when there is no step
+	named  bool // lo and hi written as names where the field has them
+}
+
+// cronSpec is an expression as fields of elements plus, for invalid
+// expressions, the corruption applied when rendering.
+type cronSpec struct {
+	fields  [][]cronElem
+	seconds bool
+	corrupt int // -1 when valid
+	at      int // field index the corruption applies to
+}
+
+// Cron generates valid 5-field cron expressions ("minute hour day-of-month
+// month day-of-week", e.g. "*/15 9-17 * JAN,JUL MON-FRI"), for testing cron
+// parsers and schedulers. Fields mix "*", values, ranges ("a-b"), steps
+// ("*/n", "a-b/n") and lists ("a,b-c"); months and weekdays are sometimes
+// written as names (JAN-DEC, SUN-SAT), and day of week 7 (Sunday) appears.
+// Shrink: turns fields into "*", drops list elements and steps, writes names
+// as numbers and moves values toward the field's minimum, converging toward
+// "* * * * *".
+func Cron() gen.Generator[string] {
+	return cronGenerator(false, false)
+}
+
+// CronWithSeconds is like Cron with a leading seconds field (0-59), as
+// accepted by Quartz-style and some Go schedulers. Shrinks toward
+// "* * * * * *".
+func CronWithSeconds() gen.Generator[string] {
+	return cronGenerator(true, false)
+}
+
+// CronInvalid generates 5-field cron expressions with one defect: four or
+// seven fields, a value out of range, a reversed range, a zero step, an empty
+// list element or an unknown name.
+// Shrink: like Cron, keeping the same defect so every candidate stays
+// invalid.
+func CronInvalid() gen.Generator[string] {
+	return cronGenerator(false, true)
+}
+
+// ValidCron reports whether s is a valid 5-field (or, with a leading seconds
+// field, 6-field) cron expression: fields separated by whitespace, each a
+// comma-separated list of "*", values or ranges "a-b" (a <= b) with optional
+// steps "/n" (1 <= n <= the field's span) on "*" and ranges. Values must be in
+// the field's range; months and weekdays may be names in any case. Macros
+// ("@daily") and Quartz extensions ("?", "L", "W", "#") are not accepted.
+func ValidCron(s string) bool {
+	parts := strings.Fields(s)
+	var fields []cronField
+	switch len(parts) {
+	case 5:
+		fields = cronFields[1:]
+	case 6:
+		fields = cronFields[:]
+	default:
+		return false
+	}
+	for i, p := range parts {
+		if !validCronField(p, fields[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// validCronField reports whether s is a valid list for field f.
+func validCronField(s string, f cronField) bool {
+	for _, e := range strings.Split(s, ",") {
+		base, step, hasStep := strings.Cut(e, "/")
+		if hasStep {
+			n, ok := cronNumber(step)
+			if !ok || n < 1 || n > f.max-f.min+1 {
+				return false
+			}
+		}
+		if base == "*" {
+			continue
+		}
+		lo, hi, isRange := strings.Cut(base, "-")
+		if hasStep && !isRange {
+			return false
+		}
+		a, ok := cronValue(lo, f)
+		if !ok {
+			return false
+		}
+		if isRange {
+			b, ok := cronValue(hi, f)
+			if !ok || a > b {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// cronValue parses a value of field f: a number in range or one of its names.
+func cronValue(s string, f cronField) (int, bool) {
+	for i, name := range f.names {
+		if strings.EqualFold(s, name) {
+			return f.min + i, true
+		}
+	}
+	n, ok := cronNumber(s)
+	return n, ok && n >= f.min && n <= f.max
+}
+
+// cronNumber parses a non-empty run of decimal digits.
+func cronNumber(s string) (int, bool) {
+	if s == "" || len(s) > 4 || strings.Trim(s, "0123456789") != "" {
+		return 0, false
+	}
+	n, err := strconv.Atoi(s)
+	return n, err == nil
+}
+
+// cronGenerator implements Cron, CronWithSeconds and CronInvalid.
+func cronGenerator(seconds, invalid bool) gen.Generator[string] {
+	return gen.From(func(r *rand.Rand, _ gen.Size) (string, gen.Shrinker[string]) {
+		if r == nil {
+			r = rand.New(rand.NewSource(rand.Int63())) // #nosec G404 -- Using math/rand for deterministic property-based testing
+		}
+		spec := cronSpec{seconds: seconds, corrupt: -1}
+		for _, f := range spec.fieldDefs() {
+			spec.fields = append(spec.fields, generateCronField(r, f))
+		}
+		if invalid {
+			spec.corrupt = r.Intn(cronCorruptions)
+			spec.at = r.Intn(len(spec.fields))
+		}
+		return spec.render(), createCronShrinker(spec)
+	})
+}
+
+// generateCronField draws the element list of one field: "*" most often,
+// otherwise a value, a range, a step or a list of them.
+func generateCronField(r *rand.Rand, f cronField) []cronElem {
+	switch r.Intn(10) {
+	case 0, 1, 2, 3:
+		return []cronElem{{star: true}}
+	case 4:
+		return []cronElem{{star: true, step: 1 + r.Intn(f.max-f.min+1)}}
+	case 5, 6:
+		n := 2 + r.Intn(3)
+		out := make([]cronElem, n)
+		for i := range out {
+			out[i] = generateCronElem(r, f)
+		}
+		return out
+	}
+	return []cronElem{generateCronElem(r, f)}
+}
+
+// generateCronElem draws a value, a range or a range with a step.
+func generateCronElem(r *rand.Rand, f cronField) cronElem {
+	value := func() int {
+		switch r.Intn(6) {
+		case 0:
+			return f.min
+		case 1:
+			return f.max
+		}
+		return f.min + r.Intn(f.max-f.min+1)
+	}
+	e := cronElem{named: f.names != nil && r.Intn(3) == 0}
+	e.lo = value()
+	e.hi = e.lo
+	if r.Intn(2) == 0 {
+		e.hi = value()
+		if e.hi < e.lo {
+			e.lo, e.hi = e.hi, e.lo
+		}
+		if r.Intn(3) == 0 {
+			e.step = 1 + r.Intn(f.max-f.min+1)
+		}
+	}
+	return e
+}
+
+// createCronShrinker creates a shrinker for cron specs.
+func createCronShrinker(initial cronSpec) gen.Shrinker[string] {
+	render := cronSpec.render
+	return gen.ShrinkNeighbors(initial, render, render, func(base cronSpec, add func(cronSpec)) {
+		push := func(s cronSpec) {
+			// an invalid expression must stay invalid while shrinking
+			if s.corrupt >= 0 && ValidCron(s.render()) {
+				return
+			}
+			add(s)
+		}
+		// (1) every field "*", then each field "*" (L->R)
+		all := base.clone()
+		for i := range all.fields {
+			all.fields[i] = []cronElem{{star: true}}
+		}
+		push(all)
+		for i := range base.fields {
+			s := base.clone()
+			s.fields[i] = []cronElem{{star: true}}
+			push(s)
+		}
+		// (2) drop list elements, then simplify elements
+		base.simplify(push)
+	})
+}
+
+// simplify pushes the specs one step simpler than s within its fields: with
+// a list element dropped (R->L), then with an element simplified (no step,
+// numbers for names, a single value, values toward the field's minimum).
+func (s cronSpec) simplify(push func(cronSpec)) {
+	defs := s.fieldDefs()
+	withElem := func(i, j int, e cronElem) {
+		c := s.clone()
+		c.fields[i][j] = e
+		push(c)
+	}
+	// drop list elements (R->L)
+	for i, es := range s.fields {
+		for j := len(es) - 1; j >= 0 && len(es) > 1; j-- {
+			c := s.clone()
+			c.fields[i] = append(c.fields[i][:j], c.fields[i][j+1:]...)
+			push(c)
+		}
+	}
+	// simplify elements: no step, numbers for names, a single value,
+	// then values toward the minimum
+	for i, es := range s.fields {
+		f := defs[i]
+		for j, e := range es {
+			if e.step > 0 {
+				n := e
+				n.step = 0
+				withElem(i, j, n)
+			}
+			if e.star {
+				if e.step > 0 {
+					withElem(i, j, cronElem{lo: f.min, hi: f.min})
+				}
+				continue
+			}
+			if e.named {
+				n := e
+				n.named = false
+				withElem(i, j, n)
+			}
+			if e.hi != e.lo {
+				n := e
+				n.hi, n.step = n.lo, 0
+				withElem(i, j, n)
+			}
+			if e.lo > f.min {
+				n := e
+				n.lo = f.min
+				withElem(i, j, n)
+				n.lo = f.min + (e.lo-f.min)/2
+				withElem(i, j, n)
+			}
+			if e.hi > e.lo {
+				n := e
+				n.hi = e.lo + (e.hi-e.lo)/2
+				withElem(i, j, n)
+			}
+		}
+	}
+}
+
+// fieldDefs returns the definitions of the spec's fields.
+func (s cronSpec) fieldDefs() []cronField {
+	if s.seconds {
+		return cronFields[:]
+	}
+	return cronFields[1:]
+}
+
+// render returns the expression text, applying the corruption of invalid
+// specs.
+func (s cronSpec) render() string {
+	defs := s.fieldDefs()
+	parts := make([]string, len(s.fields))
+	for i, es := range s.fields {
+		parts[i] = renderCronField(es, defs[i])
+	}
+	if s.corrupt < 0 {
+		return strings.Join(parts, " ")
+	}
+	f := defs[s.at]
+	switch s.corrupt {
+	case cronTooFewFields:
+		parts = parts[:4]
+	case cronTooManyFields:
+		for len(parts) < 7 {
+			parts = append(parts, "*")
+		}
+	case cronOutOfRange:
+		parts[s.at] = strconv.Itoa(f.max + 1)
+	case cronReversedRange:
+		parts[s.at] = strconv.Itoa(f.max) + "-" + strconv.Itoa(f.min)
+	case cronZeroStep:
+		parts[s.at] = "*/0"
+	case cronEmptyElement:
+		parts[s.at] += ","
+	case cronUnknownName:
+		parts[s.at] = "FOO"
+	}
+	return strings.Join(parts, " ")
+}
+
+// renderCronField writes the element list of field f.
+func renderCronField(es []cronElem, f cronField) string {
+	value := func(v int, named bool) string {
+		if named && v-f.min < len(f.names) {
+			return f.names[v-f.min]
+		}
+		return strconv.Itoa(v)
+	}
+	out := make([]string, len(es))
+	for i, e := range es {
+		var b strings.Builder
+		switch {
+		case e.star:
+			b.WriteString("*")
+		case e.hi == e.lo && e.step == 0: // a stepped value is written "a-a/n"
+			b.WriteString(value(e.lo, e.named))
+		default:
+			b.WriteString(value(e.lo, e.named))
+			b.WriteString("-")
+			b.WriteString(value(e.hi, e.named))
+		}
+		if e.step > 0 {
+			b.WriteString("/")
+			b.WriteString(strconv.Itoa(e.step))
+		}
+		out[i] = b.String()
+	}
+	return strings.Join(out, ",")
+}
+
+// clone returns a deep copy of the spec.
+func (s cronSpec) clone() cronSpec {
+	fields := make([][]cronElem, len(s.fields))
+	for i, es := range s.fields {
+		fields[i] = append([]cronElem(nil), es...)
+	}
+	s.fields = fields
+	return s
+}
@@ -0,0 +1,162 @@
+package domain
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"arcsyn.io/propx/gen"
+)
+
+func TestCron(t *testing.T) {
+	tests := []struct {
+		name   string
+		g      gen.Generator[string]
+		fields int
+	}{
+		{"Cron", Cron(), 5},
+		{"CronWithSeconds", CronWithSeconds(), 6},
+	}
+	for _, tt := range tests {
+		r := rand.New(rand.NewSource(123))
+		var all strings.Builder
+		for i := 0; i < 300; i++ {
+			value, shrink := tt.g.Generate(r, gen.Size{})
+			if !ValidCron(value) {
+				t.Errorf("%s().Generate() = %q, expected a valid expression", tt.name, value)
+			}
+			if n := len(strings.Fields(value)); n != tt.fields {
+				t.Errorf("%s().Generate() = %q has %d fields, expected %d", tt.name, value, n, tt.fields)
+			}
+			if shrink == nil {
+				t.Errorf("%s().Generate() returned nil shrinker", tt.name)
+			}
+			all.WriteString(value + "\n")
+		}
+		// the grammar's building blocks all show up
+		for _, part := range []string{"*/", "-", ",", "/"} {
+			if !strings.Contains(all.String(), part) {
+				t.Errorf("%s() never generated %q", tt.name, part)
+			}
+		}
+		if !strings.ContainsAny(all.String(), "JFMASOND") {
+			t.Errorf("%s() never generated a name", tt.name)
+		}
+	}
+}
+
+func TestCron_ShrinksTowardAllStars(t *testing.T) {
+	tests := []struct {
+		g    gen.Generator[string]
+		want string
+	}{
+		{Cron(), "* * * * *"},
+		{CronWithSeconds(), "* * * * * *"},
+	}
+	for _, tt := range tests {
+		for seed := int64(0); seed < 20; seed++ {
+			_, shrink := tt.g.Generate(rand.New(rand.NewSource(seed)), gen.Size{})
+			min := ""
+			for i := 0; i < 1000; i++ {
+				next, ok := shrink(true)
+				if !ok {
+					break
+				}
+				if !ValidCron(next) {
+					t.Fatalf("shrink candidate %q is not a valid expression", next)
+				}
+				min = next
+			}
+			if min != "" && min != tt.want {
+				t.Errorf("seed %d: shrunk to %q, expected %q", seed, min, tt.want)
+			}
+		}
+	}
+}
+
+func TestCron_ShrinkKeepsFailingField(t *testing.T) {
+	// fails while the hour field is not "*": shrinking keeps it, simplified
+	g := Cron()
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 50; i++ {
+		value, shrink := g.Generate(r, gen.Size{})
+		fails := func(s string) bool { return strings.Fields(s)[1] != "*" }
+		if !fails(value) {
+			continue
+		}
+		min, accept := value, false
+		for j := 0; j < 1000; j++ {
+			next, ok := shrink(accept)
+			if !ok {
+				break
+			}
+			if accept = fails(next); accept {
+				min = next
+			}
+		}
+		if min != "* 0 * * *" {
+			t.Errorf("%q shrunk to %q, expected \"* 0 * * *\"", value, min)
+		}
+	}
+}
+
+func TestCronInvalid(t *testing.T) {
+	g := CronInvalid()
+	r := rand.New(rand.NewSource(7))
+	for i := 0; i < 300; i++ {
+		value, shrink := g.Generate(r, gen.Size{})
+		if ValidCron(value) {
+			t.Fatalf("CronInvalid().Generate() = %q, expected an invalid expression", value)
+		}
+		for j := 0; j < 200; j++ {
+			next, ok := shrink(true)
+			if !ok {
+				break
+			}
+			if ValidCron(next) {
+				t.Fatalf("shrink candidate %q of %q is valid", next, value)
+			}
+		}
+	}
+}
+
+func TestValidCron(t *testing.T) {
+	tests := []struct {
+		in   string
+		want bool
+	}{
+		{"* * * * *", true},
+		{"0 0 1 1 0", true},
+		{"59 23 31 12 7", true},
+		{"*/15 9-17 * JAN,jul MON-FRI", true},
+		{"0-59/59 0-23/24 1-31/31 1-12/12 0-7/8", true},
+		{"5-5/2 * * * *", true},
+		{"  0   12 * *  sun ", true},
+		{"30 * * * * *", true}, // with seconds
+		{"* * * *", false},
+		{"* * * * * * *", false},
+		{"60 * * * *", false},
+		{"* 24 * * *", false},
+		{"* * 0 * *", false},
+		{"* * * 13 *", false},
+		{"* * * * 8", false},
+		{"59-0 * * * *", false},
+		{"*/0 * * * *", false},
+		{"*/61 * * * *", false},
+		{"5/15 * * * *", false}, // step on a single value
+		{"1,,2 * * * *", false},
+		{"1, * * * *", false},
+		{"* * * FOO *", false},
+		{"* * * MON *", false}, // weekday name in the month field
+		{"-1 * * * *", false},
+		{"@daily", false},
+		{"0 0 ? * MON", false},
+		{"0 0 L * *", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := ValidCron(tt.in); got != tt.want {
+			t.Errorf("ValidCron(%q) = %v, expected %v", tt.in, got, tt.want)
+		}
+	}
+}
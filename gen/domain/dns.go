@@ -0,0 +1,329 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"arcsyn.io/propx/gen"
+)
+
+// DNS length limits (RFC 1035): 63 octets per label and 253 characters for
+// a name in dotted text form.
+const (
+	maxDNSLabelLen = 63
+	maxDomainLen   = 253
+)
+
+// ldhAlphabet holds the letters, digits and hyphen allowed in host labels,
+// with the shrink target 'a' first.
+const ldhAlphabet = "abcdefghijklmnopqrstuvwxyz0123456789-"
+
+// idnPrefix marks a label holding a punycode-encoded internationalized label.
+const idnPrefix = "xn--"
+
+// publicSuffixes are registry suffixes a registrable name ends with, "com"
+// first.
+var publicSuffixes = strings.Fields(`com
+org net io dev app info biz xyz de fr br jp uk nl it es ca au
+co.uk org.uk com.br net.br co.jp com.au`)
+
+// idnRunes are the non-ASCII letters used in internationalized labels, 'ü'
+// first, followed by ASCII letters that may be mixed in.
+var idnRunes = []rune("üéñöçåøłßждяλπ日本中文한국ไทยالعربيةabcdefghijklmnopqrstuvwxyz")
+
+// DNSLabel generates host labels: 1-63 letters, digits and hyphens, never
+// starting or ending with a hyphen nor holding "--" at positions 3-4 (which
+// is reserved for "xn--" labels). Mostly short, sometimes up to 63 characters.
+// Shrink: toward "a".
+func DNSLabel() gen.Generator[string] {
+	return gen.Do(func(d *gen.Draw) string {
+		max := 10
+		if gen.DrawFrom(d, gen.IntRange(0, 7)) == 7 {
+			max = maxDNSLabelLen
+		}
+		return ldhLabel(gen.DrawFrom(d, gen.String(ldhAlphabet, gen.Size{Min: 1, Max: max})))
+	})
+}
+
+// IDNLabel generates internationalized labels in their ASCII form: "xn--"
+// followed by the punycode encoding of a lowercase Unicode label with at
+// least one non-ASCII letter, such as "xn--mnchen-3ya" (münchen).
+// Shrink: toward "xn--tda" (ü).
+func IDNLabel() gen.Generator[string] {
+	return gen.Map(gen.SliceOf(gen.IntRange(0, len(idnRunes)-1), gen.Size{Min: 1, Max: 8}), func(idx []int) string {
+		rs := make([]rune, 0, len(idx))
+		ascii := true
+		for _, i := range idx {
+			rs = append(rs, idnRunes[i])
+			ascii = ascii && idnRunes[i] < utf8.RuneSelf
+		}
+		if ascii {
+			rs = append([]rune{idnRunes[0]}, rs...)
+		}
+		return idnPrefix + punycodeEncode(rs)
+	})
+}
+
+// DomainName generates registrable domain names: one to three labels (see
+// DNSLabel) under a public suffix, such as "shop.example.co.uk".
+// Shrink: drops labels and simplifies them, and moves the suffix toward
+// "com", converging toward "a.com".
+func DomainName() gen.Generator[string] {
+	return domainName(func(*gen.Draw) bool { return false })
+}
+
+// DomainNameIDN is like DomainName, but each label is an internationalized
+// one (see IDNLabel) half of the time.
+// Shrink: as DomainName, converging toward "a.com".
+func DomainNameIDN() gen.Generator[string] {
+	return domainName(func(d *gen.Draw) bool { return gen.DrawFrom(d, gen.Bool()) })
+}
+
+// domainName draws the labels of a name, using IDNLabel when idn says so.
+func domainName(idn func(*gen.Draw) bool) gen.Generator[string] {
+	return gen.Do(func(d *gen.Draw) string {
+		n := 1 + gen.DrawFrom(d, gen.IntRange(0, 2))
+		labels := make([]string, 0, n+1)
+		for i := 0; i < n; i++ {
+			if idn(d) {
+				labels = append(labels, gen.DrawFrom(d, IDNLabel()))
+			} else {
+				labels = append(labels, gen.DrawFrom(d, DNSLabel()))
+			}
+		}
+		labels = append(labels, gen.DrawFrom(d, codeFrom(publicSuffixes)))
+		return strings.Join(labels, ".")
+	})
+}
+
+// ValidDNSLabel reports whether s is a valid host label: 1-63 letters,
+// digits and hyphens (any case), not starting or ending with a hyphen, and
+// with "--" at positions 3-4 only in "xn--" labels whose punycode decodes to
+// a canonical label with non-ASCII letters.
+func ValidDNSLabel(s string) bool {
+	if len(s) == 0 || len(s) > maxDNSLabelLen || s[0] == '-' || s[len(s)-1] == '-' {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i] | 0x20 // lowercase letters; digits and '-' are unchanged
+		if !(c >= 'a' && c <= 'z' || s[i] >= '0' && s[i] <= '9' || s[i] == '-') {
+			return false
+		}
+	}
+	if len(s) < 4 || s[2:4] != "--" {
+		return true
+	}
+	if !strings.EqualFold(s[:4], idnPrefix) {
+		return false
+	}
+	rs, err := punycodeDecode(strings.ToLower(s[4:]))
+	if err != nil || punycodeEncode(rs) != strings.ToLower(s[4:]) {
+		return false
+	}
+	for _, r := range rs {
+		if r >= utf8.RuneSelf {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidDomainName reports whether s is a domain name of at most 253
+// characters with at least two valid labels (see ValidDNSLabel) and a
+// top-level label that is not all digits. A trailing root dot is not allowed.
+func ValidDomainName(s string) bool {
+	if len(s) > maxDomainLen {
+		return false
+	}
+	labels := strings.Split(s, ".")
+	if len(labels) < 2 {
+		return false
+	}
+	for _, l := range labels {
+		if !ValidDNSLabel(l) {
+			return false
+		}
+	}
+	return strings.Trim(labels[len(labels)-1], "0123456789") != ""
+}
+
+// ldhLabel turns s (drawn from ldhAlphabet) into a valid label by replacing
+// the hyphens in forbidden positions with 'a'.
+func ldhLabel(s string) string {
+	if s == "" {
+		return "a"
+	}
+	b := []byte(s)
+	if b[0] == '-' {
+		b[0] = 'a'
+	}
+	if b[len(b)-1] == '-' {
+		b[len(b)-1] = 'a'
+	}
+	if len(b) >= 4 && b[2] == '-' && b[3] == '-' {
+		b[2] = 'a'
+	}
+	return string(b)
+}
+
+// Punycode parameters (RFC 3492, section 5).
+const (
+	punyBase        = 36
+	punyTMin        = 1
+	punyTMax        = 26
+	punySkew        = 38
+	punyDamp        = 700
+	punyInitialBias = 72
+	punyInitialN    = 128
+)
+
+// errPunycode reports malformed punycode input.
+var errPunycode = errors.New("domain: invalid punycode")
+
+// punycodeEncode encodes a Unicode label as punycode (without the "xn--"
+// prefix), following RFC 3492.
+func punycodeEncode(input []rune) string {
+	var out strings.Builder
+	for _, r := range input {
+		if r < utf8.RuneSelf {
+			out.WriteRune(r)
+		}
+	}
+	b := out.Len()
+	h := b
+	if b > 0 {
+		out.WriteByte('-')
+	}
+	n, delta, bias := rune(punyInitialN), 0, punyInitialBias
+	for h < len(input) {
+		m := rune(utf8.MaxRune)
+		for _, r := range input {
+			if r >= n && r < m {
+				m = r
+			}
+		}
+		delta += int(m-n) * (h + 1)
+		n = m
+		for _, r := range input {
+			if r < n {
+				delta++
+			}
+			if r != n {
+				continue
+			}
+			q := delta
+			for k := punyBase; ; k += punyBase {
+				t := punyThreshold(k, bias)
+				if q < t {
+					break
+				}
+				out.WriteByte(punyDigit(t + (q-t)%(punyBase-t)))
+				q = (q - t) / (punyBase - t)
+			}
+			out.WriteByte(punyDigit(q))
+			bias = punyAdapt(delta, h+1, h == b)
+			delta = 0
+			h++
+		}
+		delta++
+		n++
+	}
+	return out.String()
+}
+
+// punycodeDecode decodes a punycode label (without the "xn--" prefix),
+// following RFC 3492.
+func punycodeDecode(s string) ([]rune, error) {
+	var out []rune
+	pos := 0
+	if b := strings.LastIndexByte(s, '-'); b >= 0 {
+		for i := 0; i < b; i++ {
+			if s[i] >= utf8.RuneSelf {
+				return nil, errPunycode
+			}
+			out = append(out, rune(s[i]))
+		}
+		pos = b + 1
+	}
+	n, i, bias := rune(punyInitialN), 0, punyInitialBias
+	for pos < len(s) {
+		oldi, w := i, 1
+		for k := punyBase; ; k += punyBase {
+			if pos >= len(s) {
+				return nil, errPunycode
+			}
+			digit, ok := punyDigitValue(s[pos])
+			pos++
+			if !ok || digit > (utf8.MaxRune-i)/w {
+				return nil, errPunycode
+			}
+			i += digit * w
+			t := punyThreshold(k, bias)
+			if digit < t {
+				break
+			}
+			w *= punyBase - t
+		}
+		bias = punyAdapt(i-oldi, len(out)+1, oldi == 0)
+		n += rune(i / (len(out) + 1))
+		i %= len(out) + 1
+		if n > utf8.MaxRune || n < punyInitialN {
+			return nil, errPunycode
+		}
+		out = append(out, 0)
+		copy(out[i+1:], out[i:])
+		out[i] = n
+		i++
+	}
+	return out, nil
+}
+
+// punyThreshold clamps k - bias to [tmin, tmax].
+func punyThreshold(k, bias int) int {
+	switch {
+	case k <= bias:
+		return punyTMin
+	case k >= bias+punyTMax:
+		return punyTMax
+	default:
+		return k - bias
+	}
+}
+
+// punyAdapt is the bias adaptation function of RFC 3492, section 6.1.
+func punyAdapt(delta, numPoints int, first bool) int {
+	if first {
+		delta /= punyDamp
+	} else {
+		delta /= 2
+	}
+	delta += delta / numPoints
+	k := 0
+	for delta > ((punyBase-punyTMin)*punyTMax)/2 {
+		delta /= punyBase - punyTMin
+		k += punyBase
+	}
+	return k + (punyBase-punyTMin+1)*delta/(delta+punySkew)
+}
+
+// punyDigit returns the lowercase basic code point of digit d.
+func punyDigit(d int) byte {
+	if d < 26 {
+		return byte('a' + d)
+	}
+	return byte('0' + d - 26)
+}
+
+// punyDigitValue returns the value of a basic code point used as a digit.
+func punyDigitValue(c byte) (int, bool) {
+	switch {
+	case c >= 'a' && c <= 'z':
+		return int(c - 'a'), true
+	case c >= 'A' && c <= 'Z':
+		return int(c - 'A'), true
+	case c >= '0' && c <= '9':
+		return int(c-'0') + 26, true
+	}
+	return 0, false
+}
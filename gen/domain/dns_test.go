@@ -0,0 +1,177 @@
+package domain
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"arcsyn.io/propx/gen"
+)
+
+func TestPunycode_KnownVectors(t *testing.T) {
+	tests := []struct{ unicode, puny string }{
+		{"münchen", "mnchen-3ya"},
+		{"bücher", "bcher-kva"},
+		{"ü", "tda"},
+		{"日本", "wgv71a"},
+		{"пример", "e1afmkfd"},
+		{"abc", "abc-"},
+	}
+	for _, tt := range tests {
+		if got := punycodeEncode([]rune(tt.unicode)); got != tt.puny {
+			t.Errorf("punycodeEncode(%q) = %q, expected %q", tt.unicode, got, tt.puny)
+		}
+		got, err := punycodeDecode(tt.puny)
+		if err != nil || string(got) != tt.unicode {
+			t.Errorf("punycodeDecode(%q) = (%q, %v), expected %q", tt.puny, string(got), err, tt.unicode)
+		}
+	}
+	for _, bad := range []string{"a-b!", "9999999999a", "ü-abc", "abc-z"} {
+		if got, err := punycodeDecode(bad); err == nil && punycodeEncode(got) == bad {
+			t.Errorf("punycodeDecode(%q) = %q, expected an error", bad, string(got))
+		}
+	}
+}
+
+func TestPunycode_RoundTrip(t *testing.T) {
+	g := gen.SliceOf(gen.IntRange(0, 0x2FFFF), gen.Size{Min: 1, Max: 12})
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 500; i++ {
+		cps, _ := g.Generate(r, gen.Size{})
+		rs := make([]rune, len(cps))
+		for j, c := range cps {
+			rs[j] = rune(c)
+			if rs[j] >= 0xD800 && rs[j] <= 0xDFFF {
+				rs[j] = 'x' // surrogates are not valid runes
+			}
+		}
+		enc := punycodeEncode(rs)
+		dec, err := punycodeDecode(enc)
+		if err != nil || string(dec) != string(rs) {
+			t.Fatalf("round trip of %q via %q = (%q, %v)", string(rs), enc, string(dec), err)
+		}
+	}
+}
+
+func TestValidDNSLabel(t *testing.T) {
+	valid := []string{"a", "example", "my-host", "a1", "123", "xn--mnchen-3ya", "XN--TDA", "Example", strings.Repeat("a", 63)}
+	for _, s := range valid {
+		if !ValidDNSLabel(s) {
+			t.Errorf("ValidDNSLabel(%q) = false, expected true", s)
+		}
+	}
+	invalid := []string{"", "-a", "a-", "a_b", "a.b", "ab--c", "xn--abc-", "xn--", "xn--!!", "ü", strings.Repeat("a", 64)}
+	for _, s := range invalid {
+		if ValidDNSLabel(s) {
+			t.Errorf("ValidDNSLabel(%q) = true, expected false", s)
+		}
+	}
+}
+
+func TestValidDomainName(t *testing.T) {
+	valid := []string{"a.com", "example.co.uk", "xn--mnchen-3ya.de", "a.b.c.d"}
+	for _, s := range valid {
+		if !ValidDomainName(s) {
+			t.Errorf("ValidDomainName(%q) = false, expected true", s)
+		}
+	}
+	invalid := []string{"com", "a..com", "a.com.", "-a.com", "a.123", strings.Repeat("a.", 127) + "com"}
+	for _, s := range invalid {
+		if ValidDomainName(s) {
+			t.Errorf("ValidDomainName(%q) = true, expected false", s)
+		}
+	}
+}
+
+func TestDNSGenerators(t *testing.T) {
+	r := rand.New(rand.NewSource(123))
+	long := false
+	for i := 0; i < 300; i++ {
+		label, _ := DNSLabel().Generate(r, gen.Size{})
+		if !ValidDNSLabel(label) {
+			t.Fatalf("DNSLabel() = %q is invalid", label)
+		}
+		long = long || len(label) > 20
+
+		idn, _ := IDNLabel().Generate(r, gen.Size{})
+		if !strings.HasPrefix(idn, "xn--") || !ValidDNSLabel(idn) {
+			t.Fatalf("IDNLabel() = %q is invalid", idn)
+		}
+
+		name, _ := DomainName().Generate(r, gen.Size{})
+		if !ValidDomainName(name) || strings.Contains(name, "xn--") {
+			t.Fatalf("DomainName() = %q is invalid or internationalized", name)
+		}
+
+		name, _ = DomainNameIDN().Generate(r, gen.Size{})
+		if !ValidDomainName(name) {
+			t.Fatalf("DomainNameIDN() = %q is invalid", name)
+		}
+	}
+	if !long {
+		t.Error("DNSLabel() never generated a long label")
+	}
+}
+
+func TestDNSGenerators_ShrinkToShortest(t *testing.T) {
+	tests := []struct {
+		name string
+		g    gen.Generator[string]
+		want string
+	}{
+		{"DNSLabel", DNSLabel(), "a"},
+		{"IDNLabel", IDNLabel(), "xn--tda"},
+		{"DomainName", DomainName(), "a.com"},
+		{"DomainNameIDN", DomainNameIDN(), "a.com"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := rand.New(rand.NewSource(9))
+			for i := 0; i < 20; i++ {
+				value, shrink := tt.g.Generate(r, gen.Size{})
+				// every value "fails": accept candidates that are no more complex
+				min, accept := value, false
+				for j := 0; j < 3000; j++ {
+					next, ok := shrink(accept)
+					if !ok {
+						break
+					}
+					accept = !dnsSimpler(min, next)
+					if accept {
+						min = next
+					}
+				}
+				if min != tt.want {
+					t.Errorf("%s() %q shrunk to %q, expected %q", tt.name, value, min, tt.want)
+				}
+			}
+		})
+	}
+}
+
+// dnsSimpler reports whether a is strictly simpler than b: fewer labels, then
+// fewer internationalized labels, then a suffix earlier in publicSuffixes,
+// then shorter.
+func dnsSimpler(a, b string) bool {
+	ka, kb := dnsComplexity(a), dnsComplexity(b)
+	for i := range ka {
+		if ka[i] != kb[i] {
+			return ka[i] < kb[i]
+		}
+	}
+	return false
+}
+
+func dnsComplexity(s string) [4]int {
+	suffix := -1
+	for i, ps := range publicSuffixes {
+		if strings.HasSuffix(s, "."+ps) && (suffix < 0 || len(ps) > len(publicSuffixes[suffix])) {
+			suffix = i
+		}
+	}
+	labels := strings.Count(s, ".") + 1
+	if suffix >= 0 {
+		labels -= strings.Count(publicSuffixes[suffix], ".") + 1
+	}
+	return [4]int{labels, strings.Count(s, idnPrefix), suffix, len(s)}
+}
@@ -0,0 +1,160 @@
+package domain
+
+import (
+	"strings"
+
+	"arcsyn.io/propx/gen"
+)
+
+// filenameSafeChars is the POSIX portable filename character set.
+const filenameSafeChars = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789._-"
+
+// windowsReservedNames are the device names Windows reserves as file names,
+// with any extension and in any case.
+var windowsReservedNames = []string{
+	"CON", "PRN", "AUX", "NUL",
+	"COM1", "COM2", "COM3", "COM4", "COM5", "COM6", "COM7", "COM8", "COM9",
+	"LPT1", "LPT2", "LPT3", "LPT4", "LPT5", "LPT6", "LPT7", "LPT8", "LPT9",
+}
+
+// filenameExts are the extensions of generated names, the simplest first.
+var filenameExts = []string{"", ".txt", ".pdf", ".jpeg", ".tar.gz", ".TXT"}
+
+// filenameTrailers make names end in dots or spaces, which Windows strips.
+var filenameTrailers = []string{".", " ", "..", ". ", " .", "   "}
+
+// filenameUnicode are inserted into names: composed and decomposed accents,
+// CJK, an emoji, a right-to-left override that disguises the extension
+// (a, U+202E, "fdp.exe" displays as "aexe.pdf"), invisible and compatibility
+// characters, a character whose lowercase is longer, and invalid UTF-8.
+var filenameUnicode = []string{
+	"é", "e\u0301", "日本語", "😀", "\u202efdp", "\u200b", "\ufeff", "Ａ", "ﬁ", "İ", "\xc3\x28",
+}
+
+// filenameForbidden are characters Windows forbids in names, path
+// separators and control characters.
+var filenameForbidden = []string{"<", ">", ":", `"`, "/", `\`, "|", "?", "*", "\x00", "\n", "\t", "\x1f", "\x7f"}
+
+// filenamePrefixes start names as hidden files, command-line options or
+// traversal paths.
+var filenamePrefixes = []string{".", "-", " ", "~", "../", `..\`, "/", "C:"}
+
+// FilenameOption configures Filename.
+type FilenameOption func(*filenameConfig)
+
+type filenameConfig struct {
+	safe bool
+}
+
+// FilenameSafe restricts Filename to names SafeFilename accepts: the POSIX
+// portable characters A-Z, a-z, 0-9, '.', '_' and '-', never a reserved
+// Windows name, for the valid case of a sanitizer.
+func FilenameSafe() FilenameOption {
+	return func(c *filenameConfig) {
+		c.safe = true
+	}
+}
+
+// Filename generates file names for hardening upload sanitizers: safe names
+// ("report.pdf") and, most of the time, adversarial ones:
+//   - reserved Windows device names in any case, with or without an
+//     extension ("con", "NUL.txt")
+//   - names ending in dots or spaces ("a.txt.", "a ")
+//   - names of 255, 256, 260 or 1024 characters, ASCII or multi-byte, so
+//     some exceed 255 bytes but not 255 characters
+//   - Unicode: decomposed accents, emoji, a right-to-left override, zero-width
+//     and compatibility characters, and invalid UTF-8
+//   - forbidden and control characters and path separators ("a:b", "a/b")
+//   - hidden, option-like and traversal names (".a", "-a", "../a"), "." and
+//     ".."
+//
+// With FilenameSafe only safe names are generated.
+// Shrink: toward the simple safe name "a", or the simplest name of the same
+// kind (e.g. "con" among reserved names).
+func Filename(opts ...FilenameOption) gen.Generator[string] {
+	var cfg filenameConfig
+	for _, o := range opts {
+		o(&cfg)
+	}
+	if cfg.safe {
+		return gen.Do(drawSafeFilename)
+	}
+	return gen.Do(func(d *gen.Draw) string {
+		// the kind is drawn first, so shrinking settles it before the name
+		kind := gen.DrawFrom(d, smallInt(0, 7))
+		name := drawSafeFilename(d)
+		switch kind {
+		case 0:
+			return name
+		case 1:
+			reserved := gen.DrawFrom(d, constFrom(windowsReservedNames))
+			switch gen.DrawFrom(d, smallInt(0, 2)) {
+			case 0:
+				reserved = strings.ToLower(reserved)
+			case 1:
+				reserved = reserved[:1] + strings.ToLower(reserved[1:])
+			}
+			return reserved + gen.DrawFrom(d, constFrom(filenameExts))
+		case 2:
+			return name + gen.DrawFrom(d, constFrom(filenameTrailers))
+		case 3:
+			fill := gen.DrawFrom(d, constFrom([]string{"a", "é", "日", "😀"}))
+			n := gen.DrawFrom(d, gen.OneOf(gen.Const(256), gen.Const(255), gen.Const(260), gen.Const(1024)))
+			ext := gen.DrawFrom(d, constFrom(filenameExts))
+			return strings.Repeat(fill, n-len(ext)) + ext
+		case 4:
+			return name[:1] + gen.DrawFrom(d, constFrom(filenameUnicode)) + name[1:]
+		case 5:
+			return name[:1] + gen.DrawFrom(d, constFrom(filenameForbidden)) + name[1:]
+		case 6:
+			return gen.DrawFrom(d, constFrom(filenamePrefixes)) + name
+		default:
+			return gen.DrawFrom(d, constFrom([]string{".", ".."}))
+		}
+	})
+}
+
+// SafeFilename reports whether name is safe to store as is on POSIX and
+// Windows file systems: 1 to 255 characters of the POSIX portable set (A-Z,
+// a-z, 0-9, '.', '_' and '-'), not starting with '.' or '-', not ending with
+// '.', and not a reserved Windows device name, with or without an extension.
+func SafeFilename(name string) bool {
+	if name == "" || len(name) > 255 || name[0] == '.' || name[0] == '-' || name[len(name)-1] == '.' {
+		return false
+	}
+	for i := 0; i < len(name); i++ {
+		if strings.IndexByte(filenameSafeChars, name[i]) < 0 {
+			return false
+		}
+	}
+	return !windowsReserved(name)
+}
+
+// drawSafeFilename draws a name SafeFilename accepts: a letter or digit,
+// then up to 11 safe characters, and an extension.
+func drawSafeFilename(d *gen.Draw) string {
+	first := gen.DrawFrom(d, gen.String(filenameSafeChars[:62], gen.Size{Min: 1, Max: 1}))
+	rest := gen.DrawFrom(d, gen.String(filenameSafeChars[:62]+"_-", gen.Size{Min: 0, Max: 11}))
+	stem := (first + "a")[:1] + rest[:min(len(rest), 11)]
+	if windowsReserved(stem) {
+		stem += "_"
+	}
+	return stem + gen.DrawFrom(d, constFrom(filenameExts))
+}
+
+// windowsReserved reports whether name is a reserved Windows device name,
+// ignoring case and any extension.
+func windowsReserved(name string) bool {
+	stem, _, _ := strings.Cut(name, ".")
+	return containsCode(windowsReservedNames, strings.ToUpper(stem))
+}
+
+// constFrom generates the elements of list uniformly, as OneOf constants,
+// which shrink to the first under either strategy.
+func constFrom(list []string) gen.Generator[string] {
+	gs := make([]gen.Generator[string], len(list))
+	for i, s := range list {
+		gs[i] = gen.Const(s)
+	}
+	return gen.OneOf(gs...)
+}
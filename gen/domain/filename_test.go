@@ -0,0 +1,121 @@
+package domain
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"arcsyn.io/propx/gen"
+)
+
+func TestSafeFilename(t *testing.T) {
+	for _, s := range []string{"a", "report.pdf", "A_b-9.tar.gz", "CONSOLE.txt", "nul_", strings.Repeat("a", 255)} {
+		if !SafeFilename(s) {
+			t.Errorf("SafeFilename(%q) = false, expected true", s)
+		}
+	}
+	unsafe := []string{
+		"", ".", "..", ".hidden", "-rf", "a.", "a ", " a", "a b", "a/b", `a\b`, "a:b", "é.txt",
+		"CON", "con", "Nul.txt", "lpt9.tar.gz", strings.Repeat("a", 256),
+	}
+	for _, s := range unsafe {
+		if SafeFilename(s) {
+			t.Errorf("SafeFilename(%q) = true, expected false", s)
+		}
+	}
+}
+
+func TestFilenameGenerators(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	var safe, reserved, trailing, long, multibyte, invalidUTF8, forbidden, traversal, dots bool
+	for i := 0; i < 2000; i++ {
+		name, shrink := Filename().Generate(r, gen.Size{})
+		if shrink == nil {
+			t.Fatal("Filename().Generate() returned nil shrinker")
+		}
+		safe = safe || SafeFilename(name)
+		reserved = reserved || windowsReserved(name)
+		trailing = trailing || strings.HasSuffix(name, ".") && name != "." && name != ".." || strings.HasSuffix(name, " ")
+		long = long || len(name) > 255
+		multibyte = multibyte || len(name) > 255 && utf8.RuneCountInString(name) <= 255
+		invalidUTF8 = invalidUTF8 || !utf8.ValidString(name)
+		forbidden = forbidden || strings.ContainsAny(name, "<>:\"|?*\x00")
+		traversal = traversal || strings.HasPrefix(name, "../")
+		dots = dots || name == ".."
+
+		s, _ := Filename(FilenameSafe()).Generate(r, gen.Size{})
+		if !SafeFilename(s) {
+			t.Fatalf("Filename(FilenameSafe()) = %q is not safe", s)
+		}
+	}
+	if !safe || !reserved || !trailing || !long || !multibyte || !invalidUTF8 || !forbidden || !traversal || !dots {
+		t.Errorf("coverage: safe=%v reserved=%v trailing=%v long=%v multibyte=%v invalid=%v forbidden=%v traversal=%v dots=%v",
+			safe, reserved, trailing, long, multibyte, invalidUTF8, forbidden, traversal, dots)
+	}
+}
+
+func TestFilename_Shrink(t *testing.T) {
+	tests := []struct {
+		name  string
+		g     gen.Generator[string]
+		fails func(string) bool
+		want  string
+	}{
+		{"any", Filename(), func(string) bool { return true }, "a"},
+		{"safe", Filename(FilenameSafe()), func(string) bool { return true }, "a"},
+		{"reserved", Filename(), windowsReserved, "con"},
+		{"long", Filename(), func(s string) bool { return utf8.RuneCountInString(s) > 255 }, strings.Repeat("a", 256)},
+	}
+	for _, tt := range tests {
+		for _, strategy := range []string{"bfs", "dfs"} {
+			gen.SetShrinkStrategy(strategy)
+			r := rand.New(rand.NewSource(2))
+			for found := 0; found < 10; {
+				name, shrink := tt.g.Generate(r, gen.Size{})
+				if !tt.fails(name) {
+					continue
+				}
+				found++
+				min, accept := name, false
+				for j := 0; j < 5000; j++ {
+					next, ok := shrink(accept)
+					if !ok {
+						break
+					}
+					if accept = tt.fails(next) && filenameSimpler(next, min); accept {
+						min = next
+					}
+				}
+				if min != tt.want {
+					t.Errorf("%s/%s: %q shrunk to %q, expected %q", tt.name, strategy, name, min, tt.want)
+				}
+			}
+		}
+	}
+	gen.SetShrinkStrategy("bfs")
+}
+
+// filenameSimpler reports whether a is safe and b is not, or both are alike
+// and a is shorter, or as long and has the first differing byte earlier in
+// filenameSafeChars (other bytes last).
+func filenameSimpler(a, b string) bool {
+	if sa, sb := SafeFilename(a), SafeFilename(b); sa != sb {
+		return sa
+	}
+	if len(a) != len(b) {
+		return len(a) < len(b)
+	}
+	rank := func(c byte) int {
+		if i := strings.IndexByte(filenameSafeChars, c); i >= 0 {
+			return i
+		}
+		return len(filenameSafeChars) + int(c)
+	}
+	for i := 0; i < len(a); i++ {
+		if a[i] != b[i] {
+			return rank(a[i]) < rank(b[i])
+		}
+	}
+	return false
+}
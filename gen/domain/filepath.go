@@ -0,0 +1,236 @@
+package domain
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+
+	"arcsyn.io/propx/gen"
+)
+
+// PathStyle selects the separator and root conventions of generated paths.
+type PathStyle int
+
+const (
+	// PathPOSIX produces paths like "/usr/./lib//x/" and "a/../b".
+	PathPOSIX PathStyle = iota
+	// PathWindows produces paths like `C:\Users\..\x\` and `a\.\b`.
+	PathWindows
+)
+
+// pathNameAlphabet contains the characters used in generated path names.
+const pathNameAlphabet = "abcdefghijklmnopqrstuvwxyz0123456789-_. "
+
+// filePathSpec is the plain-data description of a path. Empty components
+// render as repeated separators.
+type filePathSpec struct {
+	abs      bool
+	drive    byte // Windows drive letter of absolute paths
+	comps    []string
+	trailing bool
+}
+
+// FilePath generates syntactically valid absolute and relative paths in the
+// given style, including tricky components: ".", "..", repeated separators
+// and trailing separators.
+// Shrink: removes components and separators, makes the path relative and
+// simplifies names, toward the shortest path "a".
+func FilePath(style PathStyle) gen.Generator[string] {
+	return filePath(style, false)
+}
+
+// FilePathTraversal is like FilePath but biased toward traversal-prone paths:
+// every path contains at least one "..", often enough to climb above its
+// starting directory (see EscapesRoot). Shrinking keeps at least one "..".
+func FilePathTraversal(style PathStyle) gen.Generator[string] {
+	return filePath(style, true)
+}
+
+// EscapesRoot reports whether the path, resolved lexically from its starting
+// directory (or root), climbs above it through ".." components.
+func EscapesRoot(path string, style PathStyle) bool {
+	sep := style.sep()
+	if style == PathWindows {
+		path = strings.ReplaceAll(path, "/", sep)
+		if len(path) >= 2 && path[1] == ':' {
+			path = path[2:]
+		}
+	}
+	depth := 0
+	for _, c := range strings.Split(path, sep) {
+		switch c {
+		case "", ".":
+		case "..":
+			depth--
+			if depth < 0 {
+				return true
+			}
+		default:
+			depth++
+		}
+	}
+	return false
+}
+
+// filePath builds the FilePath/FilePathTraversal generators.
+func filePath(style PathStyle, traversal bool) gen.Generator[string] {
+	return gen.From(func(r *rand.Rand, _ gen.Size) (string, gen.Shrinker[string]) {
+		if r == nil {
+			r = rand.New(rand.NewSource(rand.Int63())) // #nosec G404 -- Using math/rand for deterministic property-based testing
+		}
+		spec := generateFilePathSpec(r, traversal)
+		return spec.render(style), createFilePathShrinker(spec, style, traversal)
+	})
+}
+
+// generateFilePathSpec draws 1..6 components.
+func generateFilePathSpec(r *rand.Rand, traversal bool) filePathSpec {
+	spec := filePathSpec{
+		abs:      r.Intn(2) == 0,
+		drive:    byte('C' + r.Intn(4)),
+		trailing: r.Intn(4) == 0,
+	}
+	dotdot := 8 // 1 in 8 components is ".."
+	if traversal {
+		dotdot = 2
+	}
+	for i, n := 0, 1+r.Intn(6); i < n; i++ {
+		switch {
+		case r.Intn(dotdot) == 0:
+			spec.comps = append(spec.comps, "..")
+		case r.Intn(8) == 0:
+			spec.comps = append(spec.comps, ".")
+		case i > 0 && r.Intn(8) == 0:
+			spec.comps = append(spec.comps, "") // repeated separator
+		default:
+			spec.comps = append(spec.comps, strings.TrimSpace(randomToken(r, pathNameAlphabet, 1, 10))+"x")
+		}
+	}
+	if traversal && !spec.hasDotDot() {
+		spec.comps[r.Intn(len(spec.comps))] = ".."
+	}
+	return spec
+}
+
+// createFilePathShrinker creates a shrinker for path specs.
+func createFilePathShrinker(initial filePathSpec, style PathStyle, traversal bool) gen.Shrinker[string] {
+	queue := make([]filePathSpec, 0, 16)
+	seen := map[string]struct{}{initial.render(style): {}}
+	cur, last := initial, initial
+
+	push := func(s filePathSpec) {
+		if len(s.comps) == 0 || (traversal && !s.hasDotDot()) {
+			return
+		}
+		k := s.render(style)
+		if _, ok := seen[k]; ok {
+			return
+		}
+		seen[k] = struct{}{}
+		queue = append(queue, s)
+	}
+
+	growNeighbors := func(base filePathSpec) {
+		queue = queue[:0]
+		// (1) single component
+		for _, c := range base.comps {
+			if c != "" && (!traversal || c == "..") {
+				push(filePathSpec{comps: []string{c}})
+			}
+		}
+		// (2) drop each component (R->L)
+		for i := len(base.comps) - 1; i >= 0; i-- {
+			s := base.clone()
+			s.comps = append(s.comps[:i], s.comps[i+1:]...)
+			push(s)
+		}
+		// (3) relative, no trailing separator
+		if base.abs {
+			s := base.clone()
+			s.abs = false
+			push(s)
+		}
+		if base.trailing {
+			s := base.clone()
+			s.trailing = false
+			push(s)
+		}
+		// (4) simplest names
+		for i, c := range base.comps {
+			if c != "" && c != "." && c != ".." && c != "a" {
+				s := base.clone()
+				s.comps[i] = "a"
+				push(s)
+			}
+		}
+	}
+	growNeighbors(cur)
+
+	popNext := func() (filePathSpec, bool) {
+		if len(queue) == 0 {
+			return filePathSpec{}, false
+		}
+		if gen.GetShrinkStrategy() == gen.ShrinkStrategyDFS {
+			v := queue[len(queue)-1]
+			queue = queue[:len(queue)-1]
+			return v, true
+		}
+		v := queue[0]
+		queue = queue[1:]
+		return v, true
+	}
+
+	return func(accept bool) (string, bool) {
+		if accept && last.render(style) != cur.render(style) {
+			cur = last
+			growNeighbors(cur)
+		}
+		nxt, ok := popNext()
+		if !ok {
+			return "", false
+		}
+		last = nxt
+		return nxt.render(style), true
+	}
+}
+
+// render returns the path text in the given style.
+func (s filePathSpec) render(style PathStyle) string {
+	sep := style.sep()
+	var b strings.Builder
+	if s.abs {
+		if style == PathWindows {
+			fmt.Fprintf(&b, "%c:", s.drive)
+		}
+		b.WriteString(sep)
+	}
+	b.WriteString(strings.Join(s.comps, sep))
+	if s.trailing {
+		b.WriteString(sep)
+	}
+	return b.String()
+}
+
+// hasDotDot reports whether the spec has a ".." component.
+func (s filePathSpec) hasDotDot() bool {
+	for _, c := range s.comps {
+		if c == ".." {
+			return true
+		}
+	}
+	return false
+}
+
+// clone returns a deep copy of the spec.
+func (s filePathSpec) clone() filePathSpec {
+	s.comps = append([]string(nil), s.comps...)
+	return s
+}
+
+// sep returns the separator of the style.
+func (p PathStyle) sep() string {
+	if p == PathWindows {
+		return `\`
+	}
+	return "/"
+}
@@ -0,0 +1,123 @@
+package domain
+
+import (
+	"math/rand"
+	"path"
+	"strings"
+	"testing"
+
+	"arcsyn.io/propx/gen"
+)
+
+func TestFilePath_POSIX(t *testing.T) {
+	g := FilePath(PathPOSIX)
+	r := rand.New(rand.NewSource(123))
+
+	sawAbs, sawRel, sawDot, sawDouble, sawTrailing := false, false, false, false, false
+	for i := 0; i < 500; i++ {
+		p, shrink := g.Generate(r, gen.Size{})
+
+		if p == "" || strings.Contains(p, `\`) {
+			t.Fatalf("FilePath(PathPOSIX).Generate() = %q, expected non-empty POSIX path", p)
+		}
+		sawAbs = sawAbs || path.IsAbs(p)
+		sawRel = sawRel || !path.IsAbs(p)
+		sawDot = sawDot || strings.Contains(p, "..") || strings.Contains(p, "/./")
+		sawDouble = sawDouble || strings.Contains(p, "//")
+		sawTrailing = sawTrailing || (len(p) > 1 && strings.HasSuffix(p, "/"))
+		if shrink == nil {
+			t.Fatal("FilePath().Generate() returned nil shrinker")
+		}
+	}
+	if !sawAbs || !sawRel || !sawDot || !sawDouble || !sawTrailing {
+		t.Errorf("FilePath(PathPOSIX) coverage: abs=%v rel=%v dots=%v double=%v trailing=%v",
+			sawAbs, sawRel, sawDot, sawDouble, sawTrailing)
+	}
+}
+
+func TestFilePath_Windows(t *testing.T) {
+	g := FilePath(PathWindows)
+	r := rand.New(rand.NewSource(123))
+
+	for i := 0; i < 200; i++ {
+		p, _ := g.Generate(r, gen.Size{})
+		if strings.Contains(p, "/") {
+			t.Fatalf("FilePath(PathWindows).Generate() = %q, expected backslashes only", p)
+		}
+		if strings.HasPrefix(p, `\`) || (len(p) > 1 && p[1] == ':' && !strings.HasPrefix(p[2:], `\`)) {
+			t.Fatalf("FilePath(PathWindows).Generate() = %q, expected drive-rooted or relative path", p)
+		}
+	}
+}
+
+func TestFilePath_ShrinksToShortest(t *testing.T) {
+	g := FilePath(PathPOSIX)
+	r := rand.New(rand.NewSource(42))
+
+	for i := 0; i < 20; i++ {
+		p, shrink := g.Generate(r, gen.Size{})
+		min, accept := p, false
+		for j := 0; j < 1000; j++ {
+			next, ok := shrink(accept)
+			if !ok {
+				break
+			}
+			accept = len(next) <= len(min)
+			if accept {
+				min = next
+			}
+		}
+		if len(min) > 2 {
+			t.Errorf("FilePath() %q shrunk to %q, expected a single short component", p, min)
+		}
+	}
+}
+
+func TestFilePathTraversal(t *testing.T) {
+	g := FilePathTraversal(PathPOSIX)
+	r := rand.New(rand.NewSource(123))
+
+	escapes := 0
+	for i := 0; i < 200; i++ {
+		p, shrink := g.Generate(r, gen.Size{})
+		if !strings.Contains(p, "..") {
+			t.Fatalf("FilePathTraversal().Generate() = %q, expected a '..' component", p)
+		}
+		if EscapesRoot(p, PathPOSIX) {
+			escapes++
+		}
+		for j := 0; j < 100; j++ {
+			next, ok := shrink(true)
+			if !ok {
+				break
+			}
+			if !strings.Contains(next, "..") {
+				t.Fatalf("FilePathTraversal() shrink candidate %q lost its '..'", next)
+			}
+		}
+	}
+	if escapes < 50 {
+		t.Errorf("FilePathTraversal() escaped its root %d/200 times, expected a strong bias", escapes)
+	}
+}
+
+func TestEscapesRoot(t *testing.T) {
+	tests := []struct {
+		in    string
+		style PathStyle
+		want  bool
+	}{
+		{"a/b/../c", PathPOSIX, false},
+		{"a/../../etc", PathPOSIX, true},
+		{"/../etc/passwd", PathPOSIX, true},
+		{"./a/./b/..", PathPOSIX, false},
+		{`C:\a\..\..\x`, PathWindows, true},
+		{`a\b\..`, PathWindows, false},
+		{`a/../../x`, PathWindows, true},
+	}
+	for _, tt := range tests {
+		if got := EscapesRoot(tt.in, tt.style); got != tt.want {
+			t.Errorf("EscapesRoot(%q, %d) = %v, expected %v", tt.in, tt.style, got, tt.want)
+		}
+	}
+}
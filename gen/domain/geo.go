@@ -0,0 +1,164 @@
+package domain
+
+import (
+	"math"
+	"math/rand"
+
+	"arcsyn.io/propx/gen"
+)
+
+// LatLng is a geographic coordinate in decimal degrees.
+type LatLng struct {
+	// Lat is the latitude in [-90, 90].
+	Lat float64
+	// Lng is the longitude in [-180, 180].
+	Lng float64
+}
+
+// BoundingBox restricts generated coordinates to a rectangle (inclusive).
+type BoundingBox struct {
+	MinLat, MaxLat float64
+	MinLng, MaxLng float64
+}
+
+// LatLngOptions controls LatLngOf. The zero value generates coordinates over
+// the whole globe with 6 decimal places.
+type LatLngOptions struct {
+	// Precision is the number of decimal places (1..15). Default: 6.
+	Precision int
+
+	// Box restricts the coordinates; nil means the whole globe.
+	Box *BoundingBox
+}
+
+// LatLngOf generates valid coordinates (latitude in [-90, 90], longitude in
+// [-180, 180]) rounded to the configured precision. Edge values inside the
+// box (the poles, the antimeridian at ±180 and the equator/prime meridian)
+// are drawn more often than uniform sampling would.
+// Shrink: moves each coordinate toward 0 (or the box bound closest to 0),
+// latitude first.
+func LatLngOf(opts LatLngOptions) gen.Generator[LatLng] {
+	prec := opts.Precision
+	if prec <= 0 || prec > 15 {
+		prec = 6
+	}
+	box := BoundingBox{MinLat: -90, MaxLat: 90, MinLng: -180, MaxLng: 180}
+	if opts.Box != nil {
+		box = clampBox(*opts.Box)
+	}
+	scale := math.Pow10(prec)
+
+	lat := coordinateUnits(box.MinLat, box.MaxLat, scale, []float64{-90, 90, 0})
+	lng := coordinateUnits(box.MinLng, box.MaxLng, scale, []float64{-180, 180, 0})
+	return gen.Map(gen.PairOf(lat, lng), func(p gen.Pair[int64, int64]) LatLng {
+		return LatLng{Lat: float64(p.First) / scale, Lng: float64(p.Second) / scale}
+	})
+}
+
+// ValidLatLng reports whether c is within the valid coordinate ranges.
+func ValidLatLng(c LatLng) bool {
+	return c.Lat >= -90 && c.Lat <= 90 && c.Lng >= -180 && c.Lng <= 180
+}
+
+// coordinateUnits generates a coordinate as an integer count of 1/scale degrees
+// in [min, max], picking one of the edges inside the range 1 time in 8.
+func coordinateUnits(min, max, scale float64, edges []float64) gen.Generator[int64] {
+	lo, hi := int64(math.Ceil(min*scale)), int64(math.Floor(max*scale))
+	if lo > hi {
+		lo, hi = hi, lo
+	}
+	var inRange []int64
+	for _, e := range edges {
+		if u := int64(e * scale); u >= lo && u <= hi {
+			inRange = append(inRange, u)
+		}
+	}
+	return gen.From(func(r *rand.Rand, _ gen.Size) (int64, gen.Shrinker[int64]) {
+		if r == nil {
+			r = rand.New(rand.NewSource(rand.Int63())) // #nosec G404 -- Using math/rand for deterministic property-based testing
+		}
+		v := lo + r.Int63n(hi-lo+1)
+		if len(inRange) > 0 && r.Intn(8) == 0 {
+			v = inRange[r.Intn(len(inRange))]
+		}
+		return v, createUnitsShrinker(v, lo, hi)
+	})
+}
+
+// createUnitsShrinker creates a shrinker moving v toward 0 (or the bound of
+// [lo, hi] closest to 0) by direct jump, bisection and unit steps.
+func createUnitsShrinker(v, lo, hi int64) gen.Shrinker[int64] {
+	target := lo
+	if lo <= 0 && 0 <= hi {
+		target = 0
+	} else if hi < 0 {
+		target = hi
+	}
+
+	queue := make([]int64, 0, 16)
+	seen := map[int64]struct{}{v: {}}
+	cur, last := v, v
+
+	push := func(x int64) {
+		if _, ok := seen[x]; ok {
+			return
+		}
+		seen[x] = struct{}{}
+		queue = append(queue, x)
+	}
+
+	growNeighbors := func(base int64) {
+		queue = queue[:0]
+		if base == target {
+			return
+		}
+		push(target)
+		for x := base + (target-base)/2; x != target && x != base; x += (target - x) / 2 {
+			push(x)
+			if (target-x)/2 == 0 {
+				break
+			}
+		}
+		if base > target {
+			push(base - 1)
+		} else {
+			push(base + 1)
+		}
+	}
+	growNeighbors(cur)
+
+	popNext := func() (int64, bool) {
+		if len(queue) == 0 {
+			return 0, false
+		}
+		if gen.GetShrinkStrategy() == gen.ShrinkStrategyDFS {
+			x := queue[len(queue)-1]
+			queue = queue[:len(queue)-1]
+			return x, true
+		}
+		x := queue[0]
+		queue = queue[1:]
+		return x, true
+	}
+
+	return func(accept bool) (int64, bool) {
+		if accept && last != cur {
+			cur = last
+			growNeighbors(cur)
+		}
+		nxt, ok := popNext()
+		if !ok {
+			return 0, false
+		}
+		last = nxt
+		return nxt, true
+	}
+}
+
+// clampBox constrains a bounding box to the valid coordinate ranges.
+func clampBox(b BoundingBox) BoundingBox {
+	clampF := func(x, lo, hi float64) float64 { return math.Max(lo, math.Min(hi, x)) }
+	b.MinLat, b.MaxLat = clampF(b.MinLat, -90, 90), clampF(b.MaxLat, -90, 90)
+	b.MinLng, b.MaxLng = clampF(b.MinLng, -180, 180), clampF(b.MaxLng, -180, 180)
+	return b
+}
@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"arcsyn.io/propx/gen"
+)
+
+func TestLatLngOf(t *testing.T) {
+	g := LatLngOf(LatLngOptions{})
+	r := rand.New(rand.NewSource(123))
+
+	sawPole, sawAntimeridian := false, false
+	for i := 0; i < 500; i++ {
+		value, shrink := g.Generate(r, gen.Size{})
+
+		if !ValidLatLng(value) {
+			t.Fatalf("LatLngOf().Generate() = %+v, expected valid coordinate", value)
+		}
+		if math.Abs(value.Lat) == 90 {
+			sawPole = true
+		}
+		if math.Abs(value.Lng) == 180 {
+			sawAntimeridian = true
+		}
+		if shrink == nil {
+			t.Fatal("LatLngOf().Generate() returned nil shrinker")
+		}
+	}
+	if !sawPole || !sawAntimeridian {
+		t.Errorf("LatLngOf() edge cases: pole=%v antimeridian=%v, expected both", sawPole, sawAntimeridian)
+	}
+}
+
+func TestLatLngOf_PrecisionAndBox(t *testing.T) {
+	box := &BoundingBox{MinLat: -23.6, MaxLat: -23.4, MinLng: -46.8, MaxLng: -46.5}
+	g := LatLngOf(LatLngOptions{Precision: 2, Box: box})
+	r := rand.New(rand.NewSource(123))
+
+	for i := 0; i < 100; i++ {
+		value, _ := g.Generate(r, gen.Size{})
+
+		if value.Lat < box.MinLat || value.Lat > box.MaxLat || value.Lng < box.MinLng || value.Lng > box.MaxLng {
+			t.Fatalf("LatLngOf().Generate() = %+v, expected inside %+v", value, *box)
+		}
+		if scaled := value.Lat * 100; math.Abs(scaled-math.Round(scaled)) > 1e-6 {
+			t.Errorf("LatLngOf().Generate() lat = %v, expected 2 decimal places", value.Lat)
+		}
+	}
+}
+
+func TestLatLngOf_ShrinksTowardOrigin(t *testing.T) {
+	g := LatLngOf(LatLngOptions{})
+	r := rand.New(rand.NewSource(7))
+	_, shrink := g.Generate(r, gen.Size{})
+
+	min := LatLng{Lat: 1, Lng: 1}
+	for i := 0; i < 1000; i++ {
+		next, ok := shrink(true)
+		if !ok {
+			break
+		}
+		if !ValidLatLng(next) {
+			t.Fatalf("shrink candidate %+v is not a valid coordinate", next)
+		}
+		min = next
+	}
+	if min != (LatLng{}) {
+		t.Errorf("LatLngOf() shrunk to %+v, expected (0, 0)", min)
+	}
+}
+
+func TestValidLatLng(t *testing.T) {
+	tests := []struct {
+		in   LatLng
+		want bool
+	}{
+		{LatLng{0, 0}, true},
+		{LatLng{90, 180}, true},
+		{LatLng{-90, -180}, true},
+		{LatLng{90.0001, 0}, false},
+		{LatLng{0, -180.5}, false},
+	}
+	for _, tt := range tests {
+		if got := ValidLatLng(tt.in); got != tt.want {
+			t.Errorf("ValidLatLng(%+v) = %v, expected %v", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,291 @@
+package domain
+
+import (
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strings"
+
+	"arcsyn.io/propx/gen"
+)
+
+// RequestOptions controls the shape of the requests produced by HTTPRequest.
+// Zero values select the defaults documented on each field.
+type RequestOptions struct {
+	// Methods restricts the HTTP methods to draw from.
+	// Default: GET, POST, PUT, PATCH, DELETE, HEAD, OPTIONS.
+	Methods []string
+
+	// Host is the host used in the request URL. Default: "example.com".
+	Host string
+
+	// MaxPathSegments is the maximum number of path segments. Default: 4.
+	MaxPathSegments int
+
+	// MaxHeaders is the maximum number of extra headers. Default: 4.
+	MaxHeaders int
+
+	// MaxBodyLen is the maximum body length in bytes for methods that carry
+	// a body (POST, PUT, PATCH). Default: 64.
+	MaxBodyLen int
+}
+
+// defaultHTTPMethods are the methods used when RequestOptions.Methods is empty.
+var defaultHTTPMethods = []string{
+	http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch,
+	http.MethodDelete, http.MethodHead, http.MethodOptions,
+}
+
+// httpMethods are the methods drawn by HTTPMethod: GET first (the shrink
+// target), then the common ones, then the rare ones.
+var httpMethods = []string{
+	http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete,
+	http.MethodPatch, http.MethodHead, http.MethodOptions,
+	http.MethodConnect, http.MethodTrace,
+}
+
+// httpStatusCodes are the status codes registered in net/http, with the
+// shrink target 200 first and the rest in ascending order.
+var httpStatusCodes = func() []int {
+	codes := []int{http.StatusOK}
+	for c := 100; c < 600; c++ {
+		if c != http.StatusOK && http.StatusText(c) != "" {
+			codes = append(codes, c)
+		}
+	}
+	return codes
+}()
+
+// httpHeaderNames are the header names drawn when generating requests.
+var httpHeaderNames = []string{
+	"Accept", "Accept-Language", "Authorization", "Cache-Control",
+	"Content-Type", "User-Agent", "X-Request-Id", "X-Forwarded-For",
+}
+
+// pathAlphabet contains the characters used in generated path segments.
+const pathAlphabet = "abcdefghijklmnopqrstuvwxyz0123456789-_."
+
+// headerValueAlphabet contains the characters used in generated header values.
+const headerValueAlphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_./;=, "
+
+// httpRequestSpec is the plain-data description of a request; shrinking works
+// on specs and every candidate is materialized into a fresh *http.Request.
+type httpRequestSpec struct {
+	method  string
+	segs    []string
+	headers [][2]string
+	body    string
+}
+
+// HTTPRequest generates *http.Request values with valid methods, paths,
+// headers and bodies, suitable for exercising handlers and middleware.
+// Every request returned (including shrink candidates) is freshly built, so
+// its Body can always be read, and GetBody is set for replays.
+// Shrink: strips headers, simplifies the path toward "/", empties the body
+// and moves the method toward GET.
+func HTTPRequest(opts RequestOptions) gen.Generator[*http.Request] {
+	opts = opts.withDefaults()
+	return gen.From(func(r *rand.Rand, _ gen.Size) (*http.Request, gen.Shrinker[*http.Request]) {
+		if r == nil {
+			r = rand.New(rand.NewSource(rand.Int63())) // #nosec G404 -- Using math/rand for deterministic property-based testing
+		}
+		spec := generateHTTPRequestSpec(r, opts)
+		shrink := createHTTPRequestShrinker(spec, opts.Host)
+		return spec.build(opts.Host), shrink
+	})
+}
+
+// HTTPStatus generates HTTP status codes from the 1xx-5xx classes known to
+// net/http, such as 201, 304 or 503.
+// Shrink: toward 200.
+func HTTPStatus() gen.Generator[int] {
+	return gen.Map(gen.IntRange(0, len(httpStatusCodes)-1), func(i int) int { return httpStatusCodes[i] })
+}
+
+// HTTPMethod generates HTTP request methods, including the rarely used
+// CONNECT and TRACE.
+// Shrink: toward GET.
+func HTTPMethod() gen.Generator[string] {
+	return codeFrom(httpMethods)
+}
+
+// withDefaults fills zero-valued options with their defaults.
+func (o RequestOptions) withDefaults() RequestOptions {
+	if len(o.Methods) == 0 {
+		o.Methods = defaultHTTPMethods
+	}
+	if o.Host == "" {
+		o.Host = "example.com"
+	}
+	if o.MaxPathSegments <= 0 {
+		o.MaxPathSegments = 4
+	}
+	if o.MaxHeaders <= 0 {
+		o.MaxHeaders = 4
+	}
+	if o.MaxBodyLen <= 0 {
+		o.MaxBodyLen = 64
+	}
+	return o
+}
+
+// generateHTTPRequestSpec draws a random request description.
+func generateHTTPRequestSpec(r *rand.Rand, opts RequestOptions) httpRequestSpec {
+	spec := httpRequestSpec{method: opts.Methods[r.Intn(len(opts.Methods))]}
+
+	nsegs := r.Intn(opts.MaxPathSegments + 1)
+	for i := 0; i < nsegs; i++ {
+		spec.segs = append(spec.segs, randomToken(r, pathAlphabet, 1, 8))
+	}
+
+	nheaders := r.Intn(opts.MaxHeaders + 1)
+	for i := 0; i < nheaders; i++ {
+		name := httpHeaderNames[r.Intn(len(httpHeaderNames))]
+		spec.headers = append(spec.headers, [2]string{name, strings.TrimSpace(randomToken(r, headerValueAlphabet, 1, 24))})
+	}
+
+	if methodHasBody(spec.method) {
+		spec.body = randomToken(r, headerValueAlphabet, 0, opts.MaxBodyLen)
+	}
+	return spec
+}
+
+// createHTTPRequestShrinker creates a shrinker for request specs.
+func createHTTPRequestShrinker(initial httpRequestSpec, host string) gen.Shrinker[*http.Request] {
+	queue := make([]httpRequestSpec, 0, 16)
+	seen := make(map[string]struct{}, 32)
+	var last *httpRequestSpec
+	cur := initial
+
+	push := func(s httpRequestSpec) {
+		k := s.key()
+		if _, ok := seen[k]; ok {
+			return
+		}
+		seen[k] = struct{}{}
+		queue = append(queue, s)
+	}
+
+	growNeighbors := func(base httpRequestSpec) {
+		queue = queue[:0]
+
+		// (1) strip all headers, then one at a time (R->L)
+		if len(base.headers) > 0 {
+			s := base.clone()
+			s.headers = nil
+			push(s)
+			for i := len(base.headers) - 1; i >= 0; i-- {
+				s := base.clone()
+				s.headers = append(s.headers[:i], s.headers[i+1:]...)
+				push(s)
+			}
+		}
+
+		// (2) simplify the path toward "/": drop everything, then the last segment
+		if len(base.segs) > 0 {
+			s := base.clone()
+			s.segs = nil
+			push(s)
+			s = base.clone()
+			s.segs = s.segs[:len(s.segs)-1]
+			push(s)
+		}
+
+		// (3) empty the body, then halve it
+		if base.body != "" {
+			s := base.clone()
+			s.body = ""
+			push(s)
+			s = base.clone()
+			s.body = s.body[:len(s.body)/2]
+			push(s)
+		}
+
+		// (4) method toward GET (GET carries no body)
+		if base.method != http.MethodGet {
+			s := base.clone()
+			s.method = http.MethodGet
+			s.body = ""
+			push(s)
+		}
+	}
+
+	popNext := func() (httpRequestSpec, bool) {
+		if len(queue) == 0 {
+			return httpRequestSpec{}, false
+		}
+		if gen.GetShrinkStrategy() == gen.ShrinkStrategyDFS {
+			v := queue[len(queue)-1]
+			queue = queue[:len(queue)-1]
+			return v, true
+		}
+		v := queue[0]
+		queue = queue[1:]
+		return v, true
+	}
+
+	seen[cur.key()] = struct{}{}
+	growNeighbors(cur)
+
+	return func(accept bool) (*http.Request, bool) {
+		if accept && last != nil && last.key() != cur.key() {
+			cur = *last
+			growNeighbors(cur)
+		}
+		nxt, ok := popNext()
+		if !ok {
+			return nil, false
+		}
+		last = &nxt
+		return nxt.build(host), true
+	}
+}
+
+// build materializes the spec into a new *http.Request with a fresh body.
+func (s httpRequestSpec) build(host string) *http.Request {
+	target := "http://" + host + s.path()
+	req, err := http.NewRequest(s.method, target, strings.NewReader(s.body))
+	if err != nil {
+		panic(fmt.Errorf("domain.HTTPRequest: invalid request %s %s: %w", s.method, target, err))
+	}
+	for _, h := range s.headers {
+		req.Header.Add(h[0], h[1])
+	}
+	return req
+}
+
+// path returns the URL path of the spec ("/" when there are no segments).
+func (s httpRequestSpec) path() string {
+	return "/" + strings.Join(s.segs, "/")
+}
+
+// clone returns a deep copy of the spec.
+func (s httpRequestSpec) clone() httpRequestSpec {
+	c := s
+	c.segs = append([]string(nil), s.segs...)
+	c.headers = append([][2]string(nil), s.headers...)
+	return c
+}
+
+// key returns a textual signature used for shrink deduplication.
+func (s httpRequestSpec) key() string {
+	return fmt.Sprintf("%s %s %q %q", s.method, s.path(), s.headers, s.body)
+}
+
+// methodHasBody reports whether requests with the method usually carry a body.
+func methodHasBody(m string) bool {
+	return m == http.MethodPost || m == http.MethodPut || m == http.MethodPatch
+}
+
+// randomToken returns a string of length in [minLen, maxLen] drawn from alphabet.
+func randomToken(r *rand.Rand, alphabet string, minLen, maxLen int) string {
+	n := minLen
+	if maxLen > minLen {
+		n += r.Intn(maxLen - minLen + 1)
+	}
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = alphabet[r.Intn(len(alphabet))]
+	}
+	return string(b)
+}
@@ -0,0 +1,170 @@
+package domain
+
+import (
+	"io"
+	"math/rand"
+	"net/http"
+	"testing"
+
+	"arcsyn.io/propx/gen"
+)
+
+func TestHTTPRequest(t *testing.T) {
+	g := HTTPRequest(RequestOptions{})
+	r := rand.New(rand.NewSource(123))
+
+	for i := 0; i < 50; i++ {
+		req, shrink := g.Generate(r, gen.Size{})
+
+		if req == nil {
+			t.Fatal("HTTPRequest().Generate() returned nil request")
+		}
+		if req.URL.Host != "example.com" {
+			t.Errorf("HTTPRequest().Generate() host = %q, expected 'example.com'", req.URL.Host)
+		}
+		if req.URL.Path == "" || req.URL.Path[0] != '/' {
+			t.Errorf("HTTPRequest().Generate() path = %q, expected absolute path", req.URL.Path)
+		}
+		if _, err := io.ReadAll(req.Body); err != nil {
+			t.Errorf("HTTPRequest().Generate() body not readable: %v", err)
+		}
+		if shrink == nil {
+			t.Error("HTTPRequest().Generate() returned nil shrinker")
+		}
+	}
+}
+
+func TestHTTPRequest_Options(t *testing.T) {
+	g := HTTPRequest(RequestOptions{Methods: []string{http.MethodPost}, Host: "api.local", MaxBodyLen: 8})
+	r := rand.New(rand.NewSource(123))
+
+	for i := 0; i < 20; i++ {
+		req, _ := g.Generate(r, gen.Size{})
+		if req.Method != http.MethodPost {
+			t.Errorf("HTTPRequest() method = %q, expected POST", req.Method)
+		}
+		if req.Host != "api.local" {
+			t.Errorf("HTTPRequest() host = %q, expected 'api.local'", req.Host)
+		}
+		if req.ContentLength > 8 {
+			t.Errorf("HTTPRequest() body length = %d, expected <= 8", req.ContentLength)
+		}
+	}
+}
+
+func TestHTTPRequest_ShrinksTowardRoot(t *testing.T) {
+	g := HTTPRequest(RequestOptions{MaxPathSegments: 6, MaxHeaders: 6})
+	r := rand.New(rand.NewSource(42))
+	req, shrink := g.Generate(r, gen.Size{})
+
+	// accept every candidate: shrinking must end on a bare GET / with no headers
+	min := req
+	for i := 0; i < 1000; i++ {
+		next, ok := shrink(true)
+		if !ok {
+			break
+		}
+		// every candidate must carry its own readable body
+		if _, err := io.ReadAll(next.Body); err != nil {
+			t.Fatalf("shrink candidate body not readable: %v", err)
+		}
+		min = next
+	}
+
+	if min.URL.Path != "/" {
+		t.Errorf("shrunk path = %q, expected '/'", min.URL.Path)
+	}
+	if len(min.Header) != 0 {
+		t.Errorf("shrunk headers = %v, expected none", min.Header)
+	}
+	if min.Method != http.MethodGet {
+		t.Errorf("shrunk method = %q, expected GET", min.Method)
+	}
+}
+
+func TestHTTPStatus(t *testing.T) {
+	g := HTTPStatus()
+	r := rand.New(rand.NewSource(123))
+
+	classes := map[int]bool{}
+	for i := 0; i < 500; i++ {
+		code, shrink := g.Generate(r, gen.Size{})
+		if code < 100 || code > 599 || http.StatusText(code) == "" {
+			t.Fatalf("HTTPStatus().Generate() = %d, expected a known 1xx-5xx code", code)
+		}
+		if shrink == nil {
+			t.Fatal("HTTPStatus().Generate() returned nil shrinker")
+		}
+		classes[code/100] = true
+	}
+	if len(classes) != 5 {
+		t.Errorf("HTTPStatus() covered classes %v, expected 1xx-5xx", classes)
+	}
+}
+
+func TestHTTPMethod(t *testing.T) {
+	g := HTTPMethod()
+	r := rand.New(rand.NewSource(123))
+
+	seen := map[string]bool{}
+	for i := 0; i < 500; i++ {
+		m, _ := g.Generate(r, gen.Size{})
+		seen[m] = true
+	}
+	for _, m := range httpMethods {
+		if !seen[m] {
+			t.Errorf("HTTPMethod() never generated %s", m)
+		}
+	}
+	if len(seen) != len(httpMethods) {
+		t.Errorf("HTTPMethod() generated unexpected methods: %v", seen)
+	}
+}
+
+func TestHTTPStatusAndMethod_Shrink(t *testing.T) {
+	r := rand.New(rand.NewSource(7))
+
+	for i := 0; i < 30; i++ {
+		// every value "fails": accept candidates that are no further from the target
+		code, shrink := HTTPStatus().Generate(r, gen.Size{})
+		min, accept := code, false
+		for j := 0; j < 500; j++ {
+			next, ok := shrink(accept)
+			if !ok {
+				break
+			}
+			accept = indexOf(httpStatusCodes, next) <= indexOf(httpStatusCodes, min)
+			if accept {
+				min = next
+			}
+		}
+		if min != http.StatusOK {
+			t.Errorf("HTTPStatus() %d shrunk to %d, expected 200", code, min)
+		}
+
+		method, shrinkM := HTTPMethod().Generate(r, gen.Size{})
+		minM, accept := method, false
+		for j := 0; j < 500; j++ {
+			next, ok := shrinkM(accept)
+			if !ok {
+				break
+			}
+			accept = indexOf(httpMethods, next) <= indexOf(httpMethods, minM)
+			if accept {
+				minM = next
+			}
+		}
+		if minM != http.MethodGet {
+			t.Errorf("HTTPMethod() %q shrunk to %q, expected GET", method, minM)
+		}
+	}
+}
+
+func indexOf[T comparable](xs []T, x T) int {
+	for i, v := range xs {
+		if v == x {
+			return i
+		}
+	}
+	return -1
+}
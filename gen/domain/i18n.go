@@ -0,0 +1,113 @@
+package domain
+
+import (
+	"strings"
+
+	"arcsyn.io/propx/gen"
+)
+
+// countryCodes are the ISO 3166-1 alpha-2 codes, with the canonical shrink
+// target "US" first and the rest in alphabetical order.
+var countryCodes = strings.Fields(`US
+AD AE AF AG AI AL AM AO AQ AR AS AT AU AW AX AZ BA BB BD BE BF BG BH BI BJ BL
+BM BN BO BQ BR BS BT BV BW BY BZ CA CC CD CF CG CH CI CK CL CM CN CO CR CU CV
+CW CX CY CZ DE DJ DK DM DO DZ EC EE EG EH ER ES ET FI FJ FK FM FO FR GA GB GD
+GE GF GG GH GI GL GM GN GP GQ GR GS GT GU GW GY HK HM HN HR HT HU ID IE IL IM
+IN IO IQ IR IS IT JE JM JO JP KE KG KH KI KM KN KP KR KW KY KZ LA LB LC LI LK
+LR LS LT LU LV LY MA MC MD ME MF MG MH MK ML MM MN MO MP MQ MR MS MT MU MV MW
+MX MY MZ NA NC NE NF NG NI NL NO NP NR NU NZ OM PA PE PF PG PH PK PL PM PN PR
+PS PT PW PY QA RE RO RS RU RW SA SB SC SD SE SG SH SI SJ SK SL SM SN SO SR SS
+ST SV SX SY SZ TC TD TF TG TH TJ TK TL TM TN TO TR TT TV TW TZ UA UG UM UY UZ
+VA VC VE VG VI VN VU WF WS YE YT ZA ZM ZW`)
+
+// languageCodes are ISO 639-1 language subtags, "en" first.
+var languageCodes = strings.Fields(`en
+af am ar as az be bg bn bo bs ca cs cy da de el eo es et eu fa fi fo fr ga gl
+gu ha he hi hr hu hy id ig is it ja ka kk km kn ko ky lb lo lt lv mk ml mn mr
+ms mt my nb ne nl nn no or pa pl ps pt ro ru rw si sk sl so sq sr sv sw ta te
+tg th ti tk tr uk ur uz vi xh yo zh zu`)
+
+// scriptCodes are common ISO 15924 script subtags.
+var scriptCodes = strings.Fields(`Latn Cyrl Arab Hans Hant Deva Grek Hebr Jpan Kore`)
+
+// currencyCodes are the active ISO 4217 currency codes, "USD" first.
+var currencyCodes = strings.Fields(`USD
+AED AFN ALL AMD ANG AOA ARS AUD AWG AZN BAM BBD BDT BGN BHD BIF BMD BND BOB BRL
+BSD BTN BWP BYN BZD CAD CDF CHF CLP CNY COP CRC CUP CVE CZK DJF DKK DOP DZD EGP
+ERN ETB EUR FJD FKP GBP GEL GHS GIP GMD GNF GTQ GYD HKD HNL HTG HUF IDR ILS INR
+IQD IRR ISK JMD JOD JPY KES KGS KHR KMF KPW KRW KWD KYD KZT LAK LBP LKR LRD LSL
+LYD MAD MDL MGA MKD MMK MNT MOP MRU MUR MVR MWK MXN MYR MZN NAD NGN NIO NOK NPR
+NZD OMR PAB PEN PGK PHP PKR PLN PYG QAR RON RSD RUB RWF SAR SBD SCR SDG SEK SGD
+SHP SLE SOS SRD SSP STN SVC SYP SZL THB TJS TMT TND TOP TRY TTD TWD TZS UAH UGX
+UYU UZS VES VND VUV WST XAF XCD XOF XPF YER ZAR ZMW ZWL`)
+
+// CountryCode generates ISO 3166-1 alpha-2 country codes such as "BR".
+// Shrink: toward "US".
+func CountryCode() gen.Generator[string] {
+	return codeFrom(countryCodes)
+}
+
+// LanguageTag generates BCP 47 language tags: a language subtag, optionally
+// followed by a script and/or a region, such as "en", "pt-BR" or "zh-Hant-TW".
+// Shrink: drops the script and region, then moves the language toward "en".
+func LanguageTag() gen.Generator[string] {
+	return gen.Do(func(d *gen.Draw) string {
+		tag := gen.DrawFrom(d, codeFrom(languageCodes))
+		if gen.DrawFrom(d, gen.IntRange(0, 3)) == 3 {
+			tag += "-" + gen.DrawFrom(d, codeFrom(scriptCodes))
+		}
+		if gen.DrawFrom(d, gen.IntRange(0, 1)) == 1 {
+			tag += "-" + gen.DrawFrom(d, codeFrom(countryCodes))
+		}
+		return tag
+	})
+}
+
+// CurrencyCode generates ISO 4217 currency codes such as "EUR".
+// Shrink: toward "USD".
+func CurrencyCode() gen.Generator[string] {
+	return codeFrom(currencyCodes)
+}
+
+// ValidCountryCode reports whether s is an ISO 3166-1 alpha-2 code.
+func ValidCountryCode(s string) bool {
+	return containsCode(countryCodes, s)
+}
+
+// ValidLanguageTag reports whether s is a language tag of the form
+// language[-Script][-REGION] built from the known subtags (case-sensitive,
+// in the canonical casing).
+func ValidLanguageTag(s string) bool {
+	parts := strings.Split(s, "-")
+	if !containsCode(languageCodes, parts[0]) {
+		return false
+	}
+	parts = parts[1:]
+	if len(parts) > 0 && containsCode(scriptCodes, parts[0]) {
+		parts = parts[1:]
+	}
+	if len(parts) > 0 && containsCode(countryCodes, parts[0]) {
+		parts = parts[1:]
+	}
+	return len(parts) == 0
+}
+
+// ValidCurrencyCode reports whether s is an active ISO 4217 code.
+func ValidCurrencyCode(s string) bool {
+	return containsCode(currencyCodes, s)
+}
+
+// codeFrom draws an entry of codes; shrinking moves toward codes[0].
+func codeFrom(codes []string) gen.Generator[string] {
+	return gen.Map(gen.IntRange(0, len(codes)-1), func(i int) string { return codes[i] })
+}
+
+// containsCode reports whether s is one of codes.
+func containsCode(codes []string, s string) bool {
+	for _, c := range codes {
+		if c == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,120 @@
+package domain
+
+import (
+	"math/rand"
+	"testing"
+
+	"arcsyn.io/propx/gen"
+)
+
+func TestCodeSets(t *testing.T) {
+	tests := []struct {
+		name   string
+		g      gen.Generator[string]
+		valid  func(string) bool
+		target string
+	}{
+		{"CountryCode", CountryCode(), ValidCountryCode, "US"},
+		{"LanguageTag", LanguageTag(), ValidLanguageTag, "en"},
+		{"CurrencyCode", CurrencyCode(), ValidCurrencyCode, "USD"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := rand.New(rand.NewSource(123))
+			for i := 0; i < 200; i++ {
+				value, shrink := tt.g.Generate(r, gen.Size{})
+				if !tt.valid(value) {
+					t.Fatalf("%s().Generate() = %q, expected valid code", tt.name, value)
+				}
+				if value == tt.target {
+					continue
+				}
+				// the canonical entry is always reachable by shrinking
+				found := false
+				for j := 0; j < 200 && !found; j++ {
+					next, ok := shrink(false)
+					if !ok {
+						break
+					}
+					if !tt.valid(next) {
+						t.Fatalf("%s() shrink candidate %q is not valid", tt.name, next)
+					}
+					found = next == tt.target
+				}
+				if !found && tt.name != "LanguageTag" {
+					t.Fatalf("%s() %q never proposed %q while shrinking", tt.name, value, tt.target)
+				}
+			}
+		})
+	}
+}
+
+func TestCodeSets_Unique(t *testing.T) {
+	for name, codes := range map[string][]string{
+		"country": countryCodes, "language": languageCodes, "script": scriptCodes, "currency": currencyCodes,
+	} {
+		seen := map[string]bool{}
+		for _, c := range codes {
+			if seen[c] {
+				t.Errorf("%s code %q listed twice", name, c)
+			}
+			seen[c] = true
+		}
+	}
+}
+
+func TestValidLanguageTag(t *testing.T) {
+	tests := []struct {
+		in   string
+		want bool
+	}{
+		{"en", true},
+		{"pt-BR", true},
+		{"zh-Hant-TW", true},
+		{"sr-Cyrl", true},
+		{"", false},
+		{"EN", false},
+		{"en-XX", false},
+		{"en-US-US", false},
+		{"xx-US", false},
+	}
+	for _, tt := range tests {
+		if got := ValidLanguageTag(tt.in); got != tt.want {
+			t.Errorf("ValidLanguageTag(%q) = %v, expected %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestValidCountryAndCurrencyCode(t *testing.T) {
+	if !ValidCountryCode("BR") || ValidCountryCode("br") || ValidCountryCode("XX") {
+		t.Error("ValidCountryCode() misclassified BR, br or XX")
+	}
+	if !ValidCurrencyCode("EUR") || ValidCurrencyCode("eur") || ValidCurrencyCode("ABC") {
+		t.Error("ValidCurrencyCode() misclassified EUR, eur or ABC")
+	}
+}
+
+func TestLanguageTag_ShrinksToEn(t *testing.T) {
+	g := LanguageTag()
+	r := rand.New(rand.NewSource(9))
+
+	for i := 0; i < 30; i++ {
+		value, shrink := g.Generate(r, gen.Size{})
+		// every tag "fails": accept candidates that are no longer
+		min, accept := value, false
+		for j := 0; j < 500; j++ {
+			next, ok := shrink(accept)
+			if !ok {
+				break
+			}
+			accept = len(next) <= len(min)
+			if accept {
+				min = next
+			}
+		}
+		if min != "en" {
+			t.Errorf("LanguageTag() %q shrunk to %q, expected 'en'", value, min)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+
+	"arcsyn.io/propx/gen"
+)
+
+// ISBN10 generates valid ISBN-10 numbers: nine digits and a mod-11 check
+// digit, which is 'X' for 10. With masked, hyphens split the number into
+// registration group, registrant, publication and check digit
+// ("0-306-40615-2"); the group (1-5 digits) and registrant (1-7 digits)
+// lengths are drawn at random, as they vary between real ISBNs, not looked up
+// in the ISBN range table.
+// Shrink: toward "0000000000" ("0-0-0000000-0" masked).
+func ISBN10(masked bool) gen.Generator[string] {
+	return gen.Do(func(d *gen.Draw) string {
+		data := drawDigits(d, 9)
+		raw := data + string(isbn10CheckDigit(data))
+		if !masked {
+			return raw
+		}
+		return hyphenateISBN(d, "", raw)
+	})
+}
+
+// ISBN13 generates valid ISBN-13 numbers: the prefix 978 or 979, nine digits
+// and a mod-10 check digit. With masked, hyphens split it as ISBN10 does,
+// after the prefix ("978-0-306-40615-7").
+// Shrink: toward "9780000000002" ("978-0-0-0000000-2" masked).
+func ISBN13(masked bool) gen.Generator[string] {
+	return gen.Do(func(d *gen.Draw) string {
+		prefix := gen.DrawFrom(d, gen.OneOf(gen.Const("978"), gen.Const("979")))
+		data := prefix + drawDigits(d, 9)
+		raw := data + string(isbn13CheckDigit(data))
+		if !masked {
+			return raw
+		}
+		return hyphenateISBN(d, prefix, raw[3:])
+	})
+}
+
+// ValidISBN10 checks if a string is a valid ISBN-10, with or without hyphens
+// or spaces: nine digits and the matching check digit (an uppercase 'X' for
+// 10).
+func ValidISBN10(s string) bool {
+	raw := UnmaskISBN(s)
+	if len(raw) != 10 || !allDigits(raw[:9]) {
+		return false
+	}
+	return raw[9] == isbn10CheckDigit(raw[:9])
+}
+
+// ValidISBN13 checks if a string is a valid ISBN-13, with or without hyphens
+// or spaces: 13 digits starting with 978 or 979, the last the check digit.
+func ValidISBN13(s string) bool {
+	raw := UnmaskISBN(s)
+	if len(raw) != 13 || !allDigits(raw) || (raw[:3] != "978" && raw[:3] != "979") {
+		return false
+	}
+	return raw[12] == isbn13CheckDigit(raw[:12])
+}
+
+// ISBN10To13 converts a valid ISBN-10 (masked or not) to its unmasked
+// ISBN-13: the prefix 978, the first nine digits and a new check digit.
+func ISBN10To13(s string) (string, error) {
+	if !ValidISBN10(s) {
+		return "", errors.New("ISBN10To13: invalid ISBN-10")
+	}
+	data := "978" + UnmaskISBN(s)[:9]
+	return data + string(isbn13CheckDigit(data)), nil
+}
+
+// ISBN13To10 converts a valid ISBN-13 (masked or not) with the prefix 978 to
+// its unmasked ISBN-10; ISBN-13s with the prefix 979 have none.
+func ISBN13To10(s string) (string, error) {
+	if !ValidISBN13(s) {
+		return "", errors.New("ISBN13To10: invalid ISBN-13")
+	}
+	raw := UnmaskISBN(s)
+	if raw[:3] != "978" {
+		return "", errors.New("ISBN13To10: only 978 ISBNs have an ISBN-10")
+	}
+	data := raw[3:12]
+	return data + string(isbn10CheckDigit(data)), nil
+}
+
+// UnmaskISBN removes hyphens and spaces from an ISBN.
+func UnmaskISBN(s string) string {
+	return strings.NewReplacer("-", "", " ", "").Replace(s)
+}
+
+// drawDigits draws a string of n decimal digits.
+func drawDigits(d *gen.Draw, n int) string {
+	s := gen.DrawFrom(d, gen.String("0123456789", gen.Size{Min: n, Max: n}))
+	return (s + strings.Repeat("0", n))[:n]
+}
+
+// hyphenateISBN splits the ten digits after prefix (the ISBN-10, or the rest
+// of an ISBN-13) into group, registrant, publication and check digit.
+func hyphenateISBN(d *gen.Draw, prefix, rest string) string {
+	group := gen.DrawFrom(d, smallInt(1, 5))
+	// the publication keeps at least one digit
+	registrant := min(gen.DrawFrom(d, smallInt(1, 7)), 8-group)
+	parts := []string{rest[:group], rest[group : group+registrant], rest[group+registrant : 9], rest[9:]}
+	if prefix != "" {
+		parts = append([]string{prefix}, parts...)
+	}
+	return strings.Join(parts, "-")
+}
+
+// smallInt generates ints in [lo, hi] uniformly, as OneOf constants, which
+// shrink to lo under either strategy.
+func smallInt(lo, hi int) gen.Generator[int] {
+	gs := make([]gen.Generator[int], 0, hi-lo+1)
+	for n := lo; n <= hi; n++ {
+		gs = append(gs, gen.Const(n))
+	}
+	return gen.OneOf(gs...)
+}
+
+// isbn10CheckDigit computes the check digit of nine ISBN-10 digits: digits
+// weighted 10 down to 2 must sum, with the check digit, to a multiple of 11.
+func isbn10CheckDigit(data string) byte {
+	sum := 0
+	for i := 0; i < 9; i++ {
+		sum += int(data[i]-'0') * (10 - i)
+	}
+	c := (11 - sum%11) % 11
+	if c == 10 {
+		return 'X'
+	}
+	return byte('0' + c)
+}
+
+// isbn13CheckDigit computes the check digit of twelve ISBN-13 digits: digits
+// weighted 1 and 3 alternately must sum, with the check digit, to a multiple
+// of 10.
+func isbn13CheckDigit(data string) byte {
+	sum := 0
+	for i := 0; i < 12; i++ {
+		sum += int(data[i]-'0') * (1 + 2*(i%2))
+	}
+	return byte('0' + (10-sum%10)%10)
+}
+
+// allDigits reports whether s consists of ASCII digits only.
+func allDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
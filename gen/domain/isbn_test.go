@@ -0,0 +1,143 @@
+package domain
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"arcsyn.io/propx/gen"
+)
+
+func TestValidISBN(t *testing.T) {
+	for _, s := range []string{"0306406152", "0-306-40615-2", "080442957X", "0 8044 2957 X", "0000000000"} {
+		if !ValidISBN10(s) {
+			t.Errorf("ValidISBN10(%q) = false, expected true", s)
+		}
+	}
+	for _, s := range []string{"", "030640615", "0306406153", "080442957x", "0306406152X", "03064061X2", "03O6406152", "9780306406157"} {
+		if ValidISBN10(s) {
+			t.Errorf("ValidISBN10(%q) = true, expected false", s)
+		}
+	}
+	for _, s := range []string{"9780306406157", "978-0-306-40615-7", "9791034304882", "9780000000002"} {
+		if !ValidISBN13(s) {
+			t.Errorf("ValidISBN13(%q) = false, expected true", s)
+		}
+	}
+	for _, s := range []string{"", "9780306406158", "978030640615", "9770306406150", "0306406152", "97803064061X7"} {
+		if ValidISBN13(s) {
+			t.Errorf("ValidISBN13(%q) = true, expected false", s)
+		}
+	}
+}
+
+func TestISBNConversion(t *testing.T) {
+	for _, tt := range []struct{ isbn10, isbn13 string }{
+		{"0-306-40615-2", "9780306406157"},
+		{"080442957X", "9780804429573"},
+	} {
+		if got, err := ISBN10To13(tt.isbn10); err != nil || got != tt.isbn13 {
+			t.Errorf("ISBN10To13(%q) = %q, %v; expected %q", tt.isbn10, got, err, tt.isbn13)
+		}
+		if got, err := ISBN13To10(tt.isbn13); err != nil || got != UnmaskISBN(tt.isbn10) {
+			t.Errorf("ISBN13To10(%q) = %q, %v; expected %q", tt.isbn13, got, err, UnmaskISBN(tt.isbn10))
+		}
+	}
+	for _, s := range []string{"0306406153", "9780306406157"} {
+		if _, err := ISBN10To13(s); err == nil {
+			t.Errorf("ISBN10To13(%q) succeeded, expected an error", s)
+		}
+	}
+	for _, s := range []string{"9791034304882", "9780306406158", "0306406152"} {
+		if _, err := ISBN13To10(s); err == nil {
+			t.Errorf("ISBN13To10(%q) succeeded, expected an error", s)
+		}
+	}
+}
+
+func TestISBNGenerators(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	var x, prefix979 bool
+	groups := map[int]bool{}
+	for i := 0; i < 500; i++ {
+		s10, shrink := ISBN10(false).Generate(r, gen.Size{})
+		if shrink == nil {
+			t.Fatal("ISBN10().Generate() returned nil shrinker")
+		}
+		if len(s10) != 10 || !ValidISBN10(s10) {
+			t.Fatalf("ISBN10(false) = %q is invalid", s10)
+		}
+		x = x || s10[9] == 'X'
+		s13, _ := ISBN13(false).Generate(r, gen.Size{})
+		if len(s13) != 13 || !ValidISBN13(s13) {
+			t.Fatalf("ISBN13(false) = %q is invalid", s13)
+		}
+		prefix979 = prefix979 || strings.HasPrefix(s13, "979")
+
+		m10, _ := ISBN10(true).Generate(r, gen.Size{})
+		parts := strings.Split(m10, "-")
+		if len(parts) != 4 || !ValidISBN10(m10) || len(parts[3]) != 1 {
+			t.Fatalf("ISBN10(true) = %q is not a valid hyphenated ISBN-10", m10)
+		}
+		groups[len(parts[0])] = true
+		m13, _ := ISBN13(true).Generate(r, gen.Size{})
+		if parts := strings.Split(m13, "-"); len(parts) != 5 || len(parts[0]) != 3 || !ValidISBN13(m13) {
+			t.Fatalf("ISBN13(true) = %q is not a valid hyphenated ISBN-13", m13)
+		}
+		if got, err := ISBN10To13(m10); err != nil || !ValidISBN13(got) {
+			t.Fatalf("ISBN10To13(%q) = %q, %v", m10, got, err)
+		}
+	}
+	if !x || !prefix979 || len(groups) != 5 {
+		t.Errorf("check digit X %v, prefix 979 %v, group lengths %v; expected all", x, prefix979, groups)
+	}
+}
+
+func TestISBN_ShrinkToCanonical(t *testing.T) {
+	for _, tt := range []struct {
+		g    gen.Generator[string]
+		want string
+	}{
+		{ISBN10(false), "0000000000"},
+		{ISBN10(true), "0-0-0000000-0"},
+		{ISBN13(false), "9780000000002"},
+		{ISBN13(true), "978-0-0-0000000-2"},
+	} {
+		for _, strategy := range []string{"bfs", "dfs"} {
+			gen.SetShrinkStrategy(strategy)
+			r := rand.New(rand.NewSource(2))
+			for i := 0; i < 20; i++ {
+				s, shrink := tt.g.Generate(r, gen.Size{})
+				min, accept := s, false
+				for j := 0; j < 5000; j++ {
+					next, ok := shrink(accept)
+					if !ok {
+						break
+					}
+					if !ValidISBN10(next) && !ValidISBN13(next) {
+						t.Fatalf("%s: shrinking %q proposed invalid %q", strategy, s, next)
+					}
+					if accept = isbnSimpler(next, min); accept {
+						min = next
+					}
+				}
+				if min != tt.want {
+					t.Errorf("%s: %q shrunk to %q, expected %q", strategy, s, min, tt.want)
+				}
+			}
+		}
+	}
+	gen.SetShrinkStrategy("bfs")
+}
+
+// isbnSimpler reports whether a has a smaller prefix and data digits (all
+// but the check digit) than b, or the same with hyphens further left.
+func isbnSimpler(a, b string) bool {
+	ra, rb := UnmaskISBN(a), UnmaskISBN(b)
+	da, db := ra[:len(ra)-1], rb[:len(rb)-1]
+	if da != db {
+		return da < db
+	}
+	// '-' sorts before the digits
+	return a < b
+}
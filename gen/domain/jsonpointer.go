@@ -0,0 +1,198 @@
+package domain
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+
+	"arcsyn.io/propx/gen"
+)
+
+// jsonPointerTokenAlphabet includes '~' and '/' so escaping is exercised.
+const jsonPointerTokenAlphabet = "abcxyz019_-~/ "
+
+// jsonPointerCorruptions are the ways JSONPointerInvalid breaks a pointer.
+const (
+	pointerNoLeadingSlash = iota // "xfoo/bar": non-empty pointer without the leading "/"
+	pointerBadEscape             // "~" followed by something other than 0 or 1
+	pointerTrailingTilde         // a token ending in a bare "~"
+	pointerCorruptions
+)
+
+// jsonPointerSpec is the unescaped reference tokens of a pointer plus, for
+// invalid pointers, the corruption applied when rendering.
+type jsonPointerSpec struct {
+	tokens  []string
+	corrupt int // -1 when valid
+}
+
+// JSONPointer generates valid RFC 6901 JSON Pointers such as "/foo/0/bar",
+// escaping "~" as "~0" and "/" as "~1" inside reference tokens.
+// Shrink: drops tokens and shortens them, converging toward the root
+// pointer "" (the whole document).
+func JSONPointer() gen.Generator[string] {
+	return gen.From(func(r *rand.Rand, _ gen.Size) (string, gen.Shrinker[string]) {
+		if r == nil {
+			r = rand.New(rand.NewSource(rand.Int63())) // #nosec G404 -- Using math/rand for deterministic property-based testing
+		}
+		spec := generateJSONPointerSpec(r)
+		spec.corrupt = -1
+		return spec.render(), createJSONPointerShrinker(spec)
+	})
+}
+
+// JSONPointerInvalid generates strings that are NOT valid JSON Pointers:
+// a missing leading "/", an escape other than "~0"/"~1", or a trailing "~".
+// Shrink: like JSONPointer, keeping the same defect so every candidate stays
+// invalid.
+func JSONPointerInvalid() gen.Generator[string] {
+	return gen.From(func(r *rand.Rand, _ gen.Size) (string, gen.Shrinker[string]) {
+		if r == nil {
+			r = rand.New(rand.NewSource(rand.Int63())) // #nosec G404 -- Using math/rand for deterministic property-based testing
+		}
+		spec := generateJSONPointerSpec(r)
+		spec.corrupt = r.Intn(pointerCorruptions)
+		return spec.render(), createJSONPointerShrinker(spec)
+	})
+}
+
+// ValidJSONPointer reports whether s is a syntactically valid RFC 6901 JSON
+// Pointer: empty, or "/"-prefixed tokens where "~" only appears as "~0" or "~1".
+func ValidJSONPointer(s string) bool {
+	if s == "" {
+		return true
+	}
+	if s[0] != '/' {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] != '~' {
+			continue
+		}
+		if i+1 >= len(s) || (s[i+1] != '0' && s[i+1] != '1') {
+			return false
+		}
+	}
+	return true
+}
+
+// generateJSONPointerSpec draws up to 5 reference tokens; about a third of
+// them are array indices.
+func generateJSONPointerSpec(r *rand.Rand) jsonPointerSpec {
+	var spec jsonPointerSpec
+	for i, n := 0, r.Intn(6); i < n; i++ {
+		if r.Intn(3) == 0 {
+			spec.tokens = append(spec.tokens, fmt.Sprint(r.Intn(10)))
+			continue
+		}
+		spec.tokens = append(spec.tokens, randomToken(r, jsonPointerTokenAlphabet, 0, 8))
+	}
+	return spec
+}
+
+// createJSONPointerShrinker creates a shrinker for pointer specs.
+func createJSONPointerShrinker(initial jsonPointerSpec) gen.Shrinker[string] {
+	queue := make([]jsonPointerSpec, 0, 16)
+	seen := map[string]struct{}{initial.render(): {}}
+	cur, last := initial, initial
+
+	push := func(s jsonPointerSpec) {
+		k := s.render()
+		if _, ok := seen[k]; ok {
+			return
+		}
+		// an invalid pointer must stay invalid while shrinking
+		if s.corrupt >= 0 && ValidJSONPointer(k) {
+			return
+		}
+		seen[k] = struct{}{}
+		queue = append(queue, s)
+	}
+
+	growNeighbors := func(base jsonPointerSpec) {
+		queue = queue[:0]
+		// (1) root pointer
+		if len(base.tokens) > 0 {
+			push(jsonPointerSpec{corrupt: base.corrupt})
+		}
+		// (2) drop each token (R->L)
+		for i := len(base.tokens) - 1; i >= 0; i-- {
+			s := base.clone()
+			s.tokens = append(s.tokens[:i], s.tokens[i+1:]...)
+			push(s)
+		}
+		// (3) shorten tokens: empty, then halve
+		for i, tok := range base.tokens {
+			if tok == "" {
+				continue
+			}
+			s := base.clone()
+			s.tokens[i] = ""
+			push(s)
+			s = base.clone()
+			s.tokens[i] = tok[:len(tok)/2]
+			push(s)
+		}
+	}
+	growNeighbors(cur)
+
+	popNext := func() (jsonPointerSpec, bool) {
+		if len(queue) == 0 {
+			return jsonPointerSpec{}, false
+		}
+		if gen.GetShrinkStrategy() == gen.ShrinkStrategyDFS {
+			v := queue[len(queue)-1]
+			queue = queue[:len(queue)-1]
+			return v, true
+		}
+		v := queue[0]
+		queue = queue[1:]
+		return v, true
+	}
+
+	return func(accept bool) (string, bool) {
+		if accept && last.render() != cur.render() {
+			cur = last
+			growNeighbors(cur)
+		}
+		nxt, ok := popNext()
+		if !ok {
+			return "", false
+		}
+		last = nxt
+		return nxt.render(), true
+	}
+}
+
+// render returns the pointer text, escaping each token and applying the
+// corruption of invalid specs.
+func (s jsonPointerSpec) render() string {
+	var b strings.Builder
+	for _, tok := range s.tokens {
+		b.WriteString("/")
+		b.WriteString(escapeJSONPointerToken(tok))
+	}
+	out := b.String()
+
+	switch s.corrupt {
+	case pointerNoLeadingSlash:
+		return "x" + strings.TrimPrefix(out, "/")
+	case pointerBadEscape:
+		return out + "/~2"
+	case pointerTrailingTilde:
+		return out + "/~"
+	}
+	return out
+}
+
+// clone returns a deep copy of the spec.
+func (s jsonPointerSpec) clone() jsonPointerSpec {
+	s.tokens = append([]string(nil), s.tokens...)
+	return s
+}
+
+// escapeJSONPointerToken applies the RFC 6901 escaping ("~" first, then "/").
+func escapeJSONPointerToken(tok string) string {
+	tok = strings.ReplaceAll(tok, "~", "~0")
+	return strings.ReplaceAll(tok, "/", "~1")
+}
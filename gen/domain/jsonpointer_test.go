@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"arcsyn.io/propx/gen"
+)
+
+func TestJSONPointer(t *testing.T) {
+	g := JSONPointer()
+	r := rand.New(rand.NewSource(123))
+
+	sawEscape := false
+	for i := 0; i < 200; i++ {
+		value, shrink := g.Generate(r, gen.Size{})
+
+		if !ValidJSONPointer(value) {
+			t.Fatalf("JSONPointer().Generate() = %q, expected valid pointer", value)
+		}
+		if strings.Contains(value, "~0") || strings.Contains(value, "~1") {
+			sawEscape = true
+		}
+		if shrink == nil {
+			t.Fatal("JSONPointer().Generate() returned nil shrinker")
+		}
+	}
+	if !sawEscape {
+		t.Error("JSONPointer() never produced an escaped token")
+	}
+}
+
+func TestJSONPointer_ShrinksToRoot(t *testing.T) {
+	g := JSONPointer()
+	r := rand.New(rand.NewSource(42))
+
+	for i := 0; i < 20; i++ {
+		value, shrink := g.Generate(r, gen.Size{})
+		min := value
+		for j := 0; j < 1000; j++ {
+			next, ok := shrink(true)
+			if !ok {
+				break
+			}
+			if !ValidJSONPointer(next) {
+				t.Fatalf("shrink candidate %q is not a valid pointer", next)
+			}
+			min = next
+		}
+		if value != "" && min != "" {
+			t.Errorf("JSONPointer() %q shrunk to %q, expected root pointer \"\"", value, min)
+		}
+	}
+}
+
+func TestJSONPointerInvalid(t *testing.T) {
+	g := JSONPointerInvalid()
+	r := rand.New(rand.NewSource(123))
+
+	for i := 0; i < 100; i++ {
+		value, shrink := g.Generate(r, gen.Size{})
+
+		if ValidJSONPointer(value) {
+			t.Fatalf("JSONPointerInvalid().Generate() = %q, expected invalid pointer", value)
+		}
+		for j := 0; j < 200; j++ {
+			next, ok := shrink(true)
+			if !ok {
+				break
+			}
+			if ValidJSONPointer(next) {
+				t.Fatalf("JSONPointerInvalid() shrink candidate %q is valid", next)
+			}
+		}
+	}
+}
+
+func TestValidJSONPointer(t *testing.T) {
+	tests := []struct {
+		in   string
+		want bool
+	}{
+		{"", true},
+		{"/", true},
+		{"/foo/0/bar", true},
+		{"/a~1b/m~0n", true},
+		{"foo", false},
+		{"/a~", false},
+		{"/a~2b", false},
+	}
+	for _, tt := range tests {
+		if got := ValidJSONPointer(tt.in); got != tt.want {
+			t.Errorf("ValidJSONPointer(%q) = %v, expected %v", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,216 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"arcsyn.io/propx/gen"
+)
+
+// jwtIssuers are issuers for the generated "iss" claims, the simplest first.
+var jwtIssuers = []string{
+	"https://auth.example.com",
+	"https://accounts.google.com",
+	"https://login.microsoftonline.com/common/v2.0",
+	"https://cognito-idp.us-east-1.amazonaws.com/us-east-1_example",
+}
+
+// JWTOption configures JWT and JWTTampered.
+type JWTOption func(*jwtConfig)
+
+type jwtConfig struct {
+	key []byte
+}
+
+// JWTKey signs the generated tokens with HMAC-SHA256 (alg HS256) using key,
+// so a verifier holding the same key accepts them.
+func JWTKey(key []byte) JWTOption {
+	return func(c *jwtConfig) {
+		c.key = key
+	}
+}
+
+// JWT generates structurally valid JSON Web Tokens in compact form,
+// header.payload.signature, each segment base64url-encoded without padding.
+// The header is {"alg":"HS256","typ":"JWT"}, sometimes with a "kid"; the
+// payload holds claims plus, each half of the time, the registered claims
+// "iss", "sub", "iat", "nbf", "exp" and "jti" (claims wins on a clash). With
+// JWTKey the signature is the HMAC-SHA256 of the first two segments;
+// without, it is 32 random bytes (zero one time in eight), as if signed with
+// an unknown key. Panics if
+// claims cannot be encoded as JSON.
+// Shrink: toward a token with no "kid" and the given claims only (and a zero
+// signature, without a key), then simpler registered claim values.
+func JWT(claims map[string]any, opts ...JWTOption) gen.Generator[string] {
+	cfg := newJWTConfig(claims, opts)
+	return gen.Do(func(d *gen.Draw) string {
+		header, payload := drawJWTParts(d, claims)
+		return signJWT(d, cfg, header, payload)
+	})
+}
+
+// JWTTampered generates tokens that a verifier with the JWTKey must reject,
+// built from a JWT token with the same claims and options:
+//   - one character of the signature changed
+//   - the signature removed ("header.payload.")
+//   - the payload changed (an "admin" claim set or flipped) under the
+//     original signature
+//   - the header switched to {"alg":"none","typ":"JWT"}, with no signature
+//
+// Without a key only the last two changes break ValidJWT's structural checks.
+// Shrink: toward a changed signature, then as JWT.
+func JWTTampered(claims map[string]any, opts ...JWTOption) gen.Generator[string] {
+	cfg := newJWTConfig(claims, opts)
+	return gen.Do(func(d *gen.Draw) string {
+		header, payload := drawJWTParts(d, claims)
+		token := signJWT(d, cfg, header, payload)
+		i := strings.LastIndexByte(token, '.')
+		signed, sig := token[:i], token[i+1:]
+		kinds := []gen.Generator[int]{gen.Const(0), gen.Const(1), gen.Const(2), gen.Const(3)}
+		switch gen.DrawFrom(d, gen.OneOf(kinds...)) {
+		case 0:
+			// not the last character: its low bits may be ignored by decoders
+			pos := gen.DrawFrom(d, gen.IntRange(0, len(sig)-2))
+			off := gen.DrawFrom(d, gen.IntRange(1, 63))
+			const alphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789-_"
+			c := alphabet[(strings.IndexByte(alphabet, sig[pos])+off)%64]
+			return signed + "." + sig[:pos] + string(c) + sig[pos+1:]
+		case 1:
+			return signed + "."
+		case 2:
+			admin, _ := payload["admin"].(bool)
+			payload["admin"] = !admin
+			return jwtSegment(header) + "." + jwtSegment(payload) + "." + sig
+		default:
+			return jwtSegment(map[string]any{"alg": "none", "typ": "JWT"}) + "." + jwtSegment(payload) + "."
+		}
+	})
+}
+
+// ValidJWT reports whether token is a structurally valid JWT: three
+// base64url segments without padding, the first two JSON objects, the header
+// naming an "alg", and a non-empty signature. With a non-nil key it must also
+// be HS256-signed with key.
+func ValidJWT(token string, key []byte) bool {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return false
+	}
+	var header, payload map[string]any
+	if !jwtDecode(parts[0], &header) || !jwtDecode(parts[1], &payload) {
+		return false
+	}
+	if alg, ok := header["alg"].(string); !ok || alg == "" {
+		return false
+	}
+	sig, err := base64.RawURLEncoding.Strict().DecodeString(parts[2])
+	if err != nil || len(sig) == 0 {
+		return false
+	}
+	if key == nil {
+		return true
+	}
+	return header["alg"] == "HS256" && hmac.Equal(sig, jwtHMAC(key, parts[0]+"."+parts[1]))
+}
+
+// newJWTConfig applies opts, checking that claims encode as JSON.
+func newJWTConfig(claims map[string]any, opts []JWTOption) jwtConfig {
+	if _, err := json.Marshal(claims); err != nil {
+		panic(fmt.Errorf("domain.JWT: claims are not JSON: %w", err))
+	}
+	var cfg jwtConfig
+	for _, o := range opts {
+		o(&cfg)
+	}
+	return cfg
+}
+
+// drawJWTParts draws the header and the payload (claims with some registered
+// claims added).
+func drawJWTParts(d *gen.Draw, claims map[string]any) (header, payload map[string]any) {
+	// whether the kid header and each registered claim are included is drawn
+	// first, so shrinking drops them before the values; inclusion is drawn
+	// with smallInt, which only shrinks from 1 to 0 (gen.Bool's shrinker also
+	// proposes true for false, which would add entries)
+	names := []string{"iss", "sub", "iat", "nbf", "exp", "jti"}
+	withKid := gen.DrawFrom(d, smallInt(0, 1)) == 1
+	with := make([]bool, len(names))
+	for i := range with {
+		with[i] = gen.DrawFrom(d, smallInt(0, 1)) == 1
+	}
+
+	// every value is drawn, used or not, so dropping a claim while shrinking
+	// leaves the draws of the others in place
+	header = map[string]any{"alg": "HS256", "typ": "JWT"}
+	kid := fmt.Sprintf("key-%d", gen.DrawFrom(d, gen.IntRange(1, 9)))
+	if withKid {
+		header["kid"] = kid
+	}
+
+	// iat from 2017-07-14 to 2033-05-18
+	iat := gen.DrawFrom(d, gen.Int64Range(1500000000, 2000000000))
+	values := []any{
+		gen.DrawFrom(d, codeFrom(jwtIssuers)),
+		fmt.Sprintf("user-%d", gen.DrawFrom(d, gen.IntRange(1, 99999))),
+		iat,
+		iat,
+		iat + gen.DrawFrom(d, gen.Int64Range(60, 30*86400)),
+		fmt.Sprintf("%016x", gen.DrawFrom(d, gen.Int64Range(0, 1<<62))),
+	}
+	payload = map[string]any{}
+	for i, name := range names {
+		if with[i] {
+			payload[name] = values[i]
+		}
+	}
+	for k, v := range claims {
+		payload[k] = v
+	}
+	return header, payload
+}
+
+// signJWT encodes the token, with the HMAC signature when cfg has a key and
+// 32 drawn bytes otherwise.
+func signJWT(d *gen.Draw, cfg jwtConfig, header, payload map[string]any) string {
+	signed := jwtSegment(header) + "." + jwtSegment(payload)
+	var sig []byte
+	if cfg.key != nil {
+		sig = jwtHMAC(cfg.key, signed)
+	} else {
+		sig = gen.DrawFrom(d, gen.Frequency([]gen.Freq[[]byte]{
+			{Weight: 1, Gen: gen.Const(make([]byte, 32))},
+			{Weight: 7, Gen: gen.Map(gen.Bytes(gen.Size{Min: 32, Max: 32}), func(b []byte) []byte {
+				return append(b, make([]byte, 32)...)[:32]
+			})},
+		}))
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+// jwtSegment encodes v as a JSON token segment. Maps encode with sorted keys,
+// so equal claims give equal segments.
+func jwtSegment(v map[string]any) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		// unreachable: the claims were checked by newJWTConfig
+		panic(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// jwtDecode decodes a token segment holding a JSON object into v.
+func jwtDecode(seg string, v *map[string]any) bool {
+	data, err := base64.RawURLEncoding.Strict().DecodeString(seg)
+	return err == nil && json.Unmarshal(data, v) == nil && *v != nil
+}
+
+// jwtHMAC returns the HMAC-SHA256 of signed with key.
+func jwtHMAC(key []byte, signed string) []byte {
+	m := hmac.New(sha256.New, key)
+	m.Write([]byte(signed))
+	return m.Sum(nil)
+}
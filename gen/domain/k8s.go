@@ -0,0 +1,207 @@
+package domain
+
+import (
+	"strings"
+
+	"arcsyn.io/propx/gen"
+)
+
+// Kubernetes length limits: object names and label key prefixes are DNS-1123
+// subdomains of at most 253 characters; label names and values have at most
+// 63.
+const (
+	maxK8sNameLen  = 253
+	maxK8sLabelLen = 63
+)
+
+// k8sNameAlphabet and k8sLabelAlphabet hold the characters of object names
+// and of label names and values, with the shrink target 'a' first.
+const (
+	k8sNameAlphabet  = "abcdefghijklmnopqrstuvwxyz0123456789-."
+	k8sLabelAlphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_."
+)
+
+// k8sLabelPrefixes are label key prefixes in common use, "example.com" first.
+var k8sLabelPrefixes = []string{
+	"example.com",
+	"app.kubernetes.io",
+	"kubernetes.io",
+	"k8s.io",
+	"node.kubernetes.io",
+	"topology.kubernetes.io",
+	"helm.sh",
+}
+
+// K8sLabelPair is a Kubernetes label: a key with an optional prefix
+// ("app.kubernetes.io/name") and a value, which may be empty.
+type K8sLabelPair struct {
+	Key   string
+	Value string
+}
+
+// String returns the label in selector form, "key=value".
+func (l K8sLabelPair) String() string {
+	return l.Key + "=" + l.Value
+}
+
+// K8sName generates Kubernetes object names (DNS-1123 subdomains): 1-253
+// lowercase letters, digits, hyphens and dots, with every dot-separated part
+// starting and ending with a letter or digit. One name in eight is exactly at
+// or near a length limit (63, 64, 252 or 253 characters); the rest are short,
+// often with all-digit parts, "--" or several dots.
+// Shrink: toward "a".
+func K8sName() gen.Generator[string] {
+	return gen.Do(func(d *gen.Draw) string {
+		if gen.DrawFrom(d, gen.IntRange(0, 7)) == 7 {
+			// at the 63-character DNS label limit (which subdomains do not
+			// have) or the name limit, one past or below it
+			n := gen.DrawFrom(d, gen.OneOf(
+				gen.Const(maxK8sLabelLen), gen.Const(maxK8sLabelLen+1),
+				gen.Const(maxK8sNameLen-1), gen.Const(maxK8sNameLen)))
+			return k8sSubdomain(tile(gen.DrawFrom(d, gen.String(k8sNameAlphabet, gen.Size{Min: 1, Max: 16})), n))
+		}
+		return k8sSubdomain(gen.DrawFrom(d, gen.String(k8sNameAlphabet, gen.Size{Min: 1, Max: 24})))
+	})
+}
+
+// K8sLabel generates Kubernetes labels. Keys are a name of 1-63 letters (any
+// case), digits, hyphens, underscores and dots, starting and ending with a
+// letter or digit, with a prefix half of the time: a common one such as
+// "app.kubernetes.io", or any K8sName (up to 253 characters). Values follow
+// the name rules or are empty. Names and values are exactly 63 characters one
+// time in eight.
+// Shrink: toward K8sLabelPair{Key: "a", Value: ""}: no prefix, then shorter
+// and simpler names and values.
+func K8sLabel() gen.Generator[K8sLabelPair] {
+	return gen.Do(func(d *gen.Draw) K8sLabelPair {
+		prefix := gen.DrawFrom(d, gen.Frequency([]gen.Freq[string]{
+			{Weight: 2, Gen: gen.Const("")},
+			{Weight: 1, Gen: codeFrom(k8sLabelPrefixes)},
+			{Weight: 1, Gen: K8sName()},
+		}))
+		key := drawK8sLabelName(d)
+		if prefix != "" {
+			key = prefix + "/" + key
+		}
+		if gen.DrawFrom(d, gen.IntRange(0, 3)) == 0 {
+			return K8sLabelPair{Key: key}
+		}
+		return K8sLabelPair{Key: key, Value: drawK8sLabelName(d)}
+	})
+}
+
+// drawK8sLabelName draws a label name or non-empty value: mostly short,
+// exactly 63 characters one time in eight.
+func drawK8sLabelName(d *gen.Draw) string {
+	if gen.DrawFrom(d, gen.IntRange(0, 7)) == 7 {
+		return k8sLabelName(tile(gen.DrawFrom(d, gen.String(k8sLabelAlphabet, gen.Size{Min: 1, Max: 16})), maxK8sLabelLen))
+	}
+	return k8sLabelName(gen.DrawFrom(d, gen.String(k8sLabelAlphabet, gen.Size{Min: 1, Max: 16})))
+}
+
+// ValidK8sName reports whether s is a valid Kubernetes object name (a
+// DNS-1123 subdomain): 1-253 characters of lowercase letters, digits, hyphens
+// and dots, each dot-separated part non-empty and starting and ending with a
+// letter or digit.
+func ValidK8sName(s string) bool {
+	if len(s) == 0 || len(s) > maxK8sNameLen {
+		return false
+	}
+	for _, part := range strings.Split(s, ".") {
+		if part == "" || !isLowerAlnum(part[0]) || !isLowerAlnum(part[len(part)-1]) {
+			return false
+		}
+		for i := 0; i < len(part); i++ {
+			if !isLowerAlnum(part[i]) && part[i] != '-' {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// ValidK8sLabelKey reports whether s is a valid label key: a name (see
+// ValidK8sLabelValue, but not empty), optionally preceded by a prefix that is
+// a valid K8sName and a slash.
+func ValidK8sLabelKey(s string) bool {
+	name := s
+	if i := strings.IndexByte(s, '/'); i >= 0 {
+		if !ValidK8sName(s[:i]) {
+			return false
+		}
+		name = s[i+1:]
+	}
+	return name != "" && ValidK8sLabelValue(name)
+}
+
+// ValidK8sLabelValue reports whether s is a valid label value: empty, or 1-63
+// letters (any case), digits, hyphens, underscores and dots, starting and
+// ending with a letter or digit.
+func ValidK8sLabelValue(s string) bool {
+	if s == "" {
+		return true
+	}
+	if len(s) > maxK8sLabelLen || !isAlnum(s[0]) || !isAlnum(s[len(s)-1]) {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if !isAlnum(s[i]) && s[i] != '-' && s[i] != '_' && s[i] != '.' {
+			return false
+		}
+	}
+	return true
+}
+
+// k8sSubdomain turns s (drawn from k8sNameAlphabet) into a valid name by
+// replacing with 'a' each dot or hyphen at an end, each dot after a dot or
+// hyphen, and each hyphen after a dot.
+func k8sSubdomain(s string) string {
+	if s == "" {
+		return "a"
+	}
+	b := []byte(s)
+	for i, c := range b {
+		switch {
+		case isLowerAlnum(c):
+		case i == 0 || i == len(b)-1:
+			b[i] = 'a'
+		case c == '.' && !isLowerAlnum(b[i-1]), c == '-' && b[i-1] == '.':
+			b[i] = 'a'
+		}
+	}
+	return string(b)
+}
+
+// k8sLabelName turns s (drawn from k8sLabelAlphabet) into a valid label name
+// by replacing a non-alphanumeric first or last character with 'a'.
+func k8sLabelName(s string) string {
+	if s == "" {
+		return "a"
+	}
+	b := []byte(s)
+	if !isAlnum(b[0]) {
+		b[0] = 'a'
+	}
+	if !isAlnum(b[len(b)-1]) {
+		b[len(b)-1] = 'a'
+	}
+	return string(b)
+}
+
+// tile repeats s (or "a", if empty) up to exactly n bytes.
+func tile(s string, n int) string {
+	if s == "" {
+		s = "a"
+	}
+	return strings.Repeat(s, n/len(s)+1)[:n]
+}
+
+// isLowerAlnum reports whether c is a lowercase ASCII letter or a digit.
+func isLowerAlnum(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= '0' && c <= '9'
+}
+
+// isAlnum reports whether c is an ASCII letter or digit.
+func isAlnum(c byte) bool {
+	return isLowerAlnum(c) || c >= 'A' && c <= 'Z'
+}
@@ -0,0 +1,172 @@
+package domain
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"arcsyn.io/propx/gen"
+)
+
+func TestValidK8sName(t *testing.T) {
+	valid := []string{"a", "0", "my-app", "a--b", "123", "web.example.com", "a.b.c", strings.Repeat("a", 64), strings.Repeat("a", 253)}
+	for _, s := range valid {
+		if !ValidK8sName(s) {
+			t.Errorf("ValidK8sName(%q) = false, expected true", s)
+		}
+	}
+	invalid := []string{"", "-a", "a-", "A", "a_b", "a..b", ".a", "a.", "a-.b", "a.-b", "a/b", strings.Repeat("a", 254)}
+	for _, s := range invalid {
+		if ValidK8sName(s) {
+			t.Errorf("ValidK8sName(%q) = true, expected false", s)
+		}
+	}
+}
+
+func TestValidK8sLabel(t *testing.T) {
+	validKeys := []string{"a", "app", "App_Name", "a.b-c_d", "app.kubernetes.io/name", "example.com/A", strings.Repeat("x", 63), strings.Repeat("a", 253) + "/a"}
+	for _, s := range validKeys {
+		if !ValidK8sLabelKey(s) {
+			t.Errorf("ValidK8sLabelKey(%q) = false, expected true", s)
+		}
+	}
+	invalidKeys := []string{"", "/a", "example.com/", "Example.com/a", "a/b/c", "_a", "a.", "a b", strings.Repeat("x", 64), strings.Repeat("a", 254) + "/a"}
+	for _, s := range invalidKeys {
+		if ValidK8sLabelKey(s) {
+			t.Errorf("ValidK8sLabelKey(%q) = true, expected false", s)
+		}
+	}
+	validValues := []string{"", "a", "v1.2.3", "Prod_EU-1", strings.Repeat("9", 63)}
+	for _, s := range validValues {
+		if !ValidK8sLabelValue(s) {
+			t.Errorf("ValidK8sLabelValue(%q) = false, expected true", s)
+		}
+	}
+	invalidValues := []string{"-", "a-", ".a", "a/b", "a=b", "ü", strings.Repeat("9", 64)}
+	for _, s := range invalidValues {
+		if ValidK8sLabelValue(s) {
+			t.Errorf("ValidK8sLabelValue(%q) = true, expected false", s)
+		}
+	}
+	if got := (K8sLabelPair{Key: "app.kubernetes.io/name", Value: "web"}).String(); got != "app.kubernetes.io/name=web" {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+func TestK8sGenerators(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	lens := map[int]bool{}
+	var dotted, prefixed, empty, upper bool
+	for i := 0; i < 500; i++ {
+		name, _ := K8sName().Generate(r, gen.Size{})
+		if !ValidK8sName(name) {
+			t.Fatalf("K8sName() = %q is invalid", name)
+		}
+		lens[len(name)] = true
+		dotted = dotted || strings.Contains(name, ".")
+
+		l, _ := K8sLabel().Generate(r, gen.Size{})
+		if !ValidK8sLabelKey(l.Key) || !ValidK8sLabelValue(l.Value) {
+			t.Fatalf("K8sLabel() = %q is invalid", l)
+		}
+		prefixed = prefixed || strings.Contains(l.Key, "/")
+		empty = empty || l.Value == ""
+		upper = upper || strings.ToLower(l.Key) != l.Key
+		lens[-len(l.Value)] = true
+	}
+	for _, n := range []int{253, 252, 64, 63, -63} {
+		if !lens[n] {
+			t.Errorf("no name or value (negative) of length %d generated", n)
+		}
+	}
+	if !dotted || !prefixed || !empty || !upper {
+		t.Errorf("dotted names %v, prefixed keys %v, empty values %v, uppercase keys %v; expected all", dotted, prefixed, empty, upper)
+	}
+}
+
+func TestK8sGenerators_ShrinkToMinimal(t *testing.T) {
+	for _, strategy := range []string{"bfs", "dfs"} {
+		gen.SetShrinkStrategy(strategy)
+		r := rand.New(rand.NewSource(2))
+		for i := 0; i < 20; i++ {
+			// every value "fails": accept candidates that are shorter, or as
+			// long and lexicographically smaller
+			name, shrink := K8sName().Generate(r, gen.Size{})
+			min, accept := name, false
+			for j := 0; j < 3000; j++ {
+				next, ok := shrink(accept)
+				if !ok {
+					break
+				}
+				if !ValidK8sName(next) {
+					t.Fatalf("%s: shrinking %q proposed invalid %q", strategy, name, next)
+				}
+				if accept = k8sSimpler(next, min); accept {
+					min = next
+				}
+			}
+			if min != "a" {
+				t.Errorf("%s: K8sName() %q shrunk to %q, expected \"a\"", strategy, name, min)
+			}
+
+			l, shrinkLabel := K8sLabel().Generate(r, gen.Size{})
+			minLabel, accept := l, false
+			for j := 0; j < 3000; j++ {
+				next, ok := shrinkLabel(accept)
+				if !ok {
+					break
+				}
+				if !ValidK8sLabelKey(next.Key) || !ValidK8sLabelValue(next.Value) {
+					t.Fatalf("%s: shrinking %q proposed invalid %q", strategy, l, next)
+				}
+				if accept = k8sSimpler(next.String(), minLabel.String()); accept {
+					minLabel = next
+				}
+			}
+			if minLabel != (K8sLabelPair{Key: "a"}) {
+				t.Errorf("%s: K8sLabel() %q shrunk to %q, expected \"a=\"", strategy, l, minLabel)
+			}
+		}
+	}
+	gen.SetShrinkStrategy("bfs")
+}
+
+// k8sSimpler reports whether a is shorter than b, or as long with fewer
+// characters other than 'a'.
+func k8sSimpler(a, b string) bool {
+	if len(a) != len(b) {
+		return len(a) < len(b)
+	}
+	return len(strings.ReplaceAll(a, "a", "")) < len(strings.ReplaceAll(b, "a", ""))
+}
+
+func TestK8sName_ShrinkKeepsLengthLimit(t *testing.T) {
+	// a validator that rejects names longer than 63 characters (the DNS label
+	// limit, wrongly applied to subdomains): the minimal name is 64 "a"s
+	gen.SetShrinkStrategy("bfs")
+	r := rand.New(rand.NewSource(3))
+	found := 0
+	for i := 0; i < 500 && found < 5; i++ {
+		name, shrink := K8sName().Generate(r, gen.Size{})
+		if len(name) <= 63 {
+			continue
+		}
+		found++
+		min, accept := name, false
+		for j := 0; j < 5000; j++ {
+			next, ok := shrink(accept)
+			if !ok {
+				break
+			}
+			if accept = len(next) > 63; accept {
+				min = next
+			}
+		}
+		if min != strings.Repeat("a", 64) {
+			t.Errorf("K8sName() %q shrunk to %q, expected 64 \"a\"s", name, min)
+		}
+	}
+	if found == 0 {
+		t.Fatal("K8sName() generated no name longer than 63 characters")
+	}
+}
@@ -0,0 +1,236 @@
+package domain
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"unicode/utf8"
+
+	"arcsyn.io/propx/gen"
+)
+
+// nameLists holds the given names, family names and family-name particles of
+// a locale, the shrink targets first.
+type nameLists struct {
+	given     []string
+	family    []string
+	particles []string
+}
+
+// personNames are the embedded name lists per locale.
+var personNames = map[string]nameLists{
+	"pt-BR": {
+		given: strings.Fields(`Ana
+Maria João José Antônio Francisco Carlos Paulo Pedro Lucas Luiz Marcos Luís
+Gabriel Rafael Daniel Marcelo Bruno Eduardo Felipe Gustavo Juliana Adriana
+Márcia Fernanda Patrícia Aline Sandra Camila Letícia Beatriz Conceição Luana
+Vitória Thaís Larissa Júlia Heloísa Cecília Tânia`),
+		family: strings.Fields(`Silva
+Santos Oliveira Souza Rodrigues Ferreira Alves Pereira Lima Gomes Costa
+Ribeiro Martins Carvalho Almeida Lopes Soares Fernandes Vieira Barbosa Rocha
+Dias Nascimento Andrade Moreira Nunes Marques Machado Mendes Freitas Cardoso
+Ramos Gonçalves Araújo Teixeira Correia Magalhães Brandão Conceição`),
+		particles: strings.Fields(`da de do dos das`),
+	},
+	"en": {
+		given: strings.Fields(`Amy
+James Mary John Patricia Robert Jennifer Michael Linda William Elizabeth David
+Barbara Richard Susan Joseph Jessica Thomas Sarah Charles Karen Christopher
+Nancy Daniel Lisa Matthew Betty Anthony Margaret Mark Sandra Emily Olivia Noah
+Liam Emma Sophia Ethan Chloe`),
+		family: strings.Fields(`Smith
+Johnson Williams Brown Jones Garcia Miller Davis Rodriguez Martinez Hernandez
+Lopez Wilson Anderson Thomas Taylor Moore Jackson Martin Lee Thompson White
+Harris Clark Lewis Robinson Walker Young Allen King Wright Scott Baker Adams
+Nelson Hill Campbell Mitchell Roberts O'Brien Fitzgerald-Jones`),
+	},
+}
+
+// fullNameSpec is the plain-data description of a name: given names followed
+// by family names, each with an optional particle ("da Silva").
+type fullNameSpec struct {
+	given  []string
+	family []familyName
+}
+
+// familyName is a family name with its optional particle.
+type familyName struct {
+	particle string
+	name     string
+}
+
+// FullName generates plausible personal names for the locale from embedded
+// name lists: "pt-BR" names have one or two given names and one to three
+// family names, sometimes with a particle ("Maria Conceição da Silva Santos");
+// "en" names have a given name, an optional middle name and a family name
+// ("James Robert Smith").
+// Panics if the locale is not "pt-BR" or "en".
+// Shrink: drops names and particles, moves names toward the first of their
+// list, then cuts them to one character, down to the single character "A".
+func FullName(locale string) gen.Generator[string] {
+	lists, ok := personNames[locale]
+	if !ok {
+		panic(fmt.Sprintf("domain.FullName: unsupported locale %q (supported: \"pt-BR\", \"en\")", locale))
+	}
+	return gen.From(func(r *rand.Rand, _ gen.Size) (string, gen.Shrinker[string]) {
+		if r == nil {
+			r = rand.New(rand.NewSource(rand.Int63())) // #nosec G404 -- Using math/rand for deterministic property-based testing
+		}
+		spec := generateFullNameSpec(r, locale, lists)
+		return spec.render(), createFullNameShrinker(spec, lists)
+	})
+}
+
+// generateFullNameSpec draws a name following the locale's conventions.
+func generateFullNameSpec(r *rand.Rand, locale string, lists nameLists) fullNameSpec {
+	pick := func(xs []string) string { return xs[r.Intn(len(xs))] }
+	spec := fullNameSpec{given: []string{pick(lists.given)}}
+	families := 1
+	if locale == "pt-BR" {
+		families += r.Intn(3)
+	}
+	if r.Intn(3) == 0 {
+		spec.given = append(spec.given, pick(lists.given))
+	}
+	for i := 0; i < families; i++ {
+		f := familyName{name: pick(lists.family)}
+		if len(lists.particles) > 0 && r.Intn(4) == 0 {
+			f.particle = pick(lists.particles)
+		}
+		spec.family = append(spec.family, f)
+	}
+	return spec
+}
+
+// createFullNameShrinker creates a shrinker for name specs.
+func createFullNameShrinker(initial fullNameSpec, lists nameLists) gen.Shrinker[string] {
+	queue := make([]fullNameSpec, 0, 16)
+	tried := map[string]struct{}{initial.render(): {}}
+	var queued map[string]struct{}
+	cur, last := initial, initial
+
+	push := func(s fullNameSpec) {
+		k := s.render()
+		if _, ok := tried[k]; ok {
+			return
+		}
+		if _, ok := queued[k]; ok {
+			return
+		}
+		queued[k] = struct{}{}
+		queue = append(queue, s)
+	}
+
+	growNeighbors := func(base fullNameSpec) {
+		queue = queue[:0]
+		queued = map[string]struct{}{}
+		// (1) the first given name alone
+		if len(base.given) > 1 || len(base.family) > 0 {
+			push(fullNameSpec{given: base.given[:1]})
+		}
+		// (2) drop extra given names, then each family name (R->L)
+		for i := len(base.given) - 1; i >= 1; i-- {
+			s := base.clone()
+			s.given = append(s.given[:i], s.given[i+1:]...)
+			push(s)
+		}
+		for i := len(base.family) - 1; i >= 0; i-- {
+			s := base.clone()
+			s.family = append(s.family[:i], s.family[i+1:]...)
+			push(s)
+		}
+		// (3) drop particles
+		for i, f := range base.family {
+			if f.particle != "" {
+				s := base.clone()
+				s.family[i].particle = ""
+				push(s)
+			}
+		}
+		// (4) the first name of each list, then (5) a single character
+		for i, g := range base.given {
+			for _, v := range simplerNames(g, lists.given[0]) {
+				s := base.clone()
+				s.given[i] = v
+				push(s)
+			}
+		}
+		for i, f := range base.family {
+			for _, v := range simplerNames(f.name, lists.family[0]) {
+				s := base.clone()
+				s.family[i].name = v
+				push(s)
+			}
+		}
+	}
+	growNeighbors(cur)
+
+	popNext := func() (fullNameSpec, bool) {
+		if len(queue) == 0 {
+			return fullNameSpec{}, false
+		}
+		var v fullNameSpec
+		if gen.GetShrinkStrategy() == gen.ShrinkStrategyDFS {
+			v = queue[len(queue)-1]
+			queue = queue[:len(queue)-1]
+		} else {
+			v = queue[0]
+			queue = queue[1:]
+		}
+		tried[v.render()] = struct{}{}
+		return v, true
+	}
+
+	return func(accept bool) (string, bool) {
+		if accept && last.render() != cur.render() {
+			cur = last
+			growNeighbors(cur)
+		}
+		nxt, ok := popNext()
+		if !ok {
+			return "", false
+		}
+		last = nxt
+		return nxt.render(), true
+	}
+}
+
+// render returns the name text: given names, then family names, separated by
+// single spaces.
+func (s fullNameSpec) render() string {
+	parts := append([]string(nil), s.given...)
+	for _, f := range s.family {
+		if f.particle != "" {
+			parts = append(parts, f.particle)
+		}
+		parts = append(parts, f.name)
+	}
+	return strings.Join(parts, " ")
+}
+
+// clone returns a deep copy of the spec.
+func (s fullNameSpec) clone() fullNameSpec {
+	s.given = append([]string(nil), s.given...)
+	s.family = append([]familyName(nil), s.family...)
+	return s
+}
+
+// simplerNames returns the replacements of name that make it simpler: the
+// first name of its list (unless name is already as short) and its first
+// character.
+func simplerNames(name, first string) []string {
+	var out []string
+	if name != first && utf8.RuneCountInString(name) >= utf8.RuneCountInString(first) {
+		out = append(out, first)
+	}
+	if c := firstRune(name); c != name {
+		out = append(out, c)
+	}
+	return out
+}
+
+// firstRune returns the first character of s.
+func firstRune(s string) string {
+	_, n := utf8.DecodeRuneInString(s)
+	return s[:n]
+}
@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"arcsyn.io/propx/gen"
+)
+
+func TestFullName_UsesLocaleLists(t *testing.T) {
+	for _, locale := range []string{"pt-BR", "en"} {
+		t.Run(locale, func(t *testing.T) {
+			lists := personNames[locale]
+			known := map[string]bool{}
+			for _, w := range append(append(append([]string(nil), lists.given...), lists.family...), lists.particles...) {
+				known[w] = true
+			}
+			r := rand.New(rand.NewSource(1))
+			maxWords := 0
+			for i := 0; i < 300; i++ {
+				name, _ := FullName(locale).Generate(r, gen.Size{})
+				if !utf8.ValidString(name) || strings.TrimSpace(name) != name || strings.Contains(name, "  ") {
+					t.Fatalf("FullName(%q) = %q is not a clean name", locale, name)
+				}
+				words := strings.Fields(name)
+				if len(words) < 2 {
+					t.Fatalf("FullName(%q) = %q has no family name", locale, name)
+				}
+				for _, w := range words {
+					if !known[w] {
+						t.Fatalf("FullName(%q) = %q uses %q, not in the %s lists", locale, name, w, locale)
+					}
+				}
+				if len(words) > maxWords {
+					maxWords = len(words)
+				}
+			}
+			if locale == "pt-BR" && maxWords < 5 {
+				t.Errorf("FullName(pt-BR) never produced long names (max %d words)", maxWords)
+			}
+		})
+	}
+}
+
+func TestFullName_UnsupportedLocalePanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("FullName(\"xx\") did not panic")
+		}
+	}()
+	FullName("xx")
+}
+
+func TestFullName_ShrinkToSingleCharacter(t *testing.T) {
+	for _, locale := range []string{"pt-BR", "en"} {
+		r := rand.New(rand.NewSource(2))
+		for i := 0; i < 20; i++ {
+			name, shrink := FullName(locale).Generate(r, gen.Size{})
+			min := name
+			for j := 0; j < 1000; j++ {
+				next, ok := shrink(true)
+				if !ok {
+					break
+				}
+				min = next
+			}
+			if min != "A" {
+				t.Errorf("FullName(%q) %q shrunk to %q, expected \"A\"", locale, name, min)
+			}
+		}
+	}
+}
+
+func TestFullName_ShrinkKeepsFailure(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	for i := 0; i < 20; i++ {
+		name, shrink := FullName("pt-BR").Generate(r, gen.Size{})
+		// fails while the name has a family name: minimal is "A S"
+		fails := func(s string) bool { return strings.Contains(s, " ") }
+		min, accept := name, false
+		for j := 0; j < 1000; j++ {
+			next, ok := shrink(accept)
+			if !ok {
+				break
+			}
+			accept = fails(next)
+			if accept {
+				min = next
+			}
+		}
+		if min != "A S" {
+			t.Errorf("FullName(pt-BR) %q shrunk to %q, expected \"A S\"", name, min)
+		}
+	}
+}
@@ -0,0 +1,230 @@
+package domain
+
+import (
+	"math/rand"
+	"time"
+
+	"arcsyn.io/propx/gen"
+)
+
+// ianaZones are the zones of Timezone, simplest first: UTC, then zones whose
+// clocks change in ways schedulers get wrong.
+var ianaZones = []string{
+	"UTC",
+	"Europe/London",       // DST from 01:00 to 02:00
+	"America/New_York",    // DST at 02:00
+	"Europe/Berlin",       // DST at 02:00 (01:00 UTC)
+	"America/Phoenix",     // no DST, unlike the rest of its offset
+	"Asia/Kolkata",        // +05:30, no DST
+	"Asia/Kathmandu",      // +05:45
+	"America/St_Johns",    // -03:30 with DST
+	"Australia/Sydney",    // southern hemisphere DST (October to April)
+	"America/Santiago",    // southern DST at midnight
+	"America/Havana",      // DST at midnight: 00:00 is skipped
+	"Australia/Lord_Howe", // 30-minute DST
+	"Pacific/Chatham",     // +12:45 with DST at 02:45
+	"Europe/Dublin",       // negative DST in the tz database
+	"Antarctica/Troll",    // 2-hour DST
+	"Africa/Casablanca",   // DST suspended during Ramadan
+	"Pacific/Apia",        // skipped 2011-12-30 crossing the date line
+	"Pacific/Kiritimati",  // +14:00
+	"Pacific/Pago_Pago",   // -11:00
+}
+
+// scheduleMonths are the months of the common DST transitions, for the
+// month field of DST-focused schedules.
+var scheduleMonths = []int{3, 4, 9, 10, 11}
+
+// CronSchedule is a recurring schedule: a 5-field cron expression evaluated
+// in an IANA time zone.
+type CronSchedule struct {
+	// Spec is a 5-field cron expression (see ValidCron).
+	Spec string
+	// TZ is an IANA time zone name, e.g. "America/New_York", or "UTC".
+	TZ string
+}
+
+// String returns the schedule in the CRON_TZ form understood by many cron
+// implementations: "CRON_TZ=Europe/London 30 1 * * *".
+func (s CronSchedule) String() string {
+	return "CRON_TZ=" + s.TZ + " " + s.Spec
+}
+
+// Location loads the schedule's time zone with time.LoadLocation, which
+// needs the tz database of the system or of an import of time/tzdata.
+func (s CronSchedule) Location() (*time.Location, error) {
+	return time.LoadLocation(s.TZ)
+}
+
+// scheduleSpec is a schedule as a cron spec and an index into ianaZones.
+type scheduleSpec struct {
+	cron cronSpec
+	zone int
+}
+
+// Timezone generates IANA time zone names for testing time zone handling:
+// UTC and zones with DST at 01:00, 02:00 or midnight, in the southern
+// hemisphere, with 30-minute or 2-hour shifts, with 30- and 45-minute
+// offsets, and with offsets at the edges (-11:00, +14:00).
+// Shrink: toward "UTC", through the zones listed before it.
+func Timezone() gen.Generator[string] {
+	return gen.Map(gen.IntRange(0, len(ianaZones)-1), func(i int) string { return ianaZones[i] })
+}
+
+// Schedule generates recurring schedules for testing "next run" computations
+// across DST transitions. Half of them use general cron expressions (as
+// Cron); the other half fire in the small hours when clocks change (00:00 to
+// 03:59, e.g. "30 2 * * *"), often only in transition months or on Sundays
+// ("0 1 * MAR,OCT SUN"). Zones come from Timezone.
+// Shrink: toward the daily-midnight UTC schedule {"0 0 * * *", "UTC"}: the
+// zone toward "UTC", minute and hour toward "0", the other fields toward "*",
+// then simpler fields as in Cron.
+func Schedule() gen.Generator[CronSchedule] {
+	return gen.From(func(r *rand.Rand, _ gen.Size) (CronSchedule, gen.Shrinker[CronSchedule]) {
+		if r == nil {
+			r = rand.New(rand.NewSource(rand.Int63())) // #nosec G404 -- Using math/rand for deterministic property-based testing
+		}
+		spec := scheduleSpec{cron: cronSpec{corrupt: -1}, zone: r.Intn(len(ianaZones))}
+		if r.Intn(2) == 0 {
+			for _, f := range spec.cron.fieldDefs() {
+				spec.cron.fields = append(spec.cron.fields, generateCronField(r, f))
+			}
+		} else {
+			spec.cron.fields = generateDSTFields(r)
+		}
+		return spec.schedule(), createScheduleShrinker(spec)
+	})
+}
+
+// generateDSTFields draws the fields of a schedule firing around the usual
+// DST transitions: a single minute, an hour, range or list in 00-03, every
+// day of the month, and every month or the transition months, every
+// weekday or Sundays.
+func generateDSTFields(r *rand.Rand) [][]cronElem {
+	one := func(v int) cronElem { return cronElem{lo: v, hi: v} }
+	minutes := []int{0, 15, 30, 45, 59, r.Intn(60)}
+	fields := [][]cronElem{
+		{one(minutes[r.Intn(len(minutes))])},
+		{one(r.Intn(4))},
+		{{star: true}},
+		{{star: true}},
+		{{star: true}},
+	}
+	switch r.Intn(4) {
+	case 0:
+		fields[1] = []cronElem{{lo: 1, hi: 3}}
+	case 1:
+		fields[1] = []cronElem{one(1), one(2)}
+	}
+	if r.Intn(2) == 0 {
+		named := r.Intn(2) == 0
+		fields[3] = nil
+		for _, m := range scheduleMonths {
+			if r.Intn(2) == 0 || m == 10 && fields[3] == nil {
+				fields[3] = append(fields[3], cronElem{lo: m, hi: m, named: named})
+			}
+		}
+	}
+	if r.Intn(3) == 0 {
+		fields[4] = []cronElem{{named: r.Intn(2) == 0}}
+	}
+	return fields
+}
+
+// schedule renders the spec.
+func (s scheduleSpec) schedule() CronSchedule {
+	return CronSchedule{Spec: s.cron.render(), TZ: ianaZones[s.zone]}
+}
+
+// createScheduleShrinker creates a shrinker for schedules.
+func createScheduleShrinker(initial scheduleSpec) gen.Shrinker[CronSchedule] {
+	queue := make([]scheduleSpec, 0, 32)
+	tried := map[CronSchedule]struct{}{initial.schedule(): {}}
+	var queued map[CronSchedule]struct{}
+	cur, last := initial, initial
+
+	push := func(s scheduleSpec) {
+		k := s.schedule()
+		if _, ok := tried[k]; ok {
+			return
+		}
+		if _, ok := queued[k]; ok {
+			return
+		}
+		queued[k] = struct{}{}
+		queue = append(queue, s)
+	}
+
+	// simplest is the value of field i in the daily-midnight schedule
+	simplest := func(i int) []cronElem {
+		if i < 2 {
+			return []cronElem{{}}
+		}
+		return []cronElem{{star: true}}
+	}
+
+	growNeighbors := func(base scheduleSpec) {
+		queue = queue[:0]
+		queued = map[CronSchedule]struct{}{}
+		// (1) daily at midnight in UTC
+		target := scheduleSpec{cron: base.cron.clone()}
+		for i := range target.cron.fields {
+			target.cron.fields[i] = simplest(i)
+		}
+		push(target)
+		// (2) zone toward UTC
+		for _, z := range []int{0, base.zone / 2, base.zone - 1} {
+			if z >= 0 && z < base.zone {
+				s := base
+				s.zone = z
+				push(s)
+			}
+		}
+		// (3) daily at midnight, then each field at its simplest (L->R)
+		s := base
+		s.cron = target.cron
+		push(s)
+		for i := range base.cron.fields {
+			s := base
+			s.cron = base.cron.clone()
+			s.cron.fields[i] = simplest(i)
+			push(s)
+		}
+		// (4) simpler fields
+		base.cron.simplify(func(c cronSpec) {
+			s := base
+			s.cron = c
+			push(s)
+		})
+	}
+	growNeighbors(cur)
+
+	popNext := func() (scheduleSpec, bool) {
+		if len(queue) == 0 {
+			return scheduleSpec{}, false
+		}
+		var v scheduleSpec
+		if gen.GetShrinkStrategy() == gen.ShrinkStrategyDFS {
+			v = queue[len(queue)-1]
+			queue = queue[:len(queue)-1]
+		} else {
+			v = queue[0]
+			queue = queue[1:]
+		}
+		tried[v.schedule()] = struct{}{}
+		return v, true
+	}
+
+	return func(accept bool) (CronSchedule, bool) {
+		if accept && last.schedule() != cur.schedule() {
+			cur = last
+			growNeighbors(cur)
+		}
+		nxt, ok := popNext()
+		if !ok {
+			return CronSchedule{}, false
+		}
+		last = nxt
+		return nxt.schedule(), true
+	}
+}
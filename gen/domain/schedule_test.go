@@ -0,0 +1,132 @@
+package domain
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+	_ "time/tzdata" // every zone loads, whatever the system has
+
+	"arcsyn.io/propx/gen"
+)
+
+func TestTimezone(t *testing.T) {
+	for _, name := range ianaZones {
+		if _, err := time.LoadLocation(name); err != nil {
+			t.Errorf("zone %q does not load: %v", name, err)
+		}
+	}
+	r := rand.New(rand.NewSource(1))
+	seen := map[string]bool{}
+	for i := 0; i < 300; i++ {
+		name, _ := Timezone().Generate(r, gen.Size{})
+		seen[name] = true
+	}
+	if len(seen) != len(ianaZones) {
+		t.Errorf("Timezone() produced %d of %d zones", len(seen), len(ianaZones))
+	}
+
+	gen.SetShrinkStrategy("bfs")
+	name, shrink := Timezone().Generate(rand.New(rand.NewSource(2)), gen.Size{})
+	min := name
+	for v, ok := shrink(true); ok; v, ok = shrink(true) {
+		min = v
+	}
+	if name != "UTC" && min != "UTC" {
+		t.Errorf("shrinking %q accepting everything ended at %q, expected \"UTC\"", name, min)
+	}
+}
+
+func TestSchedule(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	smallHours, dst := 0, 0
+	for i := 0; i < 300; i++ {
+		s, shrink := Schedule().Generate(r, gen.Size{})
+		if !ValidCron(s.Spec) || len(strings.Fields(s.Spec)) != 5 {
+			t.Fatalf("Schedule() = %v, expected a valid 5-field expression", s)
+		}
+		loc, err := s.Location()
+		if err != nil {
+			t.Fatalf("Schedule() = %v: %v", s, err)
+		}
+		if shrink == nil {
+			t.Fatal("Schedule().Generate() returned nil shrinker")
+		}
+		if h := strings.Fields(s.Spec)[1]; len(h) == 1 && h <= "3" || h == "1-3" || h == "1,2" {
+			smallHours++
+		}
+		// zones whose offset differs between January and July observe DST
+		_, jan := time.Date(2025, 1, 1, 0, 0, 0, 0, loc).Zone()
+		_, jul := time.Date(2025, 7, 1, 0, 0, 0, 0, loc).Zone()
+		if jan != jul {
+			dst++
+		}
+	}
+	if smallHours < 100 || dst < 100 {
+		t.Errorf("Schedule(): %d of 300 fire in the small hours, %d are in DST zones; expected at least 100 of each", smallHours, dst)
+	}
+	if got := (CronSchedule{Spec: "30 1 * * *", TZ: "Europe/London"}).String(); got != "CRON_TZ=Europe/London 30 1 * * *" {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+func TestSchedule_ShrinkTowardMidnightUTC(t *testing.T) {
+	gen.SetShrinkStrategy("bfs")
+	r := rand.New(rand.NewSource(4))
+	want := CronSchedule{Spec: "0 0 * * *", TZ: "UTC"}
+	for i := 0; i < 30; i++ {
+		s, shrink := Schedule().Generate(r, gen.Size{})
+		min := s
+		for j := 0; j < 1000; j++ {
+			next, ok := shrink(true)
+			if !ok {
+				break
+			}
+			if !ValidCron(next.Spec) {
+				t.Fatalf("shrinking %v proposed %v", s, next)
+			}
+			min = next
+		}
+		if s != want && min != want {
+			t.Errorf("shrinking %v accepting everything ended at %v, expected %v", s, min, want)
+		}
+	}
+}
+
+func TestSchedule_ShrinkKeepsFailure(t *testing.T) {
+	// fails while the schedule fires at 02:30 in a zone with DST: the
+	// minimal schedule is "30 2 * * *" in the first such zone
+	for _, strategy := range []string{"bfs", "dfs"} {
+		gen.SetShrinkStrategy(strategy)
+		r := rand.New(rand.NewSource(5))
+		fails := func(s CronSchedule) bool {
+			f := strings.Fields(s.Spec)
+			return s.TZ != "UTC" && (f[0] == "30" || f[0] == "*") && (f[1] == "2" || f[1] == "1-3" || f[1] == "*")
+		}
+		found := 0
+		for i := 0; i < 500 && found < 10; i++ {
+			s, shrink := Schedule().Generate(r, gen.Size{})
+			if !fails(s) {
+				continue
+			}
+			found++
+			min, accept := s, false
+			for j := 0; j < 2000; j++ {
+				next, ok := shrink(accept)
+				if !ok {
+					break
+				}
+				if accept = fails(next); accept {
+					min = next
+				}
+			}
+			if min.TZ != "Europe/London" || min.Spec != "* * * * *" && !strings.HasPrefix(min.Spec, "30 ") {
+				t.Errorf("%s: shrinking %v ended at %v, expected Europe/London at minimal fields", strategy, s, min)
+			}
+		}
+		if found == 0 {
+			t.Fatalf("%s: no failing schedule generated", strategy)
+		}
+	}
+	gen.SetShrinkStrategy("bfs")
+}
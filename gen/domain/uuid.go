@@ -0,0 +1,263 @@
+package domain
+
+import (
+	"crypto/md5"  // #nosec G501 -- MD5 is mandated by RFC 9562 for version 3 UUIDs
+	"crypto/sha1" // #nosec G505 -- SHA-1 is mandated by RFC 9562 for version 5 UUIDs
+	"encoding/hex"
+	"fmt"
+	"math/rand"
+
+	"arcsyn.io/propx/gen"
+)
+
+// Namespaces predefined by RFC 9562 for name-based (version 3 and 5) UUIDs.
+const (
+	NamespaceDNS  = "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+	NamespaceURL  = "6ba7b811-9dad-11d1-80b4-00c04fd430c8"
+	NamespaceOID  = "6ba7b812-9dad-11d1-80b4-00c04fd430c8"
+	NamespaceX500 = "6ba7b814-9dad-11d1-80b4-00c04fd430c8"
+)
+
+const (
+	// gregorianOffset is the number of 100ns intervals between the UUID epoch
+	// (1582-10-15) and the Unix epoch.
+	gregorianOffset = 0x01b21dd213814000
+	// uuidMinMillis and uuidMaxMillis bound the typical Unix timestamps
+	// (2000-01-01 to 2100-01-01, in milliseconds).
+	uuidMinMillis = 946684800000
+	uuidMaxMillis = 4102444800000
+)
+
+// UUID generates UUIDs of the given RFC 9562 version in the canonical
+// lowercase 8-4-4-4-12 form, with the version and variant bits set:
+//   - 1: time-based, a 60-bit Gregorian timestamp, clock sequence and node
+//   - 3, 5: name-based, the MD5 (3) or SHA-1 (5) hash of namespace[0] and
+//     a name of up to 16 letters and digits (see NameUUID to pick the names)
+//   - 4: random
+//   - 7: time-ordered, a 48-bit Unix timestamp in milliseconds followed by
+//     random bits, so string order follows timestamp order
+//
+// Timestamps are mostly in the years 2000-2100, with the zero and maximum
+// timestamps as edge cases. Namespace is required (and only allowed) for
+// versions 3 and 5; NamespaceDNS and friends are the standard ones.
+// Panics on other versions or a missing or malformed namespace.
+// Shrink: toward the smallest UUID of the version (every other bit zero,
+// e.g. "00000000-0000-7000-8000-000000000000"), clearing or halving one
+// byte at a time; name-based UUIDs shrink their name instead, toward "".
+func UUID(version int, namespace ...string) gen.Generator[string] {
+	switch version {
+	case 3, 5:
+		if len(namespace) != 1 {
+			panic(fmt.Sprintf("domain.UUID: version %d needs exactly one namespace, got %d", version, len(namespace)))
+		}
+		return nameUUID("domain.UUID", version, namespace[0], gen.StringAlphaNum(gen.Size{Max: 16}))
+	case 1, 4, 7:
+		if len(namespace) != 0 {
+			panic(fmt.Sprintf("domain.UUID: version %d takes no namespace", version))
+		}
+	default:
+		panic(fmt.Sprintf("domain.UUID: unsupported version %d (want 1, 3, 4, 5 or 7)", version))
+	}
+	return gen.From(func(r *rand.Rand, _ gen.Size) (string, gen.Shrinker[string]) {
+		if r == nil {
+			r = rand.New(rand.NewSource(rand.Int63())) // #nosec G404 -- Using math/rand for deterministic property-based testing
+		}
+		cur := generateUUID(r, version)
+		return formatUUID(cur), createUUIDShrinker(cur)
+	})
+}
+
+// NameUUID generates name-based UUIDs of version 3 (MD5) or 5 (SHA-1): the
+// hash of namespace and a name drawn from names.
+// Panics if version is not 3 or 5 or namespace is not a UUID.
+// Shrink: shrinks the name with names' shrinker and hashes it again.
+func NameUUID(version int, namespace string, names gen.Generator[string]) gen.Generator[string] {
+	return nameUUID("domain.NameUUID", version, namespace, names)
+}
+
+// nameUUID implements NameUUID, reporting panics as coming from fn.
+func nameUUID(fn string, version int, namespace string, names gen.Generator[string]) gen.Generator[string] {
+	if version != 3 && version != 5 {
+		panic(fmt.Sprintf("%s: version %d is not name-based (want 3 or 5)", fn, version))
+	}
+	ns, ok := parseUUID(namespace)
+	if !ok {
+		panic(fmt.Sprintf("%s: namespace %q is not a UUID", fn, namespace))
+	}
+	return gen.Map(names, func(name string) string {
+		var sum []byte
+		if version == 3 {
+			h := md5.Sum(append(ns[:], name...)) // #nosec G401 -- MD5 is mandated by RFC 9562 for version 3 UUIDs
+			sum = h[:]
+		} else {
+			h := sha1.Sum(append(ns[:], name...)) // #nosec G401 -- SHA-1 is mandated by RFC 9562 for version 5 UUIDs
+			sum = h[:]
+		}
+		var b [16]byte
+		copy(b[:], sum)
+		setUUIDLayout(&b, version)
+		return formatUUID(b)
+	})
+}
+
+// ValidUUID reports whether s is a UUID of the given version in the
+// 8-4-4-4-12 form (hex digits of either case) with the RFC 9562 variant.
+func ValidUUID(s string, version int) bool {
+	b, ok := parseUUID(s)
+	return ok && int(b[6]>>4) == version && b[8]&0xc0 == 0x80
+}
+
+// generateUUID draws the bytes of a version 1, 4 or 7 UUID.
+func generateUUID(r *rand.Rand, version int) [16]byte {
+	var b [16]byte
+	r.Read(b[:])
+	switch version {
+	case 1:
+		var ts uint64
+		switch r.Intn(8) {
+		case 0:
+		case 1:
+			ts = 1<<60 - 1
+		default:
+			ts = uint64(uuidMinMillis+r.Int63n(uuidMaxMillis-uuidMinMillis))*10000 + gregorianOffset
+		}
+		b[0], b[1], b[2], b[3] = byte(ts>>24), byte(ts>>16), byte(ts>>8), byte(ts)
+		b[4], b[5] = byte(ts>>40), byte(ts>>32)
+		b[6], b[7] = byte(ts>>56), byte(ts>>48)
+	case 7:
+		var ms uint64
+		switch r.Intn(8) {
+		case 0:
+		case 1:
+			ms = 1<<48 - 1
+		default:
+			ms = uint64(uuidMinMillis + r.Int63n(uuidMaxMillis-uuidMinMillis))
+		}
+		for i := 0; i < 6; i++ {
+			b[i] = byte(ms >> (40 - 8*i))
+		}
+	}
+	setUUIDLayout(&b, version)
+	return b
+}
+
+// setUUIDLayout sets the version and variant bits of b.
+func setUUIDLayout(b *[16]byte, version int) {
+	b[6] = b[6]&0x0f | byte(version)<<4
+	b[8] = b[8]&0x3f | 0x80
+}
+
+// uuidFreeBits returns the bits of byte i that are not version or variant bits.
+func uuidFreeBits(i int) byte {
+	switch i {
+	case 6:
+		return 0x0f
+	case 8:
+		return 0x3f
+	}
+	return 0xff
+}
+
+// createUUIDShrinker creates a shrinker for version 1, 4 and 7 UUIDs that
+// clears their free bits, keeping the version and variant.
+func createUUIDShrinker(initial [16]byte) gen.Shrinker[string] {
+	queue := make([][16]byte, 0, 64)
+	seen := make(map[[16]byte]struct{}, 64)
+	var last [16]byte
+	hasLast := false
+	cur := initial
+
+	push := func(b [16]byte) {
+		if _, ok := seen[b]; ok {
+			return
+		}
+		seen[b] = struct{}{}
+		queue = append(queue, b)
+	}
+
+	growNeighbors := func(base [16]byte) {
+		queue = queue[:0]
+		// (1) every free bit cleared, then halves (the first half holds the
+		// timestamp of versions 1 and 7)
+		zeroed := func(from, to int) [16]byte {
+			b := base
+			for i := from; i < to; i++ {
+				b[i] &^= uuidFreeBits(i)
+			}
+			return b
+		}
+		push(zeroed(0, 16))
+		push(zeroed(0, 8))
+		push(zeroed(8, 16))
+		// (2) clear, then halve, the free bits of each byte (L->R)
+		for i := range base {
+			m := uuidFreeBits(i)
+			if base[i]&m == 0 {
+				continue
+			}
+			b := base
+			b[i] &^= m
+			push(b)
+			b[i] |= (base[i] & m) / 2
+			push(b)
+		}
+	}
+
+	popNext := func() ([16]byte, bool) {
+		if len(queue) == 0 {
+			return [16]byte{}, false
+		}
+		if gen.GetShrinkStrategy() == gen.ShrinkStrategyDFS {
+			v := queue[len(queue)-1]
+			queue = queue[:len(queue)-1]
+			return v, true
+		}
+		v := queue[0]
+		queue = queue[1:]
+		return v, true
+	}
+
+	seen[cur] = struct{}{}
+	growNeighbors(cur)
+
+	return func(accept bool) (string, bool) {
+		if accept && hasLast && last != cur {
+			cur = last
+			growNeighbors(cur)
+		}
+		nxt, ok := popNext()
+		if !ok {
+			return "", false
+		}
+		last, hasLast = nxt, true
+		return formatUUID(nxt), true
+	}
+}
+
+// formatUUID writes b in the canonical lowercase 8-4-4-4-12 form.
+func formatUUID(b [16]byte) string {
+	var s [36]byte
+	hex.Encode(s[0:8], b[0:4])
+	s[8] = '-'
+	hex.Encode(s[9:13], b[4:6])
+	s[13] = '-'
+	hex.Encode(s[14:18], b[6:8])
+	s[18] = '-'
+	hex.Encode(s[19:23], b[8:10])
+	s[23] = '-'
+	hex.Encode(s[24:], b[10:])
+	return string(s[:])
+}
+
+// parseUUID parses a UUID in the 8-4-4-4-12 form.
+func parseUUID(s string) ([16]byte, bool) {
+	var b [16]byte
+	if len(s) != 36 || s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+		return b, false
+	}
+	digits := s[0:8] + s[9:13] + s[14:18] + s[19:23] + s[24:]
+	if _, err := hex.Decode(b[:], []byte(digits)); err != nil {
+		return b, false
+	}
+	return b, true
+}
@@ -0,0 +1,205 @@
+package domain
+
+import (
+	"math/rand"
+	"strconv"
+	"testing"
+
+	"arcsyn.io/propx/gen"
+)
+
+func TestUUID(t *testing.T) {
+	gens := map[int]gen.Generator[string]{
+		1: UUID(1),
+		3: UUID(3, NamespaceDNS),
+		4: UUID(4),
+		5: UUID(5, NamespaceURL),
+		7: UUID(7),
+	}
+	for version, g := range gens {
+		r := rand.New(rand.NewSource(123))
+		seen := map[string]bool{}
+		for i := 0; i < 100; i++ {
+			value, shrink := g.Generate(r, gen.Size{})
+			if !ValidUUID(value, version) {
+				t.Errorf("UUID(%d).Generate() = %q, expected a valid version %d UUID", version, value, version)
+			}
+			if value != formatUUID(mustParseUUID(t, value)) {
+				t.Errorf("UUID(%d).Generate() = %q, expected the canonical lowercase form", version, value)
+			}
+			if shrink == nil {
+				t.Errorf("UUID(%d).Generate() returned nil shrinker", version)
+			}
+			seen[value] = true
+		}
+		if len(seen) < 50 {
+			t.Errorf("UUID(%d) generated only %d distinct values in 100", version, len(seen))
+		}
+	}
+}
+
+func TestUUID_V7OrderFollowsTimestamp(t *testing.T) {
+	g := UUID(7)
+	r := rand.New(rand.NewSource(7))
+	millis := func(s string) uint64 {
+		ms, err := strconv.ParseUint(s[0:8]+s[9:13], 16, 64)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ms
+	}
+	edges := map[uint64]bool{}
+	for i := 0; i < 200; i++ {
+		a, _ := g.Generate(r, gen.Size{})
+		b, _ := g.Generate(r, gen.Size{})
+		if ma, mb := millis(a), millis(b); ma < mb && a >= b || ma > mb && a <= b {
+			t.Errorf("order of %q (%d ms) and %q (%d ms) does not follow their timestamps", a, ma, b, mb)
+		}
+		edges[millis(a)] = true
+	}
+	if !edges[0] || !edges[1<<48-1] {
+		t.Error("UUID(7) never generated the zero or maximum timestamp")
+	}
+}
+
+func TestUUID_ShrinksTowardSmallestOfVersion(t *testing.T) {
+	want := map[int]string{
+		1: "00000000-0000-1000-8000-000000000000",
+		4: "00000000-0000-4000-8000-000000000000",
+		7: "00000000-0000-7000-8000-000000000000",
+	}
+	for version, min := range want {
+		_, shrink := UUID(version).Generate(rand.New(rand.NewSource(42)), gen.Size{})
+
+		// accept every candidate: shrinking converges to the smallest UUID
+		last := ""
+		for i := 0; i < 1000; i++ {
+			next, ok := shrink(true)
+			if !ok {
+				break
+			}
+			if !ValidUUID(next, version) {
+				t.Fatalf("UUID(%d) shrink candidate %q is not a valid version %d UUID", version, next, version)
+			}
+			last = next
+		}
+		if last != min {
+			t.Errorf("UUID(%d) shrunk to %q, expected %q", version, last, min)
+		}
+	}
+}
+
+func TestUUID_ShrinkKeepsLayoutWhenRejected(t *testing.T) {
+	_, shrink := UUID(7).Generate(rand.New(rand.NewSource(5)), gen.Size{})
+	for i := 0; i < 100; i++ {
+		next, ok := shrink(false)
+		if !ok {
+			break
+		}
+		if !ValidUUID(next, 7) {
+			t.Fatalf("shrink candidate %q is not a valid version 7 UUID", next)
+		}
+	}
+}
+
+func TestNameUUID_KnownValues(t *testing.T) {
+	// values from the Python uuid module for the name "www.example.com"
+	tests := []struct {
+		version   int
+		namespace string
+		want      string
+	}{
+		{3, NamespaceDNS, "5df41881-3aed-3515-88a7-2f4a814cf09e"},
+		{5, NamespaceDNS, "2ed6657d-e927-568b-95e1-2665a8aea6a2"},
+	}
+	for _, tt := range tests {
+		got, _ := NameUUID(tt.version, tt.namespace, gen.Const("www.example.com")).Generate(rand.New(rand.NewSource(1)), gen.Size{})
+		if got != tt.want {
+			t.Errorf("NameUUID(%d, %q, \"www.example.com\") = %q, expected %q", tt.version, tt.namespace, got, tt.want)
+		}
+	}
+}
+
+func TestNameUUID_ShrinksName(t *testing.T) {
+	names := gen.StringAlphaNum(gen.Size{Min: 1, Max: 16})
+	_, shrink := NameUUID(5, NamespaceOID, names).Generate(rand.New(rand.NewSource(9)), gen.Size{})
+	_, shrinkName := names.Generate(rand.New(rand.NewSource(9)), gen.Size{})
+
+	// accept every candidate: each one is the UUID of the shrunk name
+	steps := 0
+	for ; steps < 1000; steps++ {
+		next, ok := shrink(true)
+		name, nameOK := shrinkName(true)
+		if ok != nameOK {
+			t.Fatalf("step %d: UUID shrinker ok=%v, name shrinker ok=%v", steps, ok, nameOK)
+		}
+		if !ok {
+			break
+		}
+		want, _ := NameUUID(5, NamespaceOID, gen.Const(name)).Generate(nil, gen.Size{})
+		if next != want {
+			t.Fatalf("step %d: candidate %q, expected %q (the UUID of %q)", steps, next, want, name)
+		}
+	}
+	if steps == 0 {
+		t.Error("NameUUID() did not shrink")
+	}
+}
+
+func TestUUID_Panics(t *testing.T) {
+	tests := []struct {
+		name string
+		f    func()
+	}{
+		{"version 2", func() { UUID(2) }},
+		{"version 8", func() { UUID(8) }},
+		{"v5 without namespace", func() { UUID(5) }},
+		{"v3 with two namespaces", func() { UUID(3, NamespaceDNS, NamespaceURL) }},
+		{"v4 with namespace", func() { UUID(4, NamespaceDNS) }},
+		{"malformed namespace", func() { UUID(5, "example.com") }},
+		{"NameUUID version 4", func() { NameUUID(4, NamespaceDNS, gen.Const("x")) }},
+	}
+	for _, tt := range tests {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: expected panic", tt.name)
+				}
+			}()
+			tt.f()
+		}()
+	}
+}
+
+func TestValidUUID(t *testing.T) {
+	tests := []struct {
+		in      string
+		version int
+		want    bool
+	}{
+		{"6ba7b810-9dad-11d1-80b4-00c04fd430c8", 1, true},
+		{"6BA7B810-9DAD-11D1-80B4-00C04FD430C8", 1, true},
+		{"2ed6657d-e927-568b-95e1-2665a8aea6a2", 5, true},
+		{"2ed6657d-e927-568b-95e1-2665a8aea6a2", 3, false},   // wrong version
+		{"00000000-0000-7000-c000-000000000000", 7, false},   // wrong variant
+		{"00000000-0000-0000-0000-000000000000", 0, false},   // nil UUID has no variant
+		{"2ed6657de927568b95e12665a8aea6a2", 5, false},       // no dashes
+		{"2ed6657d-e927-568b-95e1-2665a8aea6a", 5, false},    // too short
+		{"2ed6657d-e927-568b-95e1-2665a8aea6ag", 5, false},   // not hex
+		{"{2ed6657d-e927-568b-95e1-2665a8aea6a2}", 5, false}, // braces
+	}
+	for _, tt := range tests {
+		if got := ValidUUID(tt.in, tt.version); got != tt.want {
+			t.Errorf("ValidUUID(%q, %d) = %v, expected %v", tt.in, tt.version, got, tt.want)
+		}
+	}
+}
+
+func mustParseUUID(t *testing.T, s string) [16]byte {
+	t.Helper()
+	b, ok := parseUUID(s)
+	if !ok {
+		t.Fatalf("parseUUID(%q) failed", s)
+	}
+	return b
+}
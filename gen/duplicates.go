@@ -0,0 +1,224 @@
+// File: gen/duplicates.go
+package gen
+
+import (
+	"math/rand"
+	"slices"
+)
+
+// SliceWithDuplicates generates []T where some elements deliberately repeat
+// earlier ones, for exercising dedup/counting code that all-unique inputs miss.
+// - size.Min/Max control the length (default Min=0, Max=16), like SliceOf.
+// - dupRatio (clamped to [0,1]) is the probability that a position repeats an
+// earlier element; when dupRatio > 0 and the length is >= 2, at least one
+// duplicate is guaranteed.
+// Shrink:
+//
+//	(1) the shortest duplicate pair [x, x] for each repeated value
+//	(2) remove elements keeping at least one duplicate (blocks, then single)
+//	(3) remove elements even if that loses the duplicates
+//	(4) shrink each distinct value in place (first occurrence order) with
+//	    its own shrinker, replacing all of its copies
+func SliceWithDuplicates[T comparable](elem Generator[T], size Size, dupRatio float64) Generator[[]T] {
+	if dupRatio < 0 {
+		dupRatio = 0
+	}
+	if dupRatio > 1 {
+		dupRatio = 1
+	}
+	return From(func(r *rand.Rand, sz Size) ([]T, Shrinker[[]T]) {
+		if r == nil {
+			r = rand.New(rand.NewSource(rand.Int63())) // #nosec G404 -- Using math/rand for deterministic property-based testing
+		}
+		// defaults
+		if size.Min == 0 && size.Max == 0 {
+			size.Min, size.Max = 0, 16
+		}
+		if sz.Min != 0 || sz.Max != 0 {
+			size = sz
+		}
+		if size.Max < size.Min {
+			size.Max = size.Min
+		}
+
+		n := size.Min
+		if size.Max > size.Min {
+			n += r.Intn(size.Max - size.Min + 1)
+		}
+
+		// generate: each position either repeats an earlier element or is fresh
+		sz.Budget.Spend(n)
+		cur := make([]T, n)
+		shks := make(map[T]Shrinker[T], n)
+		for i := 0; i < n; i++ {
+			if i > 0 && r.Float64() < dupRatio {
+				cur[i] = cur[r.Intn(i)]
+				continue
+			}
+			v, s := elem.Generate(r, sz.elem())
+			cur[i] = v
+			if _, ok := shks[v]; !ok {
+				shks[v] = s
+			}
+		}
+		if n >= 2 && dupRatio > 0 && !hasDuplicate(cur) {
+			i := 1 + r.Intn(n-1)
+			cur[i] = cur[r.Intn(i)]
+		}
+
+		seen := map[string]struct{}{sig(cur): {}}
+		queue := make([][]T, 0, 64)
+		var last []T
+
+		push := func(s []T) {
+			k := sig(s)
+			if _, ok := seen[k]; ok {
+				return
+			}
+			seen[k] = struct{}{}
+			queue = append(queue, append(([]T)(nil), s...))
+		}
+
+		rem := func(base []T, i, j int) []T {
+			out := make([]T, 0, len(base)-(j-i))
+			out = append(out, base[:i]...)
+			return append(out, base[j:]...)
+		}
+
+		growNeighbors := func(base []T) {
+			queue = queue[:0]
+			L := len(base)
+			if L == 0 {
+				return
+			}
+			// (1) minimal duplicate pairs
+			counts := make(map[T]int, L)
+			for _, v := range base {
+				counts[v]++
+			}
+			for _, v := range base {
+				if counts[v] > 1 && L > 2 {
+					push([]T{v, v})
+				}
+			}
+			// (2)+(3) removals: candidates preserving a duplicate go first
+			var lossy [][]T
+			chunk := L / 2
+			for chunk >= 1 {
+				for i := 0; i+chunk <= L; i += chunk {
+					cand := rem(base, i, i+chunk)
+					if hasDuplicate(cand) {
+						push(cand)
+					} else {
+						lossy = append(lossy, cand)
+					}
+				}
+				chunk /= 2
+			}
+			for _, cand := range lossy {
+				push(cand)
+			}
+		}
+		growNeighbors(cur)
+
+		pop := func() ([]T, bool) {
+			if len(queue) == 0 {
+				return nil, false
+			}
+			if GetShrinkStrategy() == ShrinkStrategyDFS {
+				v := queue[len(queue)-1]
+				queue = queue[:len(queue)-1]
+				return v, true
+			}
+			v := queue[0]
+			queue = queue[1:]
+			return v, true
+		}
+
+		// phase (4): values[vi] is shrunk with its shrinker, which moves to
+		// each accepted value; pending holds the candidate value until the
+		// runner reports whether it still fails
+		removing := true
+		var values []T
+		vi := 0
+		var pending T
+		hasPending := false
+
+		return cur, func(accept bool) ([]T, bool) {
+			if removing {
+				if accept && last != nil && sig(last) != sig(cur) {
+					cur = last
+					growNeighbors(cur)
+				}
+				if nxt, ok := pop(); ok {
+					last = nxt
+					return nxt, true
+				}
+				removing = false
+				accept = false
+				cur = append(([]T)(nil), cur...)
+				values = distinct(cur)
+			}
+
+			if accept && hasPending {
+				old := values[vi]
+				replaceAll(cur, old, pending)
+				shks[pending] = shks[old]
+				delete(shks, old)
+				values[vi] = pending
+			}
+			for vi < len(values) {
+				if s := shks[values[vi]]; s != nil {
+					// a candidate equal to another value would merge them,
+					// so it counts as rejected
+					for v, ok := s(accept && hasPending); ok; v, ok = s(false) {
+						if v != values[vi] && slices.Contains(values, v) {
+							hasPending = false
+							continue
+						}
+						pending, hasPending = v, true
+						cand := append(([]T)(nil), cur...)
+						replaceAll(cand, values[vi], v)
+						return cand, true
+					}
+				}
+				vi++
+				hasPending = false
+				accept = false
+			}
+			return nil, false
+		}
+	})
+}
+
+// distinct returns the values of s without repeats, in first occurrence order.
+func distinct[T comparable](s []T) []T {
+	var out []T
+	for _, v := range s {
+		if !slices.Contains(out, v) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// replaceAll replaces every old in s with v.
+func replaceAll[T comparable](s []T, old, v T) {
+	for i := range s {
+		if s[i] == old {
+			s[i] = v
+		}
+	}
+}
+
+// hasDuplicate reports whether any value appears more than once in s.
+func hasDuplicate[T comparable](s []T) bool {
+	seen := make(map[T]struct{}, len(s))
+	for _, v := range s {
+		if _, ok := seen[v]; ok {
+			return true
+		}
+		seen[v] = struct{}{}
+	}
+	return false
+}
@@ -0,0 +1,98 @@
+package gen
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestSliceWithDuplicates(t *testing.T) {
+	gen := SliceWithDuplicates(IntRange(0, 1_000_000), Size{Min: 2, Max: 20}, 0.3)
+	r := rand.New(rand.NewSource(123))
+
+	for i := 0; i < 50; i++ {
+		value, shrink := gen.Generate(r, Size{})
+
+		if len(value) < 2 || len(value) > 20 {
+			t.Errorf("SliceWithDuplicates().Generate() length = %d, expected in range [2, 20]", len(value))
+		}
+		if !hasDuplicate(value) {
+			t.Errorf("SliceWithDuplicates().Generate() = %v, expected at least one duplicate", value)
+		}
+		if shrink == nil {
+			t.Error("SliceWithDuplicates().Generate() returned nil shrinker")
+		}
+	}
+}
+
+func TestSliceWithDuplicates_ZeroRatio(t *testing.T) {
+	gen := SliceWithDuplicates(IntRange(0, 1_000_000_000), Size{Min: 5, Max: 5}, 0)
+	r := rand.New(rand.NewSource(123))
+
+	value, _ := gen.Generate(r, Size{})
+	if len(value) != 5 {
+		t.Errorf("SliceWithDuplicates().Generate() length = %d, expected 5", len(value))
+	}
+}
+
+func TestSliceWithDuplicates_ShrinkKeepsDuplicate(t *testing.T) {
+	SetShrinkStrategy(ShrinkStrategyBFS)
+	gen := SliceWithDuplicates(IntRange(0, 1000), Size{Min: 6, Max: 12}, 0.5)
+	r := rand.New(rand.NewSource(99))
+	start, shrink := gen.Generate(r, Size{})
+
+	// simulate a property that fails only while a duplicate is present
+	min := start
+	accept := true
+	for i := 0; i < 500; i++ {
+		next, ok := shrink(accept)
+		if !ok {
+			break
+		}
+		accept = hasDuplicate(next)
+		if accept {
+			min = next
+		}
+	}
+
+	if len(min) != 2 || min[0] != min[1] {
+		t.Errorf("SliceWithDuplicates() shrunk to %v, expected a single duplicate pair", min)
+	}
+}
+
+func TestSliceWithDuplicates_ShrinkValueConverges(t *testing.T) {
+	SetShrinkStrategy(ShrinkStrategyBFS)
+	gen := SliceWithDuplicates(IntRange(0, 1000), Size{Min: 6, Max: 12}, 0.5)
+	r := rand.New(rand.NewSource(5))
+	for found := 0; found < 10; {
+		start, shrink := gen.Generate(r, Size{})
+		big := false
+		for i, v := range start {
+			big = big || v >= 100 && slices.Contains(start[i+1:], v)
+		}
+		if !big {
+			continue
+		}
+		found++
+
+		// a property failing while a value >= 10 repeats: the pair keeps
+		// shrinking, one accepted value after the other, down near 10 (the
+		// IntRange shrinker may stop a little above it)
+		steps := 0
+		min := minimize(start, shrink, func(next []int) bool {
+			for i, v := range next {
+				if v >= 10 && slices.Contains(next[i+1:], v) {
+					steps++
+					return true
+				}
+			}
+			return false
+		})
+		if len(min) != 2 || min[0] != min[1] || min[0] > 20 {
+			t.Errorf("%v shrank to %v, expected a pair [x x] with 10 <= x <= 20", start, min)
+		}
+		if steps < 3 {
+			t.Errorf("%v shrank in %d accepted steps, expected several", start, steps)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+// File: gen/duration.go
+package gen
+
+import (
+	"math/rand"
+	"time"
+)
+
+// maxHumanDuration bounds the values produced by HumanDuration.
+const maxHumanDuration = 24 * time.Hour
+
+// humanDurations are round values commonly used for timeouts and intervals,
+// in ascending order.
+var humanDurations = []time.Duration{
+	0,
+	time.Millisecond, 10 * time.Millisecond, 100 * time.Millisecond, 250 * time.Millisecond, 500 * time.Millisecond,
+	time.Second, 2 * time.Second, 5 * time.Second, 10 * time.Second, 15 * time.Second, 30 * time.Second,
+	time.Minute, 2 * time.Minute, 5 * time.Minute, 10 * time.Minute, 15 * time.Minute, 30 * time.Minute,
+	time.Hour, 2 * time.Hour, 6 * time.Hour, 12 * time.Hour, 24 * time.Hour,
+}
+
+// humanUnits are the units used for round multiples, largest first.
+var humanUnits = []time.Duration{time.Hour, time.Minute, time.Second, time.Millisecond}
+
+// HumanDuration generates time.Duration values in [0, 24h] biased toward
+// human-meaningful ones, for timeouts, retry and backoff settings:
+// - ~40% common values (30s, 5m, 1h, ...)
+// - ~30% round multiples of a unit (e.g. 7s, 45m, 3h)
+// - ~30% arbitrary nanosecond values
+// Shrink: toward 0 and toward round values: 0, truncation to a whole
+// hour/minute/second/millisecond, smaller common values, halving, then
+// stepping down by the largest unit that divides the value.
+func HumanDuration() Generator[time.Duration] {
+	return From(func(r *rand.Rand, _ Size) (time.Duration, Shrinker[time.Duration]) {
+		if r == nil {
+			r = rand.New(rand.NewSource(rand.Int63())) // #nosec G404 -- Using math/rand for deterministic property-based testing
+		}
+		var d time.Duration
+		switch p := r.Intn(10); {
+		case p < 4:
+			d = humanDurations[r.Intn(len(humanDurations))]
+		case p < 7:
+			unit := humanUnits[r.Intn(len(humanUnits))]
+			k := 60
+			if unit == time.Hour {
+				k = 24
+			}
+			d = time.Duration(1+r.Intn(k)) * unit
+		default:
+			d = time.Duration(r.Int63n(int64(maxHumanDuration) + 1))
+		}
+		return d, durationShrinker(d)
+	})
+}
+
+// durationShrinker creates a shrinker moving d toward 0 through round values.
+func durationShrinker(start time.Duration) Shrinker[time.Duration] {
+	self := func(d time.Duration) time.Duration { return d }
+	return ShrinkNeighbors(start, self, self, func(base time.Duration, add func(time.Duration)) {
+		// only smaller durations
+		push := func(d time.Duration) {
+			if d >= 0 && d < base {
+				add(d)
+			}
+		}
+		if base == 0 {
+			return
+		}
+		// (1) zero
+		push(0)
+		// (2) round down to a whole unit (coarsest first)
+		for _, u := range humanUnits {
+			push(base.Truncate(u))
+		}
+		// (3) smaller common values
+		for _, d := range humanDurations {
+			push(d)
+		}
+		// (4) halve
+		push(base / 2)
+		// (5) step down by the largest unit dividing base (or 1ns)
+		step := time.Duration(1)
+		for _, u := range humanUnits {
+			if base%u == 0 {
+				step = u
+				break
+			}
+		}
+		push(base - step)
+	})
+}
@@ -0,0 +1,63 @@
+package gen
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestHumanDuration(t *testing.T) {
+	g := HumanDuration()
+	r := rand.New(rand.NewSource(123))
+
+	round := 0
+	for i := 0; i < 1000; i++ {
+		d, shrink := g.Generate(r, Size{})
+
+		if d < 0 || d > 24*time.Hour {
+			t.Fatalf("HumanDuration().Generate() = %v, expected in [0, 24h]", d)
+		}
+		if d%time.Millisecond == 0 {
+			round++
+		}
+		if shrink == nil {
+			t.Fatal("HumanDuration().Generate() returned nil shrinker")
+		}
+	}
+	// ~70% are common values or round multiples
+	if round < 600 {
+		t.Errorf("HumanDuration() produced %d/1000 whole-millisecond values, expected a bias toward round values", round)
+	}
+}
+
+func TestHumanDuration_ShrinksTowardRoundValues(t *testing.T) {
+	// the property fails for durations longer than 3s
+	fails := func(d time.Duration) bool { return d > 3*time.Second }
+
+	start := 17*time.Minute + 123456789
+	s := durationShrinker(start)
+	min, accept := start, false
+	for i := 0; i < 1000; i++ {
+		next, ok := s(accept)
+		if !ok {
+			break
+		}
+		if next >= min && accept {
+			t.Fatalf("shrink candidate %v is not smaller than %v", next, min)
+		}
+		accept = fails(next)
+		if accept {
+			min = next
+		}
+	}
+	if min != 4*time.Second {
+		t.Errorf("HumanDuration shrunk %v to %v, expected 4s", start, min)
+	}
+}
+
+func TestHumanDuration_ShrinksToZero(t *testing.T) {
+	s := durationShrinker(90 * time.Minute)
+	if d, ok := s(false); !ok || d != 0 {
+		t.Errorf("first shrink candidate = (%v, %v), expected (0, true)", d, ok)
+	}
+}
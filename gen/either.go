@@ -0,0 +1,111 @@
+// File: gen/either.go
+package gen
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// Either is a tagged union holding either a Left value of type L or a Right
+// value of type R, e.g. result-or-error or any two-variant sum type.
+type Either[L, R any] struct {
+	left   L
+	right  R
+	isLeft bool
+}
+
+// NewLeft returns an Either holding the Left value v.
+func NewLeft[L, R any](v L) Either[L, R] {
+	return Either[L, R]{left: v, isLeft: true}
+}
+
+// NewRight returns an Either holding the Right value v.
+func NewRight[L, R any](v R) Either[L, R] {
+	return Either[L, R]{right: v}
+}
+
+// IsLeft reports whether e holds a Left value.
+func (e Either[L, R]) IsLeft() bool { return e.isLeft }
+
+// Left returns the Left value and whether e holds one.
+func (e Either[L, R]) Left() (L, bool) { return e.left, e.isLeft }
+
+// Right returns the Right value and whether e holds one.
+func (e Either[L, R]) Right() (R, bool) { return e.right, !e.isLeft }
+
+// GoString renders e as Left(v) or Right(v) in counterexample reports.
+func (e Either[L, R]) GoString() string {
+	if e.isLeft {
+		return fmt.Sprintf("Left(%#v)", e.left)
+	}
+	return fmt.Sprintf("Right(%#v)", e.right)
+}
+
+// EitherOf generates Either values: Left from gl with probability leftProb
+// (clamped to [0,1]), otherwise Right from gr.
+// Shrink: first proposes a fresh value of the other variant as a simpler
+// case (once, so shrinking never flips back and forth); then shrinks within
+// the chosen side.
+func EitherOf[L, R any](gl Generator[L], gr Generator[R], leftProb float64) Generator[Either[L, R]] {
+	if leftProb < 0 {
+		leftProb = 0
+	}
+	if leftProb > 1 {
+		leftProb = 1
+	}
+	return From(func(r *rand.Rand, sz Size) (Either[L, R], Shrinker[Either[L, R]]) {
+		if r == nil {
+			r = rand.New(rand.NewSource(rand.Int63())) // #nosec G404 -- Using math/rand for deterministic property-based testing
+		}
+		isLeft := r.Float64() < leftProb
+		cur, shrink := generateEither(gl, gr, isLeft, r, sz)
+
+		// the other variant is drawn from a private source so shrinking
+		// never touches r (which may be shared with other examples)
+		sr := rand.New(rand.NewSource(r.Int63())) // #nosec G404 -- Using math/rand for deterministic property-based testing
+
+		switched := false // the other variant was already proposed
+		var (
+			migrating bool
+			migShrink Shrinker[Either[L, R]]
+		)
+
+		return cur, func(accept bool) (Either[L, R], bool) {
+			// verdict on the switch: adopt the other side if it still fails
+			if migrating {
+				if accept {
+					shrink = migShrink
+				}
+				migrating = false
+				accept = false
+			}
+			// (1) the other variant
+			if !switched {
+				switched = true
+				nv, ns := generateEither(gl, gr, !cur.isLeft, sr, sz)
+				migrating, migShrink = true, ns
+				return nv, true
+			}
+			// (2) shrink within the current side
+			return shrink(accept)
+		}
+	})
+}
+
+// generateEither draws one side and lifts its shrinker to Either values.
+func generateEither[L, R any](gl Generator[L], gr Generator[R], isLeft bool, r *rand.Rand, sz Size) (Either[L, R], Shrinker[Either[L, R]]) {
+	if isLeft {
+		v, s := gl.Generate(r, sz)
+		s = orNoShrink(s, v)
+		return NewLeft[L, R](v), func(accept bool) (Either[L, R], bool) {
+			nv, ok := s(accept)
+			return NewLeft[L, R](nv), ok
+		}
+	}
+	v, s := gr.Generate(r, sz)
+	s = orNoShrink(s, v)
+	return NewRight[L](v), func(accept bool) (Either[L, R], bool) {
+		nv, ok := s(accept)
+		return NewRight[L](nv), ok
+	}
+}
@@ -0,0 +1,101 @@
+package gen
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func TestEitherOf(t *testing.T) {
+	g := EitherOf(IntRange(0, 100), StringAlpha(Size{Max: 5}), 0.5)
+	r := rand.New(rand.NewSource(123))
+
+	lefts := 0
+	for i := 0; i < 200; i++ {
+		e, shrink := g.Generate(r, Size{})
+
+		if l, ok := e.Left(); ok {
+			lefts++
+			if !e.IsLeft() || l < 0 || l > 100 {
+				t.Fatalf("EitherOf().Generate() = %#v, expected Left in [0, 100]", e)
+			}
+		} else if _, ok := e.Right(); !ok || e.IsLeft() {
+			t.Fatalf("EitherOf().Generate() = %#v holds neither side", e)
+		}
+		if shrink == nil {
+			t.Fatal("EitherOf().Generate() returned nil shrinker")
+		}
+	}
+	if lefts < 60 || lefts > 140 {
+		t.Errorf("EitherOf(leftProb=0.5) produced %d/200 Left values", lefts)
+	}
+}
+
+func TestEitherOf_Probabilities(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 50; i++ {
+		if e, _ := EitherOf(Const(1), Const("x"), 1).Generate(r, Size{}); !e.IsLeft() {
+			t.Fatalf("EitherOf(leftProb=1) = %#v, expected Left", e)
+		}
+		if e, _ := EitherOf(Const(1), Const("x"), -3).Generate(r, Size{}); e.IsLeft() {
+			t.Fatalf("EitherOf(leftProb<0) = %#v, expected Right", e)
+		}
+	}
+}
+
+func TestEitherOf_ShrinksOtherVariantFirst(t *testing.T) {
+	g := EitherOf(IntRange(0, 1000), Const("err"), 1)
+	e, shrink := g.Generate(rand.New(rand.NewSource(5)), Size{})
+	if !e.IsLeft() {
+		t.Fatalf("expected a Left value, got %#v", e)
+	}
+
+	next, ok := shrink(false)
+	if !ok || next.IsLeft() {
+		t.Fatalf("first shrink candidate = %#v, expected the Right variant", next)
+	}
+
+	// rejected: shrinking continues within Left toward 0, never switching again
+	min, accept := e, false
+	for i := 0; i < 1000; i++ {
+		next, ok := shrink(accept)
+		if !ok {
+			break
+		}
+		if !next.IsLeft() {
+			t.Fatalf("shrink candidate %#v switched variant again", next)
+		}
+		l, _ := next.Left()
+		cur, _ := min.Left()
+		accept = l < cur
+		if accept {
+			min = next
+		}
+	}
+	if l, _ := min.Left(); l != 0 {
+		t.Errorf("Left shrunk to %d, expected 0", l)
+	}
+}
+
+func TestEitherOf_AdoptsOtherVariant(t *testing.T) {
+	g := EitherOf(Const(7), IntRange(0, 1000), 0)
+	_, shrink := g.Generate(rand.New(rand.NewSource(5)), Size{})
+
+	next, ok := shrink(false)
+	if !ok || !next.IsLeft() {
+		t.Fatalf("first shrink candidate = %#v, expected Left(7)", next)
+	}
+	// accepted Left(7) is constant: no more candidates
+	if next, ok := shrink(true); ok {
+		t.Errorf("after adopting Left(7), shrink proposed %#v", next)
+	}
+}
+
+func TestEither_GoString(t *testing.T) {
+	if got := fmt.Sprintf("%#v", NewLeft[int, string](3)); got != "Left(3)" {
+		t.Errorf("GoString() = %q, expected Left(3)", got)
+	}
+	if got := fmt.Sprintf("%#v", NewRight[int]("x")); got != `Right("x")` {
+		t.Errorf("GoString() = %q, expected Right(\"x\")", got)
+	}
+}
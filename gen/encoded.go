@@ -0,0 +1,53 @@
+// File: gen/encoded.go
+package gen
+
+import "math/rand"
+
+// EncodedPair holds a generated value together with its string encoding and
+// the result of decoding that encoding back, ready for round-trip assertions.
+type EncodedPair[T any] struct {
+	// Value is the generated value.
+	Value T
+	// Encoded is encode(Value).
+	Encoded string
+	// Decoded is decode(Encoded); zero when decode is nil or failed.
+	Decoded T
+	// DecodeErr is the error returned by decode(Encoded), if any.
+	DecodeErr error
+}
+
+// Encoded generates values from g together with their encoding (and its
+// decoding), so round-trip properties don't need to duplicate the generator.
+// decode may be nil when only the encoding is needed.
+// Shrink: shrinks the underlying value and re-encodes (and re-decodes) it.
+//
+// Example:
+//
+//	g := gen.Encoded(gen.Int(gen.Size{}), strconv.Itoa, strconv.Atoi)
+//	prop.ForAll(t, cfg, g)(func(t *testing.T, p gen.EncodedPair[int]) {
+//		if p.DecodeErr != nil || p.Decoded != p.Value {
+//			t.Fatalf("round-trip of %d via %q failed: %v", p.Value, p.Encoded, p.DecodeErr)
+//		}
+//	})
+func Encoded[T any](g Generator[T], encode func(T) string, decode func(string) (T, error)) Generator[EncodedPair[T]] {
+	pair := func(v T) EncodedPair[T] {
+		p := EncodedPair[T]{Value: v, Encoded: encode(v)}
+		if decode != nil {
+			p.Decoded, p.DecodeErr = decode(p.Encoded)
+		}
+		return p
+	}
+	return From(func(r *rand.Rand, sz Size) (EncodedPair[T], Shrinker[EncodedPair[T]]) {
+		v, s := g.Generate(r, sz)
+		return pair(v), func(accept bool) (EncodedPair[T], bool) {
+			if s == nil {
+				return EncodedPair[T]{}, false
+			}
+			nv, ok := s(accept)
+			if !ok {
+				return EncodedPair[T]{}, false
+			}
+			return pair(nv), true
+		}
+	})
+}
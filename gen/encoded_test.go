@@ -0,0 +1,48 @@
+package gen
+
+import (
+	"math/rand"
+	"strconv"
+	"testing"
+)
+
+func TestEncoded(t *testing.T) {
+	g := Encoded(IntRange(-1000, 1000), strconv.Itoa, strconv.Atoi)
+	r := rand.New(rand.NewSource(123))
+
+	value, shrink := g.Generate(r, Size{})
+
+	if value.Encoded != strconv.Itoa(value.Value) {
+		t.Errorf("Encoded().Generate() encoded = %q, expected %q", value.Encoded, strconv.Itoa(value.Value))
+	}
+	if value.DecodeErr != nil || value.Decoded != value.Value {
+		t.Errorf("Encoded().Generate() decoded = (%d, %v), expected (%d, nil)", value.Decoded, value.DecodeErr, value.Value)
+	}
+	if shrink == nil {
+		t.Fatal("Encoded().Generate() returned nil shrinker")
+	}
+
+	// shrink candidates are re-encoded
+	for i := 0; i < 10; i++ {
+		next, ok := shrink(false)
+		if !ok {
+			break
+		}
+		if next.Encoded != strconv.Itoa(next.Value) {
+			t.Errorf("shrink candidate encoded = %q, expected %q", next.Encoded, strconv.Itoa(next.Value))
+		}
+	}
+}
+
+func TestEncoded_NilDecode(t *testing.T) {
+	g := Encoded(Const(42), strconv.Itoa, nil)
+	r := rand.New(rand.NewSource(123))
+
+	value, shrink := g.Generate(r, Size{})
+	if value.Encoded != "42" || value.Decoded != 0 || value.DecodeErr != nil {
+		t.Errorf("Encoded().Generate() = %+v, expected encoded '42' with no decoding", value)
+	}
+	if _, ok := shrink(true); ok {
+		t.Error("Encoded(Const()) shrinker should not propose candidates")
+	}
+}
@@ -0,0 +1,82 @@
+// File: gen/encoding.go
+package gen
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+)
+
+// Bytes generates random []byte values.
+// - size.Min/Max control the length (default Min=0, Max=16), like SliceOf.
+// Shrink: removes bytes (blocks, then single) and moves bytes toward 0.
+func Bytes(size Size) Generator[[]byte] {
+	return SliceOf(Map(IntRange(0, 255), func(b int) byte { return byte(b) }), size)
+}
+
+// Base64 generates standard (padded) base64 encodings of random binary data.
+// size controls the length of the underlying data, not of the encoding.
+// Shrink: shrinks the underlying bytes toward empty and re-encodes.
+func Base64(size Size) Generator[string] {
+	return Map(Bytes(size), base64.StdEncoding.EncodeToString)
+}
+
+// HexString generates lowercase hexadecimal encodings of random binary data.
+// size controls the length of the underlying data, not of the encoding.
+// Shrink: shrinks the underlying bytes toward empty and re-encodes.
+func HexString(size Size) Generator[string] {
+	return Map(Bytes(size), hex.EncodeToString)
+}
+
+// Base64Invalid generates strings that standard base64 decoding must reject:
+// bad padding (missing or extra '='), an illegal character, or a truncated
+// encoding. Every value, including shrink candidates, is invalid.
+func Base64Invalid(size Size) Generator[string] {
+	corruptions := []func(string) string{
+		// bad padding
+		func(s string) string {
+			if len(s) > 0 && s[len(s)-1] == '=' {
+				for len(s) > 0 && s[len(s)-1] == '=' {
+					s = s[:len(s)-1]
+				}
+				return s
+			}
+			return s + "="
+		},
+		// illegal character
+		func(s string) string { return s[:len(s)/2] + "!" + s[len(s)/2:] },
+		// truncated
+		func(s string) string {
+			if s == "" {
+				return "A"
+			}
+			return s[:len(s)-1]
+		},
+	}
+	return corrupted(Base64(size), corruptions)
+}
+
+// HexStringInvalid generates strings that hexadecimal decoding must reject:
+// odd length or an illegal character. Every value, including shrink candidates,
+// is invalid.
+func HexStringInvalid(size Size) Generator[string] {
+	corruptions := []func(string) string{
+		// odd length
+		func(s string) string {
+			if s == "" {
+				return "0"
+			}
+			return s[:len(s)-1]
+		},
+		// illegal character
+		func(s string) string { return s[:len(s)/2] + "g" + s[len(s)/2:] },
+	}
+	return corrupted(HexString(size), corruptions)
+}
+
+// corrupted applies one of the corruptions (chosen at random) to a valid
+// encoding. Shrink: moves toward the first corruption and shrinks the data.
+func corrupted(valid Generator[string], corruptions []func(string) string) Generator[string] {
+	return Map(PairOf(IntRange(0, len(corruptions)-1), valid), func(p Pair[int, string]) string {
+		return corruptions[p.First](p.Second)
+	})
+}
@@ -0,0 +1,112 @@
+package gen
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"math/rand"
+	"testing"
+)
+
+func TestBytes(t *testing.T) {
+	gen := Bytes(Size{Min: 2, Max: 8})
+	r := rand.New(rand.NewSource(123))
+
+	value, shrink := gen.Generate(r, Size{})
+
+	if len(value) < 2 || len(value) > 8 {
+		t.Errorf("Bytes().Generate() length = %d, expected in range [2, 8]", len(value))
+	}
+	if shrink == nil {
+		t.Error("Bytes().Generate() returned nil shrinker")
+	}
+}
+
+func TestBase64(t *testing.T) {
+	gen := Base64(Size{Min: 0, Max: 20})
+	r := rand.New(rand.NewSource(123))
+
+	for i := 0; i < 50; i++ {
+		value, shrink := gen.Generate(r, Size{})
+		if _, err := base64.StdEncoding.DecodeString(value); err != nil {
+			t.Errorf("Base64().Generate() = %q, not decodable: %v", value, err)
+		}
+		if shrink == nil {
+			t.Error("Base64().Generate() returned nil shrinker")
+		}
+	}
+}
+
+func TestBase64_ShrinksTowardEmpty(t *testing.T) {
+	gen := Base64(Size{Min: 5, Max: 20})
+	r := rand.New(rand.NewSource(123))
+	_, shrink := gen.Generate(r, Size{})
+
+	min := "unset"
+	for i := 0; i < 1000; i++ {
+		next, ok := shrink(true)
+		if !ok {
+			break
+		}
+		if _, err := base64.StdEncoding.DecodeString(next); err != nil {
+			t.Fatalf("shrink candidate %q not decodable: %v", next, err)
+		}
+		min = next
+	}
+	if min != "" {
+		t.Errorf("Base64() shrunk to %q, expected empty string", min)
+	}
+}
+
+func TestHexString(t *testing.T) {
+	gen := HexString(Size{Min: 0, Max: 20})
+	r := rand.New(rand.NewSource(123))
+
+	for i := 0; i < 50; i++ {
+		value, _ := gen.Generate(r, Size{})
+		if _, err := hex.DecodeString(value); err != nil {
+			t.Errorf("HexString().Generate() = %q, not decodable: %v", value, err)
+		}
+	}
+}
+
+func TestBase64Invalid(t *testing.T) {
+	gen := Base64Invalid(Size{Min: 0, Max: 10})
+	r := rand.New(rand.NewSource(123))
+
+	for i := 0; i < 100; i++ {
+		value, shrink := gen.Generate(r, Size{})
+		if _, err := base64.StdEncoding.DecodeString(value); err == nil {
+			t.Errorf("Base64Invalid().Generate() = %q, expected decoding error", value)
+		}
+		for j := 0; j < 20; j++ {
+			next, ok := shrink(true)
+			if !ok {
+				break
+			}
+			if _, err := base64.StdEncoding.DecodeString(next); err == nil {
+				t.Errorf("Base64Invalid() shrink candidate %q decodes without error", next)
+			}
+		}
+	}
+}
+
+func TestHexStringInvalid(t *testing.T) {
+	gen := HexStringInvalid(Size{Min: 0, Max: 10})
+	r := rand.New(rand.NewSource(123))
+
+	for i := 0; i < 100; i++ {
+		value, shrink := gen.Generate(r, Size{})
+		if _, err := hex.DecodeString(value); err == nil {
+			t.Errorf("HexStringInvalid().Generate() = %q, expected decoding error", value)
+		}
+		for j := 0; j < 20; j++ {
+			next, ok := shrink(true)
+			if !ok {
+				break
+			}
+			if _, err := hex.DecodeString(next); err == nil {
+				t.Errorf("HexStringInvalid() shrink candidate %q decodes without error", next)
+			}
+		}
+	}
+}
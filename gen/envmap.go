@@ -0,0 +1,194 @@
+// File: gen/envmap.go
+package gen
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+)
+
+const (
+	// envKeyStart are the characters an environment variable name starts with.
+	envKeyStart = "ABCDEFGHIJKLMNOPQRSTUVWXYZ_"
+	// envKeyRest are the characters of the rest of the name.
+	envKeyRest = envKeyStart + "0123456789"
+	// envValueChars are the characters of values, including the separators
+	// loaders trip on ('=', spaces, quotes, '#', '$').
+	envValueChars = "abcxyzABC019 =:/._-,\"'#$"
+)
+
+// envEntry is one variable of an EnvMap.
+type envEntry struct {
+	key, value string
+}
+
+// EnvMap generates environment-style maps for testing config loaders: keys
+// are valid variable names (uppercase letters, digits and underscores, not
+// starting with a digit, up to 12 characters) and values are arbitrary text
+// of up to 16 characters, including '=', spaces and quotes, or empty.
+// - size.Min/Max control the number of variables (default Min=0, Max=8).
+// Shrink: removes variables (all, halves, then single R->L), then shortens
+// keys (to their first character, or dropping the last) and values (empty,
+// half, or dropping the first or last character), never merging two keys.
+func EnvMap(size Size) Generator[map[string]string] {
+	return From(func(r *rand.Rand, sz Size) (map[string]string, Shrinker[map[string]string]) {
+		if r == nil {
+			r = rand.New(rand.NewSource(rand.Int63())) // #nosec G404 -- Using math/rand for deterministic property-based testing
+		}
+		if size.Min == 0 && size.Max == 0 {
+			size.Min, size.Max = 0, 8
+		}
+		if sz.Min != 0 || sz.Max != 0 {
+			size = sz
+		}
+		if size.Max < size.Min {
+			size.Max = size.Min
+		}
+
+		n := size.Min
+		if size.Max > size.Min {
+			n += r.Intn(size.Max - size.Min + 1)
+		}
+		sz.Budget.Spend(n)
+		seen := map[string]bool{}
+		cur := make([]envEntry, 0, n)
+		for len(cur) < n {
+			k := generateEnvKey(r)
+			if seen[k] {
+				continue
+			}
+			seen[k] = true
+			cur = append(cur, envEntry{key: k, value: generateEnvValue(r)})
+		}
+		sort.Slice(cur, func(i, j int) bool { return cur[i].key < cur[j].key })
+
+		queue := make([][]envEntry, 0, 32)
+		tried := map[string]struct{}{fmt.Sprint(cur): {}}
+		var queued map[string]struct{}
+		var last []envEntry
+
+		push := func(es []envEntry) {
+			k := fmt.Sprint(es)
+			if _, ok := tried[k]; ok {
+				return
+			}
+			if _, ok := queued[k]; ok {
+				return
+			}
+			queued[k] = struct{}{}
+			queue = append(queue, es)
+		}
+
+		rem := func(base []envEntry, i, j int) []envEntry {
+			out := make([]envEntry, 0, len(base)-(j-i))
+			out = append(out, base[:i]...)
+			return append(out, base[j:]...)
+		}
+		withKey := func(base []envEntry, i int, k string) {
+			for _, e := range base {
+				if e.key == k {
+					return
+				}
+			}
+			out := append([]envEntry(nil), base...)
+			out[i].key = k
+			sort.Slice(out, func(a, b int) bool { return out[a].key < out[b].key })
+			push(out)
+		}
+		withValue := func(base []envEntry, i int, v string) {
+			out := append([]envEntry(nil), base...)
+			out[i].value = v
+			push(out)
+		}
+
+		grow := func(base []envEntry) {
+			queue = queue[:0]
+			queued = map[string]struct{}{}
+			L := len(base)
+			// (1) remove variables: all, halves, then single (R->L)
+			if L > 0 {
+				push(rem(base, 0, L))
+				push(rem(base, L/2, L))
+				push(rem(base, 0, L/2))
+				for i := L - 1; i >= 0; i-- {
+					push(rem(base, i, i+1))
+				}
+			}
+			// (2) shorter keys (R->L); the first character stays, so keys stay valid
+			for i := L - 1; i >= 0; i-- {
+				if k := base[i].key; len(k) > 1 {
+					withKey(base, i, k[:1])
+					withKey(base, i, k[:len(k)-1])
+				}
+			}
+			// (3) shorter values (R->L)
+			for i := L - 1; i >= 0; i-- {
+				if v := base[i].value; v != "" {
+					withValue(base, i, "")
+					withValue(base, i, v[:len(v)/2])
+					withValue(base, i, v[:len(v)-1])
+					withValue(base, i, v[1:])
+				}
+			}
+		}
+		grow(cur)
+
+		pop := func() ([]envEntry, bool) {
+			if len(queue) == 0 {
+				return nil, false
+			}
+			var v []envEntry
+			if GetShrinkStrategy() == ShrinkStrategyDFS {
+				v = queue[len(queue)-1]
+				queue = queue[:len(queue)-1]
+			} else {
+				v = queue[0]
+				queue = queue[1:]
+			}
+			tried[fmt.Sprint(v)] = struct{}{}
+			return v, true
+		}
+
+		return envMap(cur), func(accept bool) (map[string]string, bool) {
+			if accept && last != nil {
+				cur = last
+				grow(cur)
+			}
+			nxt, ok := pop()
+			if !ok {
+				return nil, false
+			}
+			last = nxt
+			return envMap(nxt), true
+		}
+	})
+}
+
+// generateEnvKey draws a valid variable name of 1 to 12 characters.
+func generateEnvKey(r *rand.Rand) string {
+	var b strings.Builder
+	b.WriteByte(envKeyStart[r.Intn(len(envKeyStart))])
+	for i, n := 0, r.Intn(12); i < n; i++ {
+		b.WriteByte(envKeyRest[r.Intn(len(envKeyRest))])
+	}
+	return b.String()
+}
+
+// generateEnvValue draws a value of 0 to 16 characters.
+func generateEnvValue(r *rand.Rand) string {
+	b := make([]byte, r.Intn(17))
+	for i := range b {
+		b[i] = envValueChars[r.Intn(len(envValueChars))]
+	}
+	return string(b)
+}
+
+// envMap returns the entries as a map.
+func envMap(es []envEntry) map[string]string {
+	m := make(map[string]string, len(es))
+	for _, e := range es {
+		m[e.key] = e.value
+	}
+	return m
+}
@@ -0,0 +1,95 @@
+package gen
+
+import (
+	"math/rand"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+var envKeyPattern = regexp.MustCompile(`^[A-Z_][A-Z0-9_]*$`)
+
+// checkEnvMap fails the test unless every key of m is a valid variable name.
+func checkEnvMap(t *testing.T, m map[string]string) {
+	t.Helper()
+	for k := range m {
+		if !envKeyPattern.MatchString(k) {
+			t.Fatalf("EnvMap() key %q is not a valid variable name", k)
+		}
+	}
+}
+
+func TestEnvMap(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	g := EnvMap(Size{Min: 2, Max: 6})
+	withEquals, withSpace, empty := false, false, false
+	for i := 0; i < 300; i++ {
+		m, shrink := g.Generate(r, Size{})
+		if len(m) < 2 || len(m) > 6 {
+			t.Fatalf("EnvMap() has %d variables, expected 2..6", len(m))
+		}
+		checkEnvMap(t, m)
+		for _, v := range m {
+			withEquals = withEquals || strings.Contains(v, "=")
+			withSpace = withSpace || strings.Contains(v, " ")
+			empty = empty || v == ""
+		}
+		if shrink == nil {
+			t.Fatal("EnvMap().Generate() returned nil shrinker")
+		}
+	}
+	if !withEquals || !withSpace || !empty {
+		t.Errorf("EnvMap() values: '=' %v, space %v, empty %v; expected all of them", withEquals, withSpace, empty)
+	}
+}
+
+func TestEnvMap_ShrinkTowardEmpty(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	m, shrink := EnvMap(Size{Min: 3, Max: 6}).Generate(r, Size{})
+
+	next, ok := shrink(false)
+	if !ok || len(next) != 0 {
+		t.Errorf("first shrink candidate of %v = %v, expected the empty map", m, next)
+	}
+}
+
+func TestEnvMap_ShrinkKeepsFailure(t *testing.T) {
+	// fails while some value holds '=': minimal is one single-character key
+	// with the value "="
+	fails := func(m map[string]string) bool {
+		for _, v := range m {
+			if strings.Contains(v, "=") {
+				return true
+			}
+		}
+		return false
+	}
+	r := rand.New(rand.NewSource(3))
+	g := EnvMap(Size{Min: 4, Max: 8})
+	var m map[string]string
+	var shrink Shrinker[map[string]string]
+	for !fails(m) {
+		m, shrink = g.Generate(r, Size{})
+	}
+
+	min, accept := m, false
+	for i := 0; i < 5000; i++ {
+		next, ok := shrink(accept)
+		if !ok {
+			break
+		}
+		checkEnvMap(t, next)
+		accept = fails(next)
+		if accept {
+			min = next
+		}
+	}
+	if len(min) != 1 {
+		t.Fatalf("shrinking %v ended at %v, expected a single variable", m, min)
+	}
+	for k, v := range min {
+		if len(k) != 1 || v != "=" {
+			t.Errorf("shrinking %v ended at %v, expected a one-character key set to \"=\"", m, min)
+		}
+	}
+}
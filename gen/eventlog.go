@@ -0,0 +1,209 @@
+package gen
+
+import (
+	"math/rand"
+	"sort"
+	"time"
+)
+
+// TimedEvent is an event of an EventLog with its timestamp.
+type TimedEvent[E any] struct {
+	At    time.Time
+	Event E
+}
+
+// eventEntry is a log entry with the shrinkers of its event and timestamp.
+// eventShrunk and atShrunk mark a candidate made by one of them, so the
+// shrinker learns the candidate was accepted when the log is rebased on it.
+type eventEntry[E any] struct {
+	TimedEvent[E]
+	shrinkEvent Shrinker[E]
+	shrinkAt    Shrinker[time.Time]
+	eventShrunk bool
+	atShrunk    bool
+}
+
+// EventLog generates time-ordered event logs: events from eventGen stamped
+// with times from timeGen, sorted so timestamps never decrease (equal
+// timestamps are kept, as they happen in real logs). Useful for
+// event-sourcing and aggregation code that assumes time ordering.
+// - size.Min/Max control the number of events (default Min=0, Max=16).
+// Shrink: removes events (blocks, then single ones, R->L), then closes the
+// gaps between consecutive timestamps (collapsing or halving them), then
+// shrinks the first timestamp and the events in place. Every candidate keeps
+// the timestamps non-decreasing.
+func EventLog[E any](eventGen Generator[E], timeGen Generator[time.Time], size Size) Generator[[]TimedEvent[E]] {
+	return From(func(r *rand.Rand, sz Size) ([]TimedEvent[E], Shrinker[[]TimedEvent[E]]) {
+		if r == nil {
+			r = rand.New(rand.NewSource(rand.Int63())) // #nosec G404 -- Using math/rand for deterministic property-based testing
+		}
+		if size.Min == 0 && size.Max == 0 {
+			size.Min, size.Max = 0, 16
+		}
+		if sz.Min != 0 || sz.Max != 0 {
+			size = sz
+		}
+		if size.Max < size.Min {
+			size.Max = size.Min
+		}
+
+		n := size.Min
+		if size.Max > size.Min {
+			n += r.Intn(size.Max - size.Min + 1)
+		}
+		sz.Budget.Spend(n)
+		cur := make([]eventEntry[E], n)
+		for i := range cur {
+			ev, se := eventGen.Generate(r, sz.elem())
+			at, st := timeGen.Generate(r, sz.elem())
+			cur[i] = eventEntry[E]{TimedEvent: TimedEvent[E]{At: at, Event: ev}, shrinkEvent: se, shrinkAt: st}
+		}
+		sort.SliceStable(cur, func(i, j int) bool { return cur[i].At.Before(cur[j].At) })
+
+		events := func(es []eventEntry[E]) []TimedEvent[E] {
+			out := make([]TimedEvent[E], len(es))
+			for i, e := range es {
+				out[i] = e.TimedEvent
+			}
+			return out
+		}
+
+		queue := make([][]eventEntry[E], 0, 64)
+		tried := map[string]struct{}{sig(events(cur)): {}}
+		var queued map[string]struct{}
+		var last []eventEntry[E]
+
+		push := func(es []eventEntry[E]) {
+			k := sig(events(es))
+			if _, ok := tried[k]; ok {
+				return
+			}
+			if _, ok := queued[k]; ok {
+				return
+			}
+			queued[k] = struct{}{}
+			queue = append(queue, es)
+		}
+
+		// fresh copies base, clearing the marks of the candidate it came from
+		fresh := func(base []eventEntry[E]) []eventEntry[E] {
+			out := append([]eventEntry[E](nil), base...)
+			for i := range out {
+				out[i].eventShrunk, out[i].atShrunk = false, false
+			}
+			return out
+		}
+		rem := func(base []eventEntry[E], i, j int) []eventEntry[E] {
+			out := fresh(base[:i])
+			return append(out, fresh(base[j:])...)
+		}
+		with := func(base []eventEntry[E], i int, f func(*eventEntry[E])) []eventEntry[E] {
+			out := fresh(base)
+			f(&out[i])
+			return out
+		}
+
+		// proposeAt queues the next value of the first timestamp's shrinker,
+		// skipping values past the second timestamp; accepted tells the
+		// shrinker whether its previous value was kept
+		proposeAt := func(base []eventEntry[E], accepted bool) {
+			if len(base) == 0 || base[0].shrinkAt == nil {
+				return
+			}
+			for {
+				at, ok := base[0].shrinkAt(accepted)
+				if !ok {
+					return
+				}
+				if len(base) == 1 || !at.After(base[1].At) {
+					push(with(base, 0, func(e *eventEntry[E]) { e.At, e.atShrunk = at, true }))
+					return
+				}
+				accepted = false
+			}
+		}
+		// proposeEvent queues the next value of event i's shrinker
+		proposeEvent := func(base []eventEntry[E], i int, accepted bool) {
+			if base[i].shrinkEvent == nil {
+				return
+			}
+			if ev, ok := base[i].shrinkEvent(accepted); ok {
+				push(with(base, i, func(e *eventEntry[E]) { e.Event, e.eventShrunk = ev, true }))
+			}
+		}
+
+		grow := func(base []eventEntry[E]) {
+			queue = queue[:0]
+			queued = map[string]struct{}{}
+			L := len(base)
+			// (1) remove blocks (half, quarter, ...), then single events (R->L)
+			for chunk := L / 2; chunk >= 1; chunk /= 2 {
+				for i := 0; i+chunk <= L; i += chunk {
+					push(rem(base, i, i+chunk))
+				}
+			}
+			for i := L - 1; i >= 0; i-- {
+				push(rem(base, i, i+1))
+			}
+			// (2) close the gap to the previous timestamp (R->L)
+			for i := L - 1; i >= 1; i-- {
+				prev := base[i-1].At
+				gap := base[i].At.Sub(prev)
+				if gap <= 0 {
+					continue
+				}
+				push(with(base, i, func(e *eventEntry[E]) { e.At = prev }))
+				if gap > 1 {
+					push(with(base, i, func(e *eventEntry[E]) { e.At = prev.Add(gap / 2) }))
+				}
+			}
+			// (3) shrink the first timestamp, (4) then the events (R->L)
+			if L > 0 {
+				proposeAt(base, base[0].atShrunk)
+			}
+			for i := L - 1; i >= 0; i-- {
+				proposeEvent(base, i, base[i].eventShrunk)
+			}
+		}
+		grow(cur)
+
+		pop := func() ([]eventEntry[E], bool) {
+			if len(queue) == 0 {
+				return nil, false
+			}
+			var v []eventEntry[E]
+			if GetShrinkStrategy() == ShrinkStrategyDFS {
+				v = queue[len(queue)-1]
+				queue = queue[:len(queue)-1]
+			} else {
+				v = queue[0]
+				queue = queue[1:]
+			}
+			tried[sig(events(v))] = struct{}{}
+			return v, true
+		}
+
+		return events(cur), func(accept bool) ([]TimedEvent[E], bool) {
+			switch {
+			case accept && last != nil:
+				cur = last
+				grow(cur)
+			case last != nil:
+				// rejected: ask the shrinker that made it for its next value
+				for i, e := range last {
+					if e.atShrunk {
+						proposeAt(cur, false)
+					} else if e.eventShrunk {
+						proposeEvent(cur, i, false)
+					}
+				}
+			}
+			nxt, ok := pop()
+			if !ok {
+				return nil, false
+			}
+			last = nxt
+			return events(nxt), true
+		}
+	})
+}
@@ -0,0 +1,100 @@
+package gen
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+)
+
+// unixSeconds generates times in the first day after the Unix epoch.
+func unixSeconds() Generator[time.Time] {
+	return Map(IntRange(0, 86400), func(s int) time.Time { return time.Unix(int64(s), 0).UTC() })
+}
+
+func ordered[E any](log []TimedEvent[E]) bool {
+	for i := 1; i < len(log); i++ {
+		if log[i].At.Before(log[i-1].At) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestEventLog_Ordered(t *testing.T) {
+	g := EventLog(StringAlpha(Size{Min: 1, Max: 4}), unixSeconds(), Size{Min: 0, Max: 20})
+	r := rand.New(rand.NewSource(1))
+	lengths := map[int]bool{}
+	for i := 0; i < 200; i++ {
+		log, _ := g.Generate(r, Size{})
+		if len(log) > 20 {
+			t.Fatalf("EventLog() produced %d events, expected at most 20", len(log))
+		}
+		if !ordered(log) {
+			t.Fatalf("EventLog() = %v is not time-ordered", log)
+		}
+		lengths[len(log)] = true
+	}
+	if len(lengths) < 10 {
+		t.Errorf("EventLog() produced only %d distinct lengths", len(lengths))
+	}
+}
+
+func TestEventLog_ShrinkKeepsOrder(t *testing.T) {
+	g := EventLog(IntRange(0, 100), unixSeconds(), Size{Min: 3, Max: 12})
+	r := rand.New(rand.NewSource(2))
+	for i := 0; i < 20; i++ {
+		log, shrink := g.Generate(r, Size{})
+		// fails while some event is >= 50 and happens after the first second
+		fails := func(l []TimedEvent[int]) bool {
+			for _, e := range l {
+				if e.Event >= 50 && e.At.Unix() >= 1 {
+					return true
+				}
+			}
+			return false
+		}
+		if !fails(log) {
+			continue
+		}
+		min, accept := log, false
+		for j := 0; j < 3000; j++ {
+			next, ok := shrink(accept)
+			if !ok {
+				break
+			}
+			if !ordered(next) {
+				t.Fatalf("shrink candidate %v is not time-ordered", next)
+			}
+			accept = fails(next)
+			if accept {
+				min = next
+			}
+		}
+		if len(min) != 1 || min[0].At.Unix() > 60 {
+			t.Errorf("EventLog() %v shrunk to %v, expected one event in the first minute", log, min)
+		}
+	}
+}
+
+func TestEventLog_ShrinkClosesGaps(t *testing.T) {
+	g := EventLog(Const("tick"), unixSeconds(), Size{Min: 4, Max: 4})
+	log, shrink := g.Generate(rand.New(rand.NewSource(3)), Size{})
+	// fails while there are 4 events: the gaps close, leaving equal timestamps
+	min, accept := log, false
+	for j := 0; j < 3000; j++ {
+		next, ok := shrink(accept)
+		if !ok {
+			break
+		}
+		accept = len(next) == 4
+		if accept {
+			min = next
+		}
+	}
+	for i := 1; i < len(min); i++ {
+		if !min[i].At.Equal(min[0].At) {
+			t.Errorf("EventLog() %v shrunk to %v, expected equal timestamps", log, min)
+			break
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package gen
+
+// FilterObserver is called with each value a Filter rejects while generating
+// (not while shrinking): label is the filter's Label ("" when unlabeled) and
+// exhausted reports that the value was the last of its maxTries, after which
+// the filter gives up and generates the zero value. Pass it in Size.Filters,
+// wrapped by NewFilterLog, to find out which filter of a generator is too
+// strict and what it rejects; the runner does so with
+// Config.DiscardDiagnostics.
+//
+// Example usage:
+//
+//	log := gen.NewFilterLog(func(label string, v any, exhausted bool) {
+//	    log.Printf("filter %q rejected %#v", label, v)
+//	})
+//	v, _ := g.Generate(r, gen.Size{Filters: log})
+type FilterObserver func(label string, v any, exhausted bool)
+
+// FilterLog carries a FilterObserver in Size.Filters. It is a pointer, like
+// Budget, so that Size stays comparable. A nil *FilterLog observes nothing.
+type FilterLog struct {
+	observe FilterObserver
+}
+
+// NewFilterLog returns a log reporting to observe; a nil observe returns nil.
+func NewFilterLog(observe FilterObserver) *FilterLog {
+	if observe == nil {
+		return nil
+	}
+	return &FilterLog{observe: observe}
+}
+
+// reject reports a value rejected by the filter labeled label.
+func (l *FilterLog) reject(label string, v any, exhausted bool) {
+	if l != nil {
+		l.observe(label, v, exhausted)
+	}
+}
@@ -0,0 +1,68 @@
+package gen
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestSizeFilters(t *testing.T) {
+	type rejection struct {
+		label     string
+		v         any
+		exhausted bool
+	}
+	var got []rejection
+	sz := Size{Filters: NewFilterLog(func(label string, v any, exhausted bool) {
+		got = append(got, rejection{label, v, exhausted})
+	})}
+	if sz == (Size{}) { // Size stays comparable
+		t.Error("Size with Filters compared equal to Size{}")
+	}
+
+	odd := Filter(IntRange(0, 9), func(x int) bool { return x%2 == 1 }, 100)
+	never := Label(Filter(Const(4), func(int) bool { return false }, 3), "never")
+	r := rand.New(rand.NewSource(1))
+	v, shrink := odd.Generate(r, sz)
+	for _, rej := range got {
+		if rej.label != "" || rej.v.(int)%2 != 0 || rej.exhausted {
+			t.Errorf("unlabeled filter reported %+v, expected an even value, not exhausted", rej)
+		}
+	}
+
+	// shrinking does not report
+	n := len(got)
+	minimize(v, shrink, func(int) bool { return true })
+	if len(got) != n {
+		t.Errorf("shrinking reported %d rejections, expected none", len(got)-n)
+	}
+
+	got = nil
+	never.Generate(r, sz)
+	want := []rejection{{"never", 4, false}, {"never", 4, false}, {"never", 4, true}}
+	if len(got) != len(want) {
+		t.Fatalf("labeled filter reported %+v, expected %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("rejection %d = %+v, expected %+v", i, got[i], want[i])
+		}
+	}
+	if LabelOf(never) != "never" {
+		t.Errorf("LabelOf(labeled filter) = %q, expected \"never\"", LabelOf(never))
+	}
+
+	// collections pass the observer on to their elements
+	got = nil
+	SliceOf(never, Size{Min: 2, Max: 2}).Generate(r, sz)
+	if len(got) != 6 {
+		t.Errorf("slice of 2 labeled filters reported %d rejections, expected 6", len(got))
+	}
+
+	// without an observer in the Size, nothing is reported
+	got = nil
+	never.Generate(r, Size{})
+	never.Generate(r, Size{Filters: NewFilterLog(nil)})
+	if len(got) != 0 {
+		t.Errorf("Size without Filters reported %+v", got)
+	}
+}
@@ -0,0 +1,99 @@
+package gen
+
+import (
+	"math/rand"
+	"sort"
+)
+
+// Flags generates bitmasks made of a random subset of the given flag
+// constants ORed together, so only defined bits are ever set (unlike an
+// arbitrary uint). Each flag is included with probability 1/2; with no flags
+// the value is always 0.
+// Shrink: clears whole flags, largest first (trying 0 first), converging
+// toward 0.
+//
+// Example:
+//
+//	const (
+//		Read Perm = 1 << iota
+//		Write
+//		Exec
+//	)
+//	g := gen.Flags(Read, Write, Exec) // e.g. Read|Exec
+func Flags[T ~uint | ~uint32 | ~uint64](flags ...T) Generator[T] {
+	// clear the largest flags first: they dominate the value
+	order := append([]T(nil), flags...)
+	sort.Slice(order, func(i, j int) bool { return order[i] > order[j] })
+
+	return From(func(r *rand.Rand, _ Size) (T, Shrinker[T]) {
+		if r == nil {
+			r = rand.New(rand.NewSource(rand.Int63())) // #nosec G404 -- Using math/rand for deterministic property-based testing
+		}
+		var cur T
+		for _, f := range flags {
+			if r.Intn(2) == 0 {
+				cur |= f
+			}
+		}
+		last := cur
+
+		queue := make([]T, 0, len(flags)+1)
+		tried := map[T]struct{}{cur: {}}
+		var queued map[T]struct{}
+
+		push := func(v T) {
+			if _, ok := tried[v]; ok {
+				return
+			}
+			if _, ok := queued[v]; ok {
+				return
+			}
+			queued[v] = struct{}{}
+			queue = append(queue, v)
+		}
+
+		// neighbors: no flags at all, then cur with one flag cleared
+		grow := func(base T) {
+			queue = queue[:0]
+			queued = map[T]struct{}{}
+			if base != 0 {
+				push(0)
+			}
+			for _, f := range order {
+				if base&f != 0 {
+					push(base &^ f)
+				}
+			}
+		}
+		grow(cur)
+
+		pop := func() (T, bool) {
+			if len(queue) == 0 {
+				return 0, false
+			}
+			var v T
+			if GetShrinkStrategy() == ShrinkStrategyDFS {
+				v = queue[len(queue)-1]
+				queue = queue[:len(queue)-1]
+			} else {
+				v = queue[0]
+				queue = queue[1:]
+			}
+			tried[v] = struct{}{}
+			return v, true
+		}
+
+		return cur, func(accept bool) (T, bool) {
+			if accept && last != cur {
+				cur = last
+				grow(cur)
+			}
+			nxt, ok := pop()
+			if !ok {
+				return 0, false
+			}
+			last = nxt
+			return nxt, true
+		}
+	})
+}
@@ -0,0 +1,92 @@
+package gen
+
+import (
+	"math/rand"
+	"testing"
+)
+
+type testPerm uint32
+
+const (
+	permRead testPerm = 1 << iota
+	permWrite
+	permExec
+	permAdmin testPerm = 1 << 10
+)
+
+func TestFlags_OnlyDefinedBits(t *testing.T) {
+	defined := permRead | permWrite | permExec | permAdmin
+	g := Flags(permRead, permWrite, permExec, permAdmin)
+	r := rand.New(rand.NewSource(1))
+	seen := map[testPerm]bool{}
+	for i := 0; i < 500; i++ {
+		v, _ := g.Generate(r, Size{})
+		if v&^defined != 0 {
+			t.Fatalf("Flags() = %b sets undefined bits", v)
+		}
+		seen[v] = true
+	}
+	// 4 flags give 16 combinations, all reachable
+	if len(seen) != 16 {
+		t.Errorf("Flags() produced %d distinct combinations, expected 16", len(seen))
+	}
+}
+
+func TestFlags_NoFlags(t *testing.T) {
+	v, shrink := Flags[uint]().Generate(rand.New(rand.NewSource(1)), Size{})
+	if v != 0 {
+		t.Errorf("Flags() = %d, expected 0", v)
+	}
+	if _, ok := shrink(false); ok {
+		t.Error("Flags() shrinker of 0 proposed a candidate")
+	}
+}
+
+func TestFlags_ShrinkClearsFlags(t *testing.T) {
+	defined := permRead | permWrite | permExec | permAdmin
+	g := Flags(permRead, permWrite, permExec, permAdmin)
+	r := rand.New(rand.NewSource(2))
+	for i := 0; i < 50; i++ {
+		v, shrink := g.Generate(r, Size{})
+		// fails while Write is set: minimal is Write alone
+		if v&permWrite == 0 {
+			continue
+		}
+		min, accept := v, false
+		for j := 0; j < 100; j++ {
+			next, ok := shrink(accept)
+			if !ok {
+				break
+			}
+			if next&^defined != 0 || next&^min != 0 {
+				t.Fatalf("shrink candidate %b of %b sets new bits", next, min)
+			}
+			accept = next&permWrite != 0
+			if accept {
+				min = next
+			}
+		}
+		if min != permWrite {
+			t.Errorf("Flags() %b shrunk to %b, expected %b", v, min, permWrite)
+		}
+	}
+}
+
+func TestFlags_ShrinkToZero(t *testing.T) {
+	g := Flags[uint64](1, 2, 4, 1<<40)
+	r := rand.New(rand.NewSource(3))
+	for i := 0; i < 20; i++ {
+		v, shrink := g.Generate(r, Size{})
+		min := v
+		for j := 0; j < 100; j++ {
+			next, ok := shrink(true)
+			if !ok {
+				break
+			}
+			min = next
+		}
+		if min != 0 {
+			t.Errorf("Flags() %b shrunk to %b, expected 0", v, min)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package gen
 
 import (
+	"fmt"
 	"math"
 	"math/rand"
 )
@@ -22,7 +23,12 @@ func Float32(size Size) Generator[float32] {
 }
 
 // Float32Range generates float32 in [min, max]; can optionally produce NaN/±Inf.
+// Reversed bounds (min > max) are swapped.
+// Panics if min or max is NaN.
 func Float32Range(min, max float32, includeNaN, includeInf bool) Generator[float32] {
+	if math.IsNaN(float64(min)) || math.IsNaN(float64(max)) {
+		panic(fmt.Sprintf("gen.Float32Range: bounds must not be NaN (min=%v, max=%v)", min, max))
+	}
 	if min > max {
 		min, max = max, min
 	}
@@ -152,7 +158,7 @@ func float32ShrinkInit(start, min, max float32, allowNaN, allowInf bool) (float3
 		if len(queue) == 0 {
 			return 0, false
 		}
-		if shrinkStrategy == ShrinkStrategyDFS {
+		if GetShrinkStrategy() == ShrinkStrategyDFS {
 			v := queue[len(queue)-1]
 			queue = queue[:len(queue)-1]
 			return v, true
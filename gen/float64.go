@@ -1,6 +1,7 @@
 package gen
 
 import (
+	"fmt"
 	"math"
 	"math/rand"
 )
@@ -43,7 +44,12 @@ func autoRangeF64(local, fromRunner Size) (float64, float64) {
 
 // Float64Range generates floats uniformly in [min, max] (inclusive on finite bounds).
 // Parameters includeNaN/includeInf allow injecting special cases.
+// Reversed bounds (min > max) are swapped.
+// Panics if min or max is NaN.
 func Float64Range(min, max float64, includeNaN, includeInf bool) Generator[float64] {
+	if math.IsNaN(min) || math.IsNaN(max) {
+		panic(fmt.Sprintf("gen.Float64Range: bounds must not be NaN (min=%v, max=%v)", min, max))
+	}
 	if min > max {
 		min, max = max, min
 	}
@@ -184,7 +190,7 @@ func float64ShrinkInit(start, min, max float64, allowNaN, allowInf bool) (float6
 		if len(queue) == 0 {
 			return 0, false
 		}
-		if shrinkStrategy == ShrinkStrategyDFS {
+		if GetShrinkStrategy() == ShrinkStrategyDFS {
 			v := queue[len(queue)-1]
 			queue = queue[:len(queue)-1]
 			return v, true
@@ -341,3 +341,25 @@ func TestFloat64MidpointTowards(t *testing.T) {
 		})
 	}
 }
+
+func TestFloatRange_ReversedBounds(t *testing.T) {
+	checkReversedRange(t, Float32Range(10, 1, false, false), 1, 10)
+	checkReversedRange(t, Float64Range(-1, -100, false, false), -100, -1)
+}
+
+func TestFloatRange_PanicsOnNaNBounds(t *testing.T) {
+	nan := math.NaN()
+	for name, build := range map[string]func(){
+		"Float32Range": func() { Float32Range(float32(nan), 1, false, false) },
+		"Float64Range": func() { Float64Range(0, nan, false, false) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s with a NaN bound did not panic", name)
+				}
+			}()
+			build()
+		}()
+	}
+}
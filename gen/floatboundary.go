@@ -0,0 +1,181 @@
+// File: gen/floatboundary.go
+package gen
+
+import (
+	"math"
+	"math/rand"
+	"strconv"
+)
+
+// floatDecimals are short decimals with no exact binary representation.
+var floatDecimals = [...]float64{0.1, 0.2, 0.3, 0.7, 1.1, 2.675, 1e23, 1.0 / 3, 0.01}
+
+// FloatBoundary generates finite float64 values where the representation is
+// lossy or surprising, for testing float formatting, parsing and round-trips.
+// Each example picks one kind, with equal probability:
+//   - a power of two, or the float just below or above it (2^-60 to 2^60)
+//   - a value needing 17 significant digits to round-trip ('g' with 16
+//     digits parses back to a different float)
+//   - a float around 2^53, where consecutive integers stop being
+//     representable (2^53+1 rounds to 2^53)
+//   - a short decimal without an exact binary form (0.1, 0.3, 2.675, 1e23)
+//     or one of its neighbors
+//   - a limit: the smallest subnormal, the smallest normal, MaxFloat64, or a
+//     neighbor of one
+//
+// Signs are random. NaN and ±Inf are never generated.
+// Shrink: toward exactly representable, simple values: 0, the integer part,
+// fewer significant mantissa bits (down to a power of two), exponents closer
+// to 0, then the positive value; converges to 0.
+func FloatBoundary() Generator[float64] {
+	return From(func(r *rand.Rand, _ Size) (float64, Shrinker[float64]) {
+		if r == nil {
+			r = rand.New(rand.NewSource(rand.Int63())) // #nosec G404 -- Using math/rand for deterministic property-based testing
+		}
+		// nudge moves x to one of its neighbors, or leaves it
+		nudge := func(x float64) float64 {
+			switch r.Intn(3) {
+			case 0:
+				return math.Nextafter(x, math.Inf(-1))
+			case 1:
+				return math.Nextafter(x, math.Inf(1))
+			}
+			return x
+		}
+
+		var v float64
+		switch r.Intn(5) {
+		case 0: // around a power of two
+			v = nudge(math.Ldexp(1, r.Intn(121)-60))
+		case 1: // 17 significant digits
+			for {
+				v = math.Ldexp(1+r.Float64(), r.Intn(81)-40)
+				if needs17Digits(v) {
+					break
+				}
+			}
+		case 2: // around 2^53
+			v = float64(1<<53 + r.Intn(9) - 4)
+			if r.Intn(2) == 0 {
+				v = nudge(v)
+			}
+		case 3: // short decimals
+			v = nudge(floatDecimals[r.Intn(len(floatDecimals))])
+		default: // limits
+			limits := [...]float64{math.SmallestNonzeroFloat64, 0x1p-1022, math.MaxFloat64}
+			v = limits[r.Intn(len(limits))]
+			if v == math.MaxFloat64 {
+				// the float above MaxFloat64 is +Inf
+				if r.Intn(2) == 0 {
+					v = math.Nextafter(v, 0)
+				}
+			} else {
+				v = nudge(v)
+			}
+		}
+		if r.Intn(2) == 0 {
+			v = -v
+		}
+		return v, createFloatBoundaryShrinker(v)
+	})
+}
+
+// needs17Digits reports whether x does not round-trip through 16 significant
+// digits.
+func needs17Digits(x float64) bool {
+	y, err := strconv.ParseFloat(strconv.FormatFloat(x, 'g', 16, 64), 64)
+	return err != nil || y != x
+}
+
+// createFloatBoundaryShrinker creates a shrinker toward simple, exactly
+// representable values.
+func createFloatBoundaryShrinker(initial float64) Shrinker[float64] {
+	queue := make([]float64, 0, 32)
+	tried := map[uint64]struct{}{f64key(initial): {}}
+	var queued map[uint64]struct{}
+	cur, last := initial, initial
+
+	push := func(x float64) {
+		if !isFinite(x) {
+			return
+		}
+		k := f64key(x)
+		if _, ok := tried[k]; ok {
+			return
+		}
+		if _, ok := queued[k]; ok {
+			return
+		}
+		queued[k] = struct{}{}
+		queue = append(queue, x)
+	}
+
+	grow := func(base float64) {
+		queue = queue[:0]
+		queued = map[uint64]struct{}{}
+		if base == 0 {
+			if math.Signbit(base) {
+				push(0)
+			}
+			return
+		}
+		// (1) zero, then the integer part
+		push(0)
+		push(math.Trunc(base))
+		// (2) fewer significant bits: the power of two below, then halves of
+		// the mantissa, then without its last bit
+		frac, exp := math.Frexp(base)
+		bits := math.Float64bits(frac) & (1<<52 - 1)
+		for keep := 0; keep < 52; keep = keep*2 + 1 {
+			if bits&(1<<(52-keep)-1) != 0 {
+				push(math.Ldexp(math.Float64frombits(math.Float64bits(frac)&^(1<<(52-keep)-1)), exp))
+			}
+		}
+		if low := bits & -bits; low != 0 {
+			push(math.Ldexp(math.Float64frombits(math.Float64bits(frac)&^low), exp))
+		}
+		// (3) exponent closer to 0 (the value closer to ±1), keeping the mantissa
+		if exp != 1 {
+			push(math.Ldexp(frac, 1+(exp-1)/2))
+			if exp > 1 {
+				push(math.Ldexp(frac, exp-1))
+			} else {
+				push(math.Ldexp(frac, exp+1))
+			}
+		}
+		// (4) positive
+		if base < 0 {
+			push(-base)
+		}
+	}
+	grow(cur)
+
+	pop := func() (float64, bool) {
+		if len(queue) == 0 {
+			return 0, false
+		}
+		var v float64
+		if GetShrinkStrategy() == ShrinkStrategyDFS {
+			v = queue[len(queue)-1]
+			queue = queue[:len(queue)-1]
+		} else {
+			v = queue[0]
+			queue = queue[1:]
+		}
+		tried[f64key(v)] = struct{}{}
+		return v, true
+	}
+
+	return func(accept bool) (float64, bool) {
+		if accept && f64key(last) != f64key(cur) {
+			cur = last
+			grow(cur)
+		}
+		nxt, ok := pop()
+		if !ok {
+			return 0, false
+		}
+		last = nxt
+		return nxt, true
+	}
+}
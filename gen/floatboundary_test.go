@@ -0,0 +1,84 @@
+package gen
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestFloatBoundary(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	g := FloatBoundary()
+	var digits17, near53, pow2, neighbor, neg bool
+	for i := 0; i < 1000; i++ {
+		v, shrink := g.Generate(r, Size{})
+		if !isFinite(v) {
+			t.Fatalf("FloatBoundary() = %v, expected a finite value", v)
+		}
+		if shrink == nil {
+			t.Fatal("FloatBoundary().Generate() returned nil shrinker")
+		}
+		a := math.Abs(v)
+		frac, _ := math.Frexp(a)
+		digits17 = digits17 || needs17Digits(v)
+		near53 = near53 || math.Abs(a-1<<53) <= 8
+		pow2 = pow2 || frac == 0.5
+		up, _ := math.Frexp(math.Nextafter(a, math.Inf(1)))
+		neighbor = neighbor || frac != 0.5 && up == 0.5
+		neg = neg || v < 0
+	}
+	if !digits17 || !near53 || !pow2 || !neighbor || !neg {
+		t.Errorf("FloatBoundary(): 17 digits %v, near 2^53 %v, power of two %v, below a power of two %v, negative %v",
+			digits17, near53, pow2, neighbor, neg)
+	}
+}
+
+func TestFloatBoundary_Needs17Digits(t *testing.T) {
+	if needs17Digits(0.1) || needs17Digits(1<<53) || needs17Digits(0.5) {
+		t.Error("needs17Digits reported a short value as needing 17 digits")
+	}
+	a, b := 0.1, 0.2
+	if !needs17Digits(a+b) || !needs17Digits(math.Nextafter(1, 2)) {
+		t.Error("needs17Digits missed a value needing 17 digits")
+	}
+}
+
+func TestFloatBoundary_ShrinkTowardZero(t *testing.T) {
+	SetShrinkStrategy("bfs")
+	r := rand.New(rand.NewSource(2))
+	g := FloatBoundary()
+	for i := 0; i < 50; i++ {
+		v, shrink := g.Generate(r, Size{})
+		min := minimize(v, shrink, func(x float64) bool {
+			if !isFinite(x) {
+				t.Fatalf("shrinking %v proposed %v", v, x)
+			}
+			return true
+		})
+		if v != 0 && (min != 0 || math.Signbit(min)) {
+			t.Errorf("shrinking %v accepting everything ended at %v, expected 0", v, min)
+		}
+	}
+}
+
+func TestFloatBoundary_ShrinkKeepsFailure(t *testing.T) {
+	// fails while |x| > 1000: the minimal value is positive and has few
+	// significant mantissa bits
+	for _, strategy := range []string{"bfs", "dfs"} {
+		SetShrinkStrategy(strategy)
+		r := rand.New(rand.NewSource(3))
+		g := FloatBoundary()
+		for i := 0; i < 200; i++ {
+			v, shrink := g.Generate(r, Size{})
+			if math.Abs(v) <= 1000 {
+				continue
+			}
+			min := minimize(v, shrink, func(x float64) bool { return math.Abs(x) > 1000 })
+			frac, _ := math.Frexp(min)
+			if min <= 1000 || math.Float64bits(frac)&(1<<20-1) != 0 {
+				t.Errorf("%s: shrinking %v ended at %v, expected a positive value with a short mantissa", strategy, v, min)
+			}
+		}
+	}
+	SetShrinkStrategy("bfs")
+}
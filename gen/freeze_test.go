@@ -0,0 +1,49 @@
+package gen
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestFreeze(t *testing.T) {
+	g := Freeze(IntRange(0, 1_000_000))
+	r := rand.New(rand.NewSource(123))
+
+	first, shrink := g.Generate(r, Size{})
+	if shrink == nil {
+		t.Fatal("Freeze().Generate() returned nil shrinker")
+	}
+	if _, ok := shrink(true); ok {
+		t.Error("Freeze() shrinker should not propose candidates")
+	}
+
+	for i := 0; i < 20; i++ {
+		if v, _ := g.Generate(r, Size{}); v != first {
+			t.Fatalf("Freeze().Generate() = %d, expected cached value %d", v, first)
+		}
+	}
+}
+
+func TestFreeze_ReproducibleFromSeed(t *testing.T) {
+	a, _ := Freeze(IntRange(0, 1_000_000)).Generate(rand.New(rand.NewSource(7)), Size{})
+	b, _ := Freeze(IntRange(0, 1_000_000)).Generate(rand.New(rand.NewSource(7)), Size{})
+	if a != b {
+		t.Errorf("Freeze() with the same seed produced %d and %d", a, b)
+	}
+}
+
+func TestFreeze_InPairKeepsComponent(t *testing.T) {
+	g := PairOf(Freeze(IntRange(1, 100)), IntRange(0, 100))
+	r := rand.New(rand.NewSource(123))
+
+	p, shrink := g.Generate(r, Size{})
+	for i := 0; i < 50; i++ {
+		next, ok := shrink(true)
+		if !ok {
+			break
+		}
+		if next.First != p.First {
+			t.Fatalf("frozen component changed from %d to %d while shrinking", p.First, next.First)
+		}
+	}
+}
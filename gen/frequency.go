@@ -0,0 +1,138 @@
+// File: gen/frequency.go
+package gen
+
+import (
+	"math/rand"
+	"sort"
+)
+
+// frequencyRedraws is the number of fresh values PreferFrequent draws from
+// each more frequent branch before giving up on it.
+const frequencyRedraws = 8
+
+// Freq is a generator with its relative weight, for Frequency.
+type Freq[T any] struct {
+	Weight int
+	Gen    Generator[T]
+}
+
+// FrequencyOption configures Frequency.
+type FrequencyOption func(*frequencyConfig)
+
+type frequencyConfig struct {
+	preferFrequent bool
+}
+
+// PreferFrequent makes Frequency shrink toward the more frequent branches: a
+// counterexample drawn from a rare branch first tries fresh values from every
+// branch with a higher weight (heaviest first), and moves to the first one
+// that still fails. A failure the common case also hits is the one to report.
+func PreferFrequent() FrequencyOption {
+	return func(c *frequencyConfig) {
+		c.preferFrequent = true
+	}
+}
+
+// Frequency chooses a generator with probability proportional to its weight;
+// branches with weight 0 are never chosen. Panics if a weight is negative or
+// all weights are 0.
+// Shrink: first tries branches earlier in the list (one fresh value each, as
+// Weighted does), then shrinks within the current branch. With
+// PreferFrequent, it tries the branches weighing more than the current one
+// instead (heaviest first, up to 8 fresh values each), so the counterexample
+// moves from a rare branch to the most common branch that still fails.
+//
+// Example:
+//
+//	g := gen.Frequency([]gen.Freq[int]{
+//	    {Weight: 9, Gen: gen.IntRange(0, 100)},
+//	    {Weight: 1, Gen: gen.Const(math.MaxInt)},
+//	}, gen.PreferFrequent())
+func Frequency[T any](choices []Freq[T], opts ...FrequencyOption) Generator[T] {
+	var cfg frequencyConfig
+	for _, o := range opts {
+		o(&cfg)
+	}
+	total := 0
+	for _, c := range choices {
+		if c.Weight < 0 {
+			panic("gen.Frequency: negative weight")
+		}
+		total += c.Weight
+	}
+	if total == 0 {
+		panic("gen.Frequency: needs a positive weight")
+	}
+	return From(func(r *rand.Rand, sz Size) (T, Shrinker[T]) {
+		if r == nil {
+			r = rand.New(rand.NewSource(rand.Int63())) // #nosec G404 -- Using math/rand for deterministic property-based testing
+		}
+		idx := 0
+		for n := r.Intn(total); n >= choices[idx].Weight; idx++ {
+			n -= choices[idx].Weight
+		}
+		val, shrink := choices[idx].Gen.Generate(r, sz)
+		shrink = orNoShrink(shrink, val)
+
+		// values drawn while shrinking come from a private source, so shrinking
+		// never touches r (which may be shared with other examples)
+		sr := rand.New(rand.NewSource(r.Int63())) // #nosec G404 -- Using math/rand for deterministic property-based testing
+
+		// alternatives lists the branches to try before shrinking within
+		// branch i, with the number of fresh values to draw from each
+		alternatives := func(i int) (branches []int, draws int) {
+			if !cfg.preferFrequent {
+				for j := 0; j < i; j++ {
+					if choices[j].Weight > 0 {
+						branches = append(branches, j)
+					}
+				}
+				return branches, 1
+			}
+			for j := range choices {
+				if choices[j].Weight > choices[i].Weight {
+					branches = append(branches, j)
+				}
+			}
+			// heaviest first; ties keep list order
+			sort.SliceStable(branches, func(a, b int) bool {
+				return choices[branches[a]].Weight > choices[branches[b]].Weight
+			})
+			return branches, frequencyRedraws
+		}
+		branches, draws := alternatives(idx)
+		drawn := 0
+
+		migrating := -1 // branch of the last proposed migration, if any
+		var migShrink Shrinker[T]
+
+		return val, func(accept bool) (T, bool) {
+			// verdict on a migration: adopt the branch if it still fails
+			if migrating >= 0 {
+				if accept {
+					idx, shrink = migrating, migShrink
+					branches, draws = alternatives(idx)
+					drawn = 0
+				}
+				migrating = -1
+				accept = false
+			}
+			// (1) other branches first
+			if len(branches) > 0 {
+				j := branches[0]
+				if drawn++; drawn >= draws {
+					branches, drawn = branches[1:], 0
+				}
+				nv, ns := choices[j].Gen.Generate(sr, sz)
+				migrating, migShrink = j, orNoShrink(ns, nv)
+				return nv, true
+			}
+			// (2) shrink within the current branch
+			if next, ok := shrink(accept); ok {
+				return next, true
+			}
+			var z T
+			return z, false
+		}
+	})
+}
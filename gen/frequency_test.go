@@ -0,0 +1,94 @@
+package gen
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestFrequency(t *testing.T) {
+	g := Frequency([]Freq[int]{{Weight: 9, Gen: Const(1)}, {Weight: 1, Gen: Const(2)}, {Weight: 0, Gen: Const(3)}})
+	r := rand.New(rand.NewSource(1))
+	counts := map[int]int{}
+	for i := 0; i < 1000; i++ {
+		v, shrink := g.Generate(r, Size{})
+		if shrink == nil {
+			t.Fatal("Frequency().Generate() returned nil shrinker")
+		}
+		counts[v]++
+	}
+	if counts[3] != 0 || counts[1] < 850 || counts[2] < 50 {
+		t.Errorf("Frequency() counts = %v, expected about 900 ones, 100 twos and no threes", counts)
+	}
+
+	for _, choices := range [][]Freq[int]{nil, {{Weight: 0, Gen: Const(1)}}, {{Weight: -1, Gen: Const(1)}, {Weight: 2, Gen: Const(2)}}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Frequency(%v) did not panic", choices)
+				}
+			}()
+			Frequency(choices)
+		}()
+	}
+}
+
+func TestFrequency_PreferFrequent(t *testing.T) {
+	// the rare branch comes first, so without PreferFrequent shrinking has no
+	// earlier branch to try and stays in it
+	choices := []Freq[int]{
+		{Weight: 1, Gen: IntRange(1000, 2000)},
+		{Weight: 9, Gen: IntRange(0, 100)},
+	}
+	fails := func(v int) bool { return v > 50 }
+
+	for _, strategy := range []string{"bfs", "dfs"} {
+		SetShrinkStrategy(strategy)
+		for _, prefer := range []bool{false, true} {
+			var opts []FrequencyOption
+			if prefer {
+				opts = append(opts, PreferFrequent())
+			}
+			g := Frequency(choices, opts...)
+			r := rand.New(rand.NewSource(2))
+			found := 0
+			for i := 0; i < 2000 && found < 10; i++ {
+				v, shrink := g.Generate(r, Size{})
+				if v < 1000 {
+					continue
+				}
+				found++
+				got := minimize(v, shrink, fails)
+				switch {
+				case prefer && (got < 51 || got > 100):
+					t.Errorf("%s: PreferFrequent shrank %d to %d, expected a value of the frequent branch", strategy, v, got)
+				case !prefer && got < 1000:
+					t.Errorf("%s: shrank %d to %d, expected to stay in the first branch", strategy, v, got)
+				}
+			}
+			if found == 0 {
+				t.Fatalf("%s: no value from the rare branch", strategy)
+			}
+		}
+	}
+	SetShrinkStrategy("bfs")
+}
+
+func TestFrequency_PreferFrequentKeepsRareFailure(t *testing.T) {
+	SetShrinkStrategy("bfs")
+	// only the rare branch fails: shrinking tries the frequent one, then
+	// shrinks within the rare branch
+	g := Frequency([]Freq[int]{
+		{Weight: 9, Gen: IntRange(0, 100)},
+		{Weight: 1, Gen: IntRange(1000, 2000)},
+	}, PreferFrequent())
+	r := rand.New(rand.NewSource(3))
+	for i := 0; i < 200; i++ {
+		v, shrink := g.Generate(r, Size{})
+		if v < 1000 {
+			continue
+		}
+		if got := minimize(v, shrink, func(v int) bool { return v >= 1000 }); got != 1000 {
+			t.Errorf("shrinking %d ended at %d, expected 1000", v, got)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package gen
+
+import (
+	"iter"
+	"math/rand"
+	"runtime"
+)
+
+// FromIter creates a Generator from a function returning a value and its
+// shrink candidates as a lazy sequence, instead of a stateful Shrinker.
+// The candidates are proposed in order, one per shrink step, whether the
+// previous one was accepted or not, and the last accepted candidate is the
+// shrunk value; so the sequence should simplify progressively, each
+// candidate simpler than the ones before it. A nil sequence disables
+// shrinking. The sequence is pulled only as far as shrinking goes, so it may
+// be infinite.
+//
+// Example usage:
+//
+//	halving := gen.FromIter(func(r *rand.Rand, _ gen.Size) (int, iter.Seq[int]) {
+//	    v := r.Intn(1000)
+//	    return v, func(yield func(int) bool) {
+//	        for c := v / 2; c > 0 && yield(c); c /= 2 {
+//	        }
+//	    }
+//	})
+func FromIter[T any](fn func(*rand.Rand, Size) (T, iter.Seq[T])) Generator[T] {
+	return From(func(r *rand.Rand, sz Size) (T, Shrinker[T]) {
+		if r == nil {
+			r = rand.New(rand.NewSource(rand.Int63())) // #nosec G404 -- Using math/rand for deterministic property-based testing
+		}
+		v, seq := fn(r, sz)
+		if seq == nil {
+			return v, noShrink(v)
+		}
+		return v, seqShrinker(seq)
+	})
+}
+
+// seqShrinker proposes the elements of seq in order, ignoring accept.
+func seqShrinker[T any](seq iter.Seq[T]) Shrinker[T] {
+	type puller struct {
+		next func() (T, bool)
+		stop func()
+	}
+	var p *puller
+	done := false
+	return func(bool) (T, bool) {
+		var zero T
+		if done {
+			return zero, false
+		}
+		if p == nil {
+			next, stop := iter.Pull(seq)
+			p = &puller{next: next, stop: stop}
+			// shrinking may be abandoned before the end of the sequence;
+			// stopping it then releases the goroutine iter.Pull runs it on
+			runtime.AddCleanup(p, func(stop func()) { stop() }, stop)
+		}
+		v, ok := p.next()
+		if !ok {
+			done = true
+			p.stop()
+			return zero, false
+		}
+		return v, true
+	}
+}
@@ -0,0 +1,92 @@
+package gen
+
+import (
+	"iter"
+	"math/rand"
+	"runtime"
+	"testing"
+	"time"
+)
+
+// halving generates ints in [0, 1000), shrinking by repeated halving.
+var halving = FromIter(func(r *rand.Rand, _ Size) (int, iter.Seq[int]) {
+	v := r.Intn(1000)
+	return v, func(yield func(int) bool) {
+		for c := v / 2; c > 0 && yield(c); c /= 2 {
+		}
+	}
+})
+
+func TestFromIter_ShrinksAlongSequence(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 50; i++ {
+		v, shrink := halving.Generate(r, Size{})
+		if v < 0 || v >= 1000 {
+			t.Fatalf("FromIter() generated %d, expected [0, 1000)", v)
+		}
+		got := minimize(v, shrink, func(x int) bool { return x > 10 })
+		if v > 10 && (got <= 10 || got > 21) {
+			t.Errorf("%d shrunk to %d, expected the last value above 10 in its halving sequence", v, got)
+		}
+	}
+}
+
+func TestFromIter_Candidates(t *testing.T) {
+	g := FromIter(func(*rand.Rand, Size) (string, iter.Seq[string]) {
+		return "abc", func(yield func(string) bool) {
+			for _, s := range []string{"ab", "a", ""} {
+				if !yield(s) {
+					return
+				}
+			}
+		}
+	})
+	_, shrink := g.Generate(nil, Size{})
+	var got []string
+	for _, accept := range []bool{false, true, false, true} {
+		v, ok := shrink(accept)
+		if !ok {
+			break
+		}
+		got = append(got, v)
+	}
+	if len(got) != 3 || got[0] != "ab" || got[1] != "a" || got[2] != "" {
+		t.Errorf("FromIter() candidates = %q, expected [ab a \"\"] regardless of accept", got)
+	}
+	if _, ok := shrink(false); ok {
+		t.Error("FromIter() shrinker proposed a candidate after the end of the sequence")
+	}
+
+	v, none := FromIter(func(*rand.Rand, Size) (int, iter.Seq[int]) { return 7, nil }).Generate(nil, Size{})
+	if none == nil {
+		t.Fatal("FromIter() with a nil sequence returned a nil shrinker")
+	}
+	if _, ok := none(false); v != 7 || ok {
+		t.Errorf("FromIter() with a nil sequence: value %d, shrink ok %v; expected 7 and no candidates", v, ok)
+	}
+}
+
+func TestFromIter_AbandonedSequenceIsStopped(t *testing.T) {
+	stopped := make(chan struct{})
+	g := FromIter(func(*rand.Rand, Size) (int, iter.Seq[int]) {
+		return 0, func(yield func(int) bool) {
+			defer close(stopped)
+			for i := 1; yield(i); i++ {
+			}
+		}
+	})
+	func() {
+		_, shrink := g.Generate(nil, Size{})
+		shrink(false)
+		shrink(true)
+	}()
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); {
+		runtime.GC()
+		select {
+		case <-stopped:
+			return
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	t.Error("an infinite sequence abandoned mid-shrink was not stopped")
+}
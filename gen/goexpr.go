@@ -0,0 +1,239 @@
+// File: gen/goexpr.go
+package gen
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+)
+
+// goExprMaxDepth caps the nesting depth of GoExpr: deeper constant
+// sub-expressions could overflow int when combined with typed operands.
+const goExprMaxDepth = 5
+
+// goExprType is the static type of a generated expression.
+type goExprType int
+
+const (
+	goInt goExprType = iota
+	goBool
+)
+
+// Identifiers used by GoExpr; callers type-checking the output declare them
+// as `var x, y, n int` and `var ok, done bool`.
+var (
+	goIntIdents  = []string{"x", "y", "n"}
+	goBoolIdents = []string{"ok", "done"}
+)
+
+// goExprNode is the tree representation used to generate and shrink
+// expressions. Leaves have op == "" and a literal or identifier in lit.
+type goExprNode struct {
+	typ     goExprType
+	op      string
+	lit     string
+	kids    []*goExprNode
+	divisor bool // non-zero literal on the right of / or %
+}
+
+// GoExpr generates syntactically valid, well-typed Go expressions over int
+// and bool: literals, the identifiers x, y, n (int) and ok, done (bool),
+// unary - and !, arithmetic (+ - * / %), comparisons and && / ||. Divisors
+// are always non-zero literals, so constant sub-expressions never divide by
+// zero.
+// - size.Max bounds the nesting depth (default 3, at most 5).
+// Shrink: hoists sub-expressions of the same type and replaces nodes with
+// the simplest literal, converging toward a single literal ("0" or "false").
+func GoExpr(size Size) Generator[string] {
+	return From(func(r *rand.Rand, sz Size) (string, Shrinker[string]) {
+		if r == nil {
+			r = rand.New(rand.NewSource(rand.Int63())) // #nosec G404 -- Using math/rand for deterministic property-based testing
+		}
+		if sz.Min != 0 || sz.Max != 0 {
+			size = sz
+		}
+		depth := size.Max
+		if depth <= 0 {
+			depth = 3
+		}
+		if depth > goExprMaxDepth {
+			depth = goExprMaxDepth
+		}
+
+		typ := goInt
+		if r.Intn(2) == 0 {
+			typ = goBool
+		}
+		cur := generateGoExpr(r, typ, depth)
+
+		queue := make([]*goExprNode, 0, 32)
+		seen := map[string]struct{}{cur.render(): {}}
+		var last *goExprNode
+
+		push := func(n *goExprNode) {
+			k := n.render()
+			if _, ok := seen[k]; ok {
+				return
+			}
+			seen[k] = struct{}{}
+			queue = append(queue, n)
+		}
+
+		// neighbors: for each node (preorder), a copy of the tree with that node simplified
+		grow := func(base *goExprNode) {
+			queue = queue[:0]
+			nodes := base.preorder()
+			for i, n := range nodes {
+				edit := func(f func(*goExprNode) *goExprNode) {
+					c := base.clone()
+					target := c.preorder()[i]
+					*target = *f(target)
+					push(c)
+				}
+				// (1) simplest literal of the node's type
+				if simplest := n.simplest(); n.lit != simplest.lit || n.op != "" {
+					edit(func(*goExprNode) *goExprNode { return simplest })
+				}
+				// (2) hoist each child of the same type
+				for j, k := range n.kids {
+					if k.typ == n.typ && !k.divisor {
+						edit(func(m *goExprNode) *goExprNode { return m.kids[j] })
+					}
+				}
+			}
+		}
+		grow(cur)
+
+		pop := func() (*goExprNode, bool) {
+			if len(queue) == 0 {
+				return nil, false
+			}
+			if GetShrinkStrategy() == ShrinkStrategyDFS {
+				v := queue[len(queue)-1]
+				queue = queue[:len(queue)-1]
+				return v, true
+			}
+			v := queue[0]
+			queue = queue[1:]
+			return v, true
+		}
+
+		return cur.render(), func(accept bool) (string, bool) {
+			if accept && last != nil && last.render() != cur.render() {
+				cur = last
+				grow(cur)
+			}
+			nxt, ok := pop()
+			if !ok {
+				return "", false
+			}
+			last = nxt
+			return nxt.render(), true
+		}
+	})
+}
+
+// generateGoExpr builds a random expression of type typ with at most depth levels.
+func generateGoExpr(r *rand.Rand, typ goExprType, depth int) *goExprNode {
+	if depth <= 1 || r.Intn(4) == 0 {
+		return goExprLeaf(r, typ)
+	}
+	if typ == goInt {
+		switch r.Intn(6) {
+		case 0:
+			return &goExprNode{typ: goInt, op: "-", kids: []*goExprNode{generateGoExpr(r, goInt, depth-1)}}
+		case 1:
+			op := []string{"/", "%"}[r.Intn(2)]
+			div := &goExprNode{typ: goInt, lit: fmt.Sprint(1 + r.Intn(9)), divisor: true}
+			return &goExprNode{typ: goInt, op: op, kids: []*goExprNode{generateGoExpr(r, goInt, depth-1), div}}
+		default:
+			op := []string{"+", "-", "*"}[r.Intn(3)]
+			return &goExprNode{typ: goInt, op: op, kids: []*goExprNode{generateGoExpr(r, goInt, depth-1), generateGoExpr(r, goInt, depth-1)}}
+		}
+	}
+	switch r.Intn(4) {
+	case 0:
+		return &goExprNode{typ: goBool, op: "!", kids: []*goExprNode{generateGoExpr(r, goBool, depth-1)}}
+	case 1:
+		op := []string{"&&", "||"}[r.Intn(2)]
+		return &goExprNode{typ: goBool, op: op, kids: []*goExprNode{generateGoExpr(r, goBool, depth-1), generateGoExpr(r, goBool, depth-1)}}
+	default:
+		op := []string{"==", "!=", "<", "<=", ">", ">="}[r.Intn(6)]
+		return &goExprNode{typ: goBool, op: op, kids: []*goExprNode{generateGoExpr(r, goInt, depth-1), generateGoExpr(r, goInt, depth-1)}}
+	}
+}
+
+// goExprLeaf returns a literal or an identifier of type typ.
+func goExprLeaf(r *rand.Rand, typ goExprType) *goExprNode {
+	if typ == goInt {
+		if r.Intn(2) == 0 {
+			return &goExprNode{typ: goInt, lit: goIntIdents[r.Intn(len(goIntIdents))]}
+		}
+		return &goExprNode{typ: goInt, lit: fmt.Sprint(r.Intn(10))}
+	}
+	if r.Intn(2) == 0 {
+		return &goExprNode{typ: goBool, lit: goBoolIdents[r.Intn(len(goBoolIdents))]}
+	}
+	return &goExprNode{typ: goBool, lit: []string{"true", "false"}[r.Intn(2)]}
+}
+
+// simplest returns the simplest literal that can replace n.
+func (n *goExprNode) simplest() *goExprNode {
+	switch {
+	case n.divisor:
+		return &goExprNode{typ: goInt, lit: "1", divisor: true}
+	case n.typ == goBool:
+		return &goExprNode{typ: goBool, lit: "false"}
+	default:
+		return &goExprNode{typ: goInt, lit: "0"}
+	}
+}
+
+// render serializes the expression, parenthesizing every operator node
+// below the root.
+func (n *goExprNode) render() string {
+	var b strings.Builder
+	n.write(&b, true)
+	return b.String()
+}
+
+// write appends the serialized node to b.
+func (n *goExprNode) write(b *strings.Builder, root bool) {
+	if n.op == "" {
+		b.WriteString(n.lit)
+		return
+	}
+	if !root {
+		b.WriteString("(")
+	}
+	if len(n.kids) == 1 {
+		b.WriteString(n.op)
+		n.kids[0].write(b, false)
+	} else {
+		n.kids[0].write(b, false)
+		b.WriteString(" " + n.op + " ")
+		n.kids[1].write(b, false)
+	}
+	if !root {
+		b.WriteString(")")
+	}
+}
+
+// clone returns a deep copy of the tree.
+func (n *goExprNode) clone() *goExprNode {
+	c := *n
+	c.kids = nil
+	for _, k := range n.kids {
+		c.kids = append(c.kids, k.clone())
+	}
+	return &c
+}
+
+// preorder lists the nodes of the tree in preorder.
+func (n *goExprNode) preorder() []*goExprNode {
+	out := []*goExprNode{n}
+	for _, k := range n.kids {
+		out = append(out, k.preorder()...)
+	}
+	return out
+}
@@ -0,0 +1,81 @@
+package gen
+
+import (
+	"go/constant"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"math/rand"
+	"testing"
+)
+
+// checkGoExpr parses and type-checks expr with the GoExpr identifiers in scope.
+func checkGoExpr(t *testing.T, expr string) {
+	t.Helper()
+	if _, err := parser.ParseExpr(expr); err != nil {
+		t.Fatalf("GoExpr produced unparsable %q: %v", expr, err)
+	}
+	pkg := types.NewPackage("p", "p")
+	for _, id := range goIntIdents {
+		pkg.Scope().Insert(types.NewVar(token.NoPos, pkg, id, types.Typ[types.Int]))
+	}
+	for _, id := range goBoolIdents {
+		pkg.Scope().Insert(types.NewVar(token.NoPos, pkg, id, types.Typ[types.Bool]))
+	}
+	tv, err := types.Eval(token.NewFileSet(), pkg, token.NoPos, expr)
+	if err != nil {
+		t.Fatalf("GoExpr produced ill-typed %q: %v", expr, err)
+	}
+	if b, ok := tv.Type.Underlying().(*types.Basic); !ok || b.Info()&(types.IsInteger|types.IsBoolean) == 0 {
+		t.Fatalf("GoExpr %q has type %v, expected int or bool", expr, tv.Type)
+	}
+	if tv.Value != nil && tv.Value.Kind() == constant.Unknown {
+		t.Fatalf("GoExpr %q has an invalid constant value", expr)
+	}
+}
+
+func TestGoExpr(t *testing.T) {
+	g := GoExpr(Size{Max: 5})
+	r := rand.New(rand.NewSource(123))
+
+	for i := 0; i < 300; i++ {
+		expr, shrink := g.Generate(r, Size{})
+		checkGoExpr(t, expr)
+		if shrink == nil {
+			t.Fatal("GoExpr().Generate() returned nil shrinker")
+		}
+	}
+}
+
+func TestGoExpr_ShrinksToLiteral(t *testing.T) {
+	g := GoExpr(Size{Max: 4})
+	r := rand.New(rand.NewSource(42))
+
+	for i := 0; i < 30; i++ {
+		expr, shrink := g.Generate(r, Size{})
+		min := expr
+		for j := 0; j < 2000; j++ {
+			next, ok := shrink(true)
+			if !ok {
+				break
+			}
+			checkGoExpr(t, next)
+			min = next
+		}
+		if min != "0" && min != "false" {
+			t.Errorf("GoExpr() %q shrunk to %q, expected a single literal", expr, min)
+		}
+	}
+}
+
+func TestGoExpr_DepthOne(t *testing.T) {
+	g := GoExpr(Size{Max: 1})
+	r := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 50; i++ {
+		expr, _ := g.Generate(r, Size{})
+		if _, ok := map[string]bool{"x": true, "y": true, "n": true, "ok": true, "done": true, "true": true, "false": true}[expr]; !ok && (len(expr) != 1 || expr[0] < '0' || expr[0] > '9') {
+			t.Errorf("GoExpr(Max=1) = %q, expected a single literal or identifier", expr)
+		}
+	}
+}
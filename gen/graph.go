@@ -0,0 +1,210 @@
+// File: gen/graph.go
+package gen
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+)
+
+// Edge is an edge of a Graph, between node indices. Edges of undirected
+// graphs have From < To.
+type Edge struct {
+	From, To int
+}
+
+// Graph is a graph over the nodes 0..Nodes-1, with its edges sorted by
+// (From, To), without self-loops or repeated edges.
+type Graph struct {
+	Nodes    int
+	Edges    []Edge
+	Directed bool
+}
+
+// Adjacency returns the adjacency lists of the graph: the neighbors of each
+// node in increasing order (successors, for directed graphs).
+func (g Graph) Adjacency() [][]int {
+	adj := make([][]int, g.Nodes)
+	for _, e := range g.Edges {
+		adj[e.From] = append(adj[e.From], e.To)
+		if !g.Directed {
+			adj[e.To] = append(adj[e.To], e.From)
+		}
+	}
+	for _, ns := range adj {
+		sort.Ints(ns)
+	}
+	return adj
+}
+
+// GraphOf generates graphs over nodes nodes, each possible edge present with
+// probability edgeProb, for testing graph algorithms (traversal, connectivity,
+// cycle detection). Directed graphs may have cycles; see DAG for acyclic ones.
+// Panics if nodes < 0 or edgeProb is outside [0, 1].
+// Shrink: removes edges (all, halves, then single R->L), then nodes (R->L,
+// renumbering the ones after), toward the empty graph.
+func GraphOf(nodes int, edgeProb float64, directed bool) Generator[Graph] {
+	checkGraphArgs("gen.GraphOf", nodes, edgeProb)
+	return From(func(r *rand.Rand, sz Size) (Graph, Shrinker[Graph]) {
+		if r == nil {
+			r = rand.New(rand.NewSource(rand.Int63())) // #nosec G404 -- Using math/rand for deterministic property-based testing
+		}
+		sz.Budget.Spend(nodes)
+		g := Graph{Nodes: nodes, Directed: directed}
+		for u := 0; u < nodes; u++ {
+			for v := 0; v < nodes; v++ {
+				if u == v || !directed && v < u {
+					continue
+				}
+				if r.Float64() < edgeProb {
+					g.Edges = append(g.Edges, Edge{From: u, To: v})
+				}
+			}
+		}
+		return g, createGraphShrinker(g)
+	})
+}
+
+// DAG generates directed acyclic graphs over nodes nodes, for topological
+// sort and scheduling tests: nodes are put in a random order and each edge
+// from an earlier to a later node is present with probability edgeProb. Node
+// numbers are not a topological order. Every shrink candidate stays acyclic.
+// Panics if nodes < 0 or edgeProb is outside [0, 1].
+// Shrink: like GraphOf.
+func DAG(nodes int, edgeProb float64) Generator[Graph] {
+	checkGraphArgs("gen.DAG", nodes, edgeProb)
+	return From(func(r *rand.Rand, sz Size) (Graph, Shrinker[Graph]) {
+		if r == nil {
+			r = rand.New(rand.NewSource(rand.Int63())) // #nosec G404 -- Using math/rand for deterministic property-based testing
+		}
+		sz.Budget.Spend(nodes)
+		g := Graph{Nodes: nodes, Directed: true}
+		order := r.Perm(nodes)
+		for i := 0; i < nodes; i++ {
+			for j := i + 1; j < nodes; j++ {
+				if r.Float64() < edgeProb {
+					g.Edges = append(g.Edges, Edge{From: order[i], To: order[j]})
+				}
+			}
+		}
+		sortEdges(g.Edges)
+		return g, createGraphShrinker(g)
+	})
+}
+
+// checkGraphArgs panics on a negative node count or an edge probability
+// outside [0, 1].
+func checkGraphArgs(name string, nodes int, edgeProb float64) {
+	if nodes < 0 {
+		panic(fmt.Sprintf("%s: nodes=%d, must be >= 0", name, nodes))
+	}
+	if !(edgeProb >= 0 && edgeProb <= 1) {
+		panic(fmt.Sprintf("%s: edgeProb=%v, must be in [0, 1]", name, edgeProb))
+	}
+}
+
+// sortEdges sorts edges by (From, To).
+func sortEdges(es []Edge) {
+	sort.Slice(es, func(i, j int) bool {
+		if es[i].From != es[j].From {
+			return es[i].From < es[j].From
+		}
+		return es[i].To < es[j].To
+	})
+}
+
+// createGraphShrinker creates a shrinker that removes edges, then nodes.
+// Removing either never adds a cycle, so DAGs stay acyclic.
+func createGraphShrinker(initial Graph) Shrinker[Graph] {
+	queue := make([]Graph, 0, 32)
+	tried := map[string]struct{}{fmt.Sprint(initial): {}}
+	var queued map[string]struct{}
+	var last *Graph
+	cur := initial
+
+	push := func(g Graph) {
+		k := fmt.Sprint(g)
+		if _, ok := tried[k]; ok {
+			return
+		}
+		if _, ok := queued[k]; ok {
+			return
+		}
+		queued[k] = struct{}{}
+		queue = append(queue, g)
+	}
+
+	withoutEdges := func(base Graph, i, j int) Graph {
+		out := base
+		out.Edges = make([]Edge, 0, len(base.Edges)-(j-i))
+		out.Edges = append(out.Edges, base.Edges[:i]...)
+		out.Edges = append(out.Edges, base.Edges[j:]...)
+		return out
+	}
+	// withoutNode drops node n and its edges, renumbering the nodes after it
+	withoutNode := func(base Graph, n int) Graph {
+		out := Graph{Nodes: base.Nodes - 1, Directed: base.Directed}
+		for _, e := range base.Edges {
+			if e.From == n || e.To == n {
+				continue
+			}
+			if e.From > n {
+				e.From--
+			}
+			if e.To > n {
+				e.To--
+			}
+			out.Edges = append(out.Edges, e)
+		}
+		return out
+	}
+
+	grow := func(base Graph) {
+		queue = queue[:0]
+		queued = map[string]struct{}{}
+		// (1) remove edges: all, halves, then single (R->L)
+		L := len(base.Edges)
+		if L > 0 {
+			push(withoutEdges(base, 0, L))
+			push(withoutEdges(base, L/2, L))
+			push(withoutEdges(base, 0, L/2))
+			for i := L - 1; i >= 0; i-- {
+				push(withoutEdges(base, i, i+1))
+			}
+		}
+		// (2) remove nodes (R->L)
+		for n := base.Nodes - 1; n >= 0; n-- {
+			push(withoutNode(base, n))
+		}
+	}
+	grow(cur)
+
+	pop := func() (Graph, bool) {
+		if len(queue) == 0 {
+			return Graph{}, false
+		}
+		var v Graph
+		if GetShrinkStrategy() == ShrinkStrategyDFS {
+			v = queue[len(queue)-1]
+			queue = queue[:len(queue)-1]
+		} else {
+			v = queue[0]
+			queue = queue[1:]
+		}
+		tried[fmt.Sprint(v)] = struct{}{}
+		return v, true
+	}
+
+	return func(accept bool) (Graph, bool) {
+		if accept && last != nil {
+			cur = *last
+			grow(cur)
+		}
+		nxt, ok := pop()
+		if !ok {
+			return Graph{}, false
+		}
+		last = &nxt
+		return nxt, true
+	}
+}
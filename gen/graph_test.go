@@ -0,0 +1,192 @@
+package gen
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// checkGraph fails the test unless g is well formed: edges in range, sorted,
+// without self-loops or repeats, and with From < To when undirected.
+func checkGraph(t *testing.T, g Graph) {
+	t.Helper()
+	for i, e := range g.Edges {
+		if e.From < 0 || e.From >= g.Nodes || e.To < 0 || e.To >= g.Nodes {
+			t.Fatalf("edge %v out of range in %+v", e, g)
+		}
+		if e.From == e.To {
+			t.Fatalf("self-loop %v in %+v", e, g)
+		}
+		if !g.Directed && e.From > e.To {
+			t.Fatalf("undirected edge %v with From > To in %+v", e, g)
+		}
+		if i > 0 {
+			p := g.Edges[i-1]
+			if p.From > e.From || p.From == e.From && p.To >= e.To {
+				t.Fatalf("edges %v, %v unsorted or repeated in %+v", p, e, g)
+			}
+		}
+	}
+}
+
+// acyclic reports whether the directed graph g has no cycles (Kahn's algorithm).
+func acyclic(g Graph) bool {
+	in := make([]int, g.Nodes)
+	for _, e := range g.Edges {
+		in[e.To]++
+	}
+	var ready []int
+	for n, d := range in {
+		if d == 0 {
+			ready = append(ready, n)
+		}
+	}
+	adj, seen := g.Adjacency(), 0
+	for len(ready) > 0 {
+		n := ready[len(ready)-1]
+		ready = ready[:len(ready)-1]
+		seen++
+		for _, m := range adj[n] {
+			if in[m]--; in[m] == 0 {
+				ready = append(ready, m)
+			}
+		}
+	}
+	return seen == g.Nodes
+}
+
+func TestGraphOf(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, directed := range []bool{false, true} {
+		total := 0
+		for i := 0; i < 100; i++ {
+			g, shrink := GraphOf(10, 0.3, directed).Generate(r, Size{})
+			if g.Nodes != 10 || g.Directed != directed {
+				t.Fatalf("GraphOf(10, 0.3, %v) = %+v", directed, g)
+			}
+			checkGraph(t, g)
+			if shrink == nil {
+				t.Fatal("GraphOf().Generate() returned nil shrinker")
+			}
+			total += len(g.Edges)
+		}
+		// expected 100 * 0.3 * pairs: 45 unordered, 90 ordered
+		want := 100 * 0.3 * 45
+		if directed {
+			want *= 2
+		}
+		if float64(total) < want*0.8 || float64(total) > want*1.2 {
+			t.Errorf("GraphOf(directed=%v) made %d edges in 100 graphs, expected about %.0f", directed, total, want)
+		}
+	}
+}
+
+func TestGraphOf_Extremes(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	if g, _ := GraphOf(5, 0, true).Generate(r, Size{}); len(g.Edges) != 0 {
+		t.Errorf("GraphOf(5, 0) = %+v, expected no edges", g)
+	}
+	if g, _ := GraphOf(5, 1, false).Generate(r, Size{}); len(g.Edges) != 10 {
+		t.Errorf("GraphOf(5, 1, undirected) has %d edges, expected the 10 of K5", len(g.Edges))
+	}
+	if g, _ := GraphOf(0, 0.5, false).Generate(r, Size{}); g.Nodes != 0 || len(g.Edges) != 0 {
+		t.Errorf("GraphOf(0) = %+v, expected the empty graph", g)
+	}
+	for _, bad := range []func(){
+		func() { GraphOf(-1, 0.5, false) },
+		func() { GraphOf(3, 1.5, false) },
+		func() { DAG(3, -0.1) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Error("expected a panic for invalid arguments")
+				}
+			}()
+			bad()
+		}()
+	}
+}
+
+func TestGraph_Adjacency(t *testing.T) {
+	g := Graph{Nodes: 3, Edges: []Edge{{0, 2}, {1, 2}}}
+	adj := g.Adjacency()
+	if len(adj) != 3 || len(adj[0]) != 1 || adj[0][0] != 2 || len(adj[2]) != 2 || adj[2][0] != 0 || adj[2][1] != 1 {
+		t.Errorf("undirected Adjacency() = %v, expected [[2] [2] [0 1]]", adj)
+	}
+	g.Directed = true
+	adj = g.Adjacency()
+	if len(adj[2]) != 0 || len(adj[1]) != 1 {
+		t.Errorf("directed Adjacency() = %v, expected [[2] [2] []]", adj)
+	}
+}
+
+func TestDAG(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	backward := 0
+	for i := 0; i < 100; i++ {
+		g, _ := DAG(8, 0.5).Generate(r, Size{})
+		checkGraph(t, g)
+		if !g.Directed || !acyclic(g) {
+			t.Fatalf("DAG() = %+v, expected a directed acyclic graph", g)
+		}
+		for _, e := range g.Edges {
+			if e.From > e.To {
+				backward++
+			}
+		}
+	}
+	// node numbers are not a topological order
+	if backward == 0 {
+		t.Error("DAG() edges always go from lower to higher nodes")
+	}
+}
+
+func TestGraph_ShrinkTowardEmpty(t *testing.T) {
+	r := rand.New(rand.NewSource(4))
+	g, shrink := DAG(6, 0.6).Generate(r, Size{})
+
+	min := g
+	for i := 0; i < 1000; i++ {
+		next, ok := shrink(true)
+		if !ok {
+			break
+		}
+		checkGraph(t, next)
+		if !acyclic(next) {
+			t.Fatalf("shrink candidate %+v has a cycle", next)
+		}
+		min = next
+	}
+	if min.Nodes != 0 || len(min.Edges) != 0 {
+		t.Errorf("shrinking %+v accepting everything ended at %+v, expected the empty graph", g, min)
+	}
+}
+
+func TestGraph_ShrinkKeepsCycle(t *testing.T) {
+	// fails while the directed graph has a cycle: minimal is a 2-cycle
+	r := rand.New(rand.NewSource(5))
+	var g Graph
+	var shrink Shrinker[Graph]
+	for {
+		g, shrink = GraphOf(6, 0.4, true).Generate(r, Size{})
+		if !acyclic(g) {
+			break
+		}
+	}
+
+	min, accept := g, false
+	for i := 0; i < 5000; i++ {
+		next, ok := shrink(accept)
+		if !ok {
+			break
+		}
+		checkGraph(t, next)
+		accept = !acyclic(next)
+		if accept {
+			min = next
+		}
+	}
+	if min.Nodes != 2 || len(min.Edges) != 2 {
+		t.Errorf("shrinking %+v ended at %+v, expected 2 nodes with edges both ways", g, min)
+	}
+}
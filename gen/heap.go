@@ -0,0 +1,207 @@
+// File: gen/heap.go
+package gen
+
+import "math/rand"
+
+// heapElem is a heap element with the shrinker of its value; both move
+// together while elements are removed.
+type heapElem[T any] struct {
+	v T
+	s Shrinker[T]
+}
+
+// BinaryHeap generates slices that satisfy the binary min-heap property for
+// less: no element is less than its parent, so h[0] is a minimum and every
+// h[i] is not less than h[(i-1)/2] (the layout of container/heap). Use a
+// reversed less for max-heaps. Elements come from g and are arranged with the
+// standard O(n) heapify.
+// - size.Min/Max control the length (default Min=0, Max=16).
+// Shrink (every candidate is a valid heap):
+//
+//	(1) the empty heap, then shorter prefixes (half, quarter, ...)
+//	(2) remove a single element (R->L), moving the last element into its
+//	    place and sifting it up or down as container/heap.Remove does
+//	(3) shrink each element in place (left→right) with its own shrinker,
+//	    skipping candidates that would break the heap property
+func BinaryHeap[T any](g Generator[T], less func(a, b T) bool, size Size) Generator[[]T] {
+	return From(func(r *rand.Rand, sz Size) ([]T, Shrinker[[]T]) {
+		if r == nil {
+			r = rand.New(rand.NewSource(rand.Int63())) // #nosec G404 -- Using math/rand for deterministic property-based testing
+		}
+		// defaults
+		if size.Min == 0 && size.Max == 0 {
+			size.Min, size.Max = 0, 16
+		}
+		if sz.Min != 0 || sz.Max != 0 {
+			size = sz
+		}
+		if size.Max < size.Min {
+			size.Max = size.Min
+		}
+
+		n := size.Min
+		if size.Max > size.Min {
+			n += r.Intn(size.Max - size.Min + 1)
+		}
+
+		sz.Budget.Spend(n)
+		cur := make([]heapElem[T], n)
+		for i := range cur {
+			v, s := g.Generate(r, sz.elem())
+			cur[i] = heapElem[T]{v, orNoShrink(s, v)}
+		}
+		for i := n/2 - 1; i >= 0; i-- {
+			heapDown(cur, i, less)
+		}
+
+		values := func(h []heapElem[T]) []T {
+			out := make([]T, len(h))
+			for i, e := range h {
+				out[i] = e.v
+			}
+			return out
+		}
+
+		// phase (1): removals, queue-based with rebase on accept
+		queue := make([][]heapElem[T], 0, 32)
+		tried := map[string]struct{}{sig(values(cur)): {}}
+		var queued map[string]struct{}
+		var last []heapElem[T]
+		hasLast := false
+
+		push := func(h []heapElem[T]) {
+			k := sig(values(h))
+			if _, ok := tried[k]; ok {
+				return
+			}
+			if _, ok := queued[k]; ok {
+				return
+			}
+			queued[k] = struct{}{}
+			queue = append(queue, h)
+		}
+
+		grow := func(base []heapElem[T]) {
+			queue = queue[:0]
+			queued = map[string]struct{}{}
+			L := len(base)
+			if L == 0 {
+				return
+			}
+			// (1) prefixes of a heap are heaps
+			push(nil)
+			for k := L / 2; k >= 1; k /= 2 {
+				push(append(([]heapElem[T])(nil), base[:k]...))
+			}
+			// (2) single removals (R->L)
+			for i := L - 1; i >= 0; i-- {
+				push(heapRemove(base, i, less))
+			}
+		}
+		grow(cur)
+
+		pop := func() ([]heapElem[T], bool) {
+			if len(queue) == 0 {
+				return nil, false
+			}
+			var v []heapElem[T]
+			if GetShrinkStrategy() == ShrinkStrategyDFS {
+				v = queue[len(queue)-1]
+				queue = queue[:len(queue)-1]
+			} else {
+				v = queue[0]
+				queue = queue[1:]
+			}
+			tried[sig(values(v))] = struct{}{}
+			return v, true
+		}
+
+		// phase (2): each element in place; a candidate breaking the heap
+		// property counts as rejected
+		removing := true
+		var each func(accept bool) (int, T, bool)
+
+		return values(cur), func(accept bool) ([]T, bool) {
+			if removing {
+				if accept && hasLast {
+					cur = last
+					grow(cur)
+				}
+				if c, ok := pop(); ok {
+					last, hasLast = c, true
+					return values(c), true
+				}
+				removing = false
+				accept = false
+				each = shrinkInPlace(len(cur), func(i int) Shrinker[T] { return cur[i].s },
+					func(i int, v T) bool { return heapFits(cur, i, v, less) },
+					func(i int, v T) { cur[i].v = v })
+			}
+
+			i, v, ok := each(accept)
+			if !ok {
+				return nil, false
+			}
+			cand := values(cur)
+			cand[i] = v
+			return cand, true
+		}
+	})
+}
+
+// heapFits reports whether h stays a heap with v at position i.
+func heapFits[T any](h []heapElem[T], i int, v T, less func(a, b T) bool) bool {
+	if i > 0 && less(v, h[(i-1)/2].v) {
+		return false
+	}
+	for _, c := range [2]int{2*i + 1, 2*i + 2} {
+		if c < len(h) && less(h[c].v, v) {
+			return false
+		}
+	}
+	return true
+}
+
+// heapRemove returns a copy of h without element i, moving the last element
+// into its place and restoring the heap property.
+func heapRemove[T any](h []heapElem[T], i int, less func(a, b T) bool) []heapElem[T] {
+	out := append(([]heapElem[T])(nil), h...)
+	n := len(out) - 1
+	if i != n {
+		out[i] = out[n]
+		out = out[:n]
+		heapDown(out, i, less)
+		heapUp(out, i, less)
+		return out
+	}
+	return out[:n]
+}
+
+// heapDown moves h[i] down until neither child is less than it.
+func heapDown[T any](h []heapElem[T], i int, less func(a, b T) bool) {
+	for {
+		m := i
+		for _, c := range [2]int{2*i + 1, 2*i + 2} {
+			if c < len(h) && less(h[c].v, h[m].v) {
+				m = c
+			}
+		}
+		if m == i {
+			return
+		}
+		h[i], h[m] = h[m], h[i]
+		i = m
+	}
+}
+
+// heapUp moves h[i] up while it is less than its parent.
+func heapUp[T any](h []heapElem[T], i int, less func(a, b T) bool) {
+	for i > 0 {
+		p := (i - 1) / 2
+		if !less(h[i].v, h[p].v) {
+			return
+		}
+		h[i], h[p] = h[p], h[i]
+		i = p
+	}
+}
@@ -0,0 +1,101 @@
+package gen
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// isMinHeap reports whether h satisfies the min-heap property.
+func isMinHeap(h []int) bool {
+	for i := 1; i < len(h); i++ {
+		if h[i] < h[(i-1)/2] {
+			return false
+		}
+	}
+	return true
+}
+
+func intLess(a, b int) bool { return a < b }
+
+func TestBinaryHeap(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	g := BinaryHeap(IntRange(-50, 50), intLess, Size{Min: 0, Max: 20})
+	lengths := map[int]bool{}
+	for i := 0; i < 300; i++ {
+		h, shrink := g.Generate(r, Size{})
+		if !isMinHeap(h) {
+			t.Fatalf("BinaryHeap() = %v, not a heap", h)
+		}
+		if len(h) > 20 {
+			t.Fatalf("BinaryHeap() = %v, longer than 20", h)
+		}
+		if shrink == nil {
+			t.Fatal("BinaryHeap().Generate() returned nil shrinker")
+		}
+		lengths[len(h)] = true
+	}
+	if len(lengths) < 15 {
+		t.Errorf("BinaryHeap() produced only %d distinct lengths", len(lengths))
+	}
+
+	// a reversed less gives max-heaps
+	h, _ := BinaryHeap(IntRange(0, 100), func(a, b int) bool { return a > b }, Size{Min: 10, Max: 10}).Generate(r, Size{})
+	for i := 1; i < len(h); i++ {
+		if h[i] > h[(i-1)/2] {
+			t.Fatalf("BinaryHeap() with reversed less = %v, not a max-heap", h)
+		}
+	}
+}
+
+func TestBinaryHeap_ShrinkKeepsHeap(t *testing.T) {
+	for _, strategy := range []string{"bfs", "dfs"} {
+		SetShrinkStrategy(strategy)
+		r := rand.New(rand.NewSource(2))
+		g := BinaryHeap(IntRange(-100, 100), intLess, Size{Min: 5, Max: 20})
+		for i := 0; i < 30; i++ {
+			h, shrink := g.Generate(r, Size{})
+			// fails while the heap holds a value of at least 50
+			fails := func(h []int) bool {
+				if !isMinHeap(h) {
+					t.Fatalf("%s: shrink candidate %v is not a heap", strategy, h)
+				}
+				for _, v := range h {
+					if v >= 50 {
+						return true
+					}
+				}
+				return false
+			}
+			if !fails(h) {
+				continue
+			}
+			min := minimize(h, shrink, fails)
+			if len(min) != 1 || min[0] < 50 {
+				t.Errorf("%s: shrinking %v ended at %v, expected a single value of at least 50", strategy, h, min)
+			}
+			// IntRange's DFS order tries the upper bound last, so only BFS
+			// ends next to the threshold
+			if strategy == "bfs" && min[0] > 60 {
+				t.Errorf("bfs: shrinking %v ended at %v, expected a value near 50", h, min)
+			}
+		}
+	}
+	SetShrinkStrategy("bfs")
+}
+
+func TestBinaryHeap_ShrinkElements(t *testing.T) {
+	SetShrinkStrategy("bfs")
+	// fails while the heap has at least 7 elements: removals stop at 7 and
+	// elements shrink toward 0 as far as the heap property allows
+	r := rand.New(rand.NewSource(3))
+	h, shrink := BinaryHeap(IntRange(0, 1000), intLess, Size{Min: 10, Max: 10}).Generate(r, Size{})
+	min := minimize(h, shrink, func(h []int) bool {
+		if !isMinHeap(h) {
+			t.Fatalf("shrink candidate %v is not a heap", h)
+		}
+		return len(h) >= 7
+	})
+	if len(min) != 7 || min[0] != 0 {
+		t.Errorf("shrinking %v ended at %v, expected 7 elements starting at 0", h, min)
+	}
+}
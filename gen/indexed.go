@@ -0,0 +1,117 @@
+// File: gen/indexed.go
+package gen
+
+import "math/rand"
+
+// SliceOfIndexed generates []T where the generator of each element depends on
+// its index: element i is drawn from f(i). This expresses positional
+// constraints SliceOf cannot, e.g. element i in [0, i]:
+//
+//	gen.SliceOfIndexed(func(i int) gen.Generator[int] { return gen.IntRange(0, i) }, gen.Size{Max: 10})
+//
+// - size.Min/Max control the length (default Min=0, Max=16), like SliceOf.
+// Shrink (indices never move, so every candidate keeps the per-index constraints):
+//
+//	(1) truncate: shortest allowed prefix, half, then drop the last element
+//	(2) shrink each element in place (left→right) with its own shrinker
+func SliceOfIndexed[T any](f func(i int) Generator[T], size Size) Generator[[]T] {
+	return From(func(r *rand.Rand, sz Size) ([]T, Shrinker[[]T]) {
+		if r == nil {
+			r = rand.New(rand.NewSource(rand.Int63())) // #nosec G404 -- Using math/rand for deterministic property-based testing
+		}
+		// defaults
+		if size.Min == 0 && size.Max == 0 {
+			size.Min, size.Max = 0, 16
+		}
+		if sz.Min != 0 || sz.Max != 0 {
+			size = sz
+		}
+		if size.Max < size.Min {
+			size.Max = size.Min
+		}
+
+		n := size.Min
+		if size.Max > size.Min {
+			n += r.Intn(size.Max - size.Min + 1)
+		}
+
+		sz.Budget.Spend(n)
+		cur := make([]T, n)
+		shks := make([]Shrinker[T], n)
+		for i := 0; i < n; i++ {
+			cur[i], shks[i] = f(i).Generate(r, sz.elem())
+		}
+		out := append(([]T)(nil), cur...)
+
+		// phase (1): prefixes, queue-based with rebase on accept
+		queue := make([]int, 0, 4)
+		seen := map[int]struct{}{n: {}}
+		last := n
+
+		push := func(k int) {
+			if k < size.Min {
+				return
+			}
+			if _, ok := seen[k]; ok {
+				return
+			}
+			seen[k] = struct{}{}
+			queue = append(queue, k)
+		}
+		growNeighbors := func(L int) {
+			queue = queue[:0]
+			if L == 0 {
+				return
+			}
+			push(size.Min)
+			push(L / 2)
+			push(L - 1)
+		}
+		growNeighbors(n)
+
+		pop := func() (int, bool) {
+			if len(queue) == 0 {
+				return 0, false
+			}
+			if GetShrinkStrategy() == ShrinkStrategyDFS {
+				k := queue[len(queue)-1]
+				queue = queue[:len(queue)-1]
+				return k, true
+			}
+			k := queue[0]
+			queue = queue[1:]
+			return k, true
+		}
+
+		// phase (2): element i in place with shks[i]
+		truncating := true
+		var each func(accept bool) (int, T, bool)
+
+		return out, func(accept bool) ([]T, bool) {
+			if truncating {
+				if accept && last != len(cur) {
+					cur = cur[:last]
+					shks = shks[:last]
+					growNeighbors(last)
+				}
+				if k, ok := pop(); ok {
+					last = k
+					return append(([]T)(nil), cur[:k]...), true
+				}
+				truncating = false
+				accept = false
+			}
+
+			if each == nil {
+				each = shrinkInPlace(len(cur), func(i int) Shrinker[T] { return shks[i] }, nil, func(i int, v T) { cur[i] = v })
+			}
+			i, v, ok := each(accept)
+			if !ok {
+				return nil, false
+			}
+			cand := append(([]T)(nil), cur...)
+			cand[i] = v
+			return cand, true
+		}
+	})
+}
@@ -0,0 +1,106 @@
+package gen
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// upToIndex draws element i from [0, i].
+func upToIndex(i int) Generator[int] { return IntRange(0, i) }
+
+func TestSliceOfIndexed(t *testing.T) {
+	g := SliceOfIndexed(upToIndex, Size{Min: 2, Max: 12})
+	r := rand.New(rand.NewSource(123))
+
+	for i := 0; i < 100; i++ {
+		value, shrink := g.Generate(r, Size{})
+
+		if len(value) < 2 || len(value) > 12 {
+			t.Fatalf("SliceOfIndexed().Generate() length = %d, expected in [2, 12]", len(value))
+		}
+		for j, v := range value {
+			if v < 0 || v > j {
+				t.Fatalf("SliceOfIndexed().Generate()[%d] = %d, expected in [0, %d]", j, v, j)
+			}
+		}
+		if shrink == nil {
+			t.Fatal("SliceOfIndexed().Generate() returned nil shrinker")
+		}
+	}
+}
+
+func TestSliceOfIndexed_ShrinkRespectsIndexConstraints(t *testing.T) {
+	// element i in [i, 2i]: removing a middle element would break positions
+	g := SliceOfIndexed(func(i int) Generator[int] { return IntRange(i, 2*i) }, Size{Min: 8, Max: 16})
+	r := rand.New(rand.NewSource(42))
+	value, shrink := g.Generate(r, Size{})
+
+	// accept every candidate that does not grow the slice or its elements
+	min, accept := value, false
+	for i := 0; i < 2000; i++ {
+		next, ok := shrink(accept)
+		if !ok {
+			break
+		}
+		for j, v := range next {
+			if v < j || v > 2*j {
+				t.Fatalf("shrink candidate %v: element %d = %d, expected in [%d, %d]", next, j, v, j, 2*j)
+			}
+		}
+		accept = len(next) <= len(min) && sumInts(next) <= sumInts(min)
+		if accept {
+			min = next
+		}
+	}
+
+	// shrinking ends at the shortest allowed length with each element at its
+	// own lower bound
+	if len(min) != 8 {
+		t.Fatalf("shrunk length = %d, expected 8: %v", len(min), min)
+	}
+	for j, v := range min {
+		if v != j {
+			t.Errorf("shrunk element %d = %d, expected %d", j, v, j)
+		}
+	}
+}
+
+func TestSliceOfIndexed_ShrinkKeepsFailingElement(t *testing.T) {
+	g := SliceOfIndexed(upToIndex, Size{Min: 10, Max: 10})
+	r := rand.New(rand.NewSource(7))
+	value, shrink := g.Generate(r, Size{})
+
+	// property fails while the last element is >= 5; only accept those
+	fails := func(s []int) bool { return len(s) == 10 && s[9] >= 5 }
+	if !fails(value) {
+		t.Skip("seed did not produce a failing value")
+	}
+
+	min, accept := value, false
+	for i := 0; i < 2000; i++ {
+		next, ok := shrink(accept)
+		if !ok {
+			break
+		}
+		accept = fails(next) && sumInts(next) < sumInts(min)
+		if accept {
+			min = next
+		}
+	}
+	if min[9] != 5 {
+		t.Errorf("shrunk last element = %d, expected 5: %v", min[9], min)
+	}
+	for j := 0; j < 9; j++ {
+		if min[j] != 0 {
+			t.Errorf("shrunk element %d = %d, expected 0: %v", j, min[j], min)
+		}
+	}
+}
+
+func sumInts(s []int) int {
+	total := 0
+	for _, v := range s {
+		total += v
+	}
+	return total
+}
@@ -26,6 +26,7 @@ func Int(size Size) Generator[int] {
 
 // IntRange generates integers uniformly in the range [min, max] (inclusive).
 // Ignores sz for the range (useful when you want explicit control).
+// Reversed bounds (min > max) are swapped.
 func IntRange(min, max int) Generator[int] {
 	if min > max {
 		min, max = max, min
@@ -121,7 +122,7 @@ func intShrinkInit(start, min, max int) (int, Shrinker[int]) {
 		if len(queue) == 0 {
 			return 0, false
 		}
-		if shrinkStrategy == ShrinkStrategyDFS {
+		if GetShrinkStrategy() == ShrinkStrategyDFS {
 			v := queue[len(queue)-1]
 			queue = queue[:len(queue)-1]
 			return v, true
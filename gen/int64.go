@@ -21,6 +21,7 @@ func Int64(size Size) Generator[int64] {
 }
 
 // Int64Range generates int64 uniformly in the range [min, max] (inclusive).
+// Reversed bounds (min > max) are swapped.
 func Int64Range(min, max int64) Generator[int64] {
 	if min > max {
 		min, max = max, min
@@ -95,7 +96,7 @@ func int64ShrinkInit(start, min, max int64) (int64, Shrinker[int64]) {
 		if len(queue) == 0 {
 			return 0, false
 		}
-		if shrinkStrategy == ShrinkStrategyDFS {
+		if GetShrinkStrategy() == ShrinkStrategyDFS {
 			v := queue[len(queue)-1]
 			queue = queue[:len(queue)-1]
 			return v, true
@@ -62,3 +62,8 @@ func TestInt64Shrinker(t *testing.T) {
 		t.Errorf("Int64 shrinker returned value %d outside range [0, 100]", next)
 	}
 }
+
+func TestInt64Range_ReversedBounds(t *testing.T) {
+	checkReversedRange(t, Int64Range(10, 1), 1, 10)
+	checkReversedRange(t, Int64Range(50, -50), -50, 50)
+}
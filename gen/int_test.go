@@ -408,3 +408,51 @@ func TestIntShrinkerEdgeCases(t *testing.T) {
 		})
 	}
 }
+
+// checkReversedRange checks that g, built with reversed bounds, behaves like
+// the range [lo, hi]: values spread over it, and shrinking proposes
+// candidates that stay inside it.
+func checkReversedRange[T int | int64 | uint | uint64 | float32 | float64](t *testing.T, g Generator[T], lo, hi T) {
+	t.Helper()
+	r := rand.New(rand.NewSource(1))
+	seen := map[T]bool{}
+	for i := 0; i < 200; i++ {
+		v, _ := g.Generate(r, Size{})
+		if v < lo || v > hi {
+			t.Fatalf("value %v outside [%v, %v]", v, lo, hi)
+		}
+		seen[v] = true
+	}
+	if len(seen) < 5 {
+		t.Errorf("only %d distinct values in [%v, %v]", len(seen), lo, hi)
+	}
+
+	// accept every candidate below the current minimum
+	v, shrink := g.Generate(r, Size{})
+	for v == lo {
+		v, shrink = g.Generate(r, Size{})
+	}
+	min, accept, candidates := v, false, 0
+	for i := 0; i < 500; i++ {
+		next, ok := shrink(accept)
+		if !ok {
+			break
+		}
+		candidates++
+		if next < lo || next > hi {
+			t.Fatalf("shrink candidate %v outside [%v, %v]", next, lo, hi)
+		}
+		accept = next < min
+		if accept {
+			min = next
+		}
+	}
+	if candidates == 0 || min >= v {
+		t.Errorf("shrinking %v proposed %d candidates, ending at %v; expected smaller values", v, candidates, min)
+	}
+}
+
+func TestIntRange_ReversedBounds(t *testing.T) {
+	checkReversedRange(t, IntRange(10, 1), 1, 10)
+	checkReversedRange(t, IntRange(-5, -50), -50, -5)
+}
@@ -0,0 +1,78 @@
+package gen
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestIntervalOf_Generation(t *testing.T) {
+	g := IntervalOf(IntRange(-5, 5), intLess)
+	r := rand.New(rand.NewSource(1))
+	points, ranges := 0, 0
+	for i := 0; i < 500; i++ {
+		iv, shrink := g.Generate(r, Size{})
+		if shrink == nil {
+			t.Fatal("IntervalOf().Generate() returned nil shrinker")
+		}
+		if iv.Start > iv.End {
+			t.Fatalf("IntervalOf() = %v, Start after End", iv)
+		}
+		if iv.Start == iv.End {
+			points++
+		} else {
+			ranges++
+		}
+	}
+	if points == 0 || ranges == 0 {
+		t.Errorf("IntervalOf() generated %d points and %d ranges, expected both", points, ranges)
+	}
+}
+
+func TestIntervalOf_Shrink(t *testing.T) {
+	g := IntervalOf(Int(Size{Max: 1000}), intLess)
+	ordered := func(strategy string, iv Interval[int], fails func(Interval[int]) bool) func(Interval[int]) bool {
+		return func(next Interval[int]) bool {
+			if next.Start > next.End {
+				t.Fatalf("%s: %v proposed %v, Start after End", strategy, iv, next)
+			}
+			return fails(next)
+		}
+	}
+	for _, strategy := range []string{"bfs", "dfs"} {
+		SetShrinkStrategy(strategy)
+		for seed := int64(0); seed < 50; seed++ {
+			// accepting all collapses to a point
+			iv, shrink := g.Generate(rand.New(rand.NewSource(seed)), Size{})
+			min := minimize(iv, shrink, ordered(strategy, iv, func(Interval[int]) bool { return true }))
+			if min.Start != min.End {
+				t.Errorf("%s: %v shrunk to %v, expected a point", strategy, iv, min)
+			}
+
+			// an interval that must be wide shrinks to the minimal width
+			iv, shrink = g.Generate(rand.New(rand.NewSource(seed)), Size{})
+			if iv.End-iv.Start < 10 {
+				continue
+			}
+			// Int's shrinker may propose larger values, so accept only
+			// candidates nearer to zero
+			size := func(iv Interval[int]) int { return absInt(iv.Start) + absInt(iv.End) }
+			min = iv
+			minimize(iv, shrink, ordered(strategy, iv, func(next Interval[int]) bool {
+				if next.End-next.Start < 10 || size(next) > size(min) {
+					return false
+				}
+				min = next
+				return true
+			}))
+			if size(min) >= size(iv) {
+				t.Errorf("%s: %v did not shrink", strategy, iv)
+			}
+			// DFS steps one by one through Int's candidates, within the budget
+			// of minimize only BFS reaches the minimum
+			if strategy == "bfs" && (min.Start != 0 && min.End != 0 || min.End-min.Start > 12) {
+				t.Errorf("%s: %v shrunk to %v, expected an end at 0 and a width near 10", strategy, iv, min)
+			}
+		}
+	}
+	SetShrinkStrategy("bfs")
+}
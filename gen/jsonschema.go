@@ -0,0 +1,826 @@
+package gen
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"net/url"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// jsonTypes are the JSON Schema types, in the order minimal values prefer.
+var jsonTypes = []string{"null", "boolean", "integer", "number", "string", "array", "object"}
+
+// jsonAnnotations are the keywords JSONMatching accepts and ignores.
+var jsonAnnotations = map[string]bool{
+	"$schema": true, "$id": true, "$comment": true, "title": true, "description": true,
+	"default": true, "examples": true, "readOnly": true, "writeOnly": true, "deprecated": true,
+}
+
+// jsonDepth is the nesting depth beyond which JSONMatching generates minimal
+// values, so that recursive schemas stay finite.
+const jsonDepth = 4
+
+// jsonSchema is a compiled schema of the subset JSONMatching supports. A nil
+// *jsonSchema, like the schema true, allows any value.
+type jsonSchema struct {
+	types        []string // nil allows any type
+	enum         []any
+	hasEnum      bool
+	properties   map[string]*jsonSchema
+	propNames    []string // sorted
+	required     []string
+	additional   *jsonSchema // schema of the other properties
+	noAdditional bool
+	items        *jsonSchema
+	minimum      *float64
+	maximum      *float64
+	minLength    int
+	maxLength    int // < 0: unbounded
+	minItems     int
+	maxItems     int // < 0: unbounded
+
+	// finite lists the types of s with a finite instance (see compile)
+	finite []string
+}
+
+// jsonAny is the schema allowing any value.
+var jsonAny = &jsonSchema{maxLength: -1, maxItems: -1, finite: jsonTypes}
+
+// orAny returns s, or jsonAny when s is nil.
+func orAny(s *jsonSchema) *jsonSchema {
+	if s == nil {
+		return jsonAny
+	}
+	return s
+}
+
+// JSONMatching returns a generator of JSON values conforming to the JSON
+// Schema schema, for testing APIs with valid request bodies generated from
+// their published schema. Values are what encoding/json decodes into any:
+// nil, bool, float64, string, []any and map[string]any.
+//
+// The schema may use a practical subset of JSON Schema: type (a name or a
+// list), enum, properties, required, additionalProperties (a boolean or a
+// schema), items (a single schema), minimum, maximum, minLength, maxLength,
+// minItems, maxItems, and $ref to "#" or into definitions or $defs, so
+// schemas may be recursive (nesting stops growing after a few levels).
+// Annotations such as title and description are ignored. Other keywords
+// (allOf, pattern, format, ...) return an error, as does a schema that no
+// finite value matches. Optional properties are included at random, and
+// numbers favor the bounds.
+//
+// Example usage:
+//
+//	bodies, err := gen.JSONMatching(`{
+//	    "type": "object",
+//	    "required": ["name"],
+//	    "properties": {
+//	        "name": {"type": "string", "minLength": 1},
+//	        "age": {"type": "integer", "minimum": 0, "maximum": 150}
+//	    }
+//	}`)
+//	if err != nil {
+//	    t.Fatal(err)
+//	}
+//	propx.ForAll(t, cfg, bodies)(func(t *testing.T, body any) {
+//	    // post body to the handler and check it is accepted
+//	})
+//
+// Shrink: every candidate matches the schema: the minimal value of the
+// schema (required properties and minItems items only, each minimal), then
+// for the value and each value nested in it, its minimal value, an earlier
+// enum value, false for true, numbers toward the allowed value nearest 0,
+// shorter strings (not below minLength) and all-"a" strings, arrays without
+// blocks of items (not below minItems), objects without an optional
+// property.
+func JSONMatching(schema string) (Generator[any], error) {
+	root, err := compileJSONSchema(schema)
+	if err != nil {
+		return nil, fmt.Errorf("gen.JSONMatching: %w", err)
+	}
+	return From(func(r *rand.Rand, sz Size) (any, Shrinker[any]) {
+		if r == nil {
+			r = rand.New(rand.NewSource(rand.Int63())) // #nosec G404 -- Using math/rand for deterministic property-based testing
+		}
+		v := root.generate(r, sz, 0)
+		return cloneJSON(v), jsonShrinker(root, v)
+	}), nil
+}
+
+// MatchesJSONSchema reports whether v matches the JSON Schema schema, in the
+// subset JSONMatching supports. v is converted with encoding/json first, so
+// it may be any value that marshals (structs, ints, ...). It returns an
+// error for a schema JSONMatching rejects or a v that does not marshal.
+func MatchesJSONSchema(schema string, v any) (bool, error) {
+	root, err := compileJSONSchema(schema)
+	if err != nil {
+		return false, fmt.Errorf("gen.MatchesJSONSchema: %w", err)
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return false, fmt.Errorf("gen.MatchesJSONSchema: %w", err)
+	}
+	var norm any
+	if err := json.Unmarshal(b, &norm); err != nil {
+		return false, fmt.Errorf("gen.MatchesJSONSchema: %w", err)
+	}
+	return root.valid(norm), nil
+}
+
+// jsonCompiler compiles the schemas of a document, each once by pointer.
+type jsonCompiler struct {
+	doc     any
+	byPtr   map[string]*jsonSchema
+	all     []*jsonSchema
+	pending map[string]bool // $refs being followed
+}
+
+// compileJSONSchema parses and compiles schema.
+func compileJSONSchema(schema string) (*jsonSchema, error) {
+	var doc any
+	if err := json.Unmarshal([]byte(schema), &doc); err != nil {
+		return nil, fmt.Errorf("invalid schema JSON: %w", err)
+	}
+	c := &jsonCompiler{doc: doc, byPtr: map[string]*jsonSchema{}, pending: map[string]bool{}}
+	root, err := c.at("#")
+	if err != nil {
+		return nil, err
+	}
+
+	// enum values must match the other keywords
+	for _, s := range c.all {
+		if s.hasEnum {
+			s.enum = slices.DeleteFunc(s.enum, func(e any) bool { return !s.valid(e) })
+		}
+	}
+	// which types have a finite instance, by fixpoint: none at first, then
+	// those whose required parts have one
+	for changed := true; changed; {
+		changed = false
+		for _, s := range c.all {
+			if f := s.finiteTypes(); len(f) != len(s.finite) {
+				s.finite, changed = f, true
+			}
+		}
+	}
+	if root.unsatisfiable() {
+		return nil, errors.New("no finite value matches the schema")
+	}
+	return root, nil
+}
+
+// at returns the schema at the JSON Pointer fragment ptr ("#/..."),
+// following $ref.
+func (c *jsonCompiler) at(ptr string) (*jsonSchema, error) {
+	if s, ok := c.byPtr[ptr]; ok {
+		return s, nil
+	}
+	node, err := resolveJSONPointer(c.doc, ptr)
+	if err != nil {
+		return nil, err
+	}
+	if m, ok := node.(map[string]any); ok {
+		if ref, ok := m["$ref"]; ok {
+			target, ok := ref.(string)
+			if !ok || !strings.HasPrefix(target, "#") {
+				return nil, fmt.Errorf("unsupported $ref %v at %s: only local references (\"#...\") are supported", ref, ptr)
+			}
+			for k := range m {
+				if k != "$ref" && k != "definitions" && k != "$defs" && !jsonAnnotations[k] {
+					return nil, fmt.Errorf("unsupported keyword %q alongside $ref at %s", k, ptr)
+				}
+			}
+			if c.pending[ptr] {
+				return nil, fmt.Errorf("circular $ref at %s", ptr)
+			}
+			c.pending[ptr] = true
+			s, err := c.at(target)
+			if err != nil {
+				return nil, err
+			}
+			c.byPtr[ptr] = s
+			for _, k := range []string{"definitions", "$defs"} {
+				if defs, ok := m[k]; ok {
+					if err := c.defs(defs, k, ptr); err != nil {
+						return nil, err
+					}
+				}
+			}
+			return s, nil
+		}
+	}
+	s := &jsonSchema{maxLength: -1, maxItems: -1}
+	c.byPtr[ptr] = s
+	c.all = append(c.all, s)
+	return s, c.parse(s, node, ptr)
+}
+
+// parse fills s from the schema node at ptr.
+func (c *jsonCompiler) parse(s *jsonSchema, node any, ptr string) error {
+	if b, ok := node.(bool); ok {
+		if !b {
+			return fmt.Errorf("unsupported schema false at %s", ptr)
+		}
+		return nil
+	}
+	m, ok := node.(map[string]any)
+	if !ok {
+		return fmt.Errorf("schema at %s is %v, expected an object or a boolean", ptr, node)
+	}
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	for _, k := range keys {
+		v := m[k]
+		var err error
+		switch k {
+		case "type":
+			s.types, err = jsonTypeList(v)
+		case "enum":
+			list, ok := v.([]any)
+			if !ok {
+				err = errors.New("must be an array")
+			}
+			s.enum, s.hasEnum = list, true
+		case "properties":
+			props, ok := v.(map[string]any)
+			if !ok {
+				err = errors.New("must be an object")
+				break
+			}
+			s.properties = map[string]*jsonSchema{}
+			for name := range props {
+				s.propNames = append(s.propNames, name)
+			}
+			slices.Sort(s.propNames)
+			for _, name := range s.propNames {
+				if s.properties[name], err = c.at(ptr + "/properties/" + escapeJSONToken(name)); err != nil {
+					return err
+				}
+			}
+		case "required":
+			list, ok := v.([]any)
+			for _, name := range list {
+				n, isString := name.(string)
+				ok = ok && isString
+				s.required = append(s.required, n)
+			}
+			if !ok {
+				err = errors.New("must be an array of strings")
+			}
+		case "additionalProperties":
+			if b, ok := v.(bool); ok {
+				s.noAdditional = !b
+			} else if s.additional, err = c.at(ptr + "/additionalProperties"); err != nil {
+				return err
+			}
+		case "items":
+			if _, ok := v.([]any); ok {
+				err = errors.New("tuple items (an array of schemas) are not supported")
+			} else if s.items, err = c.at(ptr + "/items"); err != nil {
+				return err
+			}
+		case "minimum", "maximum":
+			f, ok := v.(float64)
+			if !ok {
+				err = errors.New("must be a number")
+			} else if k == "minimum" {
+				s.minimum = &f
+			} else {
+				s.maximum = &f
+			}
+		case "minLength", "maxLength", "minItems", "maxItems":
+			f, ok := v.(float64)
+			if !ok || f < 0 || f != math.Trunc(f) || f > math.MaxInt32 {
+				err = errors.New("must be a non-negative integer")
+				break
+			}
+			switch k {
+			case "minLength":
+				s.minLength = int(f)
+			case "maxLength":
+				s.maxLength = int(f)
+			case "minItems":
+				s.minItems = int(f)
+			case "maxItems":
+				s.maxItems = int(f)
+			}
+		case "definitions", "$defs":
+			if err := c.defs(v, k, ptr); err != nil {
+				return err
+			}
+		default:
+			if !jsonAnnotations[k] {
+				return fmt.Errorf("unsupported keyword %q at %s", k, ptr)
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("%s at %s %w", k, ptr, err)
+		}
+	}
+	return nil
+}
+
+// defs compiles the schemas of the definitions v of keyword k at ptr.
+func (c *jsonCompiler) defs(v any, k, ptr string) error {
+	defs, ok := v.(map[string]any)
+	if !ok {
+		return fmt.Errorf("%s at %s must be an object", k, ptr)
+	}
+	names := make([]string, 0, len(defs))
+	for name := range defs {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		if _, err := c.at(ptr + "/" + k + "/" + escapeJSONToken(name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// jsonTypeList parses the value of the type keyword.
+func jsonTypeList(v any) ([]string, error) {
+	list, ok := v.([]any)
+	if !ok {
+		list = []any{v}
+	}
+	var types []string
+	for _, t := range list {
+		name, ok := t.(string)
+		if !ok || !slices.Contains(jsonTypes, name) {
+			return nil, fmt.Errorf("has unknown type %v", t)
+		}
+		types = append(types, name)
+	}
+	return types, nil
+}
+
+// resolveJSONPointer returns the node of doc at the fragment ptr.
+func resolveJSONPointer(doc any, ptr string) (any, error) {
+	frag, err := url.PathUnescape(strings.TrimPrefix(ptr, "#"))
+	if err != nil || frag != "" && !strings.HasPrefix(frag, "/") {
+		return nil, fmt.Errorf("unsupported $ref %q", ptr)
+	}
+	node := doc
+	for _, tok := range strings.Split(frag, "/")[1:] {
+		tok = strings.NewReplacer("~1", "/", "~0", "~").Replace(tok)
+		switch n := node.(type) {
+		case map[string]any:
+			var ok bool
+			if node, ok = n[tok]; !ok {
+				return nil, fmt.Errorf("$ref %q not found", ptr)
+			}
+		case []any:
+			i, err := strconv.Atoi(tok)
+			if err != nil || i < 0 || i >= len(n) {
+				return nil, fmt.Errorf("$ref %q not found", ptr)
+			}
+			node = n[i]
+		default:
+			return nil, fmt.Errorf("$ref %q not found", ptr)
+		}
+	}
+	return node, nil
+}
+
+// escapeJSONToken escapes a property name as a JSON Pointer token.
+func escapeJSONToken(s string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(s)
+}
+
+// typeOptions returns the types s allows.
+func (s *jsonSchema) typeOptions() []string {
+	if s.types == nil {
+		return jsonTypes
+	}
+	return s.types
+}
+
+// unsatisfiable reports whether no finite value matches s.
+func (s *jsonSchema) unsatisfiable() bool {
+	if s.hasEnum {
+		return len(s.enum) == 0
+	}
+	return len(s.finite) == 0
+}
+
+// propSchema returns the schema of property name and whether s allows it.
+func (s *jsonSchema) propSchema(name string) (*jsonSchema, bool) {
+	if p, ok := s.properties[name]; ok {
+		return p, true
+	}
+	return s.additional, !s.noAdditional
+}
+
+// finiteTypes returns the types of s with a finite instance, given the
+// current finite of the other schemas.
+func (s *jsonSchema) finiteTypes() []string {
+	var out []string
+	for _, t := range s.typeOptions() {
+		ok := true
+		switch t {
+		case "integer", "number":
+			lo, hi := s.bounds(t == "integer")
+			ok = lo <= hi
+		case "string":
+			ok = s.maxLength < 0 || s.minLength <= s.maxLength
+		case "array":
+			ok = (s.maxItems < 0 || s.minItems <= s.maxItems) &&
+				(s.minItems == 0 || !orAny(s.items).unsatisfiable())
+		case "object":
+			for _, name := range s.required {
+				p, allowed := s.propSchema(name)
+				ok = ok && allowed && !orAny(p).unsatisfiable()
+			}
+		}
+		if ok {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// bounds returns the range of numbers of s, ±Inf when unbounded; integer
+// rounds it inward to integers.
+func (s *jsonSchema) bounds(integer bool) (lo, hi float64) {
+	lo, hi = math.Inf(-1), math.Inf(1)
+	if s.minimum != nil {
+		lo = *s.minimum
+	}
+	if s.maximum != nil {
+		hi = *s.maximum
+	}
+	if integer {
+		lo, hi = math.Ceil(lo), math.Floor(hi)
+	}
+	return lo, hi
+}
+
+// generate draws a value of s, minimal beyond jsonDepth.
+func (s *jsonSchema) generate(r *rand.Rand, sz Size, depth int) any {
+	if s.hasEnum {
+		return cloneJSON(s.enum[r.Intn(len(s.enum))])
+	}
+	if depth >= jsonDepth {
+		return s.minimal()
+	}
+	switch t := s.finite[r.Intn(len(s.finite))]; t {
+	case "boolean":
+		return r.Intn(2) == 0
+	case "integer", "number":
+		return s.generateNumber(r, t == "integer")
+	case "string":
+		hi := s.minLength + 8
+		if s.maxLength >= 0 {
+			hi = min(hi, s.maxLength)
+		}
+		n := s.minLength + r.Intn(hi-s.minLength+1)
+		sz.Budget.Spend(n)
+		b := make([]rune, n)
+		for i := range b {
+			b[i] = jsonStringRunes[r.Intn(len(jsonStringRunes))]
+		}
+		return string(b)
+	case "array":
+		items := orAny(s.items)
+		hi := s.minItems + 4
+		if s.maxItems >= 0 {
+			hi = min(hi, s.maxItems)
+		}
+		n := s.minItems
+		if !items.unsatisfiable() {
+			n += r.Intn(hi - s.minItems + 1)
+		}
+		sz.Budget.Spend(n)
+		out := make([]any, n)
+		for i := range out {
+			out[i] = items.generate(r, sz, depth+1)
+		}
+		return out
+	case "object":
+		out := map[string]any{}
+		for _, name := range s.required {
+			p, _ := s.propSchema(name)
+			out[name] = orAny(p).generate(r, sz, depth+1)
+		}
+		for _, name := range s.propNames {
+			p := s.properties[name]
+			if _, ok := out[name]; !ok && !p.unsatisfiable() && r.Intn(2) == 0 {
+				out[name] = p.generate(r, sz, depth+1)
+			}
+		}
+		if s.additional != nil && !s.additional.unsatisfiable() {
+			for i := r.Intn(3); i > 0; i-- {
+				name := fmt.Sprintf("extra%d", r.Intn(100))
+				if _, ok := out[name]; !ok && s.properties[name] == nil {
+					out[name] = s.additional.generate(r, sz, depth+1)
+				}
+			}
+		}
+		sz.Budget.Spend(len(out))
+		return out
+	}
+	return nil
+}
+
+// jsonStringRunes are the characters of generated strings, including ones
+// JSON escapes and multi-byte ones.
+var jsonStringRunes = []rune("abcdefghijklmnopqrstuvwxyzABCXYZ0123456789 _-./\"\\\n\té世😀")
+
+// generateNumber draws a number of s, a bound one time in two; unbounded
+// sides extend 1000 past the other bound or 0.
+func (s *jsonSchema) generateNumber(r *rand.Rand, integer bool) float64 {
+	lo, hi := s.bounds(integer)
+	switch {
+	case math.IsInf(lo, -1) && math.IsInf(hi, 1):
+		lo, hi = -1000, 1000
+	case math.IsInf(lo, -1):
+		lo = min(hi, 0) - 1000
+	case math.IsInf(hi, 1):
+		hi = max(lo, 0) + 1000
+	}
+	switch r.Intn(4) {
+	case 0:
+		return lo
+	case 1:
+		return hi
+	}
+	v := lo + r.Float64()*(hi-lo)
+	if integer || r.Intn(2) == 0 {
+		v = math.Round(v)
+	}
+	return math.Max(lo, math.Min(hi, v))
+}
+
+// minimal returns the simplest value of s: its first enum value, or a value
+// of its first finite type nearest to null, false, 0, "a"s, minItems minimal
+// items or the required properties, minimal.
+func (s *jsonSchema) minimal() any {
+	if s.hasEnum {
+		return cloneJSON(s.enum[0])
+	}
+	switch t := s.finite[0]; t {
+	case "boolean":
+		return false
+	case "integer", "number":
+		return s.nearestZero(t == "integer")
+	case "string":
+		return strings.Repeat("a", s.minLength)
+	case "array":
+		out := make([]any, s.minItems)
+		for i := range out {
+			out[i] = orAny(s.items).minimal()
+		}
+		return out
+	case "object":
+		out := map[string]any{}
+		for _, name := range s.required {
+			p, _ := s.propSchema(name)
+			out[name] = orAny(p).minimal()
+		}
+		return out
+	}
+	return nil
+}
+
+// nearestZero returns the number of s nearest to 0.
+func (s *jsonSchema) nearestZero(integer bool) float64 {
+	lo, hi := s.bounds(integer)
+	return math.Max(lo, math.Min(hi, 0))
+}
+
+// valid reports whether v (as decoded by encoding/json) matches s.
+func (s *jsonSchema) valid(v any) bool {
+	if s == nil {
+		return true
+	}
+	if s.hasEnum && !slices.ContainsFunc(s.enum, func(e any) bool { return reflect.DeepEqual(e, v) }) {
+		return false
+	}
+	if s.types != nil {
+		t := jsonTypeOf(v)
+		if !slices.Contains(s.types, t) && !(t == "number" && slices.Contains(s.types, "integer") && v == math.Trunc(v.(float64))) {
+			return false
+		}
+	}
+	switch x := v.(type) {
+	case nil, bool:
+	case float64:
+		if math.IsNaN(x) || math.IsInf(x, 0) || s.minimum != nil && x < *s.minimum || s.maximum != nil && x > *s.maximum {
+			return false
+		}
+	case string:
+		n := utf8.RuneCountInString(x)
+		if n < s.minLength || s.maxLength >= 0 && n > s.maxLength {
+			return false
+		}
+	case []any:
+		if len(x) < s.minItems || s.maxItems >= 0 && len(x) > s.maxItems {
+			return false
+		}
+		for _, e := range x {
+			if !s.items.valid(e) {
+				return false
+			}
+		}
+	case map[string]any:
+		for _, name := range s.required {
+			if _, ok := x[name]; !ok {
+				return false
+			}
+		}
+		for name, e := range x {
+			p, allowed := s.propSchema(name)
+			if !allowed || !p.valid(e) {
+				return false
+			}
+		}
+	default:
+		return false
+	}
+	return true
+}
+
+// jsonTypeOf returns the JSON Schema type of v, "number" for all numbers.
+func jsonTypeOf(v any) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	}
+	return ""
+}
+
+// cloneJSON deep-copies a decoded JSON value.
+func cloneJSON(v any) any {
+	switch x := v.(type) {
+	case []any:
+		out := make([]any, len(x))
+		for i, e := range x {
+			out[i] = cloneJSON(e)
+		}
+		return out
+	case map[string]any:
+		out := make(map[string]any, len(x))
+		for k, e := range x {
+			out[k] = cloneJSON(e)
+		}
+		return out
+	}
+	return v
+}
+
+// jsonKey renders v for deduplication; encoding/json sorts the keys.
+func jsonKey(v any) string {
+	b, _ := json.Marshal(v)
+	return string(b)
+}
+
+// candidates returns the shrink candidates of v under s, simplest first,
+// each a whole new value sharing the untouched parts of v.
+func (s *jsonSchema) candidates(v any) []any {
+	s = orAny(s)
+	out := []any{s.minimal()}
+	if s.hasEnum {
+		for _, e := range s.enum {
+			if reflect.DeepEqual(e, v) {
+				break
+			}
+			out = append(out, e)
+		}
+		return out
+	}
+	switch x := v.(type) {
+	case bool:
+		if x {
+			out = append(out, false)
+		}
+	case float64:
+		if t := math.Trunc(x); t != x {
+			out = append(out, t)
+		} else {
+			// halfway, a quarter of the way, ... one step toward the target
+			for d := x - s.nearestZero(true); math.Abs(d) >= 1; d = math.Trunc(d / 2) {
+				out = append(out, x-d)
+			}
+		}
+	case string:
+		runes := []rune(x)
+		for _, n := range []int{len(runes) / 2, len(runes) - 1} {
+			if n >= s.minLength && n >= 0 && n < len(runes) {
+				out = append(out, string(runes[:n]))
+			}
+		}
+		if a := strings.Repeat("a", len(runes)); a != x {
+			out = append(out, a)
+		}
+	case []any:
+		for chunk := max(len(x)/2, 1); len(x) > 0 && chunk >= 1; chunk /= 2 {
+			for i := 0; i+chunk <= len(x) && len(x)-chunk >= s.minItems; i += chunk {
+				out = append(out, slices.Concat(x[:i], x[i+chunk:]))
+			}
+		}
+		for i, e := range x {
+			for _, c := range s.items.candidates(e) {
+				cp := slices.Clone(x)
+				cp[i] = c
+				out = append(out, cp)
+			}
+		}
+	case map[string]any:
+		names := make([]string, 0, len(x))
+		for name := range x {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+		for _, name := range names {
+			if !slices.Contains(s.required, name) {
+				cp := make(map[string]any, len(x))
+				for k, e := range x {
+					if k != name {
+						cp[k] = e
+					}
+				}
+				out = append(out, cp)
+			}
+		}
+		for _, name := range names {
+			p, _ := s.propSchema(name)
+			for _, c := range p.candidates(x[name]) {
+				cp := make(map[string]any, len(x))
+				for k, e := range x {
+					cp[k] = e
+				}
+				cp[name] = c
+				out = append(out, cp)
+			}
+		}
+	}
+	return out
+}
+
+// jsonShrinker creates a shrinker proposing the candidates of the current
+// minimum that match root, rebasing on accept.
+func jsonShrinker(root *jsonSchema, initial any) Shrinker[any] {
+	queue := make([]any, 0, 64)
+	tried := map[string]struct{}{jsonKey(initial): {}}
+	var queued map[string]struct{}
+	cur := initial
+	var last any
+
+	grow := func(base any) {
+		queue = queue[:0]
+		queued = map[string]struct{}{}
+		for _, c := range root.candidates(base) {
+			k := jsonKey(c)
+			if _, ok := tried[k]; ok {
+				continue
+			}
+			if _, ok := queued[k]; ok || !root.valid(c) {
+				continue
+			}
+			queued[k] = struct{}{}
+			queue = append(queue, c)
+		}
+	}
+	grow(cur)
+
+	return func(accept bool) (any, bool) {
+		if accept {
+			cur = last
+			grow(cur)
+		}
+		if len(queue) == 0 {
+			return nil, false
+		}
+		if GetShrinkStrategy() == ShrinkStrategyDFS {
+			last = queue[len(queue)-1]
+			queue = queue[:len(queue)-1]
+		} else {
+			last = queue[0]
+			queue = queue[1:]
+		}
+		tried[jsonKey(last)] = struct{}{}
+		return cloneJSON(last), true
+	}
+}
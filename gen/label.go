@@ -0,0 +1,40 @@
+// File: gen/label.go
+package gen
+
+// Labeler is implemented by generators that carry a human-readable name.
+// The runner uses labels in diagnostics (e.g. when a generator panics).
+type Labeler interface {
+	Label() string
+}
+
+// labeled wraps a generator and attaches a label to it.
+type labeled[T any] struct {
+	Generator[T]
+	label string
+}
+
+// Label returns the label attached to the generator.
+func (l labeled[T]) Label() string { return l.label }
+
+// Label attaches a name to a generator without changing its values or shrinking.
+// A labeled Filter reports its rejected values under the name (see
+// Size.Filters).
+//
+// Example:
+//
+//	users := gen.Label(userGen, "users")
+func Label[T any](g Generator[T], name string) Generator[T] {
+	if f, ok := g.(filter[T]); ok {
+		f.label = name
+		g = f
+	}
+	return labeled[T]{Generator: g, label: name}
+}
+
+// LabelOf returns the label of g, or "" if g is not labeled.
+func LabelOf(g any) string {
+	if l, ok := g.(Labeler); ok {
+		return l.Label()
+	}
+	return ""
+}
@@ -0,0 +1,29 @@
+package gen
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestLabel(t *testing.T) {
+	g := Label(Const(7), "seven")
+	r := rand.New(rand.NewSource(123))
+
+	value, shrink := g.Generate(r, Size{})
+
+	if value != 7 {
+		t.Errorf("Label().Generate() = %d, expected 7", value)
+	}
+	if shrink == nil {
+		t.Error("Label().Generate() returned nil shrinker")
+	}
+	if got := LabelOf(g); got != "seven" {
+		t.Errorf("LabelOf() = %q, expected 'seven'", got)
+	}
+}
+
+func TestLabelOf_Unlabeled(t *testing.T) {
+	if got := LabelOf(Bool()); got != "" {
+		t.Errorf("LabelOf() = %q, expected empty label", got)
+	}
+}
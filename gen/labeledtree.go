@@ -0,0 +1,221 @@
+package gen
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+)
+
+// Node is a node of a tree of any arity, carrying a label.
+type Node[L any] struct {
+	Label    L
+	Children []*Node[L]
+}
+
+// labeledSpec is the generated tree with the shrinker of each label. Specs
+// are never modified once built: edits copy the path to the changed subtree.
+type labeledSpec[L any] struct {
+	label    L
+	shrink   Shrinker[L]
+	children []*labeledSpec[L]
+}
+
+// LabeledTree generates trees of labels from labelGen, at most maxDepth
+// levels deep (the root alone has depth 1), where each node has between 0
+// and branchingFactor children, drawn uniformly. Unlike BinaryTree, the
+// children are an ordered list of any length, like a component tree. The
+// depth limit is drawn uniformly in [1, maxDepth]; trees may have up to
+// branchingFactor^(maxDepth-1) leaves, so keep both moderate.
+// Panics if branchingFactor < 0 or maxDepth < 1.
+//
+// Example usage:
+//
+//	components := gen.LabeledTree(gen.OneOf(gen.Const("div"), gen.Const("span"), gen.Const("img")), 4, 5)
+//	propx.ForAll(t, cfg, components)(func(t *testing.T, root *gen.Node[string]) {
+//	    if err := Render(io.Discard, root); err != nil {
+//	        t.Fatalf("Render: %v", err)
+//	    }
+//	})
+//
+// Shrink: (1) replaces each subtree with its root alone or with one of its
+// children, and drops each child; (2) shrinks the labels in place
+// (pre-order) with labelGen's shrinker.
+func LabeledTree[L any](labelGen Generator[L], branchingFactor, maxDepth int) Generator[*Node[L]] {
+	if branchingFactor < 0 {
+		panic(fmt.Sprintf("gen.LabeledTree: branchingFactor=%d, must be >= 0", branchingFactor))
+	}
+	if maxDepth < 1 {
+		panic(fmt.Sprintf("gen.LabeledTree: maxDepth=%d, must be >= 1", maxDepth))
+	}
+	return From(func(r *rand.Rand, sz Size) (*Node[L], Shrinker[*Node[L]]) {
+		if r == nil {
+			r = rand.New(rand.NewSource(rand.Int63())) // #nosec G404 -- Using math/rand for deterministic property-based testing
+		}
+		var build func(d int) *labeledSpec[L]
+		build = func(d int) *labeledSpec[L] {
+			sz.Budget.Spend(1)
+			v, s := labelGen.Generate(r, sz.elem())
+			t := &labeledSpec[L]{label: v, shrink: s}
+			if d > 1 {
+				for k := r.Intn(branchingFactor + 1); k > 0; k-- {
+					t.children = append(t.children, build(d-1))
+				}
+			}
+			return t
+		}
+		root := build(1 + r.Intn(maxDepth))
+		return root.render(nil), createLabeledTreeShrinker(root)
+	})
+}
+
+// createLabeledTreeShrinker creates a shrinker that prunes subtrees, then
+// shrinks the labels in place.
+func createLabeledTreeShrinker[L any](initial *labeledSpec[L]) Shrinker[*Node[L]] {
+	// phase (1): pruning, queue-based with rebase on accept
+	queue := make([]*labeledSpec[L], 0, 32)
+	tried := map[string]struct{}{initial.key(): {}}
+	var queued map[string]struct{}
+	cur := initial
+	var last *labeledSpec[L]
+	hasLast := false
+
+	push := func(t *labeledSpec[L]) {
+		k := t.key()
+		if _, ok := tried[k]; ok {
+			return
+		}
+		if _, ok := queued[k]; ok {
+			return
+		}
+		queued[k] = struct{}{}
+		queue = append(queue, t)
+	}
+
+	// prunings of t: its root alone, one of its children, or t without one
+	// of its children
+	prunings := func(t *labeledSpec[L]) []*labeledSpec[L] {
+		if len(t.children) == 0 {
+			return nil
+		}
+		out := []*labeledSpec[L]{{label: t.label, shrink: t.shrink}}
+		out = append(out, t.children...)
+		for i := range t.children {
+			n := *t
+			n.children = append(append([]*labeledSpec[L](nil), t.children[:i]...), t.children[i+1:]...)
+			out = append(out, &n)
+		}
+		return out
+	}
+	grow := func(base *labeledSpec[L]) {
+		queue = queue[:0]
+		queued = map[string]struct{}{}
+		for _, t := range base.replacements(prunings) {
+			push(t)
+		}
+	}
+	grow(cur)
+
+	pop := func() (*labeledSpec[L], bool) {
+		if len(queue) == 0 {
+			return nil, false
+		}
+		var v *labeledSpec[L]
+		if GetShrinkStrategy() == ShrinkStrategyDFS {
+			v = queue[len(queue)-1]
+			queue = queue[:len(queue)-1]
+		} else {
+			v = queue[0]
+			queue = queue[1:]
+		}
+		tried[v.key()] = struct{}{}
+		return v, true
+	}
+
+	// phase (2): label i (pre-order) in place with its own shrinker
+	pruning := true
+	var labels []L
+	var each func(accept bool) (int, L, bool)
+
+	return func(accept bool) (*Node[L], bool) {
+		if pruning {
+			if accept && hasLast {
+				cur = last
+				grow(cur)
+			}
+			if t, ok := pop(); ok {
+				last, hasLast = t, true
+				return t.render(nil), true
+			}
+			pruning = false
+			accept = false
+			nodes := cur.preorder(nil)
+			labels = make([]L, len(nodes))
+			for i, n := range nodes {
+				labels[i] = n.label
+			}
+			each = shrinkInPlace(len(nodes), func(i int) Shrinker[L] { return nodes[i].shrink }, nil, func(i int, v L) { labels[i] = v })
+		}
+
+		i, v, ok := each(accept)
+		if !ok {
+			return nil, false
+		}
+		cand := append([]L(nil), labels...)
+		cand[i] = v
+		return cur.render(&cand), true
+	}
+}
+
+// replacements returns the trees made by replacing one subtree s of t with
+// each of f(s), sharing the untouched parts of t.
+func (t *labeledSpec[L]) replacements(f func(*labeledSpec[L]) []*labeledSpec[L]) []*labeledSpec[L] {
+	out := f(t)
+	for i, c := range t.children {
+		for _, rc := range c.replacements(f) {
+			n := *t
+			n.children = append([]*labeledSpec[L](nil), t.children...)
+			n.children[i] = rc
+			out = append(out, &n)
+		}
+	}
+	return out
+}
+
+// preorder appends the nodes of t in pre-order to out.
+func (t *labeledSpec[L]) preorder(out []*labeledSpec[L]) []*labeledSpec[L] {
+	out = append(out, t)
+	for _, c := range t.children {
+		out = c.preorder(out)
+	}
+	return out
+}
+
+// render builds the *Node tree, taking the labels from *labels (in
+// pre-order, consumed as it goes) when labels is not nil.
+func (t *labeledSpec[L]) render(labels *[]L) *Node[L] {
+	n := &Node[L]{Label: t.label}
+	if labels != nil {
+		n.Label, *labels = (*labels)[0], (*labels)[1:]
+	}
+	for _, c := range t.children {
+		n.Children = append(n.Children, c.render(labels))
+	}
+	return n
+}
+
+// key renders the shape and labels of t as text, for deduplication.
+func (t *labeledSpec[L]) key() string {
+	var b strings.Builder
+	t.writeKey(&b)
+	return b.String()
+}
+
+// writeKey writes the key of t to b.
+func (t *labeledSpec[L]) writeKey(b *strings.Builder) {
+	fmt.Fprintf(b, "(%#v", t.label)
+	for _, c := range t.children {
+		b.WriteByte(' ')
+		c.writeKey(b)
+	}
+	b.WriteByte(')')
+}
@@ -0,0 +1,126 @@
+package gen
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// nodeDepth returns the number of levels of t.
+func nodeDepth[L any](t *Node[L]) int {
+	d := 0
+	for _, c := range t.Children {
+		d = max(d, nodeDepth(c))
+	}
+	return 1 + d
+}
+
+// nodeLabels returns the labels of t in pre-order.
+func nodeLabels[L any](t *Node[L]) []L {
+	out := []L{t.Label}
+	for _, c := range t.Children {
+		out = append(out, nodeLabels(c)...)
+	}
+	return out
+}
+
+// maxArity returns the largest number of children of a node of t.
+func maxArity[L any](t *Node[L]) int {
+	n := len(t.Children)
+	for _, c := range t.Children {
+		n = max(n, maxArity(c))
+	}
+	return n
+}
+
+// minimalLabels reports whether each label is "" or "a".
+func minimalLabels(labels []string) bool {
+	for _, l := range labels {
+		if l != "" && l != "a" {
+			return false
+		}
+	}
+	return true
+}
+
+func TestLabeledTree_Shapes(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	g := LabeledTree(IntRange(0, 9), 4, 4)
+	wide, deep := 0, 0
+	for i := 0; i < 300; i++ {
+		tree, shrink := g.Generate(r, Size{})
+		if shrink == nil {
+			t.Fatal("LabeledTree().Generate() returned nil shrinker")
+		}
+		if d := nodeDepth(tree); d > 4 {
+			t.Fatalf("LabeledTree(maxDepth=4) has depth %d", d)
+		} else if d == 4 {
+			deep++
+		}
+		if a := maxArity(tree); a > 4 {
+			t.Fatalf("LabeledTree(branchingFactor=4) has a node with %d children", a)
+		} else if a > 2 {
+			wide++
+		}
+		for _, v := range nodeLabels(tree) {
+			if v < 0 || v > 9 {
+				t.Fatalf("LabeledTree() label %d, expected in [0, 9]", v)
+			}
+		}
+	}
+	if wide < 40 || deep < 40 {
+		t.Errorf("LabeledTree() made %d trees with more than two children and %d of depth 4 in 300, expected both often", wide, deep)
+	}
+}
+
+func TestLabeledTree_Panics(t *testing.T) {
+	for _, tt := range []struct{ branching, depth int }{{-1, 3}, {2, 0}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("LabeledTree(branchingFactor=%d, maxDepth=%d) did not panic", tt.branching, tt.depth)
+				}
+			}()
+			LabeledTree(Int(Size{}), tt.branching, tt.depth)
+		}()
+	}
+}
+
+func TestLabeledTree_Shrink(t *testing.T) {
+	// fails while some node has three children: minimal is a root with
+	// three leaves, all labeled "a" (or "" when generated empty)
+	var fails func(t *Node[string]) bool
+	fails = func(t *Node[string]) bool {
+		if len(t.Children) >= 3 {
+			return true
+		}
+		for _, c := range t.Children {
+			if fails(c) {
+				return true
+			}
+		}
+		return false
+	}
+	g := LabeledTree(StringAlpha(Size{Max: 5}), 5, 4)
+	for _, strategy := range []string{"bfs", "dfs"} {
+		SetShrinkStrategy(strategy)
+		r := rand.New(rand.NewSource(2))
+		for found := 0; found < 10; {
+			tree, shrink := g.Generate(r, Size{})
+			if !fails(tree) {
+				continue
+			}
+			found++
+			min := minimize(tree, shrink, fails)
+			if labels := nodeLabels(min); len(labels) != 4 || nodeDepth(min) != 2 || !minimalLabels(labels) {
+				t.Errorf("%s: shrunk tree has labels %q and depth %d, expected four minimal labels and 2", strategy, labels, nodeDepth(min))
+			}
+		}
+
+		// accepting all converges to a single node
+		tree, shrink := g.Generate(r, Size{})
+		if min := minimize(tree, shrink, func(*Node[string]) bool { return true }); len(min.Children) != 0 || !minimalLabels(nodeLabels(min)) {
+			t.Errorf("%s: %q shrunk to %q, expected a single node with a minimal label", strategy, nodeLabels(tree), nodeLabels(min))
+		}
+	}
+	SetShrinkStrategy("bfs")
+}
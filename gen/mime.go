@@ -0,0 +1,428 @@
+// File: gen/mime.go
+package gen
+
+import (
+	"encoding/base64"
+	"fmt"
+	"math/rand"
+	"mime"
+	"mime/quotedprintable"
+	"net/mail"
+	"strings"
+	"time"
+)
+
+// mimeBoundaryAlphabet contains the boundary characters of RFC 2046 except
+// space, including the ones that force quoting in Content-Type.
+const mimeBoundaryAlphabet = AlphabetAlphaNum + "'()+_,-./:=?"
+
+// mimeWords are the words of generated text bodies and subjects; the
+// non-ASCII ones only appear in quoted-printable and base64 parts.
+var (
+	mimeWords      = []string{"hello", "world", "invoice", "attached", "see", "below", "regards", "=", "a=b", "tab\there"}
+	mimeWordsUTF8  = []string{"café", "naïve", "日本語", "😀", "Grüße"}
+	mimeFilenames  = []string{"report.pdf", "a b;c.bin", "naïve résumé.txt", "日本.txt", `quote".txt`}
+	mimeSubtypes   = []string{"mixed", "alternative", "related"}
+	mimeMediaTypes = []string{"text/plain", "text/html", "application/octet-stream"}
+	mimeEncodings  = []string{"7bit", "quoted-printable", "base64"}
+)
+
+// mimeMessage is the plain-data description of a message.
+type mimeMessage struct {
+	from, to mail.Address
+	date     time.Time
+	id       string
+	subject  string
+	root     *mimePart // always multipart
+}
+
+// mimePart is a multipart container (parts != nil) or a leaf part.
+type mimePart struct {
+	// multipart
+	subtype            string
+	boundary           string
+	preamble, epilogue string
+	parts              []*mimePart
+
+	// leaf
+	mediaType string
+	encoding  string
+	lines     []string // text content, joined with CRLF
+	data      []byte   // application/octet-stream content
+	filename  string   // attachment filename; "" for inline parts
+	// near adds a first line resembling the enclosing boundary delimiter
+	// without being one: 1 "--b" + "x", 2 "--b" + "-x", 3 "see --b"
+	near int
+}
+
+// MIMEMessage generates structurally valid multipart MIME messages (RFC 2045,
+// 2046) with From, To, Date, Message-ID, Subject and MIME-Version headers and
+// CRLF line endings, which net/mail and mime/multipart parse. Bodies mix
+// multipart/mixed, alternative and related containers with text/plain,
+// text/html and application/octet-stream parts in 7bit, quoted-printable or
+// base64 encoding, some of them attachments with awkward filenames. The
+// cases that break parsers are frequent:
+//   - nested containers whose boundary extends the enclosing one ("b" and
+//     "b1"), and boundaries of characters that need quoting ("a:b=c?")
+//   - body lines resembling a delimiter without being one ("--bx", "--b-x")
+//   - preambles and epilogues, and quoted-printable soft line breaks
+//
+// No line of encapsulated content is ever a delimiter of an enclosing
+// boundary.
+// - size.Max bounds the multipart nesting depth (default 3); the root
+// container is depth 1.
+// Shrink: removes and unwraps parts, then preambles, epilogues, content,
+// filenames and encodings, toward a multipart/mixed message with boundary
+// "a" and a single empty text/plain part.
+func MIMEMessage(size Size) Generator[string] {
+	return From(func(r *rand.Rand, sz Size) (string, Shrinker[string]) {
+		if r == nil {
+			r = rand.New(rand.NewSource(rand.Int63())) // #nosec G404 -- Using math/rand for deterministic property-based testing
+		}
+		if sz.Min != 0 || sz.Max != 0 {
+			size = sz
+		}
+		depth := size.Max
+		if depth <= 0 {
+			depth = 3
+		}
+
+		cur := generateMIMEMessage(r, depth)
+
+		render := (*mimeMessage).render
+		// neighbors: for each part (preorder), a copy of the message with that
+		// part simplified
+		return cur.render(), ShrinkNeighbors(cur, render, render, func(base *mimeMessage, add func(*mimeMessage)) {
+			push := func(m *mimeMessage) {
+				if !mimeCollides(m.root) {
+					add(m)
+				}
+			}
+			parts := base.root.preorder()
+			for i, p := range parts {
+				edit := func(f func(*mimePart)) {
+					c := base.clone()
+					f(c.root.preorder()[i])
+					push(c)
+				}
+				if p.parts != nil {
+					// (1) reduce nesting: replace a nested container with each of
+					// its parts, and the root with each of its containers
+					for j := len(p.parts) - 1; j >= 0; j-- {
+						if i > 0 || p.parts[j].parts != nil {
+							edit(func(m *mimePart) { *m = *m.parts[j] })
+						}
+					}
+					// (2) reduce part count: keep the first part, then drop each (R->L)
+					if len(p.parts) > 1 {
+						edit(func(m *mimePart) { m.parts = m.parts[:1] })
+						for j := len(p.parts) - 1; j >= 0; j-- {
+							edit(func(m *mimePart) { m.parts = append(m.parts[:j], m.parts[j+1:]...) })
+						}
+					}
+					// (3) preamble, epilogue, subtype and boundary
+					if p.preamble != "" || p.epilogue != "" {
+						edit(func(m *mimePart) { m.preamble, m.epilogue = "", "" })
+					}
+					if p.subtype != "mixed" {
+						edit(func(m *mimePart) { m.subtype = "mixed" })
+					}
+					// "b" where "a" collides, as in a container nested in "a"
+					for _, b := range []string{"a", "b"} {
+						if p.boundary == b {
+							break
+						}
+						edit(func(m *mimePart) { m.boundary = b })
+					}
+					continue
+				}
+				// (4) leaf content: all, then each line (R->L)
+				if p.near != 0 {
+					edit(func(m *mimePart) { m.near = 0 })
+				}
+				if len(p.lines) > 0 || len(p.data) > 0 {
+					edit(func(m *mimePart) { m.lines, m.data = nil, nil })
+					if len(p.data) > 1 {
+						edit(func(m *mimePart) { m.data = m.data[:len(m.data)/2] })
+					}
+					for j := len(p.lines) - 1; j >= 0 && len(p.lines) > 1; j-- {
+						edit(func(m *mimePart) { m.lines = append(m.lines[:j], m.lines[j+1:]...) })
+					}
+				}
+				// (5) inline, text/plain, 7bit
+				if p.filename != "" {
+					edit(func(m *mimePart) { m.filename = "" })
+				}
+				if p.mediaType != "text/plain" && len(p.data) == 0 {
+					edit(func(m *mimePart) { m.mediaType = "text/plain" })
+				}
+				if p.encoding != "7bit" && p.mediaType != "application/octet-stream" && mimeASCII(p.lines) {
+					edit(func(m *mimePart) { m.encoding = "7bit" })
+				}
+			}
+			if base.subject != "" {
+				edit := base.clone()
+				edit.subject = ""
+				push(edit)
+			}
+		})
+	})
+}
+
+// generateMIMEMessage builds a random message with at most depth levels of
+// multipart nesting, then replaces boundaries until none collides.
+func generateMIMEMessage(r *rand.Rand, depth int) *mimeMessage {
+	names := []string{"", "Alice", "José Silva", "Doe, John"}
+	m := &mimeMessage{
+		from: mail.Address{Name: names[r.Intn(len(names))], Address: mimeToken(r, AlphabetLower, 1, 8) + "@example.com"},
+		to:   mail.Address{Name: names[r.Intn(len(names))], Address: mimeToken(r, AlphabetLower, 1, 8) + "@example.org"},
+		date: time.Date(2000+r.Intn(30), time.Month(1+r.Intn(12)), 1+r.Intn(28), r.Intn(24), r.Intn(60), r.Intn(60), 0, time.FixedZone("", 3600*(r.Intn(25)-12))),
+		id:   mimeToken(r, AlphabetLower+AlphabetDigits, 8, 16) + "@example.com",
+		root: generateMIMEMultipart(r, depth, ""),
+	}
+	for i, n := 0, r.Intn(4); i < n; i++ {
+		m.subject = strings.TrimSpace(m.subject + " " + mimeWord(r, true))
+	}
+	for mimeCollides(m.root) {
+		for _, p := range m.root.preorder() {
+			if p.parts != nil && mimePartCollides(p) {
+				p.boundary = mimeToken(r, mimeBoundaryAlphabet, 10, 20)
+			}
+		}
+	}
+	return m
+}
+
+// generateMIMEMultipart builds a container of 1..4 parts, nested at most
+// depth levels; a third of nested containers extend the parent boundary.
+func generateMIMEMultipart(r *rand.Rand, depth int, parent string) *mimePart {
+	p := &mimePart{
+		subtype:  mimeSubtypes[r.Intn(len(mimeSubtypes))],
+		boundary: mimeToken(r, mimeBoundaryAlphabet, 1, 30),
+	}
+	if parent != "" && r.Intn(3) == 0 {
+		p.boundary = parent + mimeToken(r, mimeBoundaryAlphabet, 1, 4)
+	}
+	if r.Intn(3) == 0 {
+		p.preamble = "This is a multi-part message in MIME format."
+	}
+	if r.Intn(4) == 0 {
+		p.epilogue = mimeWord(r, false) + "\r\n"
+	}
+	for i, n := 0, 1+r.Intn(4); i < n; i++ {
+		if depth > 1 && r.Intn(3) == 0 {
+			p.parts = append(p.parts, generateMIMEMultipart(r, depth-1, p.boundary))
+		} else {
+			p.parts = append(p.parts, generateMIMELeaf(r))
+		}
+	}
+	return p
+}
+
+// generateMIMELeaf builds a text or binary part.
+func generateMIMELeaf(r *rand.Rand) *mimePart {
+	p := &mimePart{
+		mediaType: mimeMediaTypes[r.Intn(len(mimeMediaTypes))],
+		encoding:  mimeEncodings[r.Intn(len(mimeEncodings))],
+	}
+	if r.Intn(4) == 0 {
+		p.filename = mimeFilenames[r.Intn(len(mimeFilenames))]
+	}
+	if p.mediaType == "application/octet-stream" {
+		p.encoding = "base64"
+		p.data = make([]byte, r.Intn(120))
+		r.Read(p.data)
+		return p
+	}
+	utf8 := p.encoding != "7bit"
+	for i, n := 0, r.Intn(6); i < n; i++ {
+		var words []string
+		// some lines exceed the 76 characters of a quoted-printable line
+		for j, nw := 0, 1+r.Intn(8)*r.Intn(3); j < nw; j++ {
+			words = append(words, mimeWord(r, utf8))
+		}
+		line := strings.Join(words, " ")
+		if r.Intn(6) == 0 {
+			line += " " // trailing space, encoded in quoted-printable
+		}
+		p.lines = append(p.lines, line)
+	}
+	if p.encoding != "base64" && r.Intn(3) == 0 {
+		p.near = 1 + r.Intn(3)
+	}
+	return p
+}
+
+// mimeWord returns a body word, non-ASCII some of the time if utf8.
+func mimeWord(r *rand.Rand, utf8 bool) string {
+	if utf8 && r.Intn(4) == 0 {
+		return mimeWordsUTF8[r.Intn(len(mimeWordsUTF8))]
+	}
+	return mimeWords[r.Intn(len(mimeWords))]
+}
+
+// mimeToken returns minLen..maxLen characters of alphabet.
+func mimeToken(r *rand.Rand, alphabet string, minLen, maxLen int) string {
+	b := make([]byte, minLen+r.Intn(maxLen-minLen+1))
+	for i := range b {
+		b[i] = alphabet[r.Intn(len(alphabet))]
+	}
+	return string(b)
+}
+
+// mimeASCII reports whether the lines are ASCII only.
+func mimeASCII(lines []string) bool {
+	for _, l := range lines {
+		for i := 0; i < len(l); i++ {
+			if l[i] >= 0x80 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// mimeCollides reports whether a container in the tree has content with a
+// line that a parser would take for one of its delimiters.
+func mimeCollides(p *mimePart) bool {
+	for _, q := range p.preorder() {
+		if q.parts != nil && mimePartCollides(q) {
+			return true
+		}
+	}
+	return false
+}
+
+// mimePartCollides reports whether the preamble, epilogue or a part of the
+// container has a line starting with "--" and its boundary followed by the
+// end of the line, whitespace or "--", as mime/multipart matches delimiters.
+func mimePartCollides(p *mimePart) bool {
+	texts := []string{p.preamble, p.epilogue}
+	for _, c := range p.parts {
+		var b strings.Builder
+		c.write(&b, p.boundary)
+		texts = append(texts, b.String())
+	}
+	dash := "--" + p.boundary
+	for _, text := range texts {
+		for _, line := range strings.Split(text, "\n") {
+			rest, ok := strings.CutPrefix(strings.TrimSuffix(line, "\r"), dash)
+			if ok && (rest == "" || rest[0] == ' ' || rest[0] == '\t' || strings.HasPrefix(rest, "--")) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// render serializes the message.
+func (m *mimeMessage) render() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", m.from.String())
+	fmt.Fprintf(&b, "To: %s\r\n", m.to.String())
+	fmt.Fprintf(&b, "Date: %s\r\n", m.date.Format(time.RFC1123Z))
+	fmt.Fprintf(&b, "Message-ID: <%s>\r\n", m.id)
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", m.subject))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	m.root.write(&b, "")
+	return b.String()
+}
+
+// write appends the part headers, a blank line and the encoded body to b;
+// parent is the enclosing boundary.
+func (p *mimePart) write(b *strings.Builder, parent string) {
+	if p.parts != nil {
+		fmt.Fprintf(b, "Content-Type: %s\r\n\r\n", mime.FormatMediaType("multipart/"+p.subtype, map[string]string{"boundary": p.boundary}))
+		if p.preamble != "" {
+			b.WriteString(p.preamble + "\r\n")
+		}
+		for i, c := range p.parts {
+			if i > 0 {
+				b.WriteString("\r\n")
+			}
+			b.WriteString("--" + p.boundary + "\r\n")
+			c.write(b, p.boundary)
+		}
+		b.WriteString("\r\n--" + p.boundary + "--\r\n" + p.epilogue)
+		return
+	}
+
+	ct := p.mediaType
+	if p.mediaType != "application/octet-stream" {
+		ct = mime.FormatMediaType(p.mediaType, map[string]string{"charset": "utf-8"})
+	}
+	fmt.Fprintf(b, "Content-Type: %s\r\n", ct)
+	fmt.Fprintf(b, "Content-Transfer-Encoding: %s\r\n", p.encoding)
+	if p.filename != "" {
+		fmt.Fprintf(b, "Content-Disposition: %s\r\n", mime.FormatMediaType("attachment", map[string]string{"filename": p.filename}))
+	}
+	b.WriteString("\r\n")
+
+	content := p.content(parent)
+	switch p.encoding {
+	case "base64":
+		enc := base64.StdEncoding.EncodeToString(content)
+		for len(enc) > 76 {
+			b.WriteString(enc[:76] + "\r\n")
+			enc = enc[76:]
+		}
+		b.WriteString(enc)
+	case "quoted-printable":
+		w := quotedprintable.NewWriter(b)
+		_, _ = w.Write(content)
+		_ = w.Close()
+	default:
+		b.Write(content)
+	}
+}
+
+// content returns the decoded body of a leaf; parent is the enclosing
+// boundary, which near lines resemble.
+func (p *mimePart) content(parent string) []byte {
+	if p.mediaType == "application/octet-stream" {
+		return p.data
+	}
+	lines := p.lines
+	if p.near != 0 {
+		near := [...]string{"", "--" + parent + "x", "--" + parent + "-x", "see --" + parent}[p.near]
+		lines = append([]string{near}, lines...)
+	}
+	if p.mediaType == "text/html" {
+		html := make([]string, len(lines))
+		for i, l := range lines {
+			html[i] = "<p>" + l + "</p>"
+		}
+		lines = html
+	}
+	return []byte(strings.Join(lines, "\r\n"))
+}
+
+// clone returns a deep copy of the message.
+func (m *mimeMessage) clone() *mimeMessage {
+	c := *m
+	c.root = m.root.clone()
+	return &c
+}
+
+// clone returns a deep copy of the part tree.
+func (p *mimePart) clone() *mimePart {
+	c := *p
+	c.lines = append([]string(nil), p.lines...)
+	c.data = append([]byte(nil), p.data...)
+	if p.parts != nil {
+		c.parts = make([]*mimePart, len(p.parts))
+		for i, ch := range p.parts {
+			c.parts[i] = ch.clone()
+		}
+	}
+	return &c
+}
+
+// preorder lists the parts of the tree in preorder.
+func (p *mimePart) preorder() []*mimePart {
+	out := []*mimePart{p}
+	for _, c := range p.parts {
+		out = append(out, c.preorder()...)
+	}
+	return out
+}
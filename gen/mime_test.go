@@ -0,0 +1,185 @@
+package gen
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io"
+	"math/rand"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"strings"
+	"testing"
+)
+
+// mimeLeaf is a leaf part as parsed by mime/multipart.
+type mimeLeaf struct {
+	mediaType, filename string
+	content             []byte
+}
+
+// parseMIME parses msg with net/mail and mime/multipart and returns its leaf
+// parts in order and its multipart nesting depth.
+func parseMIME(t *testing.T, msg string) ([]mimeLeaf, int) {
+	t.Helper()
+	m, err := mail.ReadMessage(strings.NewReader(msg))
+	if err != nil {
+		t.Fatalf("mail.ReadMessage(%q): %v", msg, err)
+	}
+	if _, err := m.Header.Date(); err != nil {
+		t.Fatalf("Date of %q: %v", msg, err)
+	}
+	for _, h := range []string{"From", "To"} {
+		if _, err := m.Header.AddressList(h); err != nil {
+			t.Fatalf("%s of %q: %v", h, msg, err)
+		}
+	}
+	if _, err := new(mime.WordDecoder).DecodeHeader(m.Header.Get("Subject")); err != nil {
+		t.Fatalf("Subject of %q: %v", msg, err)
+	}
+	mt, params, err := mime.ParseMediaType(m.Header.Get("Content-Type"))
+	if err != nil || !strings.HasPrefix(mt, "multipart/") {
+		t.Fatalf("Content-Type of %q = %q, %v; expected multipart", msg, mt, err)
+	}
+	var leaves []mimeLeaf
+	var walk func(r io.Reader, boundary string, depth int) int
+	walk = func(r io.Reader, boundary string, depth int) int {
+		deepest := depth
+		mr := multipart.NewReader(r, boundary)
+		n := 0
+		for {
+			p, err := mr.NextPart()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("part %d of boundary %q in %q: %v", n, boundary, msg, err)
+			}
+			n++
+			mt, params, err := mime.ParseMediaType(p.Header.Get("Content-Type"))
+			if err != nil {
+				t.Fatalf("Content-Type of part in %q: %v", msg, err)
+			}
+			if strings.HasPrefix(mt, "multipart/") {
+				deepest = max(deepest, walk(p, params["boundary"], depth+1))
+				continue
+			}
+			data, err := io.ReadAll(p)
+			if err == nil && p.Header.Get("Content-Transfer-Encoding") == "base64" {
+				data, err = io.ReadAll(base64.NewDecoder(base64.StdEncoding, bytes.NewReader(data)))
+			}
+			if err != nil {
+				t.Fatalf("body of part in %q: %v", msg, err)
+			}
+			leaves = append(leaves, mimeLeaf{mt, p.FileName(), data})
+		}
+		if n == 0 {
+			t.Fatalf("container with boundary %q in %q has no parts", boundary, msg)
+		}
+		return deepest
+	}
+	depth := walk(m.Body, params["boundary"], 1)
+	return leaves, depth
+}
+
+// expectedLeaves lists the leaves of the tree with their decoded content.
+func expectedLeaves(p *mimePart, parent string) []mimeLeaf {
+	if p.parts == nil {
+		return []mimeLeaf{{p.mediaType, p.filename, p.content(parent)}}
+	}
+	var out []mimeLeaf
+	for _, c := range p.parts {
+		out = append(out, expectedLeaves(c, p.boundary)...)
+	}
+	return out
+}
+
+func TestMIMEMessage_Parses(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	var prefixed, quoted, near, softBreak, nested, attachment bool
+	for i := 0; i < 300; i++ {
+		m := generateMIMEMessage(r, 3)
+		msg := m.render()
+		leaves, depth := parseMIME(t, msg)
+		want := expectedLeaves(m.root, "")
+		if len(leaves) != len(want) {
+			t.Fatalf("%q parsed into %d leaves, expected %d", msg, len(leaves), len(want))
+		}
+		for j := range want {
+			got := leaves[j]
+			if got.mediaType != want[j].mediaType || got.filename != want[j].filename || !bytes.Equal(got.content, want[j].content) {
+				t.Fatalf("leaf %d of %q = %q, expected %q", j, msg, got, want[j])
+			}
+		}
+		if depth > 3 {
+			t.Fatalf("%q has depth %d, expected at most 3", msg, depth)
+		}
+		nested = nested || depth > 1
+		for _, p := range m.root.preorder() {
+			for _, c := range p.parts {
+				prefixed = prefixed || c.parts != nil && strings.HasPrefix(c.boundary, p.boundary)
+				near = near || c.near != 0
+				attachment = attachment || c.filename != ""
+			}
+		}
+		quoted = quoted || strings.Contains(msg, `boundary="`)
+		softBreak = softBreak || strings.Contains(msg, "=\r\n")
+	}
+	if !prefixed || !quoted || !near || !softBreak || !nested || !attachment {
+		t.Errorf("coverage: prefixed=%v quoted=%v near=%v softBreak=%v nested=%v attachment=%v",
+			prefixed, quoted, near, softBreak, nested, attachment)
+	}
+}
+
+func TestMIMEMessage_Depth(t *testing.T) {
+	g := MIMEMessage(Size{Max: 1})
+	r := rand.New(rand.NewSource(2))
+	for i := 0; i < 50; i++ {
+		msg, shrink := g.Generate(r, Size{})
+		if shrink == nil {
+			t.Fatal("MIMEMessage().Generate() returned nil shrinker")
+		}
+		if _, depth := parseMIME(t, msg); depth != 1 {
+			t.Errorf("MIMEMessage(Size{Max: 1}) depth = %d, expected 1", depth)
+		}
+	}
+}
+
+func TestMIMEMessage_Shrink(t *testing.T) {
+	for _, strategy := range []string{"bfs", "dfs"} {
+		SetShrinkStrategy(strategy)
+		r := rand.New(rand.NewSource(3))
+		for i := 0; i < 8; i++ {
+			msg, shrink := MIMEMessage(Size{}).Generate(r, Size{})
+
+			// every candidate parses; accepting all converges to the minimal message
+			min := minimize(msg, shrink, func(next string) bool {
+				parseMIME(t, next)
+				return true
+			})
+			leaves, depth := parseMIME(t, min)
+			if len(leaves) != 1 || depth != 1 || len(leaves[0].content) != 0 || leaves[0].mediaType != "text/plain" ||
+				!strings.Contains(min, "Content-Type: multipart/mixed; boundary=a\r\n") || !strings.Contains(min, "Subject: \r\n") {
+				t.Errorf("%s: %q shrunk to %q, expected a single empty text/plain part", strategy, msg, min)
+			}
+		}
+
+		// shrinking a message with nested containers keeps one container
+		// with one part
+		for found := 0; found < 5; {
+			msg, shrink := MIMEMessage(Size{}).Generate(r, Size{})
+			if _, depth := parseMIME(t, msg); depth < 2 {
+				continue
+			}
+			found++
+			min := minimize(msg, shrink, func(next string) bool {
+				_, depth := parseMIME(t, next)
+				return depth >= 2
+			})
+			if leaves, depth := parseMIME(t, min); len(leaves) != 1 || depth != 2 {
+				t.Errorf("%s: nested %q shrunk to %q with %d leaves and depth %d, expected 1 and 2", strategy, msg, min, len(leaves), depth)
+			}
+		}
+	}
+	SetShrinkStrategy("bfs")
+}
@@ -0,0 +1,79 @@
+// File: gen/money.go
+package gen
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// Money represents a monetary amount stored as integer minor units (cents)
+// plus an ISO-like currency code. Keeping the amount as an integer avoids
+// the rounding surprises of float64 in financial properties.
+type Money struct {
+	// Cents is the amount in minor units (e.g., 1234 = 12.34).
+	Cents int64
+	// Currency is the currency code (e.g., "BRL", "USD").
+	Currency string
+}
+
+// String formats the amount with two decimal places followed by the currency,
+// e.g. "-12.34 BRL". The formatting is exact (no float conversion).
+func (m Money) String() string {
+	sign := ""
+	c := m.Cents
+	if c < 0 {
+		sign = "-"
+	}
+	units, frac := c/100, c%100
+	if units < 0 {
+		units = -units
+	}
+	if frac < 0 {
+		frac = -frac
+	}
+	return fmt.Sprintf("%s%d.%02d %s", sign, units, frac, m.Currency)
+}
+
+// MoneyOf generates Money values with Cents uniformly in [min, max] (inclusive)
+// and a fixed currency code.
+// Shrink: moves Cents toward zero (or the bound closest to zero), keeping the currency.
+//
+// Example:
+//
+//	// amounts between -100.00 and 1000.00 BRL
+//	g := gen.MoneyOf(-10_000, 100_000, "BRL")
+func MoneyOf(min, max int64, currency string) Generator[Money] {
+	if min > max {
+		min, max = max, min
+	}
+	return From(func(r *rand.Rand, _ Size) (Money, Shrinker[Money]) {
+		if r == nil {
+			r = rand.New(rand.NewSource(rand.Int63())) // #nosec G404 -- Using math/rand for deterministic property-based testing
+		}
+		v := uniformInt64(r, min, max)
+		cents, shrink := int64ShrinkInit(v, min, max)
+		return Money{Cents: cents, Currency: currency}, func(accept bool) (Money, bool) {
+			nc, ok := shrink(accept)
+			if !ok {
+				return Money{}, false
+			}
+			return Money{Cents: nc, Currency: currency}, true
+		}
+	})
+}
+
+// uniformInt64 draws an int64 uniformly in [min, max] without overflowing
+// when the span exceeds math.MaxInt64.
+func uniformInt64(r *rand.Rand, min, max int64) int64 {
+	span := uint64(max - min) // #nosec G115 -- wrap-around is intended: max >= min
+	if span < 1<<63-1 {
+		return min + r.Int63n(int64(span)+1) // #nosec G115 -- span fits in int64
+	}
+	// full range: reject values outside [min, max] (only happens on huge spans)
+	for {
+		v := int64(r.Uint64()) // #nosec G115 -- reinterpretation of random bits
+		if v >= min && v <= max {
+			return v
+		}
+	}
+}
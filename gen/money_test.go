@@ -0,0 +1,77 @@
+package gen
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestMoneyOf(t *testing.T) {
+	gen := MoneyOf(-10_000, 100_000, "BRL")
+	r := rand.New(rand.NewSource(123))
+
+	for i := 0; i < 50; i++ {
+		value, shrink := gen.Generate(r, Size{})
+
+		if value.Cents < -10_000 || value.Cents > 100_000 {
+			t.Errorf("MoneyOf().Generate() = %d cents, expected value in range [-10000, 100000]", value.Cents)
+		}
+		if value.Currency != "BRL" {
+			t.Errorf("MoneyOf().Generate() currency = %q, expected 'BRL'", value.Currency)
+		}
+		if shrink == nil {
+			t.Error("MoneyOf().Generate() returned nil shrinker")
+		}
+	}
+}
+
+func TestMoneyOf_ShrinksTowardZero(t *testing.T) {
+	SetShrinkStrategy(ShrinkStrategyBFS)
+	gen := MoneyOf(-10_000, 100_000, "USD")
+	r := rand.New(rand.NewSource(7))
+
+	value, shrink := gen.Generate(r, Size{})
+	if value.Cents == 0 {
+		t.Skip("generated zero; nothing to shrink")
+	}
+
+	// the first candidate proposed is the natural target (zero cents)
+	next, ok := shrink(false)
+	if !ok {
+		t.Fatal("MoneyOf() shrinker returned false on first call")
+	}
+	if next.Cents != 0 {
+		t.Errorf("MoneyOf() first shrink candidate = %d cents, expected 0", next.Cents)
+	}
+	if next.Currency != "USD" {
+		t.Errorf("shrinking changed currency to %q", next.Currency)
+	}
+}
+
+func TestMoneyOf_FullRange(t *testing.T) {
+	gen := MoneyOf(math.MinInt64, math.MaxInt64, "EUR")
+	r := rand.New(rand.NewSource(123))
+
+	for i := 0; i < 20; i++ {
+		if _, shrink := gen.Generate(r, Size{}); shrink == nil {
+			t.Fatal("MoneyOf().Generate() returned nil shrinker")
+		}
+	}
+}
+
+func TestMoney_String(t *testing.T) {
+	tests := []struct {
+		m    Money
+		want string
+	}{
+		{Money{Cents: 0, Currency: "BRL"}, "0.00 BRL"},
+		{Money{Cents: 1234, Currency: "USD"}, "12.34 USD"},
+		{Money{Cents: -5, Currency: "EUR"}, "-0.05 EUR"},
+		{Money{Cents: -100, Currency: "EUR"}, "-1.00 EUR"},
+	}
+	for _, tt := range tests {
+		if got := tt.m.String(); got != tt.want {
+			t.Errorf("Money{%d, %q}.String() = %q, expected %q", tt.m.Cents, tt.m.Currency, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,223 @@
+package gen
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+)
+
+// sortedItem is an element of a NearlySorted slice with its own shrinker,
+// which moves with it when the slice is reordered.
+type sortedItem[T any] struct {
+	v T
+	s Shrinker[T]
+}
+
+// NearlySorted generates mostly sorted []T, for testing adaptive sorting
+// algorithms whose cost depends on the existing order: the elements from g
+// are sorted by less, then disordered with at most inversions out-of-order
+// pairs (i < j with less(s[j], s[i])), the number drawn uniformly in
+// [0, inversions]. 0 generates sorted slices. Elements are displaced by
+// adjacent swaps, so most stay near their sorted position and a few travel
+// further.
+// - size.Min/Max control the length (default Min=0, Max=16), like SliceOf.
+// Panics if inversions < 0.
+//
+// Example usage:
+//
+//	g := gen.NearlySorted(gen.IntRange(0, 1000), func(a, b int) bool { return a < b }, 5, gen.Size{Max: 100})
+//	propx.ForAll(t, cfg, g)(func(t *testing.T, s []int) {
+//	    got := InsertionSort(slices.Clone(s))
+//	    if !slices.IsSorted(got) {
+//	        t.Errorf("InsertionSort(%v) = %v", s, got)
+//	    }
+//	})
+//
+// Shrink: (1) the sorted slice, then removes blocks (half, quarter, ...)
+// and single elements, then undoes one inversion (swapping back an
+// out-of-order adjacent pair); (2) shrinks the elements in place
+// (left→right) with g's shrinker, never adding out-of-order pairs.
+func NearlySorted[T any](g Generator[T], less func(a, b T) bool, inversions int, size Size) Generator[[]T] {
+	if inversions < 0 {
+		panic(fmt.Sprintf("gen.NearlySorted: inversions=%d, must be >= 0", inversions))
+	}
+	return From(func(r *rand.Rand, sz Size) ([]T, Shrinker[[]T]) {
+		if r == nil {
+			r = rand.New(rand.NewSource(rand.Int63())) // #nosec G404 -- Using math/rand for deterministic property-based testing
+		}
+		// defaults
+		size := size
+		if size.Min == 0 && size.Max == 0 {
+			size.Min, size.Max = 0, 16
+		}
+		if sz.Min != 0 || sz.Max != 0 {
+			size = sz
+		}
+		if size.Max < size.Min {
+			size.Max = size.Min
+		}
+
+		n := size.Min
+		if size.Max > size.Min {
+			n += r.Intn(size.Max - size.Min + 1)
+		}
+
+		sz.Budget.Spend(n)
+		items := make([]sortedItem[T], n)
+		for i := range items {
+			v, s := g.Generate(r, sz.elem())
+			items[i] = sortedItem[T]{v: v, s: s}
+		}
+		itemLess := func(a, b sortedItem[T]) int {
+			switch {
+			case less(a.v, b.v):
+				return -1
+			case less(b.v, a.v):
+				return 1
+			}
+			return 0
+		}
+		slices.SortStableFunc(items, itemLess)
+
+		// each swap of an ordered adjacent pair adds exactly one inversion;
+		// an element keeps moving right while the budget lasts, so some
+		// travel far
+		left := r.Intn(inversions + 1)
+		for tries := 0; left > 0 && n > 1 && tries < 4*inversions+n; tries++ {
+			steps := 1 + r.Intn(left)
+			for j := r.Intn(n - 1); steps > 0 && j < n-1 && less(items[j].v, items[j+1].v); j++ {
+				items[j], items[j+1] = items[j+1], items[j]
+				steps--
+				left--
+			}
+		}
+		return itemValues(items), nearlySortedShrinker(items, less, itemLess)
+	})
+}
+
+// nearlySortedShrinker creates a shrinker that reorders and removes elements,
+// then shrinks them in place without adding inversions.
+func nearlySortedShrinker[T any](initial []sortedItem[T], less func(a, b T) bool, itemLess func(a, b sortedItem[T]) int) Shrinker[[]T] {
+	key := func(items []sortedItem[T]) string { return fmt.Sprintf("%#v", itemValues(items)) }
+
+	// phase (1): structure, queue-based with rebase on accept
+	queue := make([][]sortedItem[T], 0, 64)
+	tried := map[string]struct{}{key(initial): {}}
+	var queued map[string]struct{}
+	cur := initial
+	var last []sortedItem[T]
+	hasLast := false
+
+	push := func(items []sortedItem[T]) {
+		k := key(items)
+		if _, ok := tried[k]; ok {
+			return
+		}
+		if _, ok := queued[k]; ok {
+			return
+		}
+		queued[k] = struct{}{}
+		queue = append(queue, items)
+	}
+	without := func(base []sortedItem[T], i, j int) []sortedItem[T] {
+		return slices.Concat(base[:i], base[j:])
+	}
+	grow := func(base []sortedItem[T]) {
+		queue = queue[:0]
+		queued = map[string]struct{}{}
+		L := len(base)
+		sorted := slices.Clone(base)
+		slices.SortStableFunc(sorted, itemLess)
+		push(sorted)
+		for chunk := max(L/2, 1); L > 0 && chunk >= 1; chunk /= 2 {
+			for i := 0; i+chunk <= L; i += chunk {
+				push(without(base, i, i+chunk))
+			}
+		}
+		for i := 0; i+1 < L; i++ {
+			if less(base[i+1].v, base[i].v) {
+				cand := slices.Clone(base)
+				cand[i], cand[i+1] = cand[i+1], cand[i]
+				push(cand)
+			}
+		}
+	}
+	grow(cur)
+
+	pop := func() ([]sortedItem[T], bool) {
+		if len(queue) == 0 {
+			return nil, false
+		}
+		var v []sortedItem[T]
+		if GetShrinkStrategy() == ShrinkStrategyDFS {
+			v = queue[len(queue)-1]
+			queue = queue[:len(queue)-1]
+		} else {
+			v = queue[0]
+			queue = queue[1:]
+		}
+		tried[key(v)] = struct{}{}
+		return v, true
+	}
+
+	// phase (2): each element in place, with its own shrinker; a candidate
+	// adding inversions counts as rejected
+	structural := true
+	curInv := 0 // inversions of cur in phase (2)
+	var each func(accept bool) (int, T, bool)
+
+	return func(accept bool) ([]T, bool) {
+		if structural {
+			if accept && hasLast {
+				cur = last
+				grow(cur)
+			}
+			if items, ok := pop(); ok {
+				last, hasLast = items, true
+				return itemValues(items), true
+			}
+			structural = false
+			accept = false
+			cur = slices.Clone(cur)
+			curInv = countInversions(itemValues(cur), less)
+			each = shrinkInPlace(len(cur), func(i int) Shrinker[T] { return cur[i].s }, func(i int, v T) bool {
+				cand := itemValues(cur)
+				cand[i] = v
+				return countInversions(cand, less) <= curInv
+			}, func(i int, v T) {
+				cur[i].v = v
+				curInv = countInversions(itemValues(cur), less)
+			})
+		}
+
+		i, v, ok := each(accept)
+		if !ok {
+			return nil, false
+		}
+		cand := itemValues(cur)
+		cand[i] = v
+		return cand, true
+	}
+}
+
+// itemValues returns the values of items.
+func itemValues[T any](items []sortedItem[T]) []T {
+	out := make([]T, len(items))
+	for i, it := range items {
+		out[i] = it.v
+	}
+	return out
+}
+
+// countInversions returns the number of pairs i < j with less(s[j], s[i]).
+func countInversions[T any](s []T, less func(a, b T) bool) int {
+	n := 0
+	for i := range s {
+		for j := i + 1; j < len(s); j++ {
+			if less(s[j], s[i]) {
+				n++
+			}
+		}
+	}
+	return n
+}
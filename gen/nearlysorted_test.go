@@ -0,0 +1,93 @@
+package gen
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestNearlySorted_Generation(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	g := NearlySorted(IntRange(0, 1000), intLess, 6, Size{Min: 10, Max: 30})
+	counts := map[int]int{}
+	far := false
+	for i := 0; i < 500; i++ {
+		s, shrink := g.Generate(r, Size{})
+		if shrink == nil {
+			t.Fatal("NearlySorted().Generate() returned nil shrinker")
+		}
+		if len(s) < 10 || len(s) > 30 {
+			t.Fatalf("NearlySorted() length %d, expected in [10, 30]", len(s))
+		}
+		inv := countInversions(s, intLess)
+		if inv > 6 {
+			t.Fatalf("NearlySorted(inversions=6) = %v with %d inversions", s, inv)
+		}
+		counts[inv]++
+
+		// an element more than two places from its sorted position
+		sorted := slices.Sorted(slices.Values(s))
+		for j, v := range s {
+			if k, _ := slices.BinarySearch(sorted, v); k > j+2 || k < j-2 {
+				far = true
+			}
+		}
+	}
+	for inv := 0; inv <= 6; inv++ {
+		if counts[inv] == 0 {
+			t.Errorf("no slice with %d inversions in 500 (counts %v)", inv, counts)
+		}
+	}
+	if !far {
+		t.Error("no element displaced more than two places in 500 slices")
+	}
+
+	sorted, _ := NearlySorted(IntRange(0, 9), intLess, 0, Size{Max: 20}).Generate(r, Size{})
+	if !slices.IsSorted(sorted) {
+		t.Errorf("NearlySorted(inversions=0) = %v, expected sorted", sorted)
+	}
+}
+
+func TestNearlySorted_PanicsOnNegativeInversions(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("NearlySorted(inversions=-1) did not panic")
+		}
+	}()
+	NearlySorted(Int(Size{}), intLess, -1, Size{})
+}
+
+func TestNearlySorted_Shrink(t *testing.T) {
+	g := NearlySorted(IntRange(0, 100), intLess, 8, Size{Min: 5, Max: 20})
+	for _, strategy := range []string{"bfs", "dfs"} {
+		SetShrinkStrategy(strategy)
+		r := rand.New(rand.NewSource(2))
+		for found := 0; found < 10; {
+			s, shrink := g.Generate(r, Size{})
+			if countInversions(s, intLess) < 2 {
+				continue
+			}
+			found++
+
+			// every candidate keeps the bound; a failure needing two
+			// inversions shrinks to exactly two, in three elements or two
+			// swapped pairs
+			min := minimize(s, shrink, func(next []int) bool {
+				if inv := countInversions(next, intLess); inv > 8 {
+					t.Fatalf("%s: %v proposed %v with %d inversions", strategy, s, next, inv)
+				}
+				return countInversions(next, intLess) >= 2
+			})
+			if countInversions(min, intLess) != 2 || len(min) > 4 {
+				t.Errorf("%s: %v shrunk to %v, expected 2 inversions in at most 4 elements", strategy, s, min)
+			}
+		}
+
+		// accepting all converges to the empty slice
+		s, shrink := g.Generate(r, Size{})
+		if min := minimize(s, shrink, func([]int) bool { return true }); len(min) != 0 {
+			t.Errorf("%s: %v shrunk to %v, expected []", strategy, s, min)
+		}
+	}
+	SetShrinkStrategy("bfs")
+}
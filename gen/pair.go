@@ -13,7 +13,8 @@ type Pair[A, B any] struct {
 
 // PairOf creates a generator that produces pairs of values from two generators.
 // The generated pairs will have shrinking capabilities that try to shrink both
-// components independently.
+// components independently. A component whose generator does not shrink
+// (e.g. Const) is left unchanged.
 //
 // Example usage:
 //
@@ -36,6 +37,7 @@ func PairOf[A, B any](ga Generator[A], gb Generator[B]) Generator[Pair[A, B]] {
 		// Generate both values
 		a, sa := ga.Generate(r, sz)
 		b, sb := gb.Generate(r, sz)
+		sa, sb = orNoShrink(sa, a), orNoShrink(sb, b)
 
 		pair := Pair[A, B]{First: a, Second: b}
 
@@ -70,6 +72,27 @@ func PairOf[A, B any](ga Generator[A], gb Generator[B]) Generator[Pair[A, B]] {
 	})
 }
 
+// Coupled generates pairs of a value from ga and the value derive computes
+// from it, for testing related values (an input and its encoding, a string
+// and its reverse) while reporting both. derive must be deterministic.
+// Unlike PairOf, the components are not independent; unlike Map, the
+// counterexample shows the input next to the derived value.
+// Shrink: shrinks First with ga's shrinker, re-deriving Second at every step.
+//
+// Example usage:
+//
+//	g := gen.Coupled(gen.StringAlpha(gen.Size{}), base64.StdEncoding.EncodeToString)
+//	propx.ForAll(t, cfg, g)(func(t *testing.T, p gen.Pair[string, string]) {
+//		if got, _ := base64.StdEncoding.DecodeString(p.Second); string(got) != p.First {
+//			t.Errorf("decoding %q gave %q, expected %q", p.Second, got, p.First)
+//		}
+//	})
+func Coupled[A, B any](ga Generator[A], derive func(A) B) Generator[Pair[A, B]] {
+	return Map(ga, func(a A) Pair[A, B] {
+		return Pair[A, B]{First: a, Second: derive(a)}
+	})
+}
+
 // Tuple is an alias for Pair for better readability in some contexts.
 type Tuple[A, B any] = Pair[A, B]
 
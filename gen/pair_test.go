@@ -158,3 +158,44 @@ func TestPair_StructAccess(t *testing.T) {
 		t.Error("Second field should not be empty")
 	}
 }
+
+func TestCoupled(t *testing.T) {
+	reverse := func(s string) string {
+		b := []byte(s)
+		for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+			b[i], b[j] = b[j], b[i]
+		}
+		return string(b)
+	}
+	r := rand.New(rand.NewSource(42))
+	for i := 0; i < 20; i++ {
+		p, shrink := Coupled(StringAlpha(Size{Min: 2, Max: 10}), reverse).Generate(r, Size{})
+		if p.Second != reverse(p.First) {
+			t.Fatalf("Coupled() = %#v, Second is not derived from First", p)
+		}
+		// every candidate keeps the coupling; shrink until First is minimal
+		accept := false
+		min := p
+		for j := 0; j < 1000; j++ {
+			next, ok := shrink(accept)
+			if !ok {
+				break
+			}
+			if next.Second != reverse(next.First) {
+				t.Fatalf("shrinking %#v proposed uncoupled %#v", p, next)
+			}
+			if accept = len(next.First) >= 2; accept {
+				min = next
+			}
+		}
+		if len(min.First) != 2 {
+			t.Errorf("shrinking %#v ended at %#v, expected a First of length 2", p, min)
+		}
+	}
+
+	// a non-shrinking input leaves nothing to shrink
+	_, shrink := Coupled(Const(3), func(x int) int { return -x }).Generate(r, Size{})
+	if _, ok := shrink(true); ok {
+		t.Error("Coupled(Const) shrinker proposed a candidate")
+	}
+}
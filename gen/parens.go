@@ -0,0 +1,313 @@
+// File: gen/parens.go
+package gen
+
+import (
+	"math/rand"
+	"strings"
+)
+
+// parenKinds are the bracket pairs, the simplest first.
+var parenKinds = [...]string{"()", "[]", "{}"}
+
+// parenNode is a bracket pair and the pairs nested inside it.
+type parenNode struct {
+	kind     int
+	children []parenNode
+}
+
+// BalancedParens generates correctly matched strings of "()", "[]" and "{}"
+// pairs, nested and side by side ("([]{()})[]"), for testing parsers and
+// bracket-matching code.
+// - size.Min/Max control the number of pairs (default Min=0, Max=8).
+// Shrink: toward the empty string, keeping the brackets balanced: removes a
+// pair with its contents, then removes a pair keeping its contents, then
+// turns "[]" and "{}" pairs into "()".
+func BalancedParens(size Size) Generator[string] {
+	return From(func(r *rand.Rand, sz Size) (string, Shrinker[string]) {
+		if r == nil {
+			r = rand.New(rand.NewSource(rand.Int63())) // #nosec G404 -- Using math/rand for deterministic property-based testing
+		}
+		n := parenCount(r, size, sz)
+		cur := generateParens(r, n)
+
+		queue := make([][]parenNode, 0, 32)
+		tried := map[string]struct{}{renderParens(cur): {}}
+		var queued map[string]struct{}
+		var last []parenNode
+		hasLast := false
+
+		push := func(ns []parenNode) {
+			k := renderParens(ns)
+			if _, ok := tried[k]; ok {
+				return
+			}
+			if _, ok := queued[k]; ok {
+				return
+			}
+			queued[k] = struct{}{}
+			queue = append(queue, ns)
+		}
+
+		grow := func(base []parenNode) {
+			queue = queue[:0]
+			queued = map[string]struct{}{}
+			if len(base) > 0 {
+				push(nil)
+			}
+			// (1) remove a pair with its contents
+			for _, ns := range parenEdits(base, func(parenNode) []parenNode { return nil }) {
+				push(ns)
+			}
+			// (2) remove a pair, keeping its contents
+			for _, ns := range parenEdits(base, func(p parenNode) []parenNode { return p.children }) {
+				push(ns)
+			}
+			// (3) simplest kind
+			for _, ns := range parenEdits(base, func(p parenNode) []parenNode {
+				if p.kind == 0 {
+					return []parenNode{p}
+				}
+				return []parenNode{{kind: 0, children: p.children}}
+			}) {
+				push(ns)
+			}
+		}
+		grow(cur)
+
+		pop := func() ([]parenNode, bool) {
+			if len(queue) == 0 {
+				return nil, false
+			}
+			var v []parenNode
+			if GetShrinkStrategy() == ShrinkStrategyDFS {
+				v = queue[len(queue)-1]
+				queue = queue[:len(queue)-1]
+			} else {
+				v = queue[0]
+				queue = queue[1:]
+			}
+			tried[renderParens(v)] = struct{}{}
+			return v, true
+		}
+
+		return renderParens(cur), func(accept bool) (string, bool) {
+			if accept && hasLast {
+				cur = last
+				grow(cur)
+			}
+			nxt, ok := pop()
+			if !ok {
+				return "", false
+			}
+			last, hasLast = nxt, true
+			return renderParens(nxt), true
+		}
+	})
+}
+
+// UnbalancedParens generates bracket strings that are NOT correctly matched,
+// for negative parser tests: a balanced string (as from BalancedParens) with
+// one corruption, chosen at random:
+//   - a bracket removed, or an extra opening or closing bracket inserted
+//   - a closing bracket of the wrong kind ("(]")
+//   - a pair written closing bracket first (")(")
+//
+// - size.Min/Max control the number of pairs before corruption (default
+// Min=0, Max=8).
+// Shrink: removes brackets and turns them into "(" or ")", keeping the
+// string unbalanced, toward a single bracket.
+func UnbalancedParens(size Size) Generator[string] {
+	return From(func(r *rand.Rand, sz Size) (string, Shrinker[string]) {
+		if r == nil {
+			r = rand.New(rand.NewSource(rand.Int63())) // #nosec G404 -- Using math/rand for deterministic property-based testing
+		}
+		n := parenCount(r, size, sz)
+		s := corruptParens(r, renderParens(generateParens(r, n)))
+
+		queue := make([]string, 0, 32)
+		tried := map[string]struct{}{s: {}}
+		var queued map[string]struct{}
+		cur, last := s, s
+
+		push := func(c string) {
+			if parensBalanced(c) {
+				return
+			}
+			if _, ok := tried[c]; ok {
+				return
+			}
+			if _, ok := queued[c]; ok {
+				return
+			}
+			queued[c] = struct{}{}
+			queue = append(queue, c)
+		}
+
+		grow := func(base string) {
+			queue = queue[:0]
+			queued = map[string]struct{}{}
+			// (1) remove a bracket (R->L)
+			for i := len(base) - 1; i >= 0; i-- {
+				push(base[:i] + base[i+1:])
+			}
+			// (2) simplest kind, keeping the direction
+			for i := len(base) - 1; i >= 0; i-- {
+				c := "("
+				if strings.IndexByte(")]}", base[i]) >= 0 {
+					c = ")"
+				}
+				push(base[:i] + c + base[i+1:])
+			}
+		}
+		grow(cur)
+
+		pop := func() (string, bool) {
+			if len(queue) == 0 {
+				return "", false
+			}
+			var v string
+			if GetShrinkStrategy() == ShrinkStrategyDFS {
+				v = queue[len(queue)-1]
+				queue = queue[:len(queue)-1]
+			} else {
+				v = queue[0]
+				queue = queue[1:]
+			}
+			tried[v] = struct{}{}
+			return v, true
+		}
+
+		return s, func(accept bool) (string, bool) {
+			if accept && last != cur {
+				cur = last
+				grow(cur)
+			}
+			nxt, ok := pop()
+			if !ok {
+				return "", false
+			}
+			last = nxt
+			return nxt, true
+		}
+	})
+}
+
+// parenCount resolves the number of pairs from size and the runner's sz.
+func parenCount(r *rand.Rand, size, sz Size) int {
+	if size.Min == 0 && size.Max == 0 {
+		size.Min, size.Max = 0, 8
+	}
+	if sz.Min != 0 || sz.Max != 0 {
+		size = sz
+	}
+	if size.Max < size.Min {
+		size.Max = size.Min
+	}
+	n := size.Min
+	if size.Max > size.Min {
+		n += r.Intn(size.Max - size.Min + 1)
+	}
+	sz.Budget.Spend(n)
+	return n
+}
+
+// generateParens draws n pairs: the first pair holds k of the others, the
+// rest follow it.
+func generateParens(r *rand.Rand, n int) []parenNode {
+	var out []parenNode
+	for n > 0 {
+		k := r.Intn(n)
+		out = append(out, parenNode{kind: r.Intn(len(parenKinds)), children: generateParens(r, k)})
+		n -= k + 1
+	}
+	return out
+}
+
+// corruptParens applies one random corruption to the balanced string s.
+func corruptParens(r *rand.Rand, s string) string {
+	opens, closes := "([{", ")]}"
+	kind := r.Intn(len(parenKinds))
+	switch c := r.Intn(4); {
+	case c == 0 && s != "": // remove a bracket
+		i := r.Intn(len(s))
+		return s[:i] + s[i+1:]
+	case c == 1 && s != "": // wrong closing kind
+		var at []int
+		for i := 0; i < len(s); i++ {
+			if strings.IndexByte(closes, s[i]) >= 0 {
+				at = append(at, i)
+			}
+		}
+		i := at[r.Intn(len(at))]
+		wrong := closes[(strings.IndexByte(closes, s[i])+1+r.Intn(2))%3]
+		return s[:i] + string(wrong) + s[i+1:]
+	case c == 2: // closing bracket first, between top-level pairs
+		at, depth := []int{0}, 0
+		for i := 0; i < len(s); i++ {
+			if strings.IndexByte(opens, s[i]) >= 0 {
+				depth++
+			} else if depth--; depth == 0 {
+				at = append(at, i+1)
+			}
+		}
+		i := at[r.Intn(len(at))]
+		return s[:i] + parenKinds[kind][1:] + parenKinds[kind][:1] + s[i:]
+	default: // extra bracket
+		i := r.Intn(len(s) + 1)
+		b := opens[kind]
+		if r.Intn(2) == 0 {
+			b = closes[kind]
+		}
+		return s[:i] + string(b) + s[i:]
+	}
+}
+
+// parenEdits returns the sequences made by replacing one pair p of ns (at any
+// depth) with f(p).
+func parenEdits(ns []parenNode, f func(parenNode) []parenNode) [][]parenNode {
+	var out [][]parenNode
+	for i, p := range ns {
+		with := func(repl []parenNode) []parenNode {
+			s := make([]parenNode, 0, len(ns)-1+len(repl))
+			s = append(s, ns[:i]...)
+			s = append(s, repl...)
+			return append(s, ns[i+1:]...)
+		}
+		out = append(out, with(f(p)))
+		for _, cs := range parenEdits(p.children, f) {
+			out = append(out, with([]parenNode{{kind: p.kind, children: cs}}))
+		}
+	}
+	return out
+}
+
+// renderParens writes the pairs as text.
+func renderParens(ns []parenNode) string {
+	var b strings.Builder
+	var write func([]parenNode)
+	write = func(ns []parenNode) {
+		for _, p := range ns {
+			b.WriteByte(parenKinds[p.kind][0])
+			write(p.children)
+			b.WriteByte(parenKinds[p.kind][1])
+		}
+	}
+	write(ns)
+	return b.String()
+}
+
+// parensBalanced reports whether s is a correctly matched bracket string.
+func parensBalanced(s string) bool {
+	var stack []byte
+	for i := 0; i < len(s); i++ {
+		if j := strings.IndexByte(")]}", s[i]); j >= 0 {
+			if len(stack) == 0 || stack[len(stack)-1] != "([{"[j] {
+				return false
+			}
+			stack = stack[:len(stack)-1]
+		} else {
+			stack = append(stack, s[i])
+		}
+	}
+	return len(stack) == 0
+}
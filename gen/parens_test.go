@@ -0,0 +1,124 @@
+package gen
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestParensBalanced(t *testing.T) {
+	for s, want := range map[string]bool{
+		"": true, "()": true, "([]{()})[]": true, "{[()()]}": true,
+		"(": false, ")": false, ")(": false, "(]": false, "([)]": false, "(()": false,
+	} {
+		if got := parensBalanced(s); got != want {
+			t.Errorf("parensBalanced(%q) = %v, expected %v", s, got, want)
+		}
+	}
+}
+
+func TestBalancedParens(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	g := BalancedParens(Size{Min: 1, Max: 10})
+	nested := false
+	for i := 0; i < 300; i++ {
+		s, shrink := g.Generate(r, Size{})
+		if !parensBalanced(s) {
+			t.Fatalf("BalancedParens() = %q, not balanced", s)
+		}
+		if len(s) < 2 || len(s) > 20 {
+			t.Fatalf("BalancedParens() = %q, expected 1..10 pairs", s)
+		}
+		nested = nested || strings.Contains(s, "([") || strings.Contains(s, "{(") || strings.Contains(s, "[{")
+		if shrink == nil {
+			t.Fatal("BalancedParens().Generate() returned nil shrinker")
+		}
+	}
+	for _, c := range "()[]{}" {
+		found := false
+		for i := 0; i < 50 && !found; i++ {
+			s, _ := g.Generate(r, Size{})
+			found = strings.ContainsRune(s, c)
+		}
+		if !found {
+			t.Errorf("BalancedParens() never produced %q", c)
+		}
+	}
+	if !nested {
+		t.Error("BalancedParens() never nested pairs of different kinds")
+	}
+}
+
+func TestBalancedParens_ShrinkKeepsBalance(t *testing.T) {
+	// fails while some "{}" pair is nested two levels deep: minimal is "(({}))"
+	fails := func(s string) bool {
+		depth := 0
+		for i := 0; i < len(s); i++ {
+			switch {
+			case s[i] == '{' && depth >= 2:
+				return true
+			case strings.IndexByte("([{", s[i]) >= 0:
+				depth++
+			default:
+				depth--
+			}
+		}
+		return false
+	}
+	r := rand.New(rand.NewSource(2))
+	g := BalancedParens(Size{Min: 6, Max: 12})
+	var s string
+	var shrink Shrinker[string]
+	for !fails(s) {
+		s, shrink = g.Generate(r, Size{})
+	}
+
+	min, accept := s, false
+	for i := 0; i < 5000; i++ {
+		next, ok := shrink(accept)
+		if !ok {
+			break
+		}
+		if !parensBalanced(next) {
+			t.Fatalf("shrink candidate %q is not balanced", next)
+		}
+		accept = fails(next)
+		if accept {
+			min = next
+		}
+	}
+	if min != "(({}))" {
+		t.Errorf("shrinking %q ended at %q, expected \"(({}))\"", s, min)
+	}
+}
+
+func TestUnbalancedParens(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	g := UnbalancedParens(Size{})
+	for i := 0; i < 1000; i++ {
+		s, _ := g.Generate(r, Size{})
+		if parensBalanced(s) {
+			t.Fatalf("UnbalancedParens() = %q, which is balanced", s)
+		}
+	}
+}
+
+func TestUnbalancedParens_ShrinkStaysUnbalanced(t *testing.T) {
+	r := rand.New(rand.NewSource(4))
+	s, shrink := UnbalancedParens(Size{Min: 5, Max: 10}).Generate(r, Size{})
+
+	min := s
+	for i := 0; i < 2000; i++ {
+		next, ok := shrink(true)
+		if !ok {
+			break
+		}
+		if parensBalanced(next) {
+			t.Fatalf("shrink candidate %q is balanced", next)
+		}
+		min = next
+	}
+	if min != "(" && min != ")" {
+		t.Errorf("shrinking %q accepting everything ended at %q, expected a single bracket", s, min)
+	}
+}
@@ -0,0 +1,139 @@
+package gen
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+)
+
+// PartialStruct generates values of the struct type T where a random subset
+// of the exported fields is set and the others are left zero, for testing
+// patch/merge semantics where only the provided fields should change.
+// Field values are derived from the field types: bools, integers, floats,
+// strings, slices, pointers and nested structs (fully set) are supported;
+// fields of other types (maps, channels, funcs, interfaces) and recursive
+// references to a struct being derived stay zero.
+// Panics if T is not a struct.
+// Shrink: field by field in declaration order, first unsetting the field
+// (back to its zero value), then shrinking the value it keeps, so the number
+// of set fields moves toward zero.
+func PartialStruct[T any]() Generator[T] {
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+	if typ.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("gen.PartialStruct: %s is not a struct type", typ))
+	}
+	fields := Fields{}
+	for name, g := range deriveFields(typ, map[reflect.Type]bool{}) {
+		f, _ := typ.FieldByName(name)
+		fields[name] = maybeZero(g, f.Type)
+	}
+	return Map(structValue(typ, fields, structFieldOrder(typ, fields, nil)), func(v any) T { return v.(T) })
+}
+
+// maybeZero generates the zero value of typ half of the time, otherwise a
+// value from g. Shrink: a set value first tries the zero value, then g's
+// shrinker.
+func maybeZero(g Generator[any], typ reflect.Type) Generator[any] {
+	zero := reflect.Zero(typ).Interface()
+	return From(func(r *rand.Rand, sz Size) (any, Shrinker[any]) {
+		if r == nil {
+			r = rand.New(rand.NewSource(rand.Int63())) // #nosec G404 -- Using math/rand for deterministic property-based testing
+		}
+		if r.Intn(2) == 0 {
+			return zero, noShrink(zero)
+		}
+		v, s := g.Generate(r, sz)
+		s = orNoShrink(s, v)
+		unsetTried, unsetPending := false, false
+		return v, func(accept bool) (any, bool) {
+			if !unsetTried {
+				unsetTried, unsetPending = true, true
+				return zero, true
+			}
+			if unsetPending {
+				unsetPending = false
+				if accept {
+					// the zero value still fails: nothing simpler to try
+					return nil, false
+				}
+			}
+			return s(accept)
+		}
+	})
+}
+
+// deriveFields returns a generator for every exported field of the struct
+// type typ whose type is supported by deriveGenerator. visiting holds the
+// struct types being derived, to cut recursive types.
+func deriveFields(typ reflect.Type, visiting map[reflect.Type]bool) Fields {
+	visiting[typ] = true
+	defer delete(visiting, typ)
+	fields := Fields{}
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		if !f.IsExported() || f.Anonymous {
+			continue
+		}
+		if g, ok := deriveGenerator(f.Type, visiting); ok {
+			fields[f.Name] = g
+		}
+	}
+	return fields
+}
+
+// deriveGenerator returns a generator of values of type t (boxed as any), or
+// false when t is not supported.
+func deriveGenerator(t reflect.Type, visiting map[reflect.Type]bool) (Generator[any], bool) {
+	convert := func(g Generator[any]) Generator[any] {
+		return Map(g, func(v any) any { return reflect.ValueOf(v).Convert(t).Interface() })
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return convert(ToAny(Bool())), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		hi := int64(1000)
+		if max := int64(1)<<(t.Bits()-1) - 1; max < hi {
+			hi = max
+		}
+		return convert(ToAny(IntRange(int(-hi-1), int(hi)))), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		hi := uint64(1000)
+		if max := uint64(1)<<t.Bits() - 1; max < hi {
+			hi = max
+		}
+		return convert(ToAny(IntRange(0, int(hi)))), true
+	case reflect.Float32, reflect.Float64:
+		return convert(ToAny(Float64Range(-1000, 1000, false, false))), true
+	case reflect.String:
+		return convert(ToAny(StringAlphaNum(Size{Max: 16}))), true
+	case reflect.Slice:
+		elem, ok := deriveGenerator(t.Elem(), visiting)
+		if !ok {
+			return nil, false
+		}
+		return Map(SliceOf(elem, Size{Max: 8}), func(vs []any) any {
+			s := reflect.MakeSlice(t, len(vs), len(vs))
+			for i, v := range vs {
+				s.Index(i).Set(reflect.ValueOf(v))
+			}
+			return s.Interface()
+		}), true
+	case reflect.Pointer:
+		elem, ok := deriveGenerator(t.Elem(), visiting)
+		if !ok {
+			return nil, false
+		}
+		return Map(elem, func(v any) any {
+			p := reflect.New(t.Elem())
+			p.Elem().Set(reflect.ValueOf(v))
+			return p.Interface()
+		}), true
+	case reflect.Struct:
+		if visiting[t] {
+			return nil, false
+		}
+		fields := deriveFields(t, visiting)
+		return structValue(t, fields, structFieldOrder(t, fields, nil)), true
+	}
+	return nil, false
+}
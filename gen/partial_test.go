@@ -0,0 +1,146 @@
+package gen
+
+import (
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type patchAddress struct {
+	City string
+	Zip  uint16
+}
+
+type patchSample struct {
+	Name    string
+	Age     int8
+	Score   *float64
+	Tags    []string
+	Admin   bool
+	Address patchAddress
+	Extra   map[string]int // unsupported: always zero
+	Next    *patchSample   // recursive: always zero
+	hidden  int
+}
+
+// setFields lists the exported fields of v that differ from their zero value.
+func setFields(v patchSample) []string {
+	var out []string
+	rv := reflect.ValueOf(v)
+	for i := 0; i < rv.NumField(); i++ {
+		if rv.Type().Field(i).IsExported() && !rv.Field(i).IsZero() {
+			out = append(out, rv.Type().Field(i).Name)
+		}
+	}
+	return out
+}
+
+func TestPartialStruct(t *testing.T) {
+	g := PartialStruct[patchSample]()
+	r := rand.New(rand.NewSource(123))
+
+	counts := map[string]int{}
+	addresses, partial := 0, 0
+	for i := 0; i < 300; i++ {
+		v, shrink := g.Generate(r, Size{})
+		for _, name := range setFields(v) {
+			counts[name]++
+		}
+		if v.Extra != nil || v.Next != nil || v.hidden != 0 {
+			t.Fatalf("PartialStruct() set an unsupported field: %+v", v)
+		}
+		// a set nested struct has every field drawn from its derived
+		// generator: an alphanumeric string of at most 16 runes, a uint16
+		// in [0, 1000]
+		if v.Address != (patchAddress{}) {
+			addresses++
+			if len(v.Address.City) > 16 || strings.Trim(v.Address.City, AlphabetAlphaNum) != "" || v.Address.Zip > 1000 {
+				t.Errorf("PartialStruct() set Address to %+v, outside its derived generators", v.Address)
+			}
+			if v.Address.City == "" || v.Address.Zip == 0 {
+				partial++
+			}
+		}
+		if shrink == nil {
+			t.Fatal("PartialStruct().Generate() returned nil shrinker")
+		}
+	}
+	// nested fields are not left zero at random: only when their generator
+	// draws the zero value (an empty City, a zero Zip), which is rare
+	if partial*4 > addresses {
+		t.Errorf("%d of %d set Address values have a zero field, expected nested structs fully set", partial, addresses)
+	}
+	// every supported field is set in some values and left zero in others
+	for _, name := range []string{"Name", "Age", "Score", "Tags", "Admin", "Address"} {
+		if counts[name] == 0 || counts[name] == 300 {
+			t.Errorf("field %s set in %d/300 values, expected a random subset", name, counts[name])
+		}
+	}
+}
+
+func TestPartialStruct_NotAStructPanics(t *testing.T) {
+	defer func() {
+		msg, _ := recover().(string)
+		if !strings.Contains(msg, "not a struct") {
+			t.Errorf("PartialStruct[int]() panic = %q, expected 'not a struct'", msg)
+		}
+	}()
+	PartialStruct[int]()
+}
+
+func TestPartialStruct_ShrinksToZero(t *testing.T) {
+	g := PartialStruct[patchSample]()
+	r := rand.New(rand.NewSource(42))
+
+	for i := 0; i < 30; i++ {
+		v, shrink := g.Generate(r, Size{})
+		min := v
+		for j := 0; j < 2000; j++ {
+			next, ok := shrink(true)
+			if !ok {
+				break
+			}
+			min = next
+		}
+		if set := setFields(min); len(set) != 0 {
+			t.Errorf("PartialStruct() %+v shrunk to %+v, fields %v still set", v, min, set)
+		}
+	}
+}
+
+func TestPartialStruct_ShrinkKeepsNeededFields(t *testing.T) {
+	g := PartialStruct[patchSample]()
+	r := rand.New(rand.NewSource(7))
+
+	// the "bug" shows when Name and Tags are both provided
+	fails := func(v patchSample) bool { return v.Name != "" && len(v.Tags) > 0 }
+	checked := 0
+	for i := 0; i < 200 && checked < 20; i++ {
+		v, shrink := g.Generate(r, Size{})
+		if !fails(v) {
+			continue
+		}
+		checked++
+		min, accept := v, false
+		for j := 0; j < 2000; j++ {
+			next, ok := shrink(accept)
+			if !ok {
+				break
+			}
+			accept = fails(next)
+			if accept {
+				min = next
+			}
+		}
+		if set := setFields(min); !reflect.DeepEqual(set, []string{"Name", "Tags"}) {
+			t.Errorf("PartialStruct() %+v shrunk to %+v with fields %v set, expected [Name Tags]", v, min, set)
+		}
+		if len(min.Tags) != 1 {
+			t.Errorf("PartialStruct() kept Tags=%q, expected a single tag", min.Tags)
+		}
+	}
+	if checked == 0 {
+		t.Fatal("no generated value set both Name and Tags")
+	}
+}
@@ -0,0 +1,215 @@
+// File: gen/partition.go
+package gen
+
+import (
+	"math/rand"
+	"sort"
+)
+
+// partElem is a partition element with the shrinker of its value; both move
+// together while elements are removed.
+type partElem[T any] struct {
+	v T
+	s Shrinker[T]
+}
+
+// partSpec is a partition as its elements in order and the groups-1 cut
+// points between groups: group k is elems[cuts[k-1]:cuts[k]], with an implicit
+// 0 before the first cut and len(elems) after the last.
+type partSpec[T any] struct {
+	elems []partElem[T]
+	cuts  []int
+}
+
+// Partition generates groups sub-slices whose concatenation is a slice of
+// elements from g, for testing chunking, batching and map-reduce code. Groups
+// may be empty; the boundaries between them are drawn uniformly.
+// - size.Min/Max control the total number of elements (default Min=0, Max=16).
+// Panics if groups < 1.
+// Shrink (the number of groups is kept):
+//
+//	(1) remove elements: all, blocks (half, quarter, ...), then single (R->L),
+//	    keeping the total above size.Min
+//	(2) merge each group into the one before it (L->R), so elements gather in
+//	    the first group
+//	(3) shrink each element in place (left→right) with its own shrinker
+//
+// Accepting every candidate converges to groups empty sub-slices (or, with
+// size.Min > 0, to all elements in the first group).
+func Partition[T any](g Generator[T], groups int, size Size) Generator[[][]T] {
+	if groups < 1 {
+		panic("gen.Partition: needs at least one group")
+	}
+	return From(func(r *rand.Rand, sz Size) ([][]T, Shrinker[[][]T]) {
+		if r == nil {
+			r = rand.New(rand.NewSource(rand.Int63())) // #nosec G404 -- Using math/rand for deterministic property-based testing
+		}
+		// defaults
+		if size.Min == 0 && size.Max == 0 {
+			size.Min, size.Max = 0, 16
+		}
+		if sz.Min != 0 || sz.Max != 0 {
+			size = sz
+		}
+		if size.Max < size.Min {
+			size.Max = size.Min
+		}
+
+		n := size.Min
+		if size.Max > size.Min {
+			n += r.Intn(size.Max - size.Min + 1)
+		}
+
+		sz.Budget.Spend(n)
+		cur := partSpec[T]{elems: make([]partElem[T], n), cuts: make([]int, groups-1)}
+		for i := range cur.elems {
+			v, s := g.Generate(r, sz.elem())
+			cur.elems[i] = partElem[T]{v, orNoShrink(s, v)}
+		}
+		for i := range cur.cuts {
+			cur.cuts[i] = r.Intn(n + 1)
+		}
+		sort.Ints(cur.cuts)
+
+		// phase (1): removals and merges, queue-based with rebase on accept
+		queue := make([]partSpec[T], 0, 32)
+		tried := map[string]struct{}{sig(cur.groups()): {}}
+		var queued map[string]struct{}
+		var last partSpec[T]
+		hasLast := false
+
+		push := func(p partSpec[T]) {
+			k := sig(p.groups())
+			if _, ok := tried[k]; ok {
+				return
+			}
+			if _, ok := queued[k]; ok {
+				return
+			}
+			queued[k] = struct{}{}
+			queue = append(queue, p)
+		}
+
+		grow := func(base partSpec[T]) {
+			queue = queue[:0]
+			queued = map[string]struct{}{}
+			L := len(base.elems)
+			// (1) remove elements: all, blocks (half, quarter, ...), then single (R->L)
+			if L > size.Min {
+				push(base.without(size.Min, L))
+				for chunk := L / 2; chunk >= 1; chunk /= 2 {
+					if L-chunk < size.Min {
+						continue
+					}
+					for i := 0; i+chunk <= L; i += chunk {
+						push(base.without(i, i+chunk))
+					}
+				}
+				for i := L - 1; i >= 0; i-- {
+					push(base.without(i, i+1))
+				}
+			}
+			// (2) merge group k+1 into group k (L->R)
+			for k := range base.cuts {
+				end := L
+				if k+1 < len(base.cuts) {
+					end = base.cuts[k+1]
+				}
+				if base.cuts[k] < end {
+					p := base.clone()
+					p.cuts[k] = end
+					push(p)
+				}
+			}
+		}
+		grow(cur)
+
+		pop := func() (partSpec[T], bool) {
+			if len(queue) == 0 {
+				return partSpec[T]{}, false
+			}
+			var v partSpec[T]
+			if GetShrinkStrategy() == ShrinkStrategyDFS {
+				v = queue[len(queue)-1]
+				queue = queue[:len(queue)-1]
+			} else {
+				v = queue[0]
+				queue = queue[1:]
+			}
+			tried[sig(v.groups())] = struct{}{}
+			return v, true
+		}
+
+		// phase (2): each element in place
+		restructuring := true
+		var each func(accept bool) (int, T, bool)
+
+		return cur.groups(), func(accept bool) ([][]T, bool) {
+			if restructuring {
+				if accept && hasLast {
+					cur = last
+					grow(cur)
+				}
+				if c, ok := pop(); ok {
+					last, hasLast = c, true
+					return c.groups(), true
+				}
+				restructuring = false
+				accept = false
+				each = shrinkInPlace(len(cur.elems), func(i int) Shrinker[T] { return cur.elems[i].s }, nil, func(i int, v T) { cur.elems[i].v = v })
+			}
+
+			i, v, ok := each(accept)
+			if !ok {
+				return nil, false
+			}
+			cand := cur.clone()
+			cand.elems[i].v = v
+			return cand.groups(), true
+		}
+	})
+}
+
+// groups returns the sub-slices of the partition.
+func (p partSpec[T]) groups() [][]T {
+	out := make([][]T, len(p.cuts)+1)
+	lo := 0
+	for k := range out {
+		hi := len(p.elems)
+		if k < len(p.cuts) {
+			hi = p.cuts[k]
+		}
+		out[k] = make([]T, hi-lo)
+		for i := range out[k] {
+			out[k][i] = p.elems[lo+i].v
+		}
+		lo = hi
+	}
+	return out
+}
+
+// clone returns a copy of the partition.
+func (p partSpec[T]) clone() partSpec[T] {
+	return partSpec[T]{
+		elems: append([]partElem[T](nil), p.elems...),
+		cuts:  append([]int(nil), p.cuts...),
+	}
+}
+
+// without returns a copy of the partition without elements [i, j), moving
+// the cuts after them back.
+func (p partSpec[T]) without(i, j int) partSpec[T] {
+	out := partSpec[T]{cuts: make([]int, len(p.cuts))}
+	out.elems = append(append(out.elems, p.elems[:i]...), p.elems[j:]...)
+	for k, c := range p.cuts {
+		switch {
+		case c >= j:
+			out.cuts[k] = c - (j - i)
+		case c > i:
+			out.cuts[k] = i
+		default:
+			out.cuts[k] = c
+		}
+	}
+	return out
+}
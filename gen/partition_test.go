@@ -0,0 +1,112 @@
+package gen
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestPartition(t *testing.T) {
+	g := Partition(IntRange(0, 99), 4, Size{Min: 2, Max: 10})
+	r := rand.New(rand.NewSource(1))
+	empty := 0
+	for i := 0; i < 200; i++ {
+		p, shrink := g.Generate(r, Size{})
+		if shrink == nil {
+			t.Fatal("Partition().Generate() returned nil shrinker")
+		}
+		if len(p) != 4 {
+			t.Fatalf("Partition() = %v, expected 4 groups", p)
+		}
+		total := 0
+		for _, grp := range p {
+			total += len(grp)
+			if len(grp) == 0 {
+				empty++
+			}
+		}
+		if total < 2 || total > 10 {
+			t.Fatalf("Partition() = %v with %d elements, expected 2 to 10", p, total)
+		}
+	}
+	if empty == 0 {
+		t.Error("Partition() never produced an empty group")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Partition(g, 0, size) did not panic")
+		}
+	}()
+	Partition(IntRange(0, 9), 0, Size{})
+}
+
+// shape returns the group lengths of p: IntRange's DFS shrinking does not
+// reach the minimum of each element, so DFS checks compare shapes only.
+func shape(p [][]int) []int {
+	out := make([]int, len(p))
+	for i, g := range p {
+		out[i] = len(g)
+	}
+	return out
+}
+
+func TestPartition_ShrinkAcceptAll(t *testing.T) {
+	for _, strategy := range []string{"bfs", "dfs"} {
+		SetShrinkStrategy(strategy)
+		r := rand.New(rand.NewSource(2))
+		for i := 0; i < 20; i++ {
+			p, shrink := Partition(IntRange(0, 99), 3, Size{Min: 2, Max: 12}).Generate(r, Size{})
+			got := minimize(p, shrink, func([][]int) bool { return true })
+			if sig(shape(got)) != sig([]int{2, 0, 0}) || strategy == "bfs" && sig(got) != sig([][]int{{0, 0}, {}, {}}) {
+				t.Errorf("%s: shrinking %v accepting everything ended at %v, expected [[0 0] [] []]", strategy, p, got)
+			}
+		}
+	}
+	SetShrinkStrategy("bfs")
+}
+
+func TestPartition_ShrinkBatcher(t *testing.T) {
+	// a batcher that drops a batch holding more than 2 items: the minimal
+	// counterexample is a single batch of 3 zeros
+	sumBatches := func(batches [][]int) int {
+		sum := 0
+		for _, b := range batches {
+			if len(b) > 2 {
+				continue // bug
+			}
+			for _, v := range b {
+				sum += v + 1
+			}
+		}
+		return sum
+	}
+	fails := func(p [][]int) bool {
+		want := 0
+		for _, b := range p {
+			for _, v := range b {
+				want += v + 1
+			}
+		}
+		return sumBatches(p) != want
+	}
+	for _, strategy := range []string{"bfs", "dfs"} {
+		SetShrinkStrategy(strategy)
+		r := rand.New(rand.NewSource(3))
+		found := 0
+		for i := 0; i < 200 && found < 10; i++ {
+			p, shrink := Partition(IntRange(0, 99), 4, Size{Max: 16}).Generate(r, Size{})
+			if !fails(p) {
+				continue
+			}
+			found++
+			got := minimize(p, shrink, fails)
+			if sig(shape(got)) != sig([]int{3, 0, 0, 0}) || strategy == "bfs" && sig(got) != sig([][]int{{0, 0, 0}, {}, {}, {}}) {
+				t.Errorf("%s: shrinking %v ended at %v, expected [[0 0 0] [] [] []]", strategy, p, got)
+			}
+		}
+		if found == 0 {
+			t.Fatalf("%s: no failing partition generated", strategy)
+		}
+	}
+	SetShrinkStrategy("bfs")
+}
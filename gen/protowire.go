@@ -0,0 +1,296 @@
+package gen
+
+import "math/rand"
+
+// Protobuf wire types covered by ProtoWire. The deprecated group wire types
+// (3, start group and 4, end group) are never generated.
+const (
+	protoVarint  = 0
+	protoFixed64 = 1
+	protoBytes   = 2
+	protoFixed32 = 5
+)
+
+// protoMaxField is the largest valid protobuf field number (2^29 - 1).
+const protoMaxField = 1<<29 - 1
+
+// protoMaxDepth bounds the nesting of embedded messages.
+const protoMaxDepth = 3
+
+// protoMsg is the tree representation used to generate and shrink messages.
+type protoMsg struct {
+	fields []*protoField
+}
+
+// protoField is one field of a message. Varint and fixed fields use val;
+// length-delimited fields hold either raw data or an embedded message.
+type protoField struct {
+	num  uint64
+	wire int
+	val  uint64
+	data []byte
+	msg  *protoMsg
+}
+
+// ProtoWire generates byte slices in valid protobuf wire format: every field
+// has a valid tag (field number in [1, 2^29-1]) and a well-formed value.
+// Covered wire types: VARINT (0) with values up to 10-byte varints, I64 (1),
+// LEN (2) holding raw bytes or embedded messages (nested up to 3 levels) and
+// I32 (5). The deprecated group wire types (3 and 4) are not generated.
+// - size.Min/Max bound the number of top-level fields (default Min=0, Max=8).
+// Shrink: removes fields, flattens embedded messages, then moves field numbers
+// toward 1 and values toward 0 or empty, converging toward the empty message.
+func ProtoWire(size Size) Generator[[]byte] {
+	return From(func(r *rand.Rand, sz Size) ([]byte, Shrinker[[]byte]) {
+		if r == nil {
+			r = rand.New(rand.NewSource(rand.Int63())) // #nosec G404 -- Using math/rand for deterministic property-based testing
+		}
+		if size.Min == 0 && size.Max == 0 {
+			size.Min, size.Max = 0, 8
+		}
+		if sz.Min != 0 || sz.Max != 0 {
+			size = sz
+		}
+		if size.Max < size.Min {
+			size.Max = size.Min
+		}
+
+		n := size.Min
+		if size.Max > size.Min {
+			n += r.Intn(size.Max - size.Min + 1)
+		}
+		sz.Budget.Spend(n)
+		cur := &protoMsg{}
+		for i := 0; i < n; i++ {
+			cur.fields = append(cur.fields, generateProtoField(r, protoMaxDepth))
+		}
+		min := size.Min
+
+		queue := make([]*protoMsg, 0, 32)
+		seen := map[string]struct{}{string(cur.encode()): {}}
+		var last *protoMsg
+
+		push := func(m *protoMsg) {
+			k := string(m.encode())
+			if _, ok := seen[k]; ok {
+				return
+			}
+			seen[k] = struct{}{}
+			queue = append(queue, m)
+		}
+
+		// neighbors: for each message (preorder), a copy of the tree with one of
+		// its fields removed or simplified
+		grow := func(base *protoMsg) {
+			queue = queue[:0]
+			msgs := base.preorder()
+			for i, m := range msgs {
+				edit := func(f func(*protoMsg)) {
+					c := base.clone()
+					f(c.preorder()[i])
+					push(c)
+				}
+				keep := 0
+				if i == 0 {
+					keep = min
+				}
+				// (1) fewer fields: keep the minimum, then drop each (R->L)
+				if len(m.fields) > keep {
+					edit(func(c *protoMsg) { c.fields = c.fields[:keep] })
+					for j := len(m.fields) - 1; j >= 0; j-- {
+						edit(func(c *protoMsg) { c.fields = append(c.fields[:j], c.fields[j+1:]...) })
+					}
+				}
+				// (2) simpler fields (R->L)
+				for j := len(m.fields) - 1; j >= 0; j-- {
+					fd := m.fields[j]
+					if fd.msg != nil {
+						// flatten an embedded message to empty bytes
+						edit(func(c *protoMsg) { c.fields[j].msg = nil })
+					}
+					if fd.wire != protoVarint || fd.val != 0 {
+						edit(func(c *protoMsg) { *c.fields[j] = protoField{num: c.fields[j].num, wire: protoVarint} })
+					}
+					if fd.num != 1 {
+						edit(func(c *protoMsg) { c.fields[j].num = 1 })
+						edit(func(c *protoMsg) { c.fields[j].num /= 2 })
+					}
+					if fd.val != 0 {
+						edit(func(c *protoMsg) { c.fields[j].val /= 2 })
+					}
+					if len(fd.data) > 0 {
+						edit(func(c *protoMsg) { c.fields[j].data = nil })
+						edit(func(c *protoMsg) { c.fields[j].data = c.fields[j].data[:len(c.fields[j].data)/2] })
+					}
+				}
+			}
+		}
+		grow(cur)
+
+		pop := func() (*protoMsg, bool) {
+			if len(queue) == 0 {
+				return nil, false
+			}
+			if GetShrinkStrategy() == ShrinkStrategyDFS {
+				v := queue[len(queue)-1]
+				queue = queue[:len(queue)-1]
+				return v, true
+			}
+			v := queue[0]
+			queue = queue[1:]
+			return v, true
+		}
+
+		return cur.encode(), func(accept bool) ([]byte, bool) {
+			if accept && last != nil && string(last.encode()) != string(cur.encode()) {
+				cur = last
+				grow(cur)
+			}
+			nxt, ok := pop()
+			if !ok {
+				return nil, false
+			}
+			last = nxt
+			return nxt.encode(), true
+		}
+	})
+}
+
+// generateProtoField draws a field; LEN fields may embed a message while
+// depth allows.
+func generateProtoField(r *rand.Rand, depth int) *protoField {
+	f := &protoField{num: protoFieldNumber(r)}
+	switch r.Intn(4) {
+	case 0:
+		f.wire, f.val = protoVarint, protoVarintValue(r)
+	case 1:
+		f.wire, f.val = protoFixed64, r.Uint64()
+	case 2:
+		f.wire = protoBytes
+		if depth > 1 && r.Intn(3) == 0 {
+			f.msg = &protoMsg{}
+			for i, n := 0, r.Intn(4); i < n; i++ {
+				f.msg.fields = append(f.msg.fields, generateProtoField(r, depth-1))
+			}
+		} else {
+			f.data = make([]byte, r.Intn(17))
+			r.Read(f.data)
+		}
+	default:
+		f.wire, f.val = protoFixed32, uint64(r.Uint32())
+	}
+	return f
+}
+
+// protoFieldNumber favors the one-byte-tag numbers 1-15, with occasional
+// larger ones up to the maximum.
+func protoFieldNumber(r *rand.Rand) uint64 {
+	switch r.Intn(8) {
+	case 0:
+		return protoMaxField
+	case 1, 2:
+		return 1 + uint64(r.Int63n(protoMaxField))
+	default:
+		return 1 + uint64(r.Intn(15))
+	}
+}
+
+// protoVarintValue mixes small values, values of every byte length and the
+// 10-byte encodings of negative int64s.
+func protoVarintValue(r *rand.Rand) uint64 {
+	switch r.Intn(4) {
+	case 0:
+		return uint64(r.Intn(128))
+	case 1:
+		return uint64(-1 - r.Int63n(1000)) // negative int32/int64 fields
+	default:
+		return r.Uint64() >> r.Intn(64)
+	}
+}
+
+// encode serializes the message in wire format.
+func (m *protoMsg) encode() []byte {
+	var b []byte
+	for _, f := range m.fields {
+		b = appendProtoVarint(b, f.num<<3|uint64(f.wire))
+		switch f.wire {
+		case protoVarint:
+			b = appendProtoVarint(b, f.val)
+		case protoFixed64:
+			for i := 0; i < 8; i++ {
+				b = append(b, byte(f.val>>(8*i)))
+			}
+		case protoFixed32:
+			for i := 0; i < 4; i++ {
+				b = append(b, byte(f.val>>(8*i)))
+			}
+		case protoBytes:
+			data := f.data
+			if f.msg != nil {
+				data = f.msg.encode()
+			}
+			b = appendProtoVarint(b, uint64(len(data)))
+			b = append(b, data...)
+		}
+	}
+	return b
+}
+
+// clone returns a deep copy of the message tree.
+func (m *protoMsg) clone() *protoMsg {
+	c := &protoMsg{}
+	for _, f := range m.fields {
+		cf := *f
+		cf.data = append([]byte(nil), f.data...)
+		if f.msg != nil {
+			cf.msg = f.msg.clone()
+		}
+		c.fields = append(c.fields, &cf)
+	}
+	return c
+}
+
+// preorder lists the message and its embedded messages in preorder.
+func (m *protoMsg) preorder() []*protoMsg {
+	out := []*protoMsg{m}
+	for _, f := range m.fields {
+		if f.msg != nil {
+			out = append(out, f.msg.preorder()...)
+		}
+	}
+	return out
+}
+
+// appendProtoVarint appends the base-128 varint encoding of v.
+func appendProtoVarint(b []byte, v uint64) []byte {
+	for v >= 0x80 {
+		b = append(b, byte(v)|0x80)
+		v >>= 7
+	}
+	return append(b, byte(v))
+}
+
+// protoCorruptions are malformed tails appended to a valid message by
+// ProtoWireInvalid; each one makes decoding fail at its start.
+var protoCorruptions = [][]byte{
+	{0x08, 0x80}, // truncated varint
+	{0x08, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x02}, // varint overflowing 64 bits
+	{0x12, 0x05, 'a'},        // length past the end of the input
+	{0x0d, 0x01, 0x02},       // truncated I32
+	{0x09, 0x01, 0x02, 0x03}, // truncated I64
+	{0x00, 0x01},             // field number 0
+	{0x0e, 0x00},             // reserved wire type 6
+	{0x0c},                   // end group without a start group
+}
+
+// ProtoWireInvalid generates plausible but malformed wire-format input: a
+// valid message (see ProtoWire) followed by a malformed field, such as a
+// truncated varint or fixed value, a varint overflowing 64 bits, a length
+// past the end of the input, field number 0, a reserved wire type or an
+// unmatched end group. Every value, including shrink candidates, is invalid.
+// Shrink: moves toward the first corruption and shrinks the valid prefix.
+func ProtoWireInvalid(size Size) Generator[[]byte] {
+	return Map(PairOf(IntRange(0, len(protoCorruptions)-1), ProtoWire(size)), func(p Pair[int, []byte]) []byte {
+		return append(append([]byte(nil), p.Second...), protoCorruptions[p.First]...)
+	})
+}
@@ -0,0 +1,203 @@
+package gen
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+// decodedProtoField is a field header read by decodeProtoWire.
+type decodedProtoField struct {
+	num  uint64
+	wire int
+}
+
+// consumeProtoVarint decodes a varint, rejecting truncated and overlong ones.
+func consumeProtoVarint(b []byte) (uint64, int, error) {
+	var v uint64
+	for i := 0; i < 10 && i < len(b); i++ {
+		if i == 9 && b[i] > 1 {
+			return 0, 0, errors.New("varint overflows 64 bits")
+		}
+		v |= uint64(b[i]&0x7f) << (7 * i)
+		if b[i] < 0x80 {
+			return v, i + 1, nil
+		}
+	}
+	return 0, 0, errors.New("truncated varint")
+}
+
+// decodeProtoWire is a strict wire-format decoder for the wire types ProtoWire
+// generates.
+func decodeProtoWire(b []byte) ([]decodedProtoField, error) {
+	var out []decodedProtoField
+	for len(b) > 0 {
+		tag, n, err := consumeProtoVarint(b)
+		if err != nil {
+			return nil, fmt.Errorf("tag: %w", err)
+		}
+		b = b[n:]
+		num, wire := tag>>3, int(tag&7)
+		if num == 0 || num > protoMaxField {
+			return nil, fmt.Errorf("invalid field number %d", num)
+		}
+		switch wire {
+		case protoVarint:
+			_, n, err = consumeProtoVarint(b)
+			if err != nil {
+				return nil, err
+			}
+		case protoFixed64:
+			n = 8
+		case protoFixed32:
+			n = 4
+		case protoBytes:
+			l, m, err := consumeProtoVarint(b)
+			if err != nil {
+				return nil, err
+			}
+			b = b[m:]
+			if l > uint64(len(b)) {
+				return nil, errors.New("length past the end")
+			}
+			n = int(l)
+		default:
+			return nil, fmt.Errorf("unsupported wire type %d", wire)
+		}
+		if n > len(b) {
+			return nil, errors.New("truncated value")
+		}
+		b = b[n:]
+		out = append(out, decodedProtoField{num, wire})
+	}
+	return out, nil
+}
+
+func TestProtoWire(t *testing.T) {
+	g := ProtoWire(Size{Min: 1, Max: 10})
+	r := rand.New(rand.NewSource(123))
+
+	wires := map[int]int{}
+	for i := 0; i < 300; i++ {
+		b, shrink := g.Generate(r, Size{})
+		fields, err := decodeProtoWire(b)
+		if err != nil {
+			t.Fatalf("ProtoWire().Generate() = %x is invalid: %v", b, err)
+		}
+		if len(fields) < 1 || len(fields) > 10 {
+			t.Errorf("ProtoWire() produced %d fields, expected 1-10", len(fields))
+		}
+		for _, f := range fields {
+			wires[f.wire]++
+		}
+		if shrink == nil {
+			t.Fatal("ProtoWire().Generate() returned nil shrinker")
+		}
+	}
+	for _, w := range []int{protoVarint, protoFixed64, protoBytes, protoFixed32} {
+		if wires[w] == 0 {
+			t.Errorf("ProtoWire() never generated wire type %d", w)
+		}
+	}
+}
+
+func TestProtoWire_EmbeddedMessagesAreValid(t *testing.T) {
+	r := rand.New(rand.NewSource(5))
+	found := 0
+	for i := 0; i < 200; i++ {
+		f := generateProtoField(r, protoMaxDepth)
+		if f.msg == nil {
+			continue
+		}
+		found++
+		if _, err := decodeProtoWire(f.msg.encode()); err != nil {
+			t.Fatalf("embedded message %x is invalid: %v", f.msg.encode(), err)
+		}
+	}
+	if found == 0 {
+		t.Fatal("no embedded message generated")
+	}
+}
+
+func TestProtoWire_ShrinksToEmpty(t *testing.T) {
+	g := ProtoWire(Size{Max: 8})
+	r := rand.New(rand.NewSource(42))
+
+	for i := 0; i < 30; i++ {
+		b, shrink := g.Generate(r, Size{})
+		min := b
+		for j := 0; j < 2000; j++ {
+			next, ok := shrink(true)
+			if !ok {
+				break
+			}
+			if _, err := decodeProtoWire(next); err != nil {
+				t.Fatalf("shrink candidate %x is invalid: %v", next, err)
+			}
+			min = next
+		}
+		if len(min) != 0 {
+			t.Errorf("ProtoWire() %x shrunk to %x, expected empty", b, min)
+		}
+	}
+}
+
+func TestProtoWire_ShrinkKeepsFailingFeature(t *testing.T) {
+	g := ProtoWire(Size{Max: 8})
+	r := rand.New(rand.NewSource(9))
+
+	// the "bug" needs an I32 field: shrinking must keep one, at field 1 and value 0
+	fails := func(b []byte) bool {
+		fields, _ := decodeProtoWire(b)
+		for _, f := range fields {
+			if f.wire == protoFixed32 {
+				return true
+			}
+		}
+		return false
+	}
+	want := []byte{0x0d, 0, 0, 0, 0}
+	for i := 0; i < 50; i++ {
+		b, shrink := g.Generate(r, Size{})
+		if !fails(b) {
+			continue
+		}
+		min, accept := b, false
+		for j := 0; j < 5000; j++ {
+			next, ok := shrink(accept)
+			if !ok {
+				break
+			}
+			accept = fails(next)
+			if accept {
+				min = next
+			}
+		}
+		if !bytes.Equal(min, want) {
+			t.Errorf("ProtoWire() %x shrunk to %x, expected %x", b, min, want)
+		}
+	}
+}
+
+func TestProtoWireInvalid(t *testing.T) {
+	g := ProtoWireInvalid(Size{Max: 6})
+	r := rand.New(rand.NewSource(7))
+
+	for i := 0; i < 200; i++ {
+		b, shrink := g.Generate(r, Size{})
+		if _, err := decodeProtoWire(b); err == nil {
+			t.Fatalf("ProtoWireInvalid().Generate() = %x decodes without error", b)
+		}
+		for j := 0; j < 200; j++ {
+			next, ok := shrink(true)
+			if !ok {
+				break
+			}
+			if _, err := decodeProtoWire(next); err == nil {
+				t.Fatalf("shrink candidate %x decodes without error", next)
+			}
+		}
+	}
+}
@@ -0,0 +1,225 @@
+// File: gen/regexp.go
+package gen
+
+import (
+	"fmt"
+	"math/rand"
+	"regexp"
+	"strings"
+)
+
+// regexpNode kinds.
+const (
+	reLit = iota
+	reDot
+	reClass
+	reConcat
+	reAlt
+	reGroup
+	reRepeat
+)
+
+// regexpClassItems are the items drawn inside character classes.
+var regexpClassItems = []string{"a-z", "A-Z", "0-9", "_", `\d`, `\s`, `\w`, "."}
+
+// regexpLiterals are the literal characters drawn, including metacharacters
+// (always escaped when rendered).
+const regexpLiterals = "abcxyz019 .*+?()[]{}|^$\\-"
+
+// regexpNode is the tree representation used to generate and shrink patterns.
+type regexpNode struct {
+	kind    int
+	lit     byte     // reLit
+	items   []string // reClass
+	negated bool     // reClass
+	capture bool     // reGroup
+	quant   string   // reRepeat
+	kids    []*regexpNode
+}
+
+// Regexp generates regular expressions that always compile with
+// regexp.Compile, built from literals (escaped with regexp.QuoteMeta), ".",
+// character classes, concatenation, alternation, capturing and non-capturing
+// groups and the quantifiers *, +, ?, {m} and {m,n}.
+// - size.Max bounds the nesting depth (default 3).
+// Every output is compiled before being returned; shrink candidates that do
+// not compile are skipped.
+// Shrink: drops and hoists sub-patterns and replaces nodes with the literal
+// "a", converging toward the single literal "a".
+func Regexp(size Size) Generator[string] {
+	return From(func(r *rand.Rand, sz Size) (string, Shrinker[string]) {
+		if r == nil {
+			r = rand.New(rand.NewSource(rand.Int63())) // #nosec G404 -- Using math/rand for deterministic property-based testing
+		}
+		if sz.Min != 0 || sz.Max != 0 {
+			size = sz
+		}
+		depth := size.Max
+		if depth <= 0 {
+			depth = 3
+		}
+
+		cur := generateRegexpNode(r, depth)
+		if _, err := regexp.Compile(cur.render()); err != nil {
+			cur = &regexpNode{kind: reLit, lit: 'a'}
+		}
+
+		render := (*regexpNode).render
+		// neighbors: for each node (preorder), a copy of the tree with that node simplified
+		return cur.render(), ShrinkNeighbors(cur, render, render, func(base *regexpNode, add func(*regexpNode)) {
+			push := func(n *regexpNode) {
+				if _, err := regexp.Compile(n.render()); err == nil {
+					add(n)
+				}
+			}
+			nodes := base.preorder()
+			for i, n := range nodes {
+				edit := func(f func(*regexpNode)) {
+					c := base.clone()
+					f(c.preorder()[i])
+					push(c)
+				}
+				// (1) the simplest pattern
+				if n.kind != reLit || n.lit != 'a' {
+					edit(func(m *regexpNode) { *m = regexpNode{kind: reLit, lit: 'a'} })
+				}
+				// (2) hoist each child
+				for j := range n.kids {
+					edit(func(m *regexpNode) { *m = *m.kids[j] })
+				}
+				// (3) drop a branch of concatenations and alternations (R->L)
+				if (n.kind == reConcat || n.kind == reAlt) && len(n.kids) > 2 {
+					for j := len(n.kids) - 1; j >= 0; j-- {
+						edit(func(m *regexpNode) { m.kids = append(m.kids[:j], m.kids[j+1:]...) })
+					}
+				}
+				// (4) class with a single item
+				if n.kind == reClass && (len(n.items) > 1 || n.negated) {
+					edit(func(m *regexpNode) { m.items, m.negated = m.items[:1], false })
+				}
+			}
+		})
+	})
+}
+
+// generateRegexpNode builds a random pattern with at most depth levels.
+func generateRegexpNode(r *rand.Rand, depth int) *regexpNode {
+	if depth <= 1 || r.Intn(4) == 0 {
+		switch r.Intn(5) {
+		case 0:
+			return &regexpNode{kind: reDot}
+		case 1:
+			n := &regexpNode{kind: reClass, negated: r.Intn(4) == 0}
+			for i, k := 0, 1+r.Intn(3); i < k; i++ {
+				n.items = append(n.items, regexpClassItems[r.Intn(len(regexpClassItems))])
+			}
+			return n
+		default:
+			return &regexpNode{kind: reLit, lit: regexpLiterals[r.Intn(len(regexpLiterals))]}
+		}
+	}
+	switch r.Intn(4) {
+	case 0:
+		n := &regexpNode{kind: reAlt}
+		for i, k := 0, 2+r.Intn(2); i < k; i++ {
+			n.kids = append(n.kids, generateRegexpNode(r, depth-1))
+		}
+		return n
+	case 1:
+		return &regexpNode{kind: reGroup, capture: r.Intn(2) == 0, kids: []*regexpNode{generateRegexpNode(r, depth-1)}}
+	case 2:
+		quants := []string{"*", "+", "?", "{2}", "{1,3}", "*?", "+?"}
+		return &regexpNode{kind: reRepeat, quant: quants[r.Intn(len(quants))], kids: []*regexpNode{generateRegexpNode(r, depth-1)}}
+	default:
+		n := &regexpNode{kind: reConcat}
+		for i, k := 0, 2+r.Intn(3); i < k; i++ {
+			n.kids = append(n.kids, generateRegexpNode(r, depth-1))
+		}
+		return n
+	}
+}
+
+// render serializes the pattern.
+func (n *regexpNode) render() string {
+	var b strings.Builder
+	n.write(&b)
+	return b.String()
+}
+
+// write appends the serialized node to b.
+func (n *regexpNode) write(b *strings.Builder) {
+	switch n.kind {
+	case reLit:
+		b.WriteString(regexp.QuoteMeta(string(n.lit)))
+	case reDot:
+		b.WriteString(".")
+	case reClass:
+		b.WriteString("[")
+		if n.negated {
+			b.WriteString("^")
+		}
+		b.WriteString(strings.Join(n.items, ""))
+		b.WriteString("]")
+	case reConcat:
+		for _, k := range n.kids {
+			// alternation binds loosest: wrap it inside a concatenation
+			if k.kind == reAlt {
+				writeGrouped(b, k)
+				continue
+			}
+			k.write(b)
+		}
+	case reAlt:
+		for i, k := range n.kids {
+			if i > 0 {
+				b.WriteString("|")
+			}
+			k.write(b)
+		}
+	case reGroup:
+		if n.capture {
+			b.WriteString("(")
+		} else {
+			b.WriteString("(?:")
+		}
+		n.kids[0].write(b)
+		b.WriteString(")")
+	case reRepeat:
+		// quantifiers apply to a single atom; wrap anything larger
+		if k := n.kids[0]; k.kind == reLit || k.kind == reDot || k.kind == reClass || k.kind == reGroup {
+			k.write(b)
+		} else {
+			writeGrouped(b, k)
+		}
+		b.WriteString(n.quant)
+	default:
+		panic(fmt.Sprintf("gen.Regexp: unknown node kind %d", n.kind))
+	}
+}
+
+// writeGrouped writes n inside a non-capturing group.
+func writeGrouped(b *strings.Builder, n *regexpNode) {
+	b.WriteString("(?:")
+	n.write(b)
+	b.WriteString(")")
+}
+
+// clone returns a deep copy of the tree.
+func (n *regexpNode) clone() *regexpNode {
+	c := *n
+	c.items = append([]string(nil), n.items...)
+	c.kids = nil
+	for _, k := range n.kids {
+		c.kids = append(c.kids, k.clone())
+	}
+	return &c
+}
+
+// preorder lists the nodes of the tree in preorder.
+func (n *regexpNode) preorder() []*regexpNode {
+	out := []*regexpNode{n}
+	for _, k := range n.kids {
+		out = append(out, k.preorder()...)
+	}
+	return out
+}
@@ -0,0 +1,73 @@
+package gen
+
+import (
+	"math/rand"
+	"regexp"
+	"testing"
+)
+
+func TestRegexp(t *testing.T) {
+	g := Regexp(Size{Max: 5})
+	r := rand.New(rand.NewSource(123))
+
+	for i := 0; i < 500; i++ {
+		pattern, shrink := g.Generate(r, Size{})
+		if _, err := regexp.Compile(pattern); err != nil {
+			t.Fatalf("Regexp().Generate() = %q does not compile: %v", pattern, err)
+		}
+		if shrink == nil {
+			t.Fatal("Regexp().Generate() returned nil shrinker")
+		}
+	}
+}
+
+func TestRegexp_ShrinksToLiteral(t *testing.T) {
+	g := Regexp(Size{Max: 4})
+	r := rand.New(rand.NewSource(42))
+
+	for i := 0; i < 30; i++ {
+		pattern, shrink := g.Generate(r, Size{})
+		min := pattern
+		for j := 0; j < 2000; j++ {
+			next, ok := shrink(true)
+			if !ok {
+				break
+			}
+			if _, err := regexp.Compile(next); err != nil {
+				t.Fatalf("shrink candidate %q does not compile: %v", next, err)
+			}
+			min = next
+		}
+		if min != "a" {
+			t.Errorf("Regexp() %q shrunk to %q, expected 'a'", pattern, min)
+		}
+	}
+}
+
+func TestRegexp_ShrinkKeepsFailingFeature(t *testing.T) {
+	g := Regexp(Size{Max: 4})
+	r := rand.New(rand.NewSource(9))
+
+	// the "bug" needs a capturing group: shrinking must keep one
+	fails := func(p string) bool { return regexp.MustCompile(p).NumSubexp() > 0 }
+	for i := 0; i < 50; i++ {
+		pattern, shrink := g.Generate(r, Size{})
+		if !fails(pattern) {
+			continue
+		}
+		min, accept := pattern, false
+		for j := 0; j < 2000; j++ {
+			next, ok := shrink(accept)
+			if !ok {
+				break
+			}
+			accept = fails(next)
+			if accept {
+				min = next
+			}
+		}
+		if min != "(a)" {
+			t.Errorf("Regexp() %q shrunk to %q, expected '(a)'", pattern, min)
+		}
+	}
+}
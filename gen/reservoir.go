@@ -0,0 +1,141 @@
+package gen
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+)
+
+// reservoirWindow is the number of items Reservoir pulls from its source per
+// example, so effectively infinite sources are supported.
+const reservoirWindow = 1024
+
+// Reservoir generates uniform samples of k items from a pull-based source,
+// for feeding streaming or external data into properties. Each example pulls
+// the next items of the stream (up to 1024, fewer when source reports the end
+// by returning false) and keeps k of them by reservoir sampling, in stream
+// order. When the stream runs dry the sample holds every item pulled, so it
+// may be shorter than k (or empty). The source is shared by every example;
+// it is called from Generate only, never concurrently by ForAll.
+// Panics if k < 0.
+// Shrink: drops items, first down to the empty sample, then halves and single
+// items (R->L), reducing k toward zero.
+func Reservoir[T any](source func() (T, bool), k int) Generator[[]T] {
+	if k < 0 {
+		panic(fmt.Sprintf("gen.Reservoir: k=%d, must be >= 0", k))
+	}
+	return From(func(r *rand.Rand, sz Size) ([]T, Shrinker[[]T]) {
+		if r == nil {
+			r = rand.New(rand.NewSource(rand.Int63())) // #nosec G404 -- Using math/rand for deterministic property-based testing
+		}
+
+		// Algorithm R, remembering each kept item's stream position
+		sample := make([]T, 0, k)
+		pos := make([]int, 0, k)
+		if k > 0 {
+			for i := 0; i < reservoirWindow; i++ {
+				v, ok := source()
+				if !ok {
+					break
+				}
+				if len(sample) < k {
+					sample, pos = append(sample, v), append(pos, i)
+				} else if j := r.Intn(i + 1); j < k {
+					sample[j], pos[j] = v, i
+				}
+			}
+		}
+		// restore stream order
+		order := make([]T, len(sample))
+		rank := make([]int, len(sample))
+		for i := range rank {
+			rank[i] = i
+		}
+		sort.Slice(rank, func(a, b int) bool { return pos[rank[a]] < pos[rank[b]] })
+		for i, idx := range rank {
+			order[i] = sample[idx]
+		}
+		sz.Budget.Spend(len(order))
+
+		// shrink by item indices: a candidate keeps a subset of the sample
+		cur := make([]int, len(order))
+		for i := range cur {
+			cur[i] = i
+		}
+		values := func(idx []int) []T {
+			out := make([]T, len(idx))
+			for i, j := range idx {
+				out[i] = order[j]
+			}
+			return out
+		}
+
+		queue := make([][]int, 0, 16)
+		tried := map[string]struct{}{fmt.Sprint(cur): {}}
+		var queued map[string]struct{}
+		var last []int
+
+		push := func(idx []int) {
+			key := fmt.Sprint(idx)
+			if _, ok := tried[key]; ok {
+				return
+			}
+			if _, ok := queued[key]; ok {
+				return
+			}
+			queued[key] = struct{}{}
+			queue = append(queue, idx)
+		}
+
+		drop := func(base []int, i, j int) []int {
+			out := make([]int, 0, len(base)-(j-i))
+			out = append(out, base[:i]...)
+			return append(out, base[j:]...)
+		}
+
+		grow := func(base []int) {
+			queue = queue[:0]
+			queued = map[string]struct{}{}
+			L := len(base)
+			if L == 0 {
+				return
+			}
+			push([]int{})
+			push(drop(base, L/2, L))
+			push(drop(base, 0, L/2))
+			for i := L - 1; i >= 0; i-- {
+				push(drop(base, i, i+1))
+			}
+		}
+		grow(cur)
+
+		pop := func() ([]int, bool) {
+			if len(queue) == 0 {
+				return nil, false
+			}
+			var v []int
+			if GetShrinkStrategy() == ShrinkStrategyDFS {
+				v = queue[len(queue)-1]
+				queue = queue[:len(queue)-1]
+			} else {
+				v = queue[0]
+				queue = queue[1:]
+			}
+			tried[fmt.Sprint(v)] = struct{}{}
+			return v, true
+		}
+
+		return order, func(accept bool) ([]T, bool) {
+			if accept && last != nil && fmt.Sprint(last) != fmt.Sprint(cur) {
+				cur = last
+				grow(cur)
+			}
+			nxt, ok := pop()
+			if !ok {
+				return nil, false
+			}
+			last = nxt
+			return values(nxt), true
+		}
+	})
+}
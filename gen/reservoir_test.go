@@ -0,0 +1,122 @@
+package gen
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+// counting returns an infinite source of 0, 1, 2, ...
+func counting() func() (int, bool) {
+	n := -1
+	return func() (int, bool) {
+		n++
+		return n, true
+	}
+}
+
+// finite returns a source of 0..n-1.
+func finite(n int) func() (int, bool) {
+	i := -1
+	return func() (int, bool) {
+		i++
+		return i, i < n
+	}
+}
+
+func TestReservoir_SampleSize(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	xs, _ := Reservoir(counting(), 10).Generate(r, Size{})
+	if len(xs) != 10 {
+		t.Fatalf("Reservoir() sampled %d items, expected 10", len(xs))
+	}
+	if !sort.IntsAreSorted(xs) {
+		t.Errorf("Reservoir() = %v, expected stream order", xs)
+	}
+	for i := 1; i < len(xs); i++ {
+		if xs[i] == xs[i-1] {
+			t.Errorf("Reservoir() = %v repeats an item", xs)
+		}
+	}
+
+	// the next example continues the stream
+	ys, _ := Reservoir(counting(), 0).Generate(r, Size{})
+	if len(ys) != 0 {
+		t.Errorf("Reservoir(k=0) = %v, expected empty", ys)
+	}
+}
+
+func TestReservoir_ShortStream(t *testing.T) {
+	xs, _ := Reservoir(finite(3), 10).Generate(rand.New(rand.NewSource(1)), Size{})
+	if len(xs) != 3 || xs[0] != 0 || xs[1] != 1 || xs[2] != 2 {
+		t.Errorf("Reservoir() over 3 items = %v, expected [0 1 2]", xs)
+	}
+}
+
+func TestReservoir_Uniform(t *testing.T) {
+	// every item of a 100-item stream is kept with probability k/100
+	counts := make([]int, 100)
+	r := rand.New(rand.NewSource(2))
+	const runs = 2000
+	for i := 0; i < runs; i++ {
+		xs, _ := Reservoir(finite(100), 10).Generate(r, Size{})
+		for _, x := range xs {
+			counts[x]++
+		}
+	}
+	for x, c := range counts {
+		// expected runs*10/100 = 200
+		if c < 130 || c > 270 {
+			t.Errorf("item %d sampled %d times, expected about 200", x, c)
+		}
+	}
+}
+
+func TestReservoir_ShrinkTowardEmpty(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	xs, shrink := Reservoir(counting(), 8).Generate(r, Size{})
+
+	// fails while the sample holds at least 3 items: minimal keeps 3 of them
+	min, accept := xs, false
+	for j := 0; j < 500; j++ {
+		next, ok := shrink(accept)
+		if !ok {
+			break
+		}
+		for _, v := range next {
+			if !containsInt(xs, v) {
+				t.Fatalf("shrink candidate %v holds items not in %v", next, xs)
+			}
+		}
+		accept = len(next) >= 3
+		if accept {
+			min = next
+		}
+	}
+	if len(min) != 3 {
+		t.Errorf("Reservoir() %v shrunk to %v, expected 3 items", xs, min)
+	}
+
+	_, shrink = Reservoir(counting(), 5).Generate(r, Size{})
+	if next, ok := shrink(false); !ok || len(next) != 0 {
+		t.Errorf("first shrink candidate = %v, expected the empty sample", next)
+	}
+}
+
+func TestReservoir_NegativeKPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Reservoir(k=-1) did not panic")
+		}
+	}()
+	Reservoir(counting(), -1)
+}
+
+func containsInt(xs []int, x int) bool {
+	for _, v := range xs {
+		if v == x {
+			return true
+		}
+	}
+	return false
+}
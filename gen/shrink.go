@@ -0,0 +1,359 @@
+// File: gen/shrink.go
+package gen
+
+import (
+	"math"
+	"unicode/utf8"
+)
+
+// Building-block shrinkers for custom generators written with From: each
+// returns the shrinker the corresponding built-in generator uses (or would
+// use) for a value, so hand-written generators shrink like the built-in ones.
+//
+//	pos := gen.From(func(r *rand.Rand, _ gen.Size) (int, gen.Shrinker[int]) {
+//	    v := 1 + r.Intn(100)
+//	    return v, gen.ShrinkIntRange(v, 1, 100)
+//	})
+//
+// All of them follow the Shrinker contract (accept reports that the previous
+// candidate still fails the property and becomes the new current value),
+// honor the BFS/DFS strategy and never propose the same candidate twice.
+
+// ShrinkBool returns a shrinker for v, as used by Bool: true shrinks to
+// false; false has no candidates.
+func ShrinkBool(v bool) Shrinker[bool] {
+	cur, last := v, v
+
+	queue := make([]bool, 0, 2)
+	seen := map[bool]struct{}{cur: {}}
+
+	push := func(b bool) {
+		if _, ok := seen[b]; ok {
+			return
+		}
+		seen[b] = struct{}{}
+		queue = append(queue, b)
+	}
+
+	grow := func(base bool) {
+		queue = queue[:0]
+		// Heuristic: try false first
+		if base {
+			push(false)
+		}
+		if !base {
+			push(true)
+		}
+	}
+	grow(cur)
+
+	pop := func() (bool, bool) {
+		if len(queue) == 0 {
+			return false, false
+		}
+		if GetShrinkStrategy() == ShrinkStrategyDFS {
+			v := queue[len(queue)-1]
+			queue = queue[:len(queue)-1]
+			return v, true
+		}
+		v := queue[0]
+		queue = queue[1:]
+		return v, true
+	}
+
+	return func(accept bool) (bool, bool) {
+		if accept && last != cur {
+			cur = last
+			grow(cur)
+		}
+		nxt, ok := pop()
+		if !ok {
+			return false, false
+		}
+		last = nxt
+		return nxt, true
+	}
+}
+
+// ShrinkInt returns a shrinker for v toward 0, proposing values in
+// [-|v|, |v|]: 0, bisections toward 0, a unit step, and -v.
+// Accepting every candidate converges to 0 in one step; when only some
+// values fail, each accepted candidate is closer to 0 (or the sign flip),
+// and shrinking ends at a local minimum, close to the failing value nearest
+// to 0.
+func ShrinkInt(v int) Shrinker[int] {
+	lo, hi := -v, v
+	if v < 0 {
+		lo, hi = v, -v
+	}
+	if hi < 0 { // v == math.MinInt
+		hi = math.MaxInt
+	}
+	return ShrinkIntRange(v, lo, hi)
+}
+
+// ShrinkIntRange returns a shrinker for v that stays in [min, max], as used by
+// IntRange: toward 0 when 0 is in range, otherwise toward the bound closest to
+// 0, trying bisections, a unit step and both bounds. Converges like ShrinkInt.
+// Reversed bounds (min > max) are swapped; v is clamped into the range.
+func ShrinkIntRange(v, min, max int) Shrinker[int] {
+	if min > max {
+		min, max = max, min
+	}
+	_, s := intShrinkInit(v, min, max)
+	return s
+}
+
+// ShrinkInt64 is ShrinkInt for int64 values.
+func ShrinkInt64(v int64) Shrinker[int64] {
+	_, s := int64ShrinkInit(v, math.MinInt64, math.MaxInt64)
+	return s
+}
+
+// ShrinkFloat64 returns a shrinker for v toward 0, as used by Float64: 0,
+// bisections toward 0, the next float toward 0 and -v. NaN and ±Inf shrink
+// to finite values (0, 1, -1); candidates are never NaN or infinite.
+// Accepting every candidate converges to 0.
+func ShrinkFloat64(v float64) Shrinker[float64] {
+	_, s := float64ShrinkInit(v, math.Inf(-1), math.Inf(1), false, false)
+	return s
+}
+
+// ShrinkString returns a shrinker for s toward "": shorter prefixes (from
+// the empty string up), s without its first rune, then each rune (R->L)
+// replaced with 'a'. It never splits a multi-byte rune.
+// Accepting every candidate converges to ""; when only some strings fail, it
+// ends at one where no single prefix, leading rune removal or 'a'
+// replacement still fails.
+func ShrinkString(s string) Shrinker[string] {
+	self := func(s string) string { return s }
+	return ShrinkNeighbors(s, self, self, func(base string, push func(string)) {
+		rs := []rune(base)
+		// (1) shorter prefixes, shortest first, then without the first rune
+		for n := 0; n < len(rs); n++ {
+			push(string(rs[:n]))
+		}
+		if len(rs) > 1 {
+			push(string(rs[1:]))
+		}
+		// (2) simplest character (R->L)
+		for i := len(rs) - 1; i >= 0; i-- {
+			if rs[i] != 'a' {
+				c := append([]rune(nil), rs...)
+				c[i] = 'a'
+				if s := string(c); utf8.ValidString(s) {
+					push(s)
+				}
+			}
+		}
+	})
+}
+
+// ShrinkNeighbors returns a shrinker for start searching its neighbors, like
+// most shrinkers of this package: neighbors(base, push) pushes the
+// candidates one step simpler than base, which are proposed in push order
+// (BFS) or from the last pushed (DFS), and accepting one replaces them with
+// its own neighbors. key tells candidates apart: one already proposed is
+// never proposed again, while one only queued before a rebase may be pushed
+// and proposed later. render converts a candidate to the shrinker's value.
+// Accepting every candidate follows the neighbors down to a value that has
+// none; otherwise it ends at one none of whose neighbors still fails.
+//
+//	shrink := gen.ShrinkNeighbors(start, Point.String, Point.String, func(p Point, push func(Point)) {
+//	    push(Point{0, p.Y})
+//	    push(Point{p.X, 0})
+//	})
+func ShrinkNeighbors[S any, K comparable, T any](start S, key func(S) K, render func(S) T, neighbors func(base S, push func(S))) Shrinker[T] {
+	queue := make([]S, 0, 32)
+	tried := map[K]struct{}{key(start): {}}
+	var queued map[K]struct{}
+	var last S
+	hasLast := false
+
+	push := func(c S) {
+		k := key(c)
+		if _, ok := tried[k]; ok {
+			return
+		}
+		if _, ok := queued[k]; ok {
+			return
+		}
+		queued[k] = struct{}{}
+		queue = append(queue, c)
+	}
+	grow := func(base S) {
+		queue = queue[:0]
+		queued = map[K]struct{}{}
+		neighbors(base, push)
+	}
+	grow(start)
+
+	return func(accept bool) (T, bool) {
+		if accept && hasLast {
+			grow(last)
+			hasLast = false
+		}
+		if len(queue) == 0 {
+			var zero T
+			return zero, false
+		}
+		var v S
+		if GetShrinkStrategy() == ShrinkStrategyDFS {
+			v = queue[len(queue)-1]
+			queue = queue[:len(queue)-1]
+		} else {
+			v = queue[0]
+			queue = queue[1:]
+		}
+		tried[key(v)] = struct{}{}
+		last, hasLast = v, true
+		return render(v), true
+	}
+}
+
+// ShrinkSlice returns a shrinker for s in two phases:
+//
+//	(1) remove elements: all, then blocks (halves, quarters, ...), then
+//	    single elements (R->L), rebasing on every accepted candidate
+//	(2) shrink each remaining element in place (left→right) with elem(x),
+//	    which may be nil to skip this phase (e.g. gen.ShrinkInt)
+//
+// Accepting every candidate converges to the empty slice; otherwise the
+// result has no removable element and no element elem can shrink further.
+func ShrinkSlice[T any](s []T, elem func(T) Shrinker[T]) Shrinker[[]T] {
+	// phase (1): removals, queue-based with rebase on accept
+	cur := append(([]T)(nil), s...)
+	queue := make([][]T, 0, 32)
+	tried := map[string]struct{}{sig(cur): {}}
+	var queued map[string]struct{}
+	var last []T
+	hasLast := false
+
+	push := func(c []T) {
+		k := sig(c)
+		if _, ok := tried[k]; ok {
+			return
+		}
+		if _, ok := queued[k]; ok {
+			return
+		}
+		queued[k] = struct{}{}
+		queue = append(queue, c)
+	}
+
+	rem := func(base []T, i, j int) []T {
+		out := make([]T, 0, len(base)-(j-i))
+		out = append(out, base[:i]...)
+		return append(out, base[j:]...)
+	}
+
+	grow := func(base []T) {
+		queue = queue[:0]
+		queued = map[string]struct{}{}
+		L := len(base)
+		if L == 0 {
+			return
+		}
+		push(rem(base, 0, L))
+		for chunk := L / 2; chunk >= 1; chunk /= 2 {
+			for i := 0; i+chunk <= L; i += chunk {
+				push(rem(base, i, i+chunk))
+			}
+		}
+		for i := L - 1; i >= 0; i-- {
+			push(rem(base, i, i+1))
+		}
+	}
+	grow(cur)
+
+	pop := func() ([]T, bool) {
+		if len(queue) == 0 {
+			return nil, false
+		}
+		var v []T
+		if GetShrinkStrategy() == ShrinkStrategyDFS {
+			v = queue[len(queue)-1]
+			queue = queue[:len(queue)-1]
+		} else {
+			v = queue[0]
+			queue = queue[1:]
+		}
+		tried[sig(v)] = struct{}{}
+		return v, true
+	}
+
+	// phase (2): each element in place
+	removing := true
+	var each func(accept bool) (int, T, bool)
+
+	return func(accept bool) ([]T, bool) {
+		if removing {
+			if accept && hasLast {
+				cur = last
+				grow(cur)
+			}
+			if c, ok := pop(); ok {
+				last, hasLast = c, true
+				return append(([]T)(nil), c...), true
+			}
+			removing = false
+			accept = false
+			if elem != nil {
+				each = shrinkInPlace(len(cur), func(i int) Shrinker[T] { return elem(cur[i]) }, nil, func(i int, v T) { cur[i] = v })
+			}
+		}
+		if each == nil {
+			return nil, false
+		}
+		i, v, ok := each(accept)
+		if !ok {
+			return nil, false
+		}
+		cand := append(([]T)(nil), cur...)
+		cand[i] = v
+		return cand, true
+	}
+}
+
+// shrinkInPlace shrinks the n elements of a collection one at a time, first
+// to last, without changing its shape (phase (2) of ShrinkSlice): element i
+// is shrunk with shrinker(i) (nil skips it), and its candidates v for which
+// fits(i, v) is false (when fits is set) count as rejected. The returned
+// function proposes the next candidate as its index and value; accepting it
+// stores the value with set before moving on, so the next candidates are
+// built on it.
+func shrinkInPlace[T any](n int, shrinker func(i int) Shrinker[T], fits func(i int, v T) bool, set func(i int, v T)) func(accept bool) (int, T, bool) {
+	idx := 0
+	var shk Shrinker[T]
+	started := false
+	// pending holds the candidate value until the runner reports whether it
+	// still fails
+	var pending T
+	hasPending := false
+
+	return func(accept bool) (int, T, bool) {
+		if accept && hasPending {
+			set(idx, pending)
+		}
+		for idx < n {
+			if !started {
+				shk, started = shrinker(idx), true
+			}
+			if shk != nil {
+				for v, ok := shk(accept && hasPending); ok; v, ok = shk(false) {
+					if fits == nil || fits(idx, v) {
+						pending, hasPending = v, true
+						return idx, v, true
+					}
+					hasPending = false
+				}
+			}
+			idx++
+			shk, started = nil, false
+			hasPending = false
+			accept = false
+		}
+		var zero T
+		return 0, zero, false
+	}
+}
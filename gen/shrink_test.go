@@ -0,0 +1,254 @@
+package gen
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// minimize drives s like the runner does, with fails as the property, and
+// returns the last accepted value (start if none was).
+func minimize[T any](start T, s Shrinker[T], fails func(T) bool) T {
+	min, accept := start, false
+	for i := 0; i < 10000; i++ {
+		v, ok := s(accept)
+		if !ok {
+			break
+		}
+		if accept = fails(v); accept {
+			min = v
+		}
+	}
+	return min
+}
+
+func TestShrinkBool(t *testing.T) {
+	SetShrinkStrategy("bfs")
+	if v, ok := ShrinkBool(true)(false); !ok || v {
+		t.Errorf("ShrinkBool(true) first candidate = %v, %v; expected false, true", v, ok)
+	}
+	if v, ok := ShrinkBool(false)(false); ok && !v {
+		t.Errorf("ShrinkBool(false) proposed %v", v)
+	}
+}
+
+func TestShrinkInt(t *testing.T) {
+	SetShrinkStrategy("bfs")
+	for _, v := range []int{1000, -1000, 1, math.MaxInt, math.MinInt} {
+		if got := minimize(v, ShrinkInt(v), func(int) bool { return true }); got != 0 {
+			t.Errorf("ShrinkInt(%d) accepting all = %d, expected 0", v, got)
+		}
+	}
+	// fails for |x| >= 10: ends near the threshold, never below it
+	got := minimize(1000, ShrinkInt(1000), func(x int) bool { return x >= 10 || x <= -10 })
+	if got < 10 && got > -10 || got > 20 || got < -20 {
+		t.Errorf("ShrinkInt(1000) with |x| >= 10 failing = %d, expected within [10, 20] in magnitude", got)
+	}
+}
+
+func TestShrinkIntRange(t *testing.T) {
+	SetShrinkStrategy("bfs")
+	s := ShrinkIntRange(70, 50, 100)
+	for {
+		v, ok := s(true)
+		if !ok {
+			break
+		}
+		if v < 50 || v > 100 {
+			t.Fatalf("ShrinkIntRange(70, 50, 100) proposed %d, out of range", v)
+		}
+	}
+	if got := minimize(70, ShrinkIntRange(70, 100, 50), func(int) bool { return true }); got != 50 {
+		t.Errorf("ShrinkIntRange(70, 100, 50) accepting all = %d, expected 50", got)
+	}
+}
+
+func TestShrinkInt64(t *testing.T) {
+	SetShrinkStrategy("bfs")
+	for _, v := range []int64{1 << 40, -(1 << 40), math.MaxInt64} {
+		if got := minimize(v, ShrinkInt64(v), func(int64) bool { return true }); got != 0 {
+			t.Errorf("ShrinkInt64(%d) accepting all = %d, expected 0", v, got)
+		}
+	}
+}
+
+func TestShrinkFloat64(t *testing.T) {
+	SetShrinkStrategy("bfs")
+	for _, v := range []float64{123.456, -0.5, math.NaN(), math.Inf(1), math.Inf(-1)} {
+		s := ShrinkFloat64(v)
+		got, accept := v, false
+		for i := 0; i < 1000; i++ {
+			c, ok := s(accept)
+			if !ok {
+				break
+			}
+			if math.IsNaN(c) || math.IsInf(c, 0) {
+				t.Fatalf("ShrinkFloat64(%v) proposed %v", v, c)
+			}
+			got, accept = c, true
+		}
+		if got != 0 {
+			t.Errorf("ShrinkFloat64(%v) accepting all = %v, expected 0", v, got)
+		}
+	}
+}
+
+func TestShrinkString(t *testing.T) {
+	SetShrinkStrategy("bfs")
+	if got := minimize("hello, wörld", ShrinkString("hello, wörld"), func(string) bool { return true }); got != "" {
+		t.Errorf("ShrinkString accepting all = %q, expected \"\"", got)
+	}
+
+	// fails when the string contains 'x': a single "x" is minimal
+	start := "abcxyzx€"
+	got := minimize(start, ShrinkString(start), func(s string) bool { return strings.ContainsRune(s, 'x') })
+	if got != "x" {
+		t.Errorf("ShrinkString(%q) with 'x' failing = %q, expected \"x\"", start, got)
+	}
+
+	s := ShrinkString("€uro")
+	for {
+		c, ok := s(false)
+		if !ok {
+			break
+		}
+		if !utf8.ValidString(c) {
+			t.Fatalf("ShrinkString(\"€uro\") proposed invalid UTF-8 %q", c)
+		}
+	}
+}
+
+func TestShrinkSlice(t *testing.T) {
+	SetShrinkStrategy("bfs")
+	start := []int{5, 80, -3, 12, 40, 7}
+	if got := minimize(start, ShrinkSlice(start, ShrinkInt), func([]int) bool { return true }); len(got) != 0 {
+		t.Errorf("ShrinkSlice accepting all = %v, expected []", got)
+	}
+
+	// fails when some element is >= 10: a single element near 10 is minimal
+	got := minimize(start, ShrinkSlice(start, ShrinkInt), func(s []int) bool {
+		for _, x := range s {
+			if x >= 10 {
+				return true
+			}
+		}
+		return false
+	})
+	if len(got) != 1 || got[0] < 10 || got[0] > 20 {
+		t.Errorf("ShrinkSlice(%v) with an element >= 10 failing = %v, expected one element in [10, 20]", start, got)
+	}
+
+	// without an element shrinker only removals are tried
+	got = minimize(start, ShrinkSlice[int](start, nil), func(s []int) bool { return len(s) >= 2 })
+	if len(got) != 2 {
+		t.Errorf("ShrinkSlice(%v, nil) with len >= 2 failing = %v, expected 2 elements", start, got)
+	}
+	if !reflect.DeepEqual(start, []int{5, 80, -3, 12, 40, 7}) {
+		t.Errorf("ShrinkSlice modified its input: %v", start)
+	}
+}
+
+func TestShrinkSlice_DFS(t *testing.T) {
+	SetShrinkStrategy("dfs")
+	defer SetShrinkStrategy("bfs")
+	start := []string{"foo", "bar", "baz"}
+	got := minimize(start, ShrinkSlice(start, ShrinkString), func(s []string) bool { return len(s) > 0 })
+	if !reflect.DeepEqual(got, []string{""}) {
+		t.Errorf("ShrinkSlice(%v) with non-empty failing = %q, expected [\"\"]", start, got)
+	}
+}
+
+func TestShrinkNeighbors(t *testing.T) {
+	type point struct{ X, Y int }
+	key := func(p point) string { return fmt.Sprint(p) }
+	neighbors := func(p point, push func(point)) {
+		if p.X > 0 {
+			push(point{p.X - 1, p.Y})
+		}
+		if p.Y > 0 {
+			push(point{p.X, p.Y - 1})
+		}
+	}
+	sum := func(p point) int { return p.X + p.Y }
+
+	for _, strategy := range []string{"bfs", "dfs"} {
+		SetShrinkStrategy(strategy)
+
+		// every candidate is proposed once, and accepting them all converges
+		seen := map[point]bool{}
+		min := minimize(point{3, 2}, ShrinkNeighbors(point{3, 2}, key, func(p point) point { return p }, neighbors), func(p point) bool {
+			if seen[p] {
+				t.Errorf("%s: %v proposed twice", strategy, p)
+			}
+			seen[p] = true
+			return true
+		})
+		if min != (point{}) {
+			t.Errorf("%s: accepting every candidate ended at %v, expected {0 0}", strategy, min)
+		}
+
+		// render converts candidates; a failure needing X+Y >= 3 stops there
+		got := minimize(5, ShrinkNeighbors(point{3, 2}, key, sum, neighbors), func(n int) bool { return n >= 3 })
+		if got != 3 {
+			t.Errorf("%s: shrank to sum %d, expected 3", strategy, got)
+		}
+	}
+	SetShrinkStrategy("bfs")
+
+	// the first candidate follows push order under BFS and reverse under DFS
+	first := func() point {
+		v, _ := ShrinkNeighbors(point{1, 1}, key, func(p point) point { return p }, neighbors)(false)
+		return v
+	}
+	if v := first(); v != (point{0, 1}) {
+		t.Errorf("bfs: first candidate %v, expected {0 1}", v)
+	}
+	SetShrinkStrategy("dfs")
+	if v := first(); v != (point{1, 0}) {
+		t.Errorf("dfs: first candidate %v, expected {1 0}", v)
+	}
+	SetShrinkStrategy("bfs")
+}
+
+func TestShrinkInPlace(t *testing.T) {
+	// down proposes v-1, v-2, ..., 0
+	down := func(v int) Shrinker[int] {
+		next := v - 1
+		return func(bool) (int, bool) {
+			if next < 0 {
+				return 0, false
+			}
+			next--
+			return next + 1, true
+		}
+	}
+	vals := []int{5, 7, 3}
+	each := shrinkInPlace(len(vals), func(i int) Shrinker[int] {
+		if i == 1 {
+			return nil
+		}
+		return down(vals[i])
+	}, func(i, v int) bool { return v%2 == 0 }, func(i, v int) { vals[i] = v })
+
+	// odd candidates do not fit, element 1 does not shrink, and only the
+	// accepted values (>= 2) are stored
+	var got []string
+	accept := false
+	for {
+		i, v, ok := each(accept)
+		if !ok {
+			break
+		}
+		got = append(got, fmt.Sprintf("%d:%d", i, v))
+		accept = v >= 2
+	}
+	if want := []string{"0:4", "0:2", "0:0", "2:2", "2:0"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("candidates %v, expected %v", got, want)
+	}
+	if want := []int{2, 7, 2}; !reflect.DeepEqual(vals, want) {
+		t.Errorf("stored %v, expected %v", vals, want)
+	}
+}
@@ -34,11 +34,12 @@ func SliceOf[T any](elem Generator[T], size Size) Generator[[]T] {
 			n += r.Intn(size.Max - size.Min + 1)
 		}
 
-		// generate elems + capture shrinkers
+		// generate elems + capture shrinkers (charging the example budget)
+		sz.Budget.Spend(n)
 		vals := make([]T, n)
 		shks := make([]Shrinker[T], n)
 		for i := 0; i < n; i++ {
-			v, s := elem.Generate(r, Size{})
+			v, s := elem.Generate(r, sz.elem())
 			vals[i], shks[i] = v, s
 		}
 		cur := append(([]T)(nil), vals...) // snapshot
@@ -104,7 +105,7 @@ func SliceOf[T any](elem Generator[T], size Size) Generator[[]T] {
 			if len(queue) == 0 {
 				return nil, false
 			}
-			if shrinkStrategy == ShrinkStrategyDFS {
+			if GetShrinkStrategy() == ShrinkStrategyDFS {
 				v := queue[len(queue)-1]
 				queue = queue[:len(queue)-1]
 				return v, true
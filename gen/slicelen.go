@@ -0,0 +1,177 @@
+// File: gen/slicelen.go
+package gen
+
+import (
+	"math"
+	"math/rand"
+)
+
+// sliceLenBiasMax is the largest length SliceLenBias draws for "many".
+const sliceLenBiasMax = 32
+
+// SliceOfLen generates []T whose length is drawn from lenGen (negative lengths
+// count as 0), so the length distribution is under the caller's control
+// instead of uniform over [Min, Max], e.g. mostly empty or single-element
+// slices with an occasional large one (one example in four):
+//
+//	small := gen.IntRange(0, 1)
+//	lens := gen.OneOf(small, small, small, gen.IntRange(500, 1000))
+//	gen.SliceOfLen(gen.Int(gen.Size{}), lens)
+//
+// Shrink:
+//
+//	(1) truncate to the lengths proposed by lenGen's shrinker (skipping those
+//	    not shorter than the current slice)
+//	(2) shrink each element in place (left→right) with its own shrinker
+func SliceOfLen[T any](g Generator[T], lenGen Generator[int]) Generator[[]T] {
+	return From(func(r *rand.Rand, sz Size) ([]T, Shrinker[[]T]) {
+		if r == nil {
+			r = rand.New(rand.NewSource(rand.Int63())) // #nosec G404 -- Using math/rand for deterministic property-based testing
+		}
+
+		n, shrinkLen := lenGen.Generate(r, sz.elem())
+		if n < 0 {
+			n = 0
+		}
+
+		sz.Budget.Spend(n)
+		cur := make([]T, n)
+		shks := make([]Shrinker[T], n)
+		for i := 0; i < n; i++ {
+			cur[i], shks[i] = g.Generate(r, sz.elem())
+		}
+		out := append(([]T)(nil), cur...)
+
+		// phase (1): lengths from lenGen's shrinker; last holds the proposed
+		// length until the runner reports whether it still fails
+		truncating := shrinkLen != nil
+		last := len(cur)
+
+		// phase (2): element i in place with shks[i]
+		var each func(accept bool) (int, T, bool)
+
+		return out, func(accept bool) ([]T, bool) {
+			if truncating {
+				accepted := accept && last != len(cur)
+				if accepted {
+					cur = cur[:last]
+					shks = shks[:last]
+				}
+				for {
+					k, ok := shrinkLen(accepted)
+					if !ok {
+						break
+					}
+					accepted = false
+					if k < 0 {
+						k = 0
+					}
+					if k < len(cur) {
+						last = k
+						return append(([]T)(nil), cur[:k]...), true
+					}
+				}
+				truncating = false
+				last = len(cur)
+				accept = false
+			}
+
+			if each == nil {
+				each = shrinkInPlace(len(cur), func(i int) Shrinker[T] { return shks[i] }, nil, func(i int, v T) { cur[i] = v })
+			}
+			i, v, ok := each(accept)
+			if !ok {
+				return nil, false
+			}
+			cand := append(([]T)(nil), cur...)
+			cand[i] = v
+			return cand, true
+		}
+	})
+}
+
+// SliceLenBias generates slice lengths for SliceOfLen: 0 with weight empty, 1
+// with weight single, and a length in [2, 32] (uniform) with weight many.
+// Weights are relative (0.3, 0.3, 0.4 is the same as 3, 3, 4), so the empty
+// and single-element boundaries get as many examples as the caller wants,
+// e.g. a third each:
+//
+//	gen.SliceOfLen(gen.Int(gen.Size{}), gen.SliceLenBias(1, 1, 1))
+//
+// Panics if a weight is negative, NaN or infinite, or if all are 0.
+// Shrink: toward the boundaries: 0, 1 and 2, then half the length, then one
+// less.
+func SliceLenBias(empty, single, many float64) Generator[int] {
+	for _, w := range []float64{empty, single, many} {
+		if w < 0 || math.IsNaN(w) || math.IsInf(w, 0) {
+			panic("gen.SliceLenBias: weights must be finite and non-negative")
+		}
+	}
+	total := empty + single + many
+	if total == 0 {
+		panic("gen.SliceLenBias: needs a positive weight")
+	}
+	return From(func(r *rand.Rand, _ Size) (int, Shrinker[int]) {
+		if r == nil {
+			r = rand.New(rand.NewSource(rand.Int63())) // #nosec G404 -- Using math/rand for deterministic property-based testing
+		}
+		var n int
+		switch u := r.Float64() * total; {
+		case u < empty:
+			n = 0
+		case u < empty+single || many == 0:
+			n = 1
+		default:
+			n = 2 + r.Intn(sliceLenBiasMax-1)
+		}
+		return n, createSliceLenBiasShrinker(n)
+	})
+}
+
+// createSliceLenBiasShrinker creates a shrinker for SliceLenBias lengths.
+func createSliceLenBiasShrinker(initial int) Shrinker[int] {
+	queue := make([]int, 0, 8)
+	tried := map[int]struct{}{initial: {}}
+	var queued map[int]struct{}
+	cur, last := initial, initial
+
+	grow := func(base int) {
+		queue = queue[:0]
+		queued = map[int]struct{}{}
+		for _, k := range []int{0, 1, 2, (base + 2) / 2, base - 1} {
+			if k >= base {
+				continue
+			}
+			if _, ok := tried[k]; ok {
+				continue
+			}
+			if _, ok := queued[k]; ok {
+				continue
+			}
+			queued[k] = struct{}{}
+			queue = append(queue, k)
+		}
+	}
+	grow(cur)
+
+	return func(accept bool) (int, bool) {
+		if accept && last != cur {
+			cur = last
+			grow(cur)
+		}
+		if len(queue) == 0 {
+			return 0, false
+		}
+		var v int
+		if GetShrinkStrategy() == ShrinkStrategyDFS {
+			v = queue[len(queue)-1]
+			queue = queue[:len(queue)-1]
+		} else {
+			v = queue[0]
+			queue = queue[1:]
+		}
+		tried[v] = struct{}{}
+		last = v
+		return v, true
+	}
+}
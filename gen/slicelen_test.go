@@ -0,0 +1,161 @@
+package gen
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestSliceOfLen_Distribution(t *testing.T) {
+	// three examples in four have length 0 or 1, the rest 50..60
+	small := IntRange(0, 1)
+	g := SliceOfLen(IntRange(0, 9), OneOf(small, small, small, IntRange(50, 60)))
+	r := rand.New(rand.NewSource(1))
+
+	smallCount := 0
+	const runs = 400
+	for i := 0; i < runs; i++ {
+		value, shrink := g.Generate(r, Size{})
+		switch {
+		case len(value) <= 1:
+			smallCount++
+		case len(value) < 50 || len(value) > 60:
+			t.Fatalf("SliceOfLen().Generate() length = %d, expected 0..1 or 50..60", len(value))
+		}
+		for _, v := range value {
+			if v < 0 || v > 9 {
+				t.Fatalf("SliceOfLen().Generate() element = %d, expected in [0, 9]", v)
+			}
+		}
+		if shrink == nil {
+			t.Fatal("SliceOfLen().Generate() returned nil shrinker")
+		}
+	}
+	if smallCount < runs*6/10 || smallCount > runs*9/10 {
+		t.Errorf("%d of %d slices had length 0..1, expected about 3/4", smallCount, runs)
+	}
+}
+
+func TestSliceOfLen_NegativeLength(t *testing.T) {
+	value, _ := SliceOfLen(Int(Size{}), Const(-3)).Generate(rand.New(rand.NewSource(1)), Size{})
+	if len(value) != 0 {
+		t.Errorf("SliceOfLen() with length -3 = %v, expected empty", value)
+	}
+}
+
+func TestSliceOfLen_ShrinkLengthThenElements(t *testing.T) {
+	// lengths in [4, 20]: lenGen's shrinker never goes below 4
+	g := SliceOfLen(IntRange(0, 100), IntRange(4, 20))
+	r := rand.New(rand.NewSource(42))
+
+	// fails while some element is >= 10
+	fails := func(s []int) bool {
+		for _, v := range s {
+			if v >= 10 {
+				return true
+			}
+		}
+		return false
+	}
+	var value []int
+	var shrink Shrinker[[]int]
+	for {
+		value, shrink = g.Generate(r, Size{})
+		if fails(value) {
+			break
+		}
+	}
+
+	min, accept := value, false
+	for i := 0; i < 5000; i++ {
+		next, ok := shrink(accept)
+		if !ok {
+			break
+		}
+		if len(next) < 4 {
+			t.Fatalf("shrink candidate %v shorter than lenGen allows", next)
+		}
+		accept = fails(next) && len(next) <= len(min) && sumInts(next) < sumInts(min)
+		if accept {
+			min = next
+		}
+	}
+	// shortest allowed length, one element at the threshold, the rest zero
+	if len(min) != 4 || sumInts(min) != 10 || !fails(min) {
+		t.Errorf("shrinking %v ended at %v, expected 4 elements: one 10, the rest 0", value, min)
+	}
+}
+
+func TestSliceLenBias(t *testing.T) {
+	g := SliceLenBias(0.3, 0.3, 0.4)
+	r := rand.New(rand.NewSource(1))
+	counts := [3]int{}
+	const runs = 1000
+	for i := 0; i < runs; i++ {
+		n, shrink := g.Generate(r, Size{})
+		if shrink == nil {
+			t.Fatal("SliceLenBias().Generate() returned nil shrinker")
+		}
+		switch {
+		case n < 0 || n > 32:
+			t.Fatalf("SliceLenBias().Generate() = %d, expected 0..32", n)
+		case n < 2:
+			counts[n]++
+		default:
+			counts[2]++
+		}
+	}
+	for i, want := range []int{300, 300, 400} {
+		if counts[i] < want-60 || counts[i] > want+60 {
+			t.Errorf("SliceLenBias(0.3, 0.3, 0.4) counts = %v, expected about [300 300 400]", counts)
+			break
+		}
+	}
+
+	// a zero weight is never drawn
+	for i := 0; i < 100; i++ {
+		if n, _ := SliceLenBias(1, 0, 0).Generate(r, Size{}); n != 0 {
+			t.Fatalf("SliceLenBias(1, 0, 0).Generate() = %d, expected 0", n)
+		}
+		if n, _ := SliceLenBias(0, 0, 1).Generate(r, Size{}); n < 2 {
+			t.Fatalf("SliceLenBias(0, 0, 1).Generate() = %d, expected >= 2", n)
+		}
+	}
+
+	for _, w := range [][3]float64{{0, 0, 0}, {-1, 1, 1}, {math.NaN(), 1, 1}, {1, math.Inf(1), 1}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("SliceLenBias(%v) did not panic", w)
+				}
+			}()
+			SliceLenBias(w[0], w[1], w[2])
+		}()
+	}
+}
+
+func TestSliceLenBias_ShrinkToBoundary(t *testing.T) {
+	// fails on slices of two or more elements: the minimal one has two
+	// elements (zeros, except with IntRange's DFS shrinking)
+	for _, strategy := range []string{"bfs", "dfs"} {
+		SetShrinkStrategy(strategy)
+		g := SliceOfLen(IntRange(0, 100), SliceLenBias(1, 1, 1))
+		r := rand.New(rand.NewSource(2))
+		found := 0
+		for i := 0; i < 100 && found < 10; i++ {
+			value, shrink := g.Generate(r, Size{})
+			if len(value) < 2 {
+				continue
+			}
+			found++
+			got := minimize(value, shrink, func(s []int) bool { return len(s) >= 2 })
+			if len(got) != 2 || strategy == "bfs" && sig(got) != sig([]int{0, 0}) {
+				t.Errorf("%s: shrinking %v ended at %v, expected [0 0]", strategy, value, got)
+			}
+		}
+		if found == 0 {
+			t.Fatalf("%s: no slice of two or more elements generated", strategy)
+		}
+	}
+	SetShrinkStrategy("bfs")
+}
@@ -53,6 +53,7 @@ func String(alphabet string, size Size) Generator[string] {
 		if size.Max > size.Min {
 			n += r.Intn(size.Max - size.Min + 1)
 		}
+		sz.Budget.Spend(n)
 		b := make([]rune, n)
 		for i := 0; i < n; i++ {
 			b[i] = rune(alphabet[r.Intn(len(alphabet))])
@@ -60,24 +61,11 @@ func String(alphabet string, size Size) Generator[string] {
 		cur := string(b)
 
 		// ---- shrinking: multi-branch (BFS/DFS) with dedup ----
-		type neighbor = string
-		queue := make([]neighbor, 0, 64)
-		seen := map[string]struct{}{cur: {}}
-		var last string
-
-		push := func(s string) {
-			if _, ok := seen[s]; ok {
-				return
-			}
-			seen[s] = struct{}{}
-			queue = append(queue, s)
-		}
-
 		// heuristic:
 		// (1) shorten (remove suffix)
 		// (2) replace characters with "simpler" ones (first in table; e.g., 'a' or '0')
-		growNeighbors := func(base string) {
-			queue = queue[:0]
+		self := func(s string) string { return s }
+		return cur, ShrinkNeighbors(cur, self, self, func(base string, push func(string)) {
 			// (1) shorten multiple steps at once (generate multiple lengths)
 			if len(base) > 0 {
 				for newLen := len(base) - 1; newLen >= 0; newLen-- {
@@ -100,37 +88,7 @@ func String(alphabet string, size Size) Generator[string] {
 					}
 				}
 			}
-		}
-		growNeighbors(cur)
-
-		pop := func() (string, bool) {
-			if len(queue) == 0 {
-				return "", false
-			}
-			if shrinkStrategy == ShrinkStrategyDFS {
-				v := queue[len(queue)-1]
-				queue = queue[:len(queue)-1]
-				return v, true
-			}
-			v := queue[0]
-			queue = queue[1:]
-			return v, true
-		}
-
-		return cur, func(accept bool) (string, bool) {
-			if accept {
-				if last != "" && last != cur {
-					cur = last
-					growNeighbors(cur)
-				}
-			}
-			next, ok := pop()
-			if !ok {
-				return "", false
-			}
-			last = next
-			return next, true
-		}
+		})
 	})
 }
 
@@ -118,3 +118,21 @@ func TestStringShrinker(t *testing.T) {
 		t.Errorf("String shrinker returned longer string: %q (len=%d) vs %q (len=%d)", next, len(next), value, len(value))
 	}
 }
+
+func TestStringShrinker_RevisitsCandidatesAfterRebase(t *testing.T) {
+	// "b" and "" are queued as neighbors of "bbb" but not proposed before
+	// "bb" is accepted; they must stay reachable after the rebase, so a
+	// property failing on any non-empty string shrinks to "a", not "aa"
+	for _, strategy := range []string{"bfs", "dfs"} {
+		SetShrinkStrategy(strategy)
+		r := rand.New(rand.NewSource(1))
+		for i := 0; i < 20; i++ {
+			start, shrink := String("ab", Size{Min: 3, Max: 8}).Generate(r, Size{})
+			min := minimize(start, shrink, func(s string) bool { return s != "" })
+			if min != "a" {
+				t.Errorf("%s: String() %q shrank to %q, expected \"a\"", strategy, start, min)
+			}
+		}
+	}
+	SetShrinkStrategy("bfs")
+}
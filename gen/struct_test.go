@@ -0,0 +1,139 @@
+package gen
+
+import (
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type structSample struct {
+	N     int
+	Items []int
+	Name  string
+	skip  int
+}
+
+// countdown generates n and shrinks it one step at a time, like a slow
+// shrinker on a field that does not matter.
+func countdown(n int) Generator[int] {
+	return From(func(_ *rand.Rand, _ Size) (int, Shrinker[int]) {
+		cur := n
+		return cur, func(bool) (int, bool) {
+			if cur == 0 {
+				return 0, false
+			}
+			cur--
+			return cur, true
+		}
+	})
+}
+
+func structSampleFields() Fields {
+	return Fields{
+		"N":     ToAny(countdown(50)),
+		"Items": ToAny(SliceOf(IntRange(0, 100), Size{Min: 10, Max: 20})),
+	}
+}
+
+func TestStruct(t *testing.T) {
+	g := Struct[structSample](structSampleFields())
+	r := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 50; i++ {
+		v, shrink := g.Generate(r, Size{})
+		if v.N != 50 {
+			t.Errorf("Struct() N = %d, want 50", v.N)
+		}
+		if len(v.Items) < 10 || len(v.Items) > 20 {
+			t.Errorf("Struct() len(Items) = %d out of range", len(v.Items))
+		}
+		if v.Name != "" || v.skip != 0 {
+			t.Errorf("Struct() set fields without generators: %+v", v)
+		}
+		if shrink == nil {
+			t.Fatal("Struct().Generate() returned nil shrinker")
+		}
+	}
+}
+
+func TestStruct_InvalidFieldsPanic(t *testing.T) {
+	tests := []struct {
+		name string
+		make func()
+		want string
+	}{
+		{"not a struct", func() { Struct[int](Fields{}) }, "not a struct"},
+		{"unknown field", func() { Struct[structSample](Fields{"Missing": ToAny(Bool())}) }, "no exported field"},
+		{"unexported field", func() { Struct[structSample](Fields{"skip": ToAny(Int(Size{}))}) }, "no exported field"},
+		{"order without generator", func() { Struct[structSample](structSampleFields(), ShrinkOrder("Name")) }, "has no generator"},
+		{"wrong type", func() { Struct[structSample](Fields{"N": ToAny(Bool())}).Generate(rand.New(rand.NewSource(1)), Size{}) }, "cannot assign"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				rec := recover()
+				msg, _ := rec.(string)
+				if !strings.Contains(msg, tt.want) {
+					t.Errorf("panic = %v, want message containing %q", rec, tt.want)
+				}
+			}()
+			tt.make()
+		})
+	}
+}
+
+// shrinkStruct drives shrink while fails holds and returns the minimum found
+// and the number of steps taken until len(Items) first reached want.
+func shrinkStruct(v structSample, shrink Shrinker[structSample], fails func(structSample) bool, want int) (structSample, int) {
+	min, accept, steps, reached := v, false, 0, -1
+	for steps < 5000 {
+		next, ok := shrink(accept)
+		if !ok {
+			break
+		}
+		steps++
+		accept = fails(next)
+		if accept {
+			min = next
+			if reached < 0 && len(min.Items) == want {
+				reached = steps
+			}
+		}
+	}
+	return min, reached
+}
+
+func TestStruct_ShrinkOrderChangesShrinkPath(t *testing.T) {
+	// the failure depends on the slice only; N is noise
+	fails := func(v structSample) bool { return len(v.Items) >= 3 }
+
+	byDeclaration := Struct[structSample](structSampleFields())
+	itemsFirst := Struct[structSample](structSampleFields(), ShrinkOrder("Items"))
+
+	v1, s1 := byDeclaration.Generate(rand.New(rand.NewSource(5)), Size{})
+	v2, s2 := itemsFirst.Generate(rand.New(rand.NewSource(5)), Size{})
+
+	// the first candidate touches N by default, Items with ShrinkOrder("Items")
+	c1, _ := s1(false)
+	if c1.N == v1.N || len(c1.Items) != len(v1.Items) {
+		t.Errorf("default order: first candidate %+v should shrink N of %+v", c1, v1)
+	}
+	c2, _ := s2(false)
+	if c2.N != v2.N || reflect.DeepEqual(c2.Items, v2.Items) {
+		t.Errorf("ShrinkOrder(Items): first candidate %+v should shrink Items of %+v", c2, v2)
+	}
+
+	// both reach the same minimum, but Items-first gets there sooner
+	v1, s1 = byDeclaration.Generate(rand.New(rand.NewSource(5)), Size{})
+	v2, s2 = itemsFirst.Generate(rand.New(rand.NewSource(5)), Size{})
+	min1, steps1 := shrinkStruct(v1, s1, fails, 3)
+	min2, steps2 := shrinkStruct(v2, s2, fails, 3)
+	if len(min1.Items) != 3 || len(min2.Items) != 3 {
+		t.Fatalf("expected 3 items after shrinking, got %v and %v", min1.Items, min2.Items)
+	}
+	if steps2 >= steps1 {
+		t.Errorf("ShrinkOrder(Items) took %d steps to reach 3 items, default order took %d", steps2, steps1)
+	}
+	t.Logf("steps to 3 items: default=%d, ShrinkOrder(Items)=%d", steps1, steps2)
+}
@@ -0,0 +1,230 @@
+// File: gen/text.go
+package gen
+
+import (
+	"math/rand"
+	"strings"
+)
+
+// LineEnding selects the line terminators of Text.
+type LineEnding int
+
+const (
+	// LineLF ends lines with "\n" (Unix).
+	LineLF LineEnding = iota
+	// LineCRLF ends lines with "\r\n" (Windows, HTTP, RFC 5322).
+	LineCRLF
+	// LineMixed picks "\n" or "\r\n" for each line independently.
+	LineMixed
+)
+
+// textAlphabet contains the characters of generated lines: letters, digits,
+// punctuation and the blanks that diff tools treat specially.
+const textAlphabet = "abcxyz019-. \t"
+
+// textSpec is the plain-data description of a text: its lines, whether each
+// line ends with "\r\n" rather than "\n", and whether the last line is
+// terminated too.
+type textSpec struct {
+	lines    []string
+	crlf     []bool
+	trailing bool
+}
+
+// Text generates multi-line text for line-based parsers and diff tools. Lines
+// hold letters, digits, punctuation, spaces and tabs (never "\r" or "\n") and
+// end with the terminator chosen by ending; in one example in four the last
+// line has no terminator. Lines of the minimum length (empty by default) and
+// lines ending in a space or tab come up more often than uniform chance
+// would give.
+// - lines.Min/Max control the number of lines (default Min=0, Max=8).
+// - lineLen.Min/Max control the length of each line (default Min=0, Max=16).
+// Shrink:
+//
+//	(1) remove lines: all, blocks (half, quarter, ...), then single (R->L)
+//	(2) terminate the last line; with LineMixed, "\r\n" becomes "\n"
+//	(3) shorten lines (R->L): empty, half, then without the last character
+//
+// The minimum line count and length are kept; shrinking converges to "" when
+// both minimums are 0.
+func Text(lines Size, lineLen Size, ending LineEnding) Generator[string] {
+	return From(func(r *rand.Rand, sz Size) (string, Shrinker[string]) {
+		if r == nil {
+			r = rand.New(rand.NewSource(rand.Int63())) // #nosec G404 -- Using math/rand for deterministic property-based testing
+		}
+		// defaults
+		if lines.Min == 0 && lines.Max == 0 {
+			lines.Min, lines.Max = 0, 8
+		}
+		if sz.Min != 0 || sz.Max != 0 {
+			lines = sz
+		}
+		if lines.Max < lines.Min {
+			lines.Max = lines.Min
+		}
+		if lineLen.Min == 0 && lineLen.Max == 0 {
+			lineLen.Min, lineLen.Max = 0, 16
+		}
+		if lineLen.Max < lineLen.Min {
+			lineLen.Max = lineLen.Min
+		}
+
+		n := lines.Min
+		if lines.Max > lines.Min {
+			n += r.Intn(lines.Max - lines.Min + 1)
+		}
+		sz.Budget.Spend(n)
+		cur := textSpec{lines: make([]string, n), crlf: make([]bool, n), trailing: r.Intn(4) != 0}
+		for i := range cur.lines {
+			cur.lines[i] = generateTextLine(r, lineLen, sz.Budget)
+			cur.crlf[i] = ending == LineCRLF || ending == LineMixed && r.Intn(2) == 0
+		}
+
+		queue := make([]textSpec, 0, 32)
+		tried := map[string]struct{}{cur.render(): {}}
+		var queued map[string]struct{}
+		var last *textSpec
+
+		push := func(t textSpec) {
+			k := t.render()
+			if _, ok := tried[k]; ok {
+				return
+			}
+			if _, ok := queued[k]; ok {
+				return
+			}
+			queued[k] = struct{}{}
+			queue = append(queue, t)
+		}
+
+		grow := func(base textSpec) {
+			queue = queue[:0]
+			queued = map[string]struct{}{}
+			L := len(base.lines)
+			// (1) remove lines: all, blocks (half, quarter, ...), then single (R->L)
+			if L > lines.Min {
+				push(base.without(lines.Min, L))
+				for chunk := L / 2; chunk >= 1; chunk /= 2 {
+					if L-chunk < lines.Min {
+						continue
+					}
+					for i := 0; i+chunk <= L; i += chunk {
+						push(base.without(i, i+chunk))
+					}
+				}
+				for i := L - 1; i >= 0; i-- {
+					push(base.without(i, i+1))
+				}
+			}
+			// (2) regular endings
+			if !base.trailing && L > 0 {
+				t := base.clone()
+				t.trailing = true
+				push(t)
+			}
+			if ending == LineMixed {
+				for i := L - 1; i >= 0; i-- {
+					if base.crlf[i] {
+						t := base.clone()
+						t.crlf[i] = false
+						push(t)
+					}
+				}
+			}
+			// (3) shorter lines (R->L)
+			for i := L - 1; i >= 0; i-- {
+				rs := []rune(base.lines[i])
+				if len(rs) <= lineLen.Min {
+					continue
+				}
+				for _, k := range []int{lineLen.Min, max(len(rs)/2, lineLen.Min), len(rs) - 1} {
+					t := base.clone()
+					t.lines[i] = string(rs[:k])
+					push(t)
+				}
+			}
+		}
+		grow(cur)
+
+		pop := func() (textSpec, bool) {
+			if len(queue) == 0 {
+				return textSpec{}, false
+			}
+			var v textSpec
+			if GetShrinkStrategy() == ShrinkStrategyDFS {
+				v = queue[len(queue)-1]
+				queue = queue[:len(queue)-1]
+			} else {
+				v = queue[0]
+				queue = queue[1:]
+			}
+			tried[v.render()] = struct{}{}
+			return v, true
+		}
+
+		return cur.render(), func(accept bool) (string, bool) {
+			if accept && last != nil {
+				cur = *last
+				grow(cur)
+			}
+			nxt, ok := pop()
+			if !ok {
+				return "", false
+			}
+			last = &nxt
+			return nxt.render(), true
+		}
+	})
+}
+
+// generateTextLine draws one line: of the minimum length one time in eight,
+// and ending in a space or tab one time in eight.
+func generateTextLine(r *rand.Rand, lineLen Size, budget *Budget) string {
+	n := lineLen.Min
+	if r.Intn(8) != 0 && lineLen.Max > lineLen.Min {
+		n += r.Intn(lineLen.Max - lineLen.Min + 1)
+	}
+	budget.Spend(n)
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = textAlphabet[r.Intn(len(textAlphabet))]
+	}
+	if n > 0 && r.Intn(8) == 0 {
+		b[n-1] = " \t"[r.Intn(2)]
+	}
+	return string(b)
+}
+
+// render writes the lines with their terminators.
+func (t textSpec) render() string {
+	var b strings.Builder
+	for i, line := range t.lines {
+		b.WriteString(line)
+		if i == len(t.lines)-1 && !t.trailing {
+			break
+		}
+		if t.crlf[i] {
+			b.WriteString("\r\n")
+		} else {
+			b.WriteByte('\n')
+		}
+	}
+	return b.String()
+}
+
+// clone returns a deep copy of the text.
+func (t textSpec) clone() textSpec {
+	return textSpec{
+		lines:    append([]string(nil), t.lines...),
+		crlf:     append([]bool(nil), t.crlf...),
+		trailing: t.trailing,
+	}
+}
+
+// without returns a copy of the text without lines [i, j).
+func (t textSpec) without(i, j int) textSpec {
+	out := textSpec{trailing: t.trailing}
+	out.lines = append(append(out.lines, t.lines[:i]...), t.lines[j:]...)
+	out.crlf = append(append(out.crlf, t.crlf[:i]...), t.crlf[j:]...)
+	return out
+}
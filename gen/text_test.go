@@ -0,0 +1,134 @@
+package gen
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// textLines splits s into lines and their terminators, checking that every
+// terminator is allowed by ending.
+func textLines(t *testing.T, s string, ending LineEnding) (lines []string, crlf, lf int, trailing bool) {
+	t.Helper()
+	for s != "" {
+		i := strings.IndexByte(s, '\n')
+		if i < 0 {
+			lines = append(lines, s)
+			break
+		}
+		line := s[:i]
+		if strings.HasSuffix(line, "\r") {
+			crlf++
+			line = line[:len(line)-1]
+		} else {
+			lf++
+		}
+		if strings.ContainsRune(line, '\r') {
+			t.Fatalf("Text() = %q has a lone \\r", s)
+		}
+		lines = append(lines, line)
+		s = s[i+1:]
+		trailing = s == ""
+	}
+	if ending == LineLF && crlf > 0 || ending == LineCRLF && lf > 0 {
+		t.Fatalf("Text() with ending %d has %d CRLF and %d LF terminators", ending, crlf, lf)
+	}
+	return lines, crlf, lf, trailing
+}
+
+func TestText(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, ending := range []LineEnding{LineLF, LineCRLF, LineMixed} {
+		g := Text(Size{Min: 1, Max: 6}, Size{Max: 10}, ending)
+		var mixed, unterminated, emptyLine, blankEnd bool
+		for i := 0; i < 300; i++ {
+			s, shrink := g.Generate(r, Size{})
+			if shrink == nil {
+				t.Fatal("Text().Generate() returned nil shrinker")
+			}
+			lines, crlf, lf, trailing := textLines(t, s, ending)
+			if len(lines) > 6 {
+				t.Fatalf("Text() = %q has %d lines, expected at most 6", s, len(lines))
+			}
+			for _, l := range lines {
+				if len(l) > 10 {
+					t.Fatalf("Text() = %q has a line longer than 10", s)
+				}
+				emptyLine = emptyLine || l == ""
+				blankEnd = blankEnd || strings.HasSuffix(l, " ") || strings.HasSuffix(l, "\t")
+			}
+			mixed = mixed || crlf > 0 && lf > 0
+			unterminated = unterminated || s != "" && !trailing
+		}
+		if ending == LineMixed && !mixed {
+			t.Error("Text() with LineMixed never mixed line endings")
+		}
+		if !unterminated || !emptyLine || !blankEnd {
+			t.Errorf("Text() with ending %d: unterminated last line %v, empty line %v, line ending in a blank %v",
+				ending, unterminated, emptyLine, blankEnd)
+		}
+	}
+}
+
+func TestText_ShrinkTowardEmpty(t *testing.T) {
+	SetShrinkStrategy("bfs")
+	r := rand.New(rand.NewSource(2))
+	g := Text(Size{}, Size{}, LineMixed)
+	for i := 0; i < 30; i++ {
+		s, shrink := g.Generate(r, Size{})
+		min := minimize(s, shrink, func(c string) bool {
+			textLines(t, c, LineMixed)
+			return true
+		})
+		if s != "" && min != "" {
+			t.Errorf("shrinking %q accepting everything ended at %q, expected \"\"", s, min)
+		}
+	}
+}
+
+func TestText_ShrinkKeepsMinimums(t *testing.T) {
+	for _, strategy := range []string{"bfs", "dfs"} {
+		SetShrinkStrategy(strategy)
+		r := rand.New(rand.NewSource(3))
+		g := Text(Size{Min: 2, Max: 6}, Size{Min: 1, Max: 8}, LineCRLF)
+		for i := 0; i < 20; i++ {
+			s, shrink := g.Generate(r, Size{})
+			min := minimize(s, shrink, func(c string) bool {
+				lines, _, _, _ := textLines(t, c, LineCRLF)
+				if len(lines) < 2 {
+					t.Fatalf("%s: shrinking %q proposed %q with fewer than 2 lines", strategy, s, c)
+				}
+				for _, l := range lines {
+					if l == "" {
+						t.Fatalf("%s: shrinking %q proposed %q with an empty line", strategy, s, c)
+					}
+				}
+				return true
+			})
+			if len(min) != len("x\r\nx\r\n") {
+				t.Errorf("%s: shrinking %q ended at %q, expected two terminated 1-character lines", strategy, s, min)
+			}
+		}
+	}
+	SetShrinkStrategy("bfs")
+}
+
+func TestText_ShrinkKeepsMixedEndings(t *testing.T) {
+	// fails while the text mixes endings: the minimal text has two empty
+	// lines, one of each
+	SetShrinkStrategy("bfs")
+	r := rand.New(rand.NewSource(4))
+	g := Text(Size{}, Size{}, LineMixed)
+	for i := 0; i < 20; i++ {
+		s, shrink := g.Generate(r, Size{})
+		mixed := func(c string) bool {
+			return strings.Contains(c, "\r\n") && strings.Contains(strings.ReplaceAll(c, "\r\n", ""), "\n")
+		}
+		if !mixed(s) {
+			continue
+		}
+		if min := minimize(s, shrink, mixed); min != "\r\n\n" && min != "\n\r\n" {
+			t.Errorf("shrinking %q ended at %q, expected two empty lines with different endings", s, min)
+		}
+	}
+}
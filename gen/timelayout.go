@@ -0,0 +1,80 @@
+// File: gen/timelayout.go
+package gen
+
+import "strings"
+
+// timeLayoutToken is an element of a Go reference-time layout; fixed marks
+// the fixed-width numeric ones, which may follow each other without a
+// separator ("20060102").
+type timeLayoutToken struct {
+	s     string
+	fixed bool
+}
+
+// timeLayoutTokens are the layout elements of package time, simplest first.
+var timeLayoutTokens = []timeLayoutToken{
+	{"2006", true}, {"01", true}, {"02", true}, {"15", true}, {"04", true}, {"05", true},
+	{"06", true}, {"03", true}, {"002", true},
+	{"Jan", false}, {"January", false}, {"Mon", false}, {"Monday", false},
+	{"1", false}, {"2", false}, {"_2", false}, {"__2", false}, {"3", false}, {"4", false}, {"5", false},
+	{"PM", false}, {"pm", false},
+	{"MST", false}, {"Z07:00", false}, {"Z0700", false}, {"-07:00", false}, {"-0700", false}, {"-07", false},
+	{".000", false}, {".000000", false}, {".000000000", false}, {".999", false}, {".999999999", false}, {",000", false},
+}
+
+// timeLayoutSeps are the separators put before every element but the first;
+// the empty one is used only between fixed-width numeric elements, "." never
+// after seconds or an optional fraction, "T" never after a zone abbreviation,
+// ":" never after a zone offset and a space never before an optional fraction
+// (all are replaced with the first separator). None of them forms a layout element with its neighbors.
+var timeLayoutSeps = []string{"-", " ", ":", "/", "T", ", ", ".", ""}
+
+// TimeLayout generates valid layouts for time.Format and time.Parse, such as
+// "2006-01-02T15:04:05Z07:00", "Mon Jan _2 15:04:05 MST 2006" or "20060102":
+// one to six elements of the reference time (years, months, days, weekdays,
+// day of year, hours, AM/PM, minutes, seconds, fractional seconds, zones)
+// joined by separators. Formatting a time with a generated layout and parsing
+// the result with the same layout succeeds, for times in UTC or in a zone with
+// an abbreviation (time.Parse cannot read "MST" back as "+0530"). Elements may
+// repeat or disagree (e.g. "15" and "03" together), as user-supplied layouts
+// do.
+// Shrink: drops elements and moves the rest toward "2006" and the separators
+// toward "-", converging toward "2006".
+func TimeLayout() Generator[string] {
+	part := PairOf(IntRange(0, len(timeLayoutTokens)-1), IntRange(0, len(timeLayoutSeps)-1))
+	return Map(SliceOf(part, Size{Min: 1, Max: 6}), renderTimeLayout)
+}
+
+// renderTimeLayout joins the elements of a layout, each pair holding the
+// index of an element and of the separator before it (unused for the first).
+// No elements render as the first one, "2006".
+func renderTimeLayout(parts []Pair[int, int]) string {
+	if len(parts) == 0 {
+		return timeLayoutTokens[0].s
+	}
+	var b strings.Builder
+	for i, p := range parts {
+		tok := timeLayoutTokens[p.First]
+		if i > 0 {
+			sep := timeLayoutSeps[p.Second]
+			prev := timeLayoutTokens[parts[i-1].First]
+			switch {
+			case sep == "" && !(tok.fixed && prev.fixed),
+				// Parse reads a "." and digits after seconds, or after an
+				// omitted ".999" fraction, as a fraction
+				sep == "." && (prev.s == "05" || prev.s == "5" || strings.HasPrefix(prev.s, ".9")),
+				// Parse reads four-letter zone abbreviations ending in "T"
+				sep == "T" && prev.s == "MST",
+				// a zone offset, ":" and "0..." would read as "-07:00:00"
+				sep == ":" && strings.Contains(prev.s, "07"),
+				// Parse matches a space with a run of spaces, which would take
+				// the one after an omitted ".999" fraction too
+				strings.HasSuffix(sep, " ") && strings.HasPrefix(tok.s, ".9"):
+				sep = timeLayoutSeps[0]
+			}
+			b.WriteString(sep)
+		}
+		b.WriteString(tok.s)
+	}
+	return b.String()
+}
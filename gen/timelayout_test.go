@@ -0,0 +1,87 @@
+package gen
+
+import (
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTimeLayout(t *testing.T) {
+	refs := []time.Time{
+		// a leap day, so a day of year without a year (parsed in year 0, also
+		// a leap year) still agrees with the month and day
+		time.Date(2024, time.February, 29, 17, 38, 56, 123456789, time.UTC),
+		// no fraction, so ".999" elements format as nothing
+		time.Date(2000, time.January, 1, 9, 5, 7, 0, time.FixedZone("IST", 5*3600+1800)),
+	}
+	r := rand.New(rand.NewSource(1))
+	compact := false
+	for i := 0; i < 2000; i++ {
+		layout, shrink := TimeLayout().Generate(r, Size{})
+		if shrink == nil {
+			t.Fatal("TimeLayout().Generate() returned nil shrinker")
+		}
+		for _, ref := range refs {
+			s := ref.Format(layout)
+			if _, err := time.Parse(layout, s); err != nil {
+				t.Fatalf("TimeLayout() = %q: parsing %q: %v", layout, s, err)
+			}
+		}
+		compact = compact || strings.Contains(layout, "0601") || strings.Contains(layout, "0102") || strings.Contains(layout, "1504")
+	}
+	if !compact {
+		t.Error("TimeLayout() never joined elements without a separator")
+	}
+
+	for _, tt := range []struct {
+		parts []Pair[int, int]
+		want  string
+	}{
+		{nil, "2006"},
+		{[]Pair[int, int]{{0, 3}}, "2006"},
+		{[]Pair[int, int]{{0, 0}, {1, 7}, {2, 7}}, "20060102"},
+		{[]Pair[int, int]{{9, 0}, {14, 7}}, "Jan-2"},
+		{[]Pair[int, int]{{0, 0}, {1, 0}, {2, 0}, {3, 4}, {4, 2}, {5, 2}, {23, 7}}, "2006-01-02T15:04:05-Z07:00"},
+		{[]Pair[int, int]{{5, 0}, {1, 6}}, "05-01"},
+		{[]Pair[int, int]{{31, 0}, {1, 6}}, ".999-01"},
+		{[]Pair[int, int]{{0, 0}, {31, 1}}, "2006-.999"},
+		{[]Pair[int, int]{{22, 0}, {3, 4}}, "MST-15"},
+		{[]Pair[int, int]{{27, 0}, {8, 2}}, "-07-002"},
+	} {
+		if got := renderTimeLayout(tt.parts); got != tt.want {
+			t.Errorf("renderTimeLayout(%v) = %q, expected %q", tt.parts, got, tt.want)
+		}
+	}
+}
+
+func TestTimeLayout_Shrink(t *testing.T) {
+	SetShrinkStrategy("bfs")
+	r := rand.New(rand.NewSource(2))
+	for i := 0; i < 20; i++ {
+		layout, shrink := TimeLayout().Generate(r, Size{})
+		if got := minimize(layout, shrink, func(string) bool { return true }); got != "2006" {
+			t.Errorf("shrinking %q accepting everything ended at %q, expected \"2006\"", layout, got)
+		}
+	}
+
+	// a parser that rejects layouts with a zone: the minimal one is a zone
+	// alone
+	fails := func(l string) bool { return strings.Contains(l, "MST") || strings.Contains(l, "07") }
+	found := 0
+	for i := 0; i < 200 && found < 10; i++ {
+		layout, shrink := TimeLayout().Generate(r, Size{})
+		if !fails(layout) {
+			continue
+		}
+		found++
+		got := minimize(layout, shrink, fails)
+		if !slices.Contains([]string{"MST", "Z07:00", "Z0700", "-07:00", "-0700", "-07"}, got) {
+			t.Errorf("shrinking %q ended at %q, expected a zone alone", layout, got)
+		}
+	}
+	if found == 0 {
+		t.Fatal("no layout with a zone generated")
+	}
+}
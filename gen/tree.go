@@ -0,0 +1,286 @@
+// File: gen/tree.go
+package gen
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+)
+
+// TreeNode is a node of a binary tree; a nil *TreeNode is the empty tree.
+type TreeNode[T any] struct {
+	Value       T
+	Left, Right *TreeNode[T]
+}
+
+// treeSpec is the generated tree with the shrinker of each value. Specs are
+// never modified once built: edits copy the path to the changed subtree.
+type treeSpec[T any] struct {
+	value       T
+	shrink      Shrinker[T]
+	left, right *treeSpec[T]
+}
+
+// BinaryTree generates binary trees of values from g, at most maxDepth levels
+// deep (a single node has depth 1, the empty tree depth 0). Each example
+// picks one of three shapes with equal probability, so tests of tree-based
+// structures see both extremes:
+//   - degenerate: a chain of nodes with one child each (a linked list)
+//   - balanced: subtree heights differ by at most one at every node (AVL)
+//   - random: each child present independently
+//
+// Depths are drawn uniformly in [0, maxDepth], so keep maxDepth moderate:
+// balanced trees may have up to 2^maxDepth-1 nodes.
+// Panics if maxDepth < 0.
+// Shrink: (1) the empty tree, the root alone, then prunes each subtree to
+// nothing, to its root alone, or to one of its children; (2) shrinks the
+// values in place (pre-order) with g's shrinker.
+func BinaryTree[T any](g Generator[T], maxDepth int) Generator[*TreeNode[T]] {
+	return binaryTree("gen.BinaryTree", g, maxDepth, false)
+}
+
+// BalancedBinaryTree is like BinaryTree with only balanced shapes: subtree
+// heights differ by at most one at every node, in every generated tree and
+// every shrink candidate.
+// Panics if maxDepth < 0.
+func BalancedBinaryTree[T any](g Generator[T], maxDepth int) Generator[*TreeNode[T]] {
+	return binaryTree("gen.BalancedBinaryTree", g, maxDepth, true)
+}
+
+// binaryTree implements BinaryTree and BalancedBinaryTree.
+func binaryTree[T any](name string, g Generator[T], maxDepth int, balanced bool) Generator[*TreeNode[T]] {
+	if maxDepth < 0 {
+		panic(fmt.Sprintf("%s: maxDepth=%d, must be >= 0", name, maxDepth))
+	}
+	return From(func(r *rand.Rand, sz Size) (*TreeNode[T], Shrinker[*TreeNode[T]]) {
+		if r == nil {
+			r = rand.New(rand.NewSource(rand.Int63())) // #nosec G404 -- Using math/rand for deterministic property-based testing
+		}
+		node := func(left, right *treeSpec[T]) *treeSpec[T] {
+			sz.Budget.Spend(1)
+			v, s := g.Generate(r, sz.elem())
+			return &treeSpec[T]{value: v, shrink: s, left: left, right: right}
+		}
+
+		depth := r.Intn(maxDepth + 1)
+		var root *treeSpec[T]
+		shape := 1
+		if !balanced {
+			shape = r.Intn(3)
+		}
+		switch shape {
+		case 0: // degenerate: all left, all right, or zigzag
+			side := r.Intn(3)
+			for d := 0; d < depth; d++ {
+				if side == 0 || side == 2 && r.Intn(2) == 0 {
+					root = node(root, nil)
+				} else {
+					root = node(nil, root)
+				}
+			}
+		case 1: // balanced
+			var build func(h int) *treeSpec[T]
+			build = func(h int) *treeSpec[T] {
+				if h <= 0 {
+					return nil
+				}
+				short := h - 1 - r.Intn(2)
+				if r.Intn(2) == 0 {
+					return node(build(h-1), build(short))
+				}
+				return node(build(short), build(h-1))
+			}
+			root = build(depth)
+		default: // random
+			var build func(d int) *treeSpec[T]
+			build = func(d int) *treeSpec[T] {
+				if d <= 0 || r.Intn(3) == 0 {
+					return nil
+				}
+				return node(build(d-1), build(d-1))
+			}
+			if depth > 0 {
+				root = node(build(depth-1), build(depth-1))
+			}
+		}
+		return root.render(nil), createTreeShrinker(root, balanced)
+	})
+}
+
+// createTreeShrinker creates a shrinker that prunes subtrees, then shrinks
+// the values in place.
+func createTreeShrinker[T any](initial *treeSpec[T], balanced bool) Shrinker[*TreeNode[T]] {
+	// phase (1): pruning, queue-based with rebase on accept
+	queue := make([]*treeSpec[T], 0, 32)
+	tried := map[string]struct{}{initial.key(): {}}
+	var queued map[string]struct{}
+	cur := initial
+	var last *treeSpec[T]
+	hasLast := false
+
+	push := func(t *treeSpec[T]) {
+		if balanced && t.height() < 0 {
+			return
+		}
+		k := t.key()
+		if _, ok := tried[k]; ok {
+			return
+		}
+		if _, ok := queued[k]; ok {
+			return
+		}
+		queued[k] = struct{}{}
+		queue = append(queue, t)
+	}
+
+	// prunings of t: nothing, its root alone, or one of its children
+	prunings := func(t *treeSpec[T]) []*treeSpec[T] {
+		out := []*treeSpec[T]{nil}
+		if t.left != nil || t.right != nil {
+			out = append(out, &treeSpec[T]{value: t.value, shrink: t.shrink})
+		}
+		if t.left != nil {
+			out = append(out, t.left)
+		}
+		if t.right != nil {
+			out = append(out, t.right)
+		}
+		return out
+	}
+	grow := func(base *treeSpec[T]) {
+		queue = queue[:0]
+		queued = map[string]struct{}{}
+		for _, t := range base.replacements(prunings) {
+			push(t)
+		}
+	}
+	grow(cur)
+
+	pop := func() (*treeSpec[T], bool) {
+		if len(queue) == 0 {
+			return nil, false
+		}
+		var v *treeSpec[T]
+		if GetShrinkStrategy() == ShrinkStrategyDFS {
+			v = queue[len(queue)-1]
+			queue = queue[:len(queue)-1]
+		} else {
+			v = queue[0]
+			queue = queue[1:]
+		}
+		tried[v.key()] = struct{}{}
+		return v, true
+	}
+
+	// phase (2): value i (pre-order) in place with its own shrinker
+	pruning := true
+	var vals []T
+	var each func(accept bool) (int, T, bool)
+
+	return func(accept bool) (*TreeNode[T], bool) {
+		if pruning {
+			if accept && hasLast {
+				cur = last
+				grow(cur)
+			}
+			if t, ok := pop(); ok {
+				last, hasLast = t, true
+				return t.render(nil), true
+			}
+			pruning = false
+			accept = false
+			nodes := cur.preorder(nil)
+			vals = make([]T, len(nodes))
+			for i, n := range nodes {
+				vals[i] = n.value
+			}
+			each = shrinkInPlace(len(nodes), func(i int) Shrinker[T] { return nodes[i].shrink }, nil, func(i int, v T) { vals[i] = v })
+		}
+
+		i, v, ok := each(accept)
+		if !ok {
+			return nil, false
+		}
+		cand := append([]T(nil), vals...)
+		cand[i] = v
+		return cur.render(&cand), true
+	}
+}
+
+// replacements returns the trees made by replacing one subtree s of t with
+// each of f(s), sharing the untouched parts of t.
+func (t *treeSpec[T]) replacements(f func(*treeSpec[T]) []*treeSpec[T]) []*treeSpec[T] {
+	if t == nil {
+		return nil
+	}
+	out := f(t)
+	for _, l := range t.left.replacements(f) {
+		n := *t
+		n.left = l
+		out = append(out, &n)
+	}
+	for _, r := range t.right.replacements(f) {
+		n := *t
+		n.right = r
+		out = append(out, &n)
+	}
+	return out
+}
+
+// height returns the height of a balanced tree, or -1 when the heights of
+// the subtrees of some node differ by more than one.
+func (t *treeSpec[T]) height() int {
+	if t == nil {
+		return 0
+	}
+	l, r := t.left.height(), t.right.height()
+	if l < 0 || r < 0 || l-r > 1 || r-l > 1 {
+		return -1
+	}
+	return 1 + max(l, r)
+}
+
+// preorder appends the nodes of t in pre-order to out.
+func (t *treeSpec[T]) preorder(out []*treeSpec[T]) []*treeSpec[T] {
+	if t == nil {
+		return out
+	}
+	out = append(out, t)
+	out = t.left.preorder(out)
+	return t.right.preorder(out)
+}
+
+// render builds the *TreeNode tree, taking the values from *vals (in
+// pre-order, consumed as it goes) when vals is not nil.
+func (t *treeSpec[T]) render(vals *[]T) *TreeNode[T] {
+	if t == nil {
+		return nil
+	}
+	n := &TreeNode[T]{Value: t.value}
+	if vals != nil {
+		n.Value, *vals = (*vals)[0], (*vals)[1:]
+	}
+	n.Left = t.left.render(vals)
+	n.Right = t.right.render(vals)
+	return n
+}
+
+// key renders the shape and values of t as text, for deduplication.
+func (t *treeSpec[T]) key() string {
+	var b strings.Builder
+	t.writeKey(&b)
+	return b.String()
+}
+
+// writeKey writes the key of t to b.
+func (t *treeSpec[T]) writeKey(b *strings.Builder) {
+	if t == nil {
+		b.WriteByte('-')
+		return
+	}
+	fmt.Fprintf(b, "(%#v ", t.value)
+	t.left.writeKey(b)
+	b.WriteByte(' ')
+	t.right.writeKey(b)
+	b.WriteByte(')')
+}
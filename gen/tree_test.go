@@ -0,0 +1,168 @@
+package gen
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// treeDepth returns the number of levels of t.
+func treeDepth[T any](t *TreeNode[T]) int {
+	if t == nil {
+		return 0
+	}
+	return 1 + max(treeDepth(t.Left), treeDepth(t.Right))
+}
+
+// treeBalanced reports whether subtree heights differ by at most one at every node.
+func treeBalanced[T any](t *TreeNode[T]) bool {
+	if t == nil {
+		return true
+	}
+	d := treeDepth(t.Left) - treeDepth(t.Right)
+	return d >= -1 && d <= 1 && treeBalanced(t.Left) && treeBalanced(t.Right)
+}
+
+// treeSize returns the number of nodes of t.
+func treeSize[T any](t *TreeNode[T]) int {
+	if t == nil {
+		return 0
+	}
+	return 1 + treeSize(t.Left) + treeSize(t.Right)
+}
+
+// treeValues returns the values of t in pre-order.
+func treeValues[T any](t *TreeNode[T]) []T {
+	if t == nil {
+		return nil
+	}
+	out := []T{t.Value}
+	out = append(out, treeValues(t.Left)...)
+	return append(out, treeValues(t.Right)...)
+}
+
+func TestBinaryTree_Shapes(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	g := BinaryTree(IntRange(0, 9), 6)
+	chains, bushy := 0, 0
+	for i := 0; i < 300; i++ {
+		tree, shrink := g.Generate(r, Size{})
+		d := treeDepth(tree)
+		if d > 6 {
+			t.Fatalf("BinaryTree(maxDepth=6) has depth %d", d)
+		}
+		for _, v := range treeValues(tree) {
+			if v < 0 || v > 9 {
+				t.Fatalf("BinaryTree() value %d, expected in [0, 9]", v)
+			}
+		}
+		if shrink == nil {
+			t.Fatal("BinaryTree().Generate() returned nil shrinker")
+		}
+		switch n := treeSize(tree); {
+		case d >= 4 && n == d:
+			chains++
+		case d >= 4 && treeBalanced(tree):
+			bushy++
+		}
+	}
+	if chains < 20 || bushy < 20 {
+		t.Errorf("BinaryTree() made %d degenerate and %d balanced deep trees in 300, expected both shapes often", chains, bushy)
+	}
+}
+
+func TestBalancedBinaryTree(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	g := BalancedBinaryTree(Int(Size{}), 5)
+	for i := 0; i < 200; i++ {
+		tree, _ := g.Generate(r, Size{})
+		if treeDepth(tree) > 5 || !treeBalanced(tree) {
+			t.Fatalf("BalancedBinaryTree(maxDepth=5) = depth %d, balanced %v", treeDepth(tree), treeBalanced(tree))
+		}
+	}
+}
+
+func TestBinaryTree_PanicsOnNegativeDepth(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("BinaryTree(maxDepth=-1) did not panic")
+		}
+	}()
+	BinaryTree(Int(Size{}), -1)
+}
+
+func TestBinaryTree_ShrinkTowardNil(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	var tree *TreeNode[int]
+	var shrink Shrinker[*TreeNode[int]]
+	for tree == nil {
+		tree, shrink = BinaryTree(Int(Size{}), 5).Generate(r, Size{})
+	}
+
+	next, ok := shrink(false)
+	if !ok || next != nil {
+		t.Errorf("first shrink candidate = %v, expected the empty tree", next)
+	}
+}
+
+func TestBinaryTree_ShrinkKeepsFailure(t *testing.T) {
+	// fails while some node has two children and a value >= 5: minimal is a
+	// root with a small failing value and two leaf children valued 0
+	var fails func(t *TreeNode[int]) bool
+	fails = func(t *TreeNode[int]) bool {
+		if t == nil {
+			return false
+		}
+		return t.Left != nil && t.Right != nil && t.Value >= 5 || fails(t.Left) || fails(t.Right)
+	}
+	r := rand.New(rand.NewSource(4))
+	g := BinaryTree(IntRange(0, 100), 6)
+	var tree *TreeNode[int]
+	var shrink Shrinker[*TreeNode[int]]
+	for !fails(tree) {
+		tree, shrink = g.Generate(r, Size{})
+	}
+
+	min, accept := tree, false
+	for i := 0; i < 5000; i++ {
+		next, ok := shrink(accept)
+		if !ok {
+			break
+		}
+		accept = fails(next)
+		if accept {
+			min = next
+		}
+	}
+	vals := treeValues(min)
+	if treeSize(min) != 3 || vals[0] < 5 || vals[0] > 9 || vals[1] != 0 || vals[2] != 0 {
+		t.Errorf("shrunk tree has %d nodes with values %v, expected [5..9 0 0]", treeSize(min), vals)
+	}
+}
+
+func TestBalancedBinaryTree_ShrinkStaysBalanced(t *testing.T) {
+	r := rand.New(rand.NewSource(5))
+	var tree *TreeNode[int]
+	var shrink Shrinker[*TreeNode[int]]
+	for treeDepth(tree) < 4 {
+		tree, shrink = BalancedBinaryTree(Int(Size{}), 5).Generate(r, Size{})
+	}
+
+	// fails while the tree has at least 4 nodes
+	min, accept := tree, false
+	for i := 0; i < 5000; i++ {
+		next, ok := shrink(accept)
+		if !ok {
+			break
+		}
+		if !treeBalanced(next) {
+			t.Fatalf("shrink candidate is not balanced: %v", treeValues(next))
+		}
+		accept = treeSize(next) >= 4
+		if accept {
+			min = next
+		}
+	}
+	if treeSize(min) != 4 || treeDepth(min) != 3 {
+		t.Errorf("shrunk tree has %d nodes and depth %d, expected 4 and 3", treeSize(min), treeDepth(min))
+	}
+}
@@ -3,15 +3,41 @@
 // custom generators with shrinking capabilities.
 package gen
 
-import "math/rand"
+import (
+	"math/rand"
+	"sync"
+)
 
 // Size controls the scale and limits of generators.
-// It defines the minimum and maximum bounds for generated values.
+// It defines the minimum and maximum bounds for generated values: lengths
+// for strings and collections, magnitudes for numbers.
+//
+// The zero Size (Min == 0 && Max == 0) means "use the generator's default
+// range", never "always empty" or "always zero": String("abc", Size{})
+// generates strings of 0 to 32 runes, Int(Size{}) integers in [-100, 100],
+// SliceOf(g, Size{}) slices of 0 to 16 elements. Each generator documents
+// its default. Setting Min or Max replaces the default; for lengths a Max
+// below Min is raised to Min, so Size{Min: 3} means exactly 3 elements. For a
+// constant empty or zero value use Const.
 type Size struct {
 	// Min is the minimum bound for generated values.
 	Min int
 	// Max is the maximum bound for generated values.
 	Max int
+	// Budget, when set, caps the total number of elements generated for the
+	// current example (see Budget). It does not count as a size override.
+	Budget *Budget
+	// Filters, when set, is notified of the values each Filter rejects while
+	// generating (see FilterLog). Like Budget, collection generators pass it
+	// on to their element generators, so it only sees the filters of the
+	// value being generated. It does not count as a size override.
+	Filters *FilterLog
+}
+
+// elem returns the Size a collection generator passes to its element
+// generators: their default range, with the Budget and Filters of sz.
+func (sz Size) elem() Size {
+	return Size{Budget: sz.Budget, Filters: sz.Filters}
 }
 
 // Shrinker proposes "smaller" candidates during the shrinking process.
@@ -36,21 +62,29 @@ const (
 
 // shrinkStrategy holds the current shrinking strategy.
 // It can be either "bfs" (breadth-first search) or "dfs" (depth-first search).
-var shrinkStrategy = ShrinkStrategyBFS
+// It is guarded by shrinkStrategyMu, since properties may run in parallel.
+var (
+	shrinkStrategyMu sync.RWMutex
+	shrinkStrategy   = ShrinkStrategyBFS
+)
 
 // SetShrinkStrategy sets the shrinking strategy for all generators.
 // Valid strategies are "dfs" (depth-first search) and "bfs" (breadth-first search).
-// Any other value defaults to "bfs".
+// Any other value defaults to "bfs". It is safe for concurrent use.
 func SetShrinkStrategy(s string) {
-	if s == ShrinkStrategyDFS {
-		shrinkStrategy = ShrinkStrategyDFS
-	} else {
-		shrinkStrategy = ShrinkStrategyBFS
+	if s != ShrinkStrategyDFS {
+		s = ShrinkStrategyBFS
 	}
+	shrinkStrategyMu.Lock()
+	defer shrinkStrategyMu.Unlock()
+	shrinkStrategy = s
 }
 
 // GetShrinkStrategy returns the current shrinking strategy.
+// It is safe for concurrent use.
 func GetShrinkStrategy() string {
+	shrinkStrategyMu.RLock()
+	defer shrinkStrategyMu.RUnlock()
 	return shrinkStrategy
 }
 
@@ -2,6 +2,7 @@ package gen
 
 import (
 	"math/rand"
+	"sync"
 	"testing"
 )
 
@@ -15,6 +16,48 @@ func TestSize(t *testing.T) {
 	}
 }
 
+func TestSize_ZeroValueUsesDefaults(t *testing.T) {
+	tests := []struct {
+		name     string
+		draw     func(*rand.Rand) float64 // the value, or its length
+		min, max float64                  // default range
+	}{
+		{"Int", func(r *rand.Rand) float64 { v, _ := Int(Size{}).Generate(r, Size{}); return float64(v) }, -100, 100},
+		{"Int64", func(r *rand.Rand) float64 { v, _ := Int64(Size{}).Generate(r, Size{}); return float64(v) }, -100, 100},
+		{"Uint", func(r *rand.Rand) float64 { v, _ := Uint(Size{}).Generate(r, Size{}); return float64(v) }, 0, 100},
+		{"Uint64", func(r *rand.Rand) float64 { v, _ := Uint64(Size{}).Generate(r, Size{}); return float64(v) }, 0, 100},
+		{"Float32", func(r *rand.Rand) float64 { v, _ := Float32(Size{}).Generate(r, Size{}); return float64(v) }, -100, 100},
+		{"Float64", func(r *rand.Rand) float64 { v, _ := Float64(Size{}).Generate(r, Size{}); return v }, -100, 100},
+		{"String", func(r *rand.Rand) float64 { v, _ := String("abc", Size{}).Generate(r, Size{}); return float64(len(v)) }, 0, 32},
+		{"SliceOf", func(r *rand.Rand) float64 {
+			v, _ := SliceOf(Bool(), Size{}).Generate(r, Size{})
+			return float64(len(v))
+		}, 0, 16},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := rand.New(rand.NewSource(1))
+			distinct := map[float64]struct{}{}
+			lo, hi := tt.max, tt.min
+			for i := 0; i < 500; i++ {
+				v := tt.draw(r)
+				if v < tt.min || v > tt.max {
+					t.Fatalf("%s(Size{}) produced %v, expected in the default range [%v, %v]", tt.name, v, tt.min, tt.max)
+				}
+				distinct[v] = struct{}{}
+				lo, hi = min(lo, v), max(hi, v)
+			}
+			// a spread over most of the default range, not a constant
+			span := tt.max - tt.min
+			if len(distinct) < 10 || hi-lo < span*3/4 {
+				t.Errorf("%s(Size{}) produced %d distinct values in [%v, %v], expected a spread over [%v, %v]",
+					tt.name, len(distinct), lo, hi, tt.min, tt.max)
+			}
+		})
+	}
+}
+
 func TestSetShrinkStrategy(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -36,6 +79,38 @@ func TestSetShrinkStrategy(t *testing.T) {
 	}
 }
 
+// TestShrinkStrategy_Concurrent sets and reads the strategy from several
+// goroutines while shrinkers run; run with -race to check for data races.
+func TestShrinkStrategy_Concurrent(t *testing.T) {
+	defer SetShrinkStrategy(ShrinkStrategyBFS)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			r := rand.New(rand.NewSource(int64(i)))
+			for j := 0; j < 200; j++ {
+				if (i+j)%2 == 0 {
+					SetShrinkStrategy(ShrinkStrategyDFS)
+				} else {
+					SetShrinkStrategy(ShrinkStrategyBFS)
+				}
+				if s := GetShrinkStrategy(); s != ShrinkStrategyBFS && s != ShrinkStrategyDFS {
+					t.Errorf("GetShrinkStrategy() = %q", s)
+					return
+				}
+				_, shrink := IntRange(0, 1000).Generate(r, Size{})
+				for k := 0; k < 5; k++ {
+					if _, ok := shrink(k%2 == 0); !ok {
+						break
+					}
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
 func TestGenFunc(t *testing.T) {
 	expected := 42
 	gen := GenFunc[int]{
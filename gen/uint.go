@@ -21,6 +21,7 @@ func Uint(size Size) Generator[uint] {
 }
 
 // UintRange generates uint uniformly in the range [min, max].
+// Reversed bounds (min > max) are swapped.
 func UintRange(min, max uint) Generator[uint] {
 	if min > max {
 		min, max = max, min
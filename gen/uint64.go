@@ -19,6 +19,7 @@ func Uint64(size Size) Generator[uint64] {
 }
 
 // Uint64Range generates uint64 uniformly in the range [min, max] (inclusive).
+// Reversed bounds (min > max) are swapped.
 func Uint64Range(min, max uint64) Generator[uint64] {
 	if min > max {
 		min, max = max, min
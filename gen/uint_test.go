@@ -120,3 +120,8 @@ func TestUint64Shrinker(t *testing.T) {
 		t.Errorf("Uint64 shrinker returned value %d outside range [0, 100]", next)
 	}
 }
+
+func TestUintRange_ReversedBounds(t *testing.T) {
+	checkReversedRange(t, UintRange(10, 1), 1, 10)
+	checkReversedRange(t, Uint64Range(1000, 100), 100, 1000)
+}
@@ -0,0 +1,167 @@
+package gen
+
+import (
+	"math/rand"
+	"strings"
+)
+
+// trickyText is a piece of text together with its NFC and NFD spellings and
+// the ASCII text it shrinks to.
+type trickyText struct {
+	nfc, nfd string
+	ascii    string
+}
+
+// trickyTexts holds texts whose NFC and NFD forms differ.
+var trickyTexts = []trickyText{
+	{"\u00e9", "e\u0301", "e"},          // é
+	{"\u00f1", "n\u0303", "n"},          // ñ
+	{"\u00fc", "u\u0308", "u"},          // ü
+	{"\u00c5", "A\u030a", "A"},          // Å
+	{"\u00e7", "c\u0327", "c"},          // ç
+	{"\u1e69", "s\u0323\u0307", "s"},    // ṩ (two marks, canonical order)
+	{"\u01d5", "U\u0308\u0304", "U"},    // Ǖ
+	{"\u1ea5", "a\u0302\u0301", "a"},    // ấ
+	{"caf\u00e9", "cafe\u0301", "cafe"}, // café
+	{"\u00c5ngstr\u00f6m", "A\u030angstro\u0308m", "Angstrom"},
+	{"\ud55c", "\u1112\u1161\u11ab", "h"}, // 한 (Hangul syllable vs jamo)
+	{"\u304c", "\u304b\u3099", "ka"},      // が
+}
+
+// trickyMarks are combining characters stacked on a base letter without a
+// precomposed form.
+var trickyMarks = []string{"\u0300", "\u0301", "\u0308", "\u0323", "\u0336", "\u20dd", "\u0489"}
+
+// trickyInvisibles are bidi controls and zero-width characters; they shrink
+// away entirely.
+var trickyInvisibles = []string{
+	"\u200e", "\u200f", // LRM, RLM
+	"\u202a", "\u202b", "\u202c", "\u202d", "\u202e", // LRE, RLE, PDF, LRO, RLO
+	"\u2066", "\u2067", "\u2068", "\u2069", // LRI, RLI, FSI, PDI
+	"\u061c",                               // ALM
+	"\u200b", "\u200c", "\u200d", "\u2060", // ZWSP, ZWNJ, ZWJ, WJ
+	"\ufeff", // BOM / ZWNBSP
+}
+
+// unicodePiece is one generated piece of a StringUnicodeTricky value.
+type unicodePiece struct {
+	text  string
+	ascii string // what the piece shrinks to; "" means drop it
+}
+
+// StringUnicodeTricky generates strings made of pieces that stress text
+// normalization and display code:
+// - precomposed (NFC) and decomposed (NFD) spellings of the same text, at
+// times both side by side
+// - letters carrying stacked combining marks
+// - bidi control characters (LRM, RLO, FSI, ...) and zero-width characters
+// (ZWSP, ZWJ, BOM, ...)
+// - plain ASCII letters in between
+// Size bounds the number of pieces (default Min=0, Max=16).
+// Shrink: toward ASCII: drop pieces, replace each piece with its ASCII base
+// (invisible characters are removed), then tame ASCII letters to 'a'.
+func StringUnicodeTricky(size Size) Generator[string] {
+	return From(func(r *rand.Rand, sz Size) (string, Shrinker[string]) {
+		if r == nil {
+			r = rand.New(rand.NewSource(rand.Int63())) // #nosec G404 -- Using math/rand for deterministic property-based testing
+		}
+		if size.Min == 0 && size.Max == 0 {
+			size.Min, size.Max = 0, 16
+		}
+		if sz.Min != 0 || sz.Max != 0 { // allow external override
+			size = sz
+		}
+		if size.Max < size.Min {
+			size.Max = size.Min
+		}
+
+		n := size.Min
+		if size.Max > size.Min {
+			n += r.Intn(size.Max - size.Min + 1)
+		}
+		sz.Budget.Spend(n)
+		pieces := make([]unicodePiece, n)
+		for i := range pieces {
+			pieces[i] = trickyPiece(r)
+		}
+		return joinPieces(pieces), unicodeTrickyShrinker(pieces, size.Min)
+	})
+}
+
+// trickyPiece draws one piece: ~1/4 each of normalization variants, combining
+// marks and invisible characters, the rest plain ASCII letters.
+func trickyPiece(r *rand.Rand) unicodePiece {
+	switch r.Intn(8) {
+	case 0, 1:
+		t := trickyTexts[r.Intn(len(trickyTexts))]
+		switch r.Intn(3) {
+		case 0:
+			return unicodePiece{t.nfc, t.ascii}
+		case 1:
+			return unicodePiece{t.nfd, t.ascii}
+		default:
+			return unicodePiece{t.nfc + t.nfd, t.ascii + t.ascii}
+		}
+	case 2, 3:
+		base := string(rune(AlphabetAlpha[r.Intn(len(AlphabetAlpha))]))
+		text := base
+		for k := 1 + r.Intn(3); k > 0; k-- {
+			text += trickyMarks[r.Intn(len(trickyMarks))]
+		}
+		return unicodePiece{text, base}
+	case 4, 5:
+		return unicodePiece{trickyInvisibles[r.Intn(len(trickyInvisibles))], ""}
+	default:
+		c := string(rune(AlphabetAlpha[r.Intn(len(AlphabetAlpha))]))
+		return unicodePiece{c, c}
+	}
+}
+
+// joinPieces renders a piece list as a string.
+func joinPieces(ps []unicodePiece) string {
+	var b strings.Builder
+	for _, p := range ps {
+		b.WriteString(p.text)
+	}
+	return b.String()
+}
+
+// unicodeTrickyShrinker shrinks a piece list toward short ASCII text, never
+// keeping fewer than min pieces (invisible pieces may still become empty).
+func unicodeTrickyShrinker(start []unicodePiece, min int) Shrinker[string] {
+	return ShrinkNeighbors(start, joinPieces, joinPieces, func(base []unicodePiece, push func([]unicodePiece)) {
+		L := len(base)
+		// (1) shorten: minimum length, half, then drop each piece
+		if L > min {
+			push(append([]unicodePiece(nil), base[:min]...))
+			if L/2 > min {
+				push(append([]unicodePiece(nil), base[:L/2]...))
+			}
+			for i := L - 1; i >= 0; i-- {
+				cand := append(append([]unicodePiece(nil), base[:i]...), base[i+1:]...)
+				push(cand)
+			}
+		}
+		// (2) replace every piece with its ASCII base at once, then one at a time
+		all := make([]unicodePiece, L)
+		for i, p := range base {
+			all[i] = unicodePiece{p.ascii, p.ascii}
+		}
+		push(all)
+		for i := L - 1; i >= 0; i-- {
+			if base[i].text != base[i].ascii {
+				cand := append([]unicodePiece(nil), base...)
+				cand[i] = unicodePiece{base[i].ascii, base[i].ascii}
+				push(cand)
+			}
+		}
+		// (3) tame ASCII pieces to 'a'
+		for i := L - 1; i >= 0; i-- {
+			if base[i].text == base[i].ascii && base[i].text != "" && base[i].text != "a" {
+				cand := append([]unicodePiece(nil), base...)
+				cand[i] = unicodePiece{"a", "a"}
+				push(cand)
+			}
+		}
+	})
+}
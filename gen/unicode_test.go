@@ -0,0 +1,115 @@
+package gen
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+func TestStringUnicodeTricky(t *testing.T) {
+	g := StringUnicodeTricky(Size{Min: 1, Max: 12})
+	r := rand.New(rand.NewSource(123))
+
+	var nfd, invisible, nonASCII int
+	for i := 0; i < 500; i++ {
+		s, shrink := g.Generate(r, Size{})
+		if !utf8.ValidString(s) {
+			t.Fatalf("StringUnicodeTricky().Generate() = %q is not valid UTF-8", s)
+		}
+		if shrink == nil {
+			t.Fatal("StringUnicodeTricky().Generate() returned nil shrinker")
+		}
+		if strings.ContainsRune(s, '\u0301') || strings.ContainsRune(s, '\u0308') {
+			nfd++
+		}
+		if strings.ContainsAny(s, "\u200b\u200c\u200d\u2060\ufeff\u200e\u200f\u202a\u202b\u202c\u202d\u202e\u2066\u2067\u2068\u2069") {
+			invisible++
+		}
+		if !isASCII(s) {
+			nonASCII++
+		}
+	}
+	if nfd == 0 || invisible == 0 {
+		t.Errorf("expected combining marks and invisible characters, got %d and %d strings", nfd, invisible)
+	}
+	if nonASCII < 400 {
+		t.Errorf("expected mostly non-ASCII strings, got %d/500", nonASCII)
+	}
+}
+
+func TestTrickyTexts_FormsDiffer(t *testing.T) {
+	for _, tt := range trickyTexts {
+		if tt.nfc == tt.nfd {
+			t.Errorf("trickyText %q: NFC and NFD forms are equal", tt.nfc)
+		}
+		if utf8.RuneCountInString(tt.nfd) <= utf8.RuneCountInString(tt.nfc) {
+			t.Errorf("trickyText %q: NFD form %q is not decomposed", tt.nfc, tt.nfd)
+		}
+		if !isASCII(tt.ascii) {
+			t.Errorf("trickyText %q: fallback %q is not ASCII", tt.nfc, tt.ascii)
+		}
+	}
+}
+
+func TestStringUnicodeTricky_ShrinksTowardASCII(t *testing.T) {
+	g := StringUnicodeTricky(Size{Min: 1, Max: 10})
+	r := rand.New(rand.NewSource(42))
+
+	for i := 0; i < 50; i++ {
+		s, shrink := g.Generate(r, Size{})
+		min := s
+		for j := 0; j < 2000; j++ {
+			next, ok := shrink(true)
+			if !ok {
+				break
+			}
+			min = next
+		}
+		if min != "a" && min != "" {
+			t.Errorf("StringUnicodeTricky() %q shrunk to %q, expected 'a' or empty", s, min)
+		}
+	}
+}
+
+func TestStringUnicodeTricky_ShrinkKeepsFailingFeature(t *testing.T) {
+	g := StringUnicodeTricky(Size{Max: 12})
+	r := rand.New(rand.NewSource(7))
+
+	// the "bug" is any zero-width space: shrinking must keep exactly one
+	fails := func(s string) bool { return strings.Contains(s, "\u200b") }
+	found := 0
+	for i := 0; i < 200; i++ {
+		s, shrink := g.Generate(r, Size{})
+		if !fails(s) {
+			continue
+		}
+		found++
+		min, accept := s, false
+		for j := 0; j < 2000; j++ {
+			next, ok := shrink(accept)
+			if !ok {
+				break
+			}
+			accept = fails(next)
+			if accept {
+				min = next
+			}
+		}
+		if min != "\u200b" {
+			t.Errorf("StringUnicodeTricky() %q shrunk to %q, expected ZWSP only", s, min)
+		}
+	}
+	if found == 0 {
+		t.Fatal("no generated string contained a zero-width space")
+	}
+}
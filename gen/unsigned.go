@@ -55,7 +55,7 @@ func unsignedShrinkInit[T ~uint | ~uint64](start, min, max T) (T, Shrinker[T]) {
 		if len(queue) == 0 {
 			return 0, false
 		}
-		if shrinkStrategy == ShrinkStrategyDFS {
+		if GetShrinkStrategy() == ShrinkStrategyDFS {
 			v := queue[len(queue)-1]
 			queue = queue[:len(queue)-1]
 			return v, true
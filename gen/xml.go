@@ -0,0 +1,205 @@
+// File: gen/xml.go
+package gen
+
+import (
+	"encoding/xml"
+	"math/rand"
+	"strings"
+)
+
+// xmlTextAlphabet mixes plain characters with the ones that require escaping.
+const xmlTextAlphabet = AlphabetAlphaNum + " <>&\"'"
+
+// xmlNode is the tree representation used to generate and shrink documents.
+type xmlNode struct {
+	name     string
+	attrs    [][2]string
+	text     string
+	children []*xmlNode
+}
+
+// XML generates well-formed XML documents with nested elements, attributes and
+// text; special characters (<, >, &, quotes) are always properly escaped.
+// - size.Max bounds the nesting depth (default 3); the root is depth 1.
+// Shrink: removes subtrees, attributes and text, and renames elements to "a",
+// converging toward a single empty root element "<a/>".
+func XML(size Size) Generator[string] {
+	return From(func(r *rand.Rand, sz Size) (string, Shrinker[string]) {
+		if r == nil {
+			r = rand.New(rand.NewSource(rand.Int63())) // #nosec G404 -- Using math/rand for deterministic property-based testing
+		}
+		if sz.Min != 0 || sz.Max != 0 {
+			size = sz
+		}
+		depth := size.Max
+		if depth <= 0 {
+			depth = 3
+		}
+
+		cur := generateXMLNode(r, depth)
+
+		queue := make([]*xmlNode, 0, 32)
+		seen := map[string]struct{}{cur.render(): {}}
+		var last *xmlNode
+
+		push := func(n *xmlNode) {
+			k := n.render()
+			if _, ok := seen[k]; ok {
+				return
+			}
+			seen[k] = struct{}{}
+			queue = append(queue, n)
+		}
+
+		// neighbors: for each node (preorder), a copy of the tree with that node simplified
+		grow := func(base *xmlNode) {
+			queue = queue[:0]
+			nodes := base.preorder()
+			for i, n := range nodes {
+				edit := func(f func(*xmlNode)) {
+					c := base.clone()
+					f(c.preorder()[i])
+					push(c)
+				}
+				// (1) reduce nesting: drop all children, then each child (R->L)
+				if len(n.children) > 0 {
+					edit(func(m *xmlNode) { m.children = nil })
+					for j := len(n.children) - 1; j >= 0; j-- {
+						edit(func(m *xmlNode) { m.children = append(m.children[:j], m.children[j+1:]...) })
+					}
+				}
+				// (2) attributes: all, then each (R->L)
+				if len(n.attrs) > 0 {
+					edit(func(m *xmlNode) { m.attrs = nil })
+					for j := len(n.attrs) - 1; j >= 0; j-- {
+						edit(func(m *xmlNode) { m.attrs = append(m.attrs[:j], m.attrs[j+1:]...) })
+					}
+				}
+				// (3) text
+				if n.text != "" {
+					edit(func(m *xmlNode) { m.text = "" })
+				}
+				// (4) simplest name
+				if n.name != "a" {
+					edit(func(m *xmlNode) { m.name = "a" })
+				}
+			}
+		}
+		grow(cur)
+
+		pop := func() (*xmlNode, bool) {
+			if len(queue) == 0 {
+				return nil, false
+			}
+			if GetShrinkStrategy() == ShrinkStrategyDFS {
+				v := queue[len(queue)-1]
+				queue = queue[:len(queue)-1]
+				return v, true
+			}
+			v := queue[0]
+			queue = queue[1:]
+			return v, true
+		}
+
+		return cur.render(), func(accept bool) (string, bool) {
+			if accept && last != nil && last.render() != cur.render() {
+				cur = last
+				grow(cur)
+			}
+			nxt, ok := pop()
+			if !ok {
+				return "", false
+			}
+			last = nxt
+			return nxt.render(), true
+		}
+	})
+}
+
+// generateXMLNode builds a random element with at most depth levels.
+func generateXMLNode(r *rand.Rand, depth int) *xmlNode {
+	n := &xmlNode{name: xmlName(r)}
+
+	used := map[string]struct{}{}
+	for i, na := 0, r.Intn(4); i < na; i++ {
+		name := xmlName(r)
+		if _, dup := used[name]; dup {
+			continue
+		}
+		used[name] = struct{}{}
+		n.attrs = append(n.attrs, [2]string{name, xmlText(r, 8)})
+	}
+	if r.Intn(2) == 0 {
+		n.text = xmlText(r, 12)
+	}
+	if depth > 1 {
+		for i, nc := 0, r.Intn(4); i < nc; i++ {
+			n.children = append(n.children, generateXMLNode(r, depth-1))
+		}
+	}
+	return n
+}
+
+// xmlName returns a valid element/attribute name (lowercase letters).
+func xmlName(r *rand.Rand) string {
+	b := make([]byte, 1+r.Intn(6))
+	for i := range b {
+		b[i] = AlphabetLower[r.Intn(len(AlphabetLower))]
+	}
+	return string(b)
+}
+
+// xmlText returns raw (unescaped) text of length up to maxLen.
+func xmlText(r *rand.Rand, maxLen int) string {
+	b := make([]byte, r.Intn(maxLen+1))
+	for i := range b {
+		b[i] = xmlTextAlphabet[r.Intn(len(xmlTextAlphabet))]
+	}
+	return string(b)
+}
+
+// render serializes the node, escaping text and attribute values.
+func (n *xmlNode) render() string {
+	var b strings.Builder
+	n.write(&b)
+	return b.String()
+}
+
+// write appends the serialized node to b.
+func (n *xmlNode) write(b *strings.Builder) {
+	b.WriteString("<" + n.name)
+	for _, a := range n.attrs {
+		b.WriteString(" " + a[0] + `="`)
+		_ = xml.EscapeText(b, []byte(a[1]))
+		b.WriteString(`"`)
+	}
+	if n.text == "" && len(n.children) == 0 {
+		b.WriteString("/>")
+		return
+	}
+	b.WriteString(">")
+	_ = xml.EscapeText(b, []byte(n.text))
+	for _, c := range n.children {
+		c.write(b)
+	}
+	b.WriteString("</" + n.name + ">")
+}
+
+// clone returns a deep copy of the tree.
+func (n *xmlNode) clone() *xmlNode {
+	c := &xmlNode{name: n.name, text: n.text}
+	c.attrs = append([][2]string(nil), n.attrs...)
+	for _, ch := range n.children {
+		c.children = append(c.children, ch.clone())
+	}
+	return c
+}
+
+// preorder lists the nodes of the tree in preorder.
+func (n *xmlNode) preorder() []*xmlNode {
+	out := []*xmlNode{n}
+	for _, c := range n.children {
+		out = append(out, c.preorder()...)
+	}
+	return out
+}
@@ -0,0 +1,87 @@
+package gen
+
+import (
+	"encoding/xml"
+	"io"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// xmlDepth parses doc and returns its maximum element depth.
+func xmlDepth(t *testing.T, doc string) int {
+	t.Helper()
+	dec := xml.NewDecoder(strings.NewReader(doc))
+	depth, maxDepth := 0, 0
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return maxDepth
+		}
+		if err != nil {
+			t.Fatalf("XML document %q is not well-formed: %v", doc, err)
+		}
+		switch tok.(type) {
+		case xml.StartElement:
+			depth++
+			if depth > maxDepth {
+				maxDepth = depth
+			}
+		case xml.EndElement:
+			depth--
+		}
+	}
+}
+
+func TestXML(t *testing.T) {
+	gen := XML(Size{Max: 4})
+	r := rand.New(rand.NewSource(123))
+
+	for i := 0; i < 50; i++ {
+		value, shrink := gen.Generate(r, Size{})
+
+		if d := xmlDepth(t, value); d < 1 || d > 4 {
+			t.Errorf("XML().Generate() depth = %d, expected in range [1, 4]", d)
+		}
+		if shrink == nil {
+			t.Error("XML().Generate() returned nil shrinker")
+		}
+	}
+}
+
+func TestXML_EscapesSpecialCharacters(t *testing.T) {
+	gen := XML(Size{})
+	r := rand.New(rand.NewSource(1))
+
+	escaped := false
+	for i := 0; i < 100; i++ {
+		value, _ := gen.Generate(r, Size{})
+		xmlDepth(t, value)
+		if strings.Contains(value, "&lt;") || strings.Contains(value, "&amp;") {
+			escaped = true
+		}
+	}
+	if !escaped {
+		t.Error("XML() never produced escaped entities in 100 documents")
+	}
+}
+
+func TestXML_ShrinksToEmptyRoot(t *testing.T) {
+	gen := XML(Size{Max: 3})
+	r := rand.New(rand.NewSource(42))
+	_, shrink := gen.Generate(r, Size{})
+
+	// accept every candidate: the shrinker must converge to a single empty root
+	min := ""
+	for i := 0; i < 5000; i++ {
+		next, ok := shrink(true)
+		if !ok {
+			break
+		}
+		xmlDepth(t, next)
+		min = next
+	}
+	if min != "" && min != "<a/>" {
+		t.Errorf("XML() shrunk to %q, expected '<a/>'", min)
+	}
+}
@@ -0,0 +1,168 @@
+package prop
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"arcsyn.io/propx/gen"
+)
+
+// args3, args4 and args5 hold the arguments of one example of ForAll3,
+// ForAll4 and ForAll5.
+type (
+	args3[A, B, C any] struct {
+		a A
+		b B
+		c C
+	}
+	args4[A, B, C, D any] struct {
+		args3[A, B, C]
+		d D
+	}
+	args5[A, B, C, D, E any] struct {
+		args4[A, B, C, D]
+		e E
+	}
+)
+
+// GoString renders the arguments as a list in failure reports.
+func (x args3[A, B, C]) GoString() string { return x.renderValues(goString) }
+
+// renderValues renders the arguments as a list, each with value.
+func (x args3[A, B, C]) renderValues(value func(any) string) string {
+	return argsString(value, x.a, x.b, x.c)
+}
+
+// MarshalJSON encodes the arguments as an array in JSON reports.
+func (x args3[A, B, C]) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]any{x.a, x.b, x.c})
+}
+
+// UnmarshalJSON decodes the arguments from an array, as read back from a
+// corpus or golden file.
+func (x *args3[A, B, C]) UnmarshalJSON(data []byte) error {
+	return unmarshalArgs(data, &x.a, &x.b, &x.c)
+}
+
+// GoString renders the arguments as a list in failure reports.
+func (x args4[A, B, C, D]) GoString() string { return x.renderValues(goString) }
+
+// renderValues renders the arguments as a list, each with value.
+func (x args4[A, B, C, D]) renderValues(value func(any) string) string {
+	return argsString(value, x.a, x.b, x.c, x.d)
+}
+
+// MarshalJSON encodes the arguments as an array in JSON reports.
+func (x args4[A, B, C, D]) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]any{x.a, x.b, x.c, x.d})
+}
+
+// UnmarshalJSON decodes the arguments from an array, as read back from a
+// corpus or golden file.
+func (x *args4[A, B, C, D]) UnmarshalJSON(data []byte) error {
+	return unmarshalArgs(data, &x.a, &x.b, &x.c, &x.d)
+}
+
+// GoString renders the arguments as a list in failure reports.
+func (x args5[A, B, C, D, E]) GoString() string { return x.renderValues(goString) }
+
+// renderValues renders the arguments as a list, each with value.
+func (x args5[A, B, C, D, E]) renderValues(value func(any) string) string {
+	return argsString(value, x.a, x.b, x.c, x.d, x.e)
+}
+
+// MarshalJSON encodes the arguments as an array in JSON reports.
+func (x args5[A, B, C, D, E]) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]any{x.a, x.b, x.c, x.d, x.e})
+}
+
+// UnmarshalJSON decodes the arguments from an array, as read back from a
+// corpus or golden file.
+func (x *args5[A, B, C, D, E]) UnmarshalJSON(data []byte) error {
+	return unmarshalArgs(data, &x.a, &x.b, &x.c, &x.d, &x.e)
+}
+
+// unmarshalArgs decodes the JSON array data into ptrs, one element each.
+func unmarshalArgs(data []byte, ptrs ...any) error {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if len(raw) != len(ptrs) {
+		return fmt.Errorf("expected an array of %d arguments, got %d", len(ptrs), len(raw))
+	}
+	for i, p := range ptrs {
+		if err := json.Unmarshal(raw[i], p); err != nil {
+			return fmt.Errorf("argument %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+// argsString renders values as "(v1, v2, ...)", each with value.
+func argsString(value func(any) string, vs ...any) string {
+	s := make([]string, len(vs))
+	for i, v := range vs {
+		s[i] = value(v)
+	}
+	return "(" + strings.Join(s, ", ") + ")"
+}
+
+// genArgs3 generates the arguments of ForAll3 from nested pairs, which
+// shrink the arguments one after the other, first to last.
+func genArgs3[A, B, C any](ga gen.Generator[A], gb gen.Generator[B], gc gen.Generator[C]) gen.Generator[args3[A, B, C]] {
+	return gen.Map(gen.PairOf(ga, gen.PairOf(gb, gc)), func(p gen.Pair[A, gen.Pair[B, C]]) args3[A, B, C] {
+		return args3[A, B, C]{p.First, p.Second.First, p.Second.Second}
+	})
+}
+
+// ForAll3 is ForAll for properties of three independently generated
+// arguments, without packing them into pairs. The arguments shrink one after
+// the other, first to last, and the counterexample is reported as
+// "(a, b, c)".
+//
+// Example usage:
+//
+//	prop.ForAll3(t, prop.Default(), gen.Int(gen.Size{}), gen.StringAlpha(gen.Size{}), gen.Bool())(
+//	    func(t *testing.T, n int, s string, upper bool) {
+//	        if got := Pad(s, n, upper); len(got) < n {
+//	            t.Errorf("Pad(%q, %d, %v) = %q, shorter than %d", s, n, upper, got, n)
+//	        }
+//	    })
+func ForAll3[A, B, C any](t *testing.T, cfg Config, ga gen.Generator[A], gb gen.Generator[B], gc gen.Generator[C]) func(func(*testing.T, A, B, C)) {
+	return func(body func(*testing.T, A, B, C)) {
+		ForAll(t, cfg, genArgs3(ga, gb, gc))(func(t *testing.T, x args3[A, B, C]) {
+			body(t, x.a, x.b, x.c)
+		})
+	}
+}
+
+// genArgs4 generates the arguments of ForAll4, as genArgs3.
+func genArgs4[A, B, C, D any](ga gen.Generator[A], gb gen.Generator[B], gc gen.Generator[C], gd gen.Generator[D]) gen.Generator[args4[A, B, C, D]] {
+	return gen.Map(gen.PairOf(genArgs3(ga, gb, gc), gd), func(p gen.Pair[args3[A, B, C], D]) args4[A, B, C, D] {
+		return args4[A, B, C, D]{p.First, p.Second}
+	})
+}
+
+// ForAll4 is ForAll3 for properties of four arguments.
+func ForAll4[A, B, C, D any](t *testing.T, cfg Config, ga gen.Generator[A], gb gen.Generator[B], gc gen.Generator[C], gd gen.Generator[D]) func(func(*testing.T, A, B, C, D)) {
+	return func(body func(*testing.T, A, B, C, D)) {
+		ForAll(t, cfg, genArgs4(ga, gb, gc, gd))(func(t *testing.T, x args4[A, B, C, D]) {
+			body(t, x.a, x.b, x.c, x.d)
+		})
+	}
+}
+
+// ForAll5 is ForAll3 for properties of five arguments.
+func ForAll5[A, B, C, D, E any](t *testing.T, cfg Config, ga gen.Generator[A], gb gen.Generator[B], gc gen.Generator[C], gd gen.Generator[D], ge gen.Generator[E]) func(func(*testing.T, A, B, C, D, E)) {
+	g := gen.Map(gen.PairOf(genArgs4(ga, gb, gc, gd), ge), func(p gen.Pair[args4[A, B, C, D], E]) args5[A, B, C, D, E] {
+		return args5[A, B, C, D, E]{p.First, p.Second}
+	})
+	return func(body func(*testing.T, A, B, C, D, E)) {
+		ForAll(t, cfg, g)(func(t *testing.T, x args5[A, B, C, D, E]) {
+			body(t, x.a, x.b, x.c, x.d, x.e)
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package prop
+
+import (
+	"encoding/json"
+	"math/rand"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"arcsyn.io/propx/gen"
+)
+
+func TestForAll3To5_PassArguments(t *testing.T) {
+	config := Config{Seed: 5, Examples: 30, MaxShrink: 10, ShrinkStrat: "bfs", Parallelism: 1}
+	small := gen.IntRange(0, 9)
+	word := gen.StringAlpha(gen.Size{Min: 1, Max: 3})
+
+	runs := 0
+	ForAll3(t, config, small, word, gen.Bool())(func(t *testing.T, n int, s string, _ bool) {
+		runs++
+		if n < 0 || n > 9 || len(s) < 1 || len(s) > 3 {
+			t.Errorf("ForAll3 arguments (%d, %q) out of range", n, s)
+		}
+	})
+	ForAll4(t, config, small, word, small, word)(func(t *testing.T, a int, b string, c int, d string) {
+		runs++
+		if a > 9 || c > 9 || len(b) > 3 || len(d) > 3 {
+			t.Errorf("ForAll4 arguments (%d, %q, %d, %q) out of range", a, b, c, d)
+		}
+	})
+	ForAll5(t, config, small, small, small, small, gen.Const("x"))(func(t *testing.T, a, b, c, d int, e string) {
+		runs++
+		if a > 9 || b > 9 || c > 9 || d > 9 || e != "x" {
+			t.Errorf("ForAll5 arguments (%d, %d, %d, %d, %q) out of range", a, b, c, d, e)
+		}
+	})
+	if runs != 3*config.Examples {
+		t.Errorf("properties ran %d times, expected %d", runs, 3*config.Examples)
+	}
+}
+
+func TestGenArgs_ShrinksEachArgument(t *testing.T) {
+	g := genArgs4(gen.IntRange(0, 100), gen.IntRange(0, 100), gen.IntRange(0, 100), gen.IntRange(0, 100))
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 10; i++ {
+		x, shrink := g.Generate(r, gen.Size{})
+		// fails while every argument is at least 3
+		fails := func(x args4[int, int, int, int]) bool { return x.a >= 3 && x.b >= 3 && x.c >= 3 && x.d >= 3 }
+		if !fails(x) || x.a < 10 || x.b < 10 || x.c < 10 || x.d < 10 {
+			continue
+		}
+		min, accept := x, false
+		for j := 0; j < 10000; j++ {
+			next, ok := shrink(accept)
+			if !ok {
+				break
+			}
+			if accept = fails(next); accept {
+				min = next
+			}
+		}
+		if min.a >= 10 || min.b >= 10 || min.c >= 10 || min.d >= 10 {
+			t.Errorf("%#v shrunk to %#v, expected every argument below 10", x, min)
+		}
+	}
+}
+
+func TestArgs_Report(t *testing.T) {
+	x := args5[int, string, bool, []int, float64]{args4[int, string, bool, []int]{args3[int, string, bool]{1, "a", true}, []int{2}}, 0.5}
+	if got, want := x.GoString(), `(1, "a", true, []int{2}, 0.5)`; got != want {
+		t.Errorf("GoString() = %s, expected %s", got, want)
+	}
+	if got := string(jsonValue(x)); got != `[1,"a",true,[2],0.5]` {
+		t.Errorf("jsonValue() = %s, expected an array of the arguments", got)
+	}
+	data, err := json.Marshal(args3[int, int, int]{1, 2, 3})
+	if err != nil || string(data) != "[1,2,3]" {
+		t.Errorf("json.Marshal(args3) = %s, %v; expected [1,2,3]", data, err)
+	}
+}
+
+func TestArgs_UnmarshalJSON(t *testing.T) {
+	x := args5[int, string, bool, []int, float64]{args4[int, string, bool, []int]{args3[int, string, bool]{1, "a", true}, []int{2}}, 0.5}
+	data, _ := json.Marshal(x)
+	var got args5[int, string, bool, []int, float64]
+	if err := json.Unmarshal(data, &got); err != nil || !reflect.DeepEqual(got, x) {
+		t.Errorf("json.Unmarshal(%s) = %#v, %v; expected %#v", data, got, err, x)
+	}
+	var short args4[int, int, int, int]
+	if err := json.Unmarshal([]byte("[1,2,3]"), &short); err == nil {
+		t.Error("json.Unmarshal([1,2,3]) into args4 returned nil error")
+	}
+	var wrong args3[int, int, bool]
+	if err := json.Unmarshal([]byte(`[1,2,"x"]`), &wrong); err == nil {
+		t.Error(`json.Unmarshal([1,2,"x"]) into args3[int, int, bool] returned nil error`)
+	}
+}
+
+func TestForAll3_GoldenFile(t *testing.T) {
+	cfg := Config{Seed: 1, Examples: 5, Parallelism: 1, GoldenFile: filepath.Join(t.TempDir(), "args3.json")}
+	g := gen.IntRange(0, 1000)
+	var recorded, replayed []args3[int, int, bool]
+	ForAll3(t, cfg, g, g, gen.Bool())(func(t *testing.T, a, b int, c bool) {
+		recorded = append(recorded, args3[int, int, bool]{a, b, c})
+	})
+	ForAll3(t, cfg.WithSeed(99), g, g, gen.Bool())(func(t *testing.T, a, b int, c bool) {
+		replayed = append(replayed, args3[int, int, bool]{a, b, c})
+	})
+	if len(recorded) != 5 || !reflect.DeepEqual(recorded, replayed) {
+		t.Errorf("replayed arguments %v, expected recorded %v", replayed, recorded)
+	}
+}
+
+func TestForAll3_Corpus(t *testing.T) {
+	// ForAll3 stores its counterexamples as args3 and reads them back as such
+	cfg := Config{CorpusDir: t.TempDir()}
+	min := args3[int, string, bool]{7, "x", true}
+	if err := saveCorpus(t, cfg, min); err != nil {
+		t.Fatalf("saveCorpus(%#v) error: %v", min, err)
+	}
+	got := loadCorpus[args3[int, string, bool]](t, cfg)
+	if len(got) != 1 || got[0] != min {
+		t.Errorf("loadCorpus() = %#v, expected [%#v]", got, min)
+	}
+}
@@ -0,0 +1,88 @@
+package prop
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"testing"
+
+	"arcsyn.io/propx/gen"
+)
+
+// defaultConcurrentCallers is the number of goroutines that invoke the property
+// for each example when cfg.Parallelism does not ask for more than one.
+const defaultConcurrentCallers = 4
+
+// ForAllConcurrent runs a property-based test where every generated input is
+// exercised by several goroutines at the same time. It is intended to be run
+// with `go test -race` to surface data races in the code under test.
+//
+// This differs from Config.Parallelism in ForAll, which spreads *examples*
+// across workers: here each single example is hammered concurrently.
+// The number of simultaneous callers is cfg.Parallelism when it is greater
+// than 1, otherwise 4.
+//
+// When the race detector (or the property itself) fails an example, the input
+// is shrunk as usual and reported as the counterexample. Properties should use
+// t.Error/t.Errorf; t.Fatal must not be called from the spawned goroutines.
+//
+// Example usage:
+//
+//	prop.ForAllConcurrent(t, prop.Default(), gen.Int(gen.Size{}), func(t *testing.T, x int) {
+//	    cache.Put(x, x)
+//	    if _, ok := cache.Get(x); !ok {
+//	        t.Errorf("missing key %d", x)
+//	    }
+//	})
+func ForAllConcurrent[T any](t *testing.T, cfg Config, g gen.Generator[T], property func(*testing.T, T)) {
+	seed := cfg.effectiveSeed()
+	r := rand.New(rand.NewSource(seed)) // #nosec G404 -- Using math/rand for deterministic property-based testing
+	gen.SetShrinkStrategy(cfg.ShrinkStrat)
+
+	callers := cfg.Parallelism
+	if callers <= 1 {
+		callers = defaultConcurrentCallers
+	}
+
+	t.Logf("[propx] seed=%d%s examples=%d maxshrink=%d strategy=%s concurrent_callers=%d",
+		seed, cfg.seedSource(), cfg.Examples, cfg.MaxShrink, cfg.ShrinkStrat, callers)
+
+	run := func(name string, v T) bool {
+		return t.Run(name, func(st *testing.T) { hammer(st, callers, v, property) })
+	}
+
+	for i := 0; i < cfg.Examples; i++ {
+		val, shrink, err := generate(g, r, cfg.exampleSize(nil), seed, i)
+		if err != nil {
+			t.Fatal(err)
+		}
+		name := fmt.Sprintf("ex#%d", i+1)
+
+		if run(name, val) {
+			continue
+		}
+
+		scfg, path := recordShrinkPath(cfg, val)
+		min, steps := shrinkCounterexample(scfg, name, val, shrink, run)
+		t.Fatal(failureMessage(t, valueRenderer(cfg.Stringer), seed, i+1, name, steps, min, path()))
+	}
+}
+
+// hammer invokes property(t, v) from n goroutines released at the same instant,
+// maximizing the chance of overlapping accesses in the code under test.
+func hammer[T any](t *testing.T, n int, v T, property func(*testing.T, T)) {
+	var (
+		wg    sync.WaitGroup
+		start = make(chan struct{})
+	)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			property(t, v)
+		}()
+	}
+	close(start)
+	wg.Wait()
+}
@@ -0,0 +1,82 @@
+package prop
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"arcsyn.io/propx/gen"
+)
+
+func TestForAllConcurrent_CallsPropertyPerCaller(t *testing.T) {
+	config := Config{
+		Seed:        12345,
+		Examples:    5,
+		MaxShrink:   10,
+		ShrinkStrat: "bfs",
+		Parallelism: 3,
+	}
+
+	var calls atomic.Int64
+	ForAllConcurrent(t, config, gen.IntRange(0, 10), func(t *testing.T, x int) {
+		calls.Add(1)
+		if x < 0 || x > 10 {
+			t.Errorf("Value %d is outside expected range", x)
+		}
+	})
+
+	if got := calls.Load(); got != 15 {
+		t.Errorf("ForAllConcurrent() invoked property %d times, expected 15", got)
+	}
+}
+
+func TestForAllConcurrent_DefaultCallers(t *testing.T) {
+	config := Config{
+		Seed:        12345,
+		Examples:    2,
+		MaxShrink:   10,
+		ShrinkStrat: "bfs",
+		Parallelism: 1,
+	}
+
+	var calls atomic.Int64
+	ForAllConcurrent(t, config, gen.Bool(), func(t *testing.T, _ bool) {
+		calls.Add(1)
+	})
+
+	if got := calls.Load(); got != 2*defaultConcurrentCallers {
+		t.Errorf("ForAllConcurrent() invoked property %d times, expected %d", got, 2*defaultConcurrentCallers)
+	}
+}
+
+func TestForAllConcurrent_SynchronizedState(t *testing.T) {
+	config := Config{
+		Seed:        12345,
+		Examples:    10,
+		MaxShrink:   10,
+		ShrinkStrat: "bfs",
+		Parallelism: 8,
+	}
+
+	// a properly synchronized map must survive concurrent hammering (run with -race)
+	var mu sync.Mutex
+	seen := map[int]int{}
+	ForAllConcurrent(t, config, gen.IntRange(0, 100), func(t *testing.T, x int) {
+		mu.Lock()
+		seen[x]++
+		mu.Unlock()
+	})
+}
+
+func TestHammer(t *testing.T) {
+	var calls atomic.Int64
+	hammer(t, 6, 42, func(t *testing.T, v int) {
+		if v != 42 {
+			t.Errorf("Expected 42, got %d", v)
+		}
+		calls.Add(1)
+	})
+	if got := calls.Load(); got != 6 {
+		t.Errorf("hammer() invoked property %d times, expected 6", got)
+	}
+}
@@ -0,0 +1,109 @@
+package prop
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"arcsyn.io/propx/gen"
+)
+
+// corpusPath returns the directory holding the corpus of the current test,
+// or "" when cfg.CorpusDir is not set.
+func corpusPath(t *testing.T, cfg Config) string {
+	if cfg.CorpusDir == "" {
+		return ""
+	}
+	name := strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || r == ':' || r == ' ' {
+			return '_'
+		}
+		return r
+	}, t.Name())
+	return filepath.Join(cfg.CorpusDir, name)
+}
+
+// loadCorpus decodes the corpus entries of the current test, in file name
+// order. Entries that cannot be read or decoded into T are skipped.
+func loadCorpus[T any](t *testing.T, cfg Config) []T {
+	dir := corpusPath(t, cfg)
+	if dir == "" {
+		return nil
+	}
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil
+	}
+	sort.Strings(files)
+
+	var out []T
+	for _, f := range files {
+		data, err := os.ReadFile(f) // #nosec G304 -- corpus files live in the configured test directory
+		if err != nil {
+			continue
+		}
+		var v T
+		if err := json.Unmarshal(data, &v); err != nil {
+			continue
+		}
+		out = append(out, v)
+	}
+	return out
+}
+
+// saveCorpus stores a minimal counterexample in the corpus of the current
+// test, named by the hash of its JSON encoding (so duplicates collapse).
+// Values that cannot be encoded as JSON are not stored.
+func saveCorpus(t *testing.T, cfg Config, min any) error {
+	dir := corpusPath(t, cfg)
+	if dir == "" {
+		return nil
+	}
+	data, err := json.Marshal(min)
+	if err != nil {
+		return fmt.Errorf("[propx] counterexample not stored in corpus: %w", err)
+	}
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return err
+	}
+	h := fnv.New64a()
+	_, _ = h.Write(data)
+	return os.WriteFile(filepath.Join(dir, fmt.Sprintf("%016x.json", h.Sum64())), data, 0o600)
+}
+
+// withCorpusHints returns a shrinker that first proposes the corpus entries
+// and then defers to s. Corpus entries are past minimal counterexamples, so
+// when one still fails it is reported as is and shrinking stops there.
+func withCorpusHints[T any](hints []T, s gen.Shrinker[T]) gen.Shrinker[T] {
+	if len(hints) == 0 {
+		return s
+	}
+	i := 0
+	hinted, done := false, false
+	return func(accept bool) (T, bool) {
+		var zero T
+		if done || (hinted && accept) {
+			done = true
+			return zero, false
+		}
+		if i < len(hints) {
+			hinted = true
+			i++
+			return hints[i-1], true
+		}
+		if s == nil {
+			return zero, false
+		}
+		if hinted {
+			// the last hint passed: start s as the runner does (its
+			// original value still fails)
+			hinted, accept = false, true
+		}
+		return s(accept)
+	}
+}
@@ -0,0 +1,109 @@
+package prop
+
+import (
+	"os"
+	"testing"
+
+	"arcsyn.io/propx/gen"
+)
+
+func TestCorpus_SaveAndLoad(t *testing.T) {
+	cfg := Config{CorpusDir: t.TempDir()}
+
+	for _, v := range []int{42, 7, 42} {
+		if err := saveCorpus(t, cfg, v); err != nil {
+			t.Fatalf("saveCorpus(%d) error: %v", v, err)
+		}
+	}
+
+	got := loadCorpus[int](t, cfg)
+	if len(got) != 2 {
+		t.Fatalf("loadCorpus() = %v, expected 2 distinct entries", got)
+	}
+	// entries of other types are skipped
+	if s := loadCorpus[string](t, cfg); len(s) != 0 {
+		t.Errorf("loadCorpus[string]() = %v, expected none", s)
+	}
+}
+
+func TestCorpus_Disabled(t *testing.T) {
+	if err := saveCorpus(t, Config{}, 1); err != nil {
+		t.Errorf("saveCorpus() without CorpusDir returned %v", err)
+	}
+	if got := loadCorpus[int](t, Config{}); got != nil {
+		t.Errorf("loadCorpus() without CorpusDir = %v, expected nil", got)
+	}
+}
+
+func TestCorpus_UnencodableValue(t *testing.T) {
+	cfg := Config{CorpusDir: t.TempDir()}
+	if err := saveCorpus(t, cfg, func() {}); err == nil {
+		t.Error("saveCorpus(func) returned nil error")
+	}
+	entries, _ := os.ReadDir(cfg.CorpusDir)
+	if len(entries) != 0 {
+		t.Errorf("saveCorpus(func) wrote %d entries", len(entries))
+	}
+}
+
+func TestWithCorpusHints_TriesCorpusFirst(t *testing.T) {
+	_, base := gen.IntRange(0, 1000).Generate(nil, gen.Size{})
+	s := withCorpusHints([]int{13, 99}, base)
+
+	// shrinkCounterexample starts with accept=true
+	if v, ok := s(true); !ok || v != 13 {
+		t.Fatalf("first candidate = (%d, %v), expected corpus entry 13", v, ok)
+	}
+	if v, ok := s(false); !ok || v != 99 {
+		t.Fatalf("second candidate = (%d, %v), expected corpus entry 99", v, ok)
+	}
+	// 99 still fails: it is a known minimal value, shrinking stops
+	if v, ok := s(true); ok {
+		t.Errorf("after an accepted corpus entry, shrink proposed %d", v)
+	}
+}
+
+func TestWithCorpusHints_FallsBackToShrinker(t *testing.T) {
+	calls := 0
+	base := func(accept bool) (int, bool) {
+		calls++
+		if calls == 1 && !accept {
+			t.Error("first call to the generator's shrinker should report accept=true")
+		}
+		return 0, calls < 3
+	}
+	s := withCorpusHints([]int{5}, base)
+
+	if v, _ := s(true); v != 5 {
+		t.Fatalf("first candidate = %d, expected corpus entry 5", v)
+	}
+	// corpus entry passed: continue with the generator's own shrinker
+	if _, ok := s(false); !ok || calls != 1 {
+		t.Errorf("after a rejected corpus entry, shrinker calls = %d, expected 1", calls)
+	}
+	if got := withCorpusHints(nil, gen.Shrinker[int](base)); got == nil {
+		t.Error("withCorpusHints(nil, s) returned nil")
+	}
+}
+
+func TestShrinkCounterexample_UsesCorpus(t *testing.T) {
+	cfg := Config{MaxShrink: 50, CorpusDir: t.TempDir()}
+	if err := saveCorpus(t, cfg, 17); err != nil {
+		t.Fatal(err)
+	}
+
+	_, base := gen.IntRange(0, 1000).Generate(nil, gen.Size{})
+	var tried []int
+	run := func(_ string, v int) bool {
+		tried = append(tried, v)
+		return v < 10 // fails for v >= 10
+	}
+	min, _ := shrinkCounterexample(cfg, "ex#1", 500, withCorpusHints(loadCorpus[int](t, cfg), base), run)
+
+	if len(tried) == 0 || tried[0] != 17 {
+		t.Errorf("first shrink candidate = %v, expected the corpus entry 17", tried)
+	}
+	if min != 17 {
+		t.Errorf("shrinkCounterexample() = %d, expected corpus entry 17", min)
+	}
+}
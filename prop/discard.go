@@ -0,0 +1,192 @@
+package prop
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// DefaultMaxDiscardRatio is the MaxDiscardRatio used when Config leaves it 0:
+// the test fails when more than 90% of its examples are discarded.
+const DefaultMaxDiscardRatio = 0.9
+
+// discardSignal is the panic value of Discard.
+type discardSignal struct{}
+
+// Discard drops the current example from inside a property: the rest of the
+// body is not run and the example counts as discarded, neither a pass nor a
+// failure. Use it when an input turns out to be irrelevant only after
+// inspecting or partially processing it; to filter before the body runs,
+// prefer gen.Filter. Unlike t.Skip, which ends the whole test when called on
+// its *testing.T, Discard only ends the example and the run continues.
+//
+// Discarded examples are not replaced, and the test fails when more than
+// Config.MaxDiscardRatio of them are discarded, since the property then
+// checks too few inputs. While shrinking, a discarded candidate counts as
+// passing, so shrinking never ends on an irrelevant input. Errors reported
+// before Discard still fail the example.
+//
+// Discard works in ForAll and everything built on it (ForAllN, ForAllErr,
+// ForAllRand and the law checks); it must be called from the goroutine
+// running the property.
+//
+// Example usage:
+//
+//	prop.ForAll(t, cfg, gen.String("ab ", gen.Size{}))(func(t *testing.T, s string) {
+//	    fields := strings.Fields(s)
+//	    if len(fields) < 2 {
+//	        prop.Discard()
+//	    }
+//	    // ...
+//	})
+func Discard() {
+	panic(discardSignal{})
+}
+
+// runBody runs body on v, turning a Discard into a skip of t that is counted
+// in stats (nil while shrinking), and stores the example's Notes in *notes
+// (when notes is not nil).
+func runBody[T any](t *testing.T, body func(*testing.T, T), v T, stats *runStats, notes *[]string) {
+	t.Helper()
+	defer captureNotes(t, notes)()
+	defer func() {
+		if p := recover(); p != nil {
+			if _, ok := p.(discardSignal); !ok {
+				panic(p)
+			}
+			stats.addDiscard()
+			stats.discardDiagnostics().add("prop.Discard", v)
+			t.Skip("[propx] example discarded by prop.Discard")
+		}
+	}()
+	body(t, v)
+}
+
+// maxDiscardRatio returns the effective MaxDiscardRatio.
+func (c Config) maxDiscardRatio() float64 {
+	if c.MaxDiscardRatio == 0 {
+		return DefaultMaxDiscardRatio
+	}
+	return c.MaxDiscardRatio
+}
+
+// checkDiscards fails t when more than cfg's MaxDiscardRatio of the run's
+// examples were discarded.
+func checkDiscards(t *testing.T, cfg Config, seed int64, stats *runStats) {
+	t.Helper()
+	n := stats.discards.Load()
+	if ratio := cfg.maxDiscardRatio(); float64(n) > ratio*float64(cfg.Examples) {
+		t.Fatalf("[propx] gave up: %d of %d examples discarded (MaxDiscardRatio=%g); seed=%d\n"+
+			"generate relevant inputs directly instead of discarding them, or raise MaxDiscardRatio%s",
+			n, cfg.Examples, ratio, seed, stats.discardDiagnostics().text())
+	}
+}
+
+// discardSampleSize is the number of discarded values DiscardDiagnostics
+// shows per source.
+const discardSampleSize = 5
+
+// discardLog records the discards of a run by source, a filter or Discard,
+// for Config.DiscardDiagnostics. All methods are safe for concurrent use and
+// a nil *discardLog ignores every call.
+type discardLog struct {
+	mu        sync.Mutex
+	sources   []*discardSource // in order of their first discard
+	exhausted string           // source of a filter that gave up, "" if none
+}
+
+// discardSource counts the discards of one source and keeps the first ones.
+type discardSource struct {
+	name    string
+	count   int
+	samples []any
+}
+
+// add records v as discarded by the named source.
+func (l *discardLog) add(name string, v any) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	i := slices.IndexFunc(l.sources, func(s *discardSource) bool { return s.name == name })
+	if i < 0 {
+		i = len(l.sources)
+		l.sources = append(l.sources, &discardSource{name: name})
+	}
+	src := l.sources[i]
+	src.count++
+	if len(src.samples) < discardSampleSize {
+		src.samples = append(src.samples, v)
+	}
+}
+
+// observeFilter is the gen.FilterObserver of the run, passed in the Size of
+// each example.
+func (l *discardLog) observeFilter(label string, v any, exhausted bool) {
+	name := "unlabeled gen.Filter"
+	if label != "" {
+		name = fmt.Sprintf("gen.Filter %q", label)
+	}
+	l.add(name, v)
+	if exhausted {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if l.exhausted == "" {
+			l.exhausted = name
+		}
+	}
+}
+
+// takeExhausted returns the source of a filter that gave up since the last
+// call, or "".
+func (l *discardLog) takeExhausted() string {
+	if l == nil {
+		return ""
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	name := l.exhausted
+	l.exhausted = ""
+	return name
+}
+
+// stormError returns the error failing the run when a filter gave up while
+// generating example index, or nil.
+func (l *discardLog) stormError(seed int64, index int) error {
+	name := l.takeExhausted()
+	if name == "" {
+		return nil
+	}
+	return fmt.Errorf("[propx] gave up: %s found no acceptable value for example %d; seed=%d\n"+
+		"relax the filter or generate matching values directly\nreplay: -propx.seed=%d%s",
+		name, index+1, seed, seed, l.text())
+}
+
+// text renders the discards by source, most discarding first, each with its
+// sample, or "" when there is nothing to show.
+func (l *discardLog) text() string {
+	if l == nil {
+		return ""
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(l.sources) == 0 {
+		return ""
+	}
+	sources := slices.Clone(l.sources)
+	slices.SortStableFunc(sources, func(a, b *discardSource) int { return cmp.Compare(b.count, a.count) })
+	var b strings.Builder
+	b.WriteString("\ndiscards by source:")
+	for _, src := range sources {
+		samples := make([]string, len(src.samples))
+		for i, v := range src.samples {
+			samples[i] = fmt.Sprintf("%#v", v)
+		}
+		fmt.Fprintf(&b, "\n  %s: %d discarded, e.g. %s", src.name, src.count, strings.Join(samples, ", "))
+	}
+	return b.String()
+}
@@ -0,0 +1,186 @@
+package prop
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"arcsyn.io/propx/gen"
+)
+
+func TestDiscard(t *testing.T) {
+	for _, parallelism := range []int{1, 4} {
+		cfg := Config{Seed: 7, Examples: 100, MaxShrink: 10, ShrinkStrat: "bfs", Parallelism: parallelism}
+		g := gen.IntRange(0, 1000)
+
+		// the same seed generates the same examples: count the odd ones
+		odd := 0
+		for n := 1; n <= cfg.Examples; n++ {
+			if Reproduce(7, n, g)%2 == 1 {
+				odd++
+			}
+		}
+
+		stats := newRunStats()
+		var ran, after atomic.Int64
+		body := func(t *testing.T, x int) {
+			if x%2 == 1 {
+				Discard()
+				after.Add(1)
+			}
+			ran.Add(1)
+		}
+		if parallelism == 1 {
+			runSequential(t, cfg, g, body, 7, stats)
+		} else {
+			runParallel(t, cfg, g, body, 7, stats)
+		}
+		if got := stats.discards.Load(); got != int64(odd) {
+			t.Errorf("parallelism %d: %d examples discarded, expected %d", parallelism, got, odd)
+		}
+		if after.Load() != 0 || ran.Load() != int64(cfg.Examples-odd) {
+			t.Errorf("parallelism %d: body ran past Discard %d times and completed %d times, expected 0 and %d",
+				parallelism, after.Load(), ran.Load(), cfg.Examples-odd)
+		}
+		checkDiscards(t, cfg, 7, stats)
+	}
+}
+
+func TestDiscard_SkipsExample(t *testing.T) {
+	var sub *testing.T
+	stats := newRunStats()
+	passed := t.Run("ex", func(st *testing.T) {
+		sub = st
+		runBody(st, func(*testing.T, int) { Discard() }, 1, stats, nil)
+	})
+	if !passed || !sub.Skipped() {
+		t.Errorf("discarded example: passed %v, skipped %v; expected a skipped, passing subtest", passed, sub.Skipped())
+	}
+	// while shrinking (nil stats) the discard is not counted
+	t.Run("shrink", func(st *testing.T) {
+		runBody(st, func(*testing.T, int) { Discard() }, 1, nil, nil)
+	})
+	if got := stats.discards.Load(); got != 1 {
+		t.Errorf("%d discards counted, expected 1", got)
+	}
+}
+
+func TestDiscard_OtherPanicsPropagate(t *testing.T) {
+	defer func() {
+		if p := recover(); p != "boom" {
+			t.Errorf("recovered %v, expected the property's panic", p)
+		}
+	}()
+	runBody(t, func(*testing.T, int) { panic("boom") }, 1, nil, nil)
+}
+
+func TestConfig_MaxDiscardRatio(t *testing.T) {
+	if got := (Config{}).maxDiscardRatio(); got != DefaultMaxDiscardRatio {
+		t.Errorf("maxDiscardRatio() = %g, expected the default %g", got, DefaultMaxDiscardRatio)
+	}
+	if got := (Config{MaxDiscardRatio: 0.25}).maxDiscardRatio(); got != 0.25 {
+		t.Errorf("maxDiscardRatio() = %g, expected 0.25", got)
+	}
+
+	// at the limit the run still passes
+	stats := newRunStats()
+	for i := 0; i < 25; i++ {
+		stats.addDiscard()
+	}
+	checkDiscards(t, Config{Examples: 100, MaxDiscardRatio: 0.25}, 1, stats)
+}
+
+func TestDiscardLog(t *testing.T) {
+	var nilLog *discardLog
+	nilLog.add("prop.Discard", 1)
+	if nilLog.text() != "" || nilLog.stormError(1, 0) != nil {
+		t.Error("a nil discardLog is not inert")
+	}
+
+	l := &discardLog{}
+	l.observeFilter("", "x", false)
+	for i := 0; i < 7; i++ {
+		l.add("prop.Discard", i)
+	}
+	l.observeFilter("adults", 3, false)
+	l.observeFilter("adults", 12, false)
+	want := "\ndiscards by source:" +
+		"\n  prop.Discard: 7 discarded, e.g. 0, 1, 2, 3, 4" +
+		"\n  gen.Filter \"adults\": 2 discarded, e.g. 3, 12" +
+		"\n  unlabeled gen.Filter: 1 discarded, e.g. \"x\""
+	if got := l.text(); got != want {
+		t.Errorf("text() = %q, expected %q", got, want)
+	}
+	if err := l.stormError(1, 0); err != nil {
+		t.Errorf("stormError() = %v before any filter gave up", err)
+	}
+}
+
+func TestDiscardDiagnostics_FilterStorm(t *testing.T) {
+	g := gen.Label(gen.Filter(gen.IntRange(0, 9), func(x int) bool { return x > 100 }, 5), "huge")
+
+	// without diagnostics the filter generates the zero value
+	next := exampleFeed(Config{Examples: 3}, g, 3, newRunStats())
+	if _, v, _, err := next(0); err != nil || v != 0 {
+		t.Errorf("next(0) = %d, %v; expected 0 and no error", v, err)
+	}
+
+	stats := newRunStats()
+	stats.diagnostics = &discardLog{}
+	next = exampleFeed(Config{Examples: 3}, g, 3, stats)
+	_, _, _, err := next(0)
+	if err == nil {
+		t.Fatal("next(0) returned nil error for a filter that gave up")
+	}
+	for _, want := range []string{`gen.Filter "huge" found no acceptable value for example 1`, "seed=3", "-propx.seed=3", `gen.Filter "huge": 5 discarded, e.g.`} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q lacks %q", err, want)
+		}
+	}
+}
+
+func TestDiscardDiagnostics_OnlyOwnFilters(t *testing.T) {
+	// a run with diagnostics beside one whose filter keeps rejecting, as
+	// with parallel tests: the first records nothing of the second
+	storm := gen.Label(gen.Filter(gen.IntRange(0, 9), func(x int) bool { return x > 100 }, 5), "huge")
+	stats := newRunStats()
+	stats.diagnostics = &discardLog{}
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		next := exampleFeed(Config{Examples: 50}, storm, 4, newRunStats())
+		for pos := 0; pos < 50; pos++ {
+			next(pos)
+		}
+	}()
+	var errs []error
+	go func() {
+		defer wg.Done()
+		next := exampleFeed(Config{Examples: 50}, gen.IntRange(0, 9), 4, stats)
+		for pos := 0; pos < 50; pos++ {
+			if _, _, _, err := next(pos); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}()
+	wg.Wait()
+	if len(errs) > 0 {
+		t.Errorf("run without filters failed: %v", errs[0])
+	}
+	if got := stats.diagnostics.text(); got != "" {
+		t.Errorf("text() = %q, expected no discards", got)
+	}
+}
+
+func TestDiscardDiagnostics_Discard(t *testing.T) {
+	stats := newRunStats()
+	stats.diagnostics = &discardLog{}
+	t.Run("ex", func(st *testing.T) {
+		runBody(st, func(*testing.T, string) { Discard() }, "irrelevant", stats, nil)
+	})
+	if got, want := stats.diagnostics.text(), `prop.Discard: 1 discarded, e.g. "irrelevant"`; !strings.Contains(got, want) {
+		t.Errorf("text() = %q, expected it to contain %q", got, want)
+	}
+}
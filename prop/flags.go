@@ -0,0 +1,101 @@
+package prop
+
+import (
+	"flag"
+	"sync"
+)
+
+// boundFlags is the flag set registered with BindFlags, read by Default.
+var (
+	boundMu    sync.Mutex
+	boundFlags *flag.FlagSet
+)
+
+// BindFlags registers the propx flags on fs, for programs that parse their
+// own flag set:
+//
+//	-propx.seed            random seed (0 = random)
+//	-propx.examples        number of examples
+//	-propx.maxshrink       maximum number of shrinking steps
+//	-propx.shrink.parallel parallel workers (0 = auto, 1 = sequential)
+//	-propx.shrink.strategy shrinking strategy (bfs or dfs)
+//
+// Flags already defined on fs (such as the ones registered on flag.CommandLine
+// by this package) are kept. Once fs is parsed, Default applies the flags that
+// were set explicitly on the command line, over the package flags; unset ones
+// keep their defaults.
+//
+// Example usage:
+//
+//	func main() {
+//	    fs := flag.NewFlagSet("props", flag.ExitOnError)
+//	    prop.BindFlags(fs)
+//	    fs.Parse(os.Args[1:])
+//	    ...
+//	}
+//
+// Under go test the command line also holds the -test.* flags, which only
+// flag.CommandLine defines, so a TestMain binds that one:
+//
+//	func TestMain(m *testing.M) {
+//	    prop.BindFlags(flag.CommandLine)
+//	    flag.Parse()
+//	    os.Exit(m.Run())
+//	}
+func BindFlags(fs *flag.FlagSet) {
+	define := func(name string, register func()) {
+		if fs.Lookup(name) == nil {
+			register()
+		}
+	}
+	define("propx.seed", func() { fs.Int64("propx.seed", 0, "Random seed for test case generation") })
+	define("propx.examples", func() { fs.Int("propx.examples", 100, "Number of test cases to generate") })
+	define("propx.maxshrink", func() { fs.Int("propx.maxshrink", 400, "Maximum number of shrinking steps") })
+	define("propx.shrink.parallel", func() {
+		fs.Int("propx.shrink.parallel", 0, "Number of parallel workers (0 = auto, 1 = sequential)")
+	})
+	define("propx.shrink.strategy", func() { fs.String("propx.shrink.strategy", "bfs", "Shrinking strategy (bfs or dfs)") })
+
+	boundMu.Lock()
+	boundFlags = fs
+	boundMu.Unlock()
+}
+
+// applyBoundFlags overrides the fields of cfg whose flags were set explicitly
+// on the parsed BindFlags flag set.
+func applyBoundFlags(cfg *Config) {
+	boundMu.Lock()
+	fs := boundFlags
+	boundMu.Unlock()
+	if fs == nil || !fs.Parsed() {
+		return
+	}
+	fs.Visit(func(f *flag.Flag) {
+		getter, ok := f.Value.(flag.Getter)
+		if !ok {
+			return
+		}
+		switch v := getter.Get().(type) {
+		case int64:
+			if f.Name == "propx.seed" {
+				cfg.Seed = v
+			}
+		case int:
+			switch f.Name {
+			case "propx.examples":
+				cfg.Examples = v
+			case "propx.maxshrink":
+				cfg.MaxShrink = v
+			case "propx.shrink.parallel":
+				cfg.Parallelism = resolveParallelism(v)
+			}
+		case string:
+			switch f.Name {
+			case "propx.shrink.strategy":
+				cfg.ShrinkStrat = v
+			case "propx.report":
+				cfg.ReportFormat = v
+			}
+		}
+	})
+}
@@ -0,0 +1,95 @@
+package prop
+
+import (
+	"flag"
+	"io"
+	"reflect"
+	"testing"
+)
+
+// bindTestFlags binds a fresh flag set and unbinds it when the test ends.
+func bindTestFlags(t *testing.T, fs *flag.FlagSet) {
+	t.Helper()
+	fs.SetOutput(io.Discard)
+	BindFlags(fs)
+	t.Cleanup(func() {
+		boundMu.Lock()
+		boundFlags = nil
+		boundMu.Unlock()
+	})
+}
+
+func TestBindFlags_DefaultReadsParsedFlags(t *testing.T) {
+	fs := flag.NewFlagSet("props", flag.ContinueOnError)
+	bindTestFlags(t, fs)
+	args := []string{
+		"-propx.seed=42", "-propx.examples=7", "-propx.maxshrink=9",
+		"-propx.shrink.parallel=3", "-propx.shrink.strategy=dfs",
+	}
+	if err := fs.Parse(args); err != nil {
+		t.Fatalf("Parse() = %v", err)
+	}
+
+	cfg := Default()
+	if cfg.Seed != 42 || cfg.Examples != 7 || cfg.MaxShrink != 9 || cfg.Parallelism != 3 || cfg.ShrinkStrat != "dfs" {
+		t.Errorf("Default() = %+v, expected the parsed flag values", cfg)
+	}
+}
+
+func TestBindFlags_OnlyExplicitFlagsApply(t *testing.T) {
+	base := Default()
+	fs := flag.NewFlagSet("props", flag.ContinueOnError)
+	bindTestFlags(t, fs)
+
+	// not parsed yet: nothing applies
+	if cfg := Default(); !reflect.DeepEqual(cfg, base) {
+		t.Errorf("Default() before Parse = %+v, expected %+v", cfg, base)
+	}
+
+	if err := fs.Parse([]string{"-propx.examples=11"}); err != nil {
+		t.Fatalf("Parse() = %v", err)
+	}
+	want := base
+	want.Examples = 11
+	if cfg := Default(); !reflect.DeepEqual(cfg, want) {
+		t.Errorf("Default() = %+v, expected only Examples changed: %+v", cfg, want)
+	}
+}
+
+func TestBindFlags_KeepsExistingFlags(t *testing.T) {
+	fs := flag.NewFlagSet("props", flag.ContinueOnError)
+	fs.Int64("propx.seed", 5, "already defined")
+	bindTestFlags(t, fs) // must not panic on the redefinition
+
+	if err := fs.Parse([]string{"-propx.seed=77"}); err != nil {
+		t.Fatalf("Parse() = %v", err)
+	}
+	if cfg := Default(); cfg.Seed != 77 {
+		t.Errorf("Default().Seed = %d, expected 77", cfg.Seed)
+	}
+}
+
+func TestBindFlags_InvalidValue(t *testing.T) {
+	fs := flag.NewFlagSet("props", flag.ContinueOnError)
+	bindTestFlags(t, fs)
+	if err := fs.Parse([]string{"-propx.examples=many"}); err == nil {
+		t.Error("Parse() accepted a non-numeric example count")
+	}
+}
+
+// TestBindFlags_TestMainUsage runs the documented TestMain usage on the real
+// command line of this test binary, which holds -test.* flags.
+func TestBindFlags_TestMainUsage(t *testing.T) {
+	base := Default()
+	BindFlags(flag.CommandLine)
+	t.Cleanup(func() {
+		boundMu.Lock()
+		boundFlags = nil
+		boundMu.Unlock()
+	})
+	flag.Parse() // exits the test binary on an undefined flag
+
+	if cfg := Default(); cfg.Examples != base.Examples || cfg.ShrinkStrat != base.ShrinkStrat {
+		t.Errorf("Default() = %+v after binding flag.CommandLine, expected %+v", cfg, base)
+	}
+}
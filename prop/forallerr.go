@@ -0,0 +1,96 @@
+package prop
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"arcsyn.io/propx/gen"
+)
+
+// errExample pairs a generated value with the error the property returned for
+// it, so the failure report of a shrunk value carries its own error.
+type errExample[T any] struct {
+	Value T
+	err   *error
+}
+
+// GoString renders the value followed by the property's error in failure reports.
+func (e errExample[T]) GoString() string { return e.renderValues(goString) }
+
+// renderValues renders the value with value, followed by the error.
+func (e errExample[T]) renderValues(value func(any) string) string {
+	if e.err == nil || *e.err == nil {
+		return value(e.Value)
+	}
+	return fmt.Sprintf("%s (error: %v)", value(e.Value), *e.err)
+}
+
+// MarshalJSON encodes the value and, once the property failed on it, its
+// error as {"value": ..., "error": "..."}.
+func (e errExample[T]) MarshalJSON() ([]byte, error) {
+	out := struct {
+		Value T      `json:"value"`
+		Error string `json:"error,omitempty"`
+	}{Value: e.Value}
+	if e.err != nil && *e.err != nil {
+		out.Error = (*e.err).Error()
+	}
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON decodes the value of an encoded example (as read back from a
+// corpus or golden file); the error is not restored.
+func (e *errExample[T]) UnmarshalJSON(data []byte) error {
+	var in struct {
+		Value T `json:"value"`
+	}
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+	*e = errExample[T]{Value: in.Value, err: new(error)}
+	return nil
+}
+
+// check runs the property on the value and records its error.
+func (e errExample[T]) check(property func(T) error) error {
+	err := property(e.Value)
+	if e.err != nil {
+		*e.err = err
+	}
+	return err
+}
+
+// ForAllErr is like ForAll for properties that return an error instead of
+// using *testing.T: a non-nil error marks the example as failing, triggers
+// shrinking, and its message is part of the failure report (next to the
+// shrunk counterexample).
+//
+// Example usage:
+//
+//	prop.ForAllErr(t, prop.Default(), gen.StringAlpha(gen.Size{}), func(s string) error {
+//	    _, err := Parse(Format(s))
+//	    return err
+//	})
+func ForAllErr[T any](t *testing.T, cfg Config, g gen.Generator[T], property func(T) error) {
+	withErr := gen.From(func(r *rand.Rand, sz gen.Size) (errExample[T], gen.Shrinker[errExample[T]]) {
+		v, s := g.Generate(r, sz)
+		return errExample[T]{Value: v, err: new(error)}, func(accept bool) (errExample[T], bool) {
+			if s == nil {
+				return errExample[T]{}, false
+			}
+			nv, ok := s(accept)
+			if !ok {
+				return errExample[T]{}, false
+			}
+			return errExample[T]{Value: nv, err: new(error)}, true
+		}
+	})
+	ForAll(t, cfg, withErr)(func(t *testing.T, e errExample[T]) {
+		t.Helper()
+		if err := e.check(property); err != nil {
+			t.Error(err)
+		}
+	})
+}
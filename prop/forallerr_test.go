@@ -0,0 +1,72 @@
+package prop
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"arcsyn.io/propx/gen"
+)
+
+func TestForAllErr_Passes(t *testing.T) {
+	config := Config{Seed: 12345, Examples: 20, MaxShrink: 10, ShrinkStrat: "bfs", Parallelism: 1}
+
+	calls := 0
+	ForAllErr(t, config, gen.IntRange(0, 10), func(x int) error {
+		calls++
+		if x < 0 || x > 10 {
+			return fmt.Errorf("value %d is outside [0, 10]", x)
+		}
+		return nil
+	})
+	if calls != 20 {
+		t.Errorf("property ran %d times, expected 20", calls)
+	}
+}
+
+func TestErrExample_CheckRecordsError(t *testing.T) {
+	property := func(x int) error {
+		if x > 3 {
+			return errors.New("too big")
+		}
+		return nil
+	}
+
+	ok := errExample[int]{Value: 1, err: new(error)}
+	if err := ok.check(property); err != nil || *ok.err != nil {
+		t.Errorf("check() = %v, recorded %v for a passing value", err, *ok.err)
+	}
+
+	bad := errExample[int]{Value: 7, err: new(error)}
+	if err := bad.check(property); err == nil || *bad.err != err {
+		t.Errorf("check() = %v, recorded %v, expected 'too big' for both", err, *bad.err)
+	}
+
+	// examples without an error slot still report the error
+	if err := (errExample[int]{Value: 9}).check(property); err == nil {
+		t.Error("check() = nil for a failing value without an error slot")
+	}
+}
+
+func TestErrExample_Report(t *testing.T) {
+	err := errors.New("parse: unexpected EOF")
+	e := errExample[string]{Value: "ab", err: &err}
+	if got := fmt.Sprintf("%#v", e); got != `"ab" (error: parse: unexpected EOF)` {
+		t.Errorf("errExample GoString = %q", got)
+	}
+	data, _ := json.Marshal(e)
+	if string(data) != `{"value":"ab","error":"parse: unexpected EOF"}` {
+		t.Errorf("errExample JSON = %s", data)
+	}
+
+	var back errExample[string]
+	if err := json.Unmarshal(data, &back); err != nil || back.Value != "ab" {
+		t.Errorf("errExample round trip = %#v, %v", back, err)
+	}
+
+	passing := errExample[string]{Value: "ok", err: new(error)}
+	if got := fmt.Sprintf("%#v", passing); got != `"ok"` {
+		t.Errorf("errExample GoString without error = %q", got)
+	}
+}
@@ -0,0 +1,64 @@
+package prop
+
+import (
+	"fmt"
+	"testing"
+
+	"arcsyn.io/propx/gen"
+)
+
+// ForEach runs the property over an explicit list of inputs, with the same
+// subtest naming and failure reporting as ForAll but no random generation.
+// Every input is run, regardless of cfg.Examples. Failing inputs are reported
+// as-is; use ForEachShrink to minimize them.
+//
+// Example usage:
+//
+//	prop.ForEach(t, prop.Default(), []string{"", "a", "ção"}, func(t *testing.T, s string) {
+//	    if Reverse(Reverse(s)) != s {
+//	        t.Errorf("double reverse changed %q", s)
+//	    }
+//	})
+func ForEach[T any](t *testing.T, cfg Config, inputs []T, property func(*testing.T, T)) {
+	ForEachShrink(t, cfg, inputs, nil, property)
+}
+
+// ForEachShrink is like ForEach, but failing inputs are shrunk with the
+// shrinker returned by shrinkerFor (up to cfg.MaxShrink steps) before being
+// reported. A nil shrinkerFor disables shrinking.
+//
+// Example usage:
+//
+//	halve := func(v int) gen.Shrinker[int] {
+//	    return func(bool) (int, bool) { v /= 2; return v, v != 0 }
+//	}
+//	prop.ForEachShrink(t, cfg, []int{10, 1000}, halve, property)
+func ForEachShrink[T any](t *testing.T, cfg Config, inputs []T, shrinkerFor func(T) gen.Shrinker[T], property func(*testing.T, T)) {
+	gen.SetShrinkStrategy(cfg.ShrinkStrat)
+	t.Logf("[propx] inputs=%d maxshrink=%d strategy=%s", len(inputs), cfg.MaxShrink, cfg.ShrinkStrat)
+
+	run := func(name string, v T) bool {
+		return t.Run(name, func(st *testing.T) { property(st, v) })
+	}
+
+	render := valueRenderer(cfg.Stringer)
+	for i, val := range inputs {
+		name := fmt.Sprintf("ex#%d", i+1)
+		if run(name, val) {
+			continue
+		}
+
+		min, steps := val, 0
+		scfg, path := recordShrinkPath(cfg, val)
+		if shrinkerFor != nil {
+			if shrink := shrinkerFor(val); shrink != nil {
+				min, steps = shrinkCounterexample(scfg, name, val, shrink, run)
+			}
+		}
+
+		full := fmt.Sprintf("^%s$/%s(/|$)", t.Name(), name)
+		t.Fatalf("[propx] property failed; input=%d/%d; shrunk_steps=%d\n"+
+			"counterexample (min): %s\nreplay: go test -run '%s'%s",
+			i+1, len(inputs), steps, render(min), full, shrinkPathText(path(), render))
+	}
+}
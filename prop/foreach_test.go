@@ -0,0 +1,58 @@
+package prop
+
+import (
+	"testing"
+
+	"arcsyn.io/propx/gen"
+)
+
+func TestForEach_RunsAllInputs(t *testing.T) {
+	config := Config{
+		Seed:        12345,
+		Examples:    2, // fewer than the inputs: every input must still run
+		MaxShrink:   10,
+		ShrinkStrat: "bfs",
+		Parallelism: 1,
+	}
+
+	inputs := []int{3, 1, 4, 1, 5}
+	var got []int
+	ForEach(t, config, inputs, func(t *testing.T, x int) {
+		got = append(got, x)
+		if x <= 0 {
+			t.Errorf("Expected positive value, got %d", x)
+		}
+	})
+
+	if len(got) != len(inputs) {
+		t.Fatalf("ForEach() ran %d inputs, expected %d", len(got), len(inputs))
+	}
+	for i := range inputs {
+		if got[i] != inputs[i] {
+			t.Errorf("ForEach() input #%d = %d, expected %d", i, got[i], inputs[i])
+		}
+	}
+}
+
+func TestForEach_EmptyInputs(t *testing.T) {
+	config := Config{Examples: 10, MaxShrink: 10, ShrinkStrat: "bfs"}
+
+	ForEach(t, config, []string{}, func(t *testing.T, s string) {
+		t.Errorf("property should not run, got %q", s)
+	})
+}
+
+func TestForEachShrink_PassingDoesNotShrink(t *testing.T) {
+	config := Config{Examples: 10, MaxShrink: 10, ShrinkStrat: "bfs"}
+
+	calls := 0
+	shrinkerFor := func(v int) gen.Shrinker[int] {
+		calls++
+		return nil
+	}
+	ForEachShrink(t, config, []int{1, 2, 3}, shrinkerFor, func(t *testing.T, x int) {})
+
+	if calls != 0 {
+		t.Errorf("shrinkerFor called %d times for passing inputs, expected 0", calls)
+	}
+}
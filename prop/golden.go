@@ -0,0 +1,79 @@
+package prop
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// goldenVersion is the current version of the golden file format.
+const goldenVersion = 1
+
+// goldenFile is the on-disk format of Config.GoldenFile: the examples of a
+// run in generation order, each as its JSON encoding.
+type goldenFile struct {
+	Version  int               `json:"version"`
+	Seed     int64             `json:"seed"`
+	Examples []json.RawMessage `json:"examples"`
+}
+
+// loadGolden reads the examples recorded at path. It returns (nil, false, nil)
+// when the file does not exist yet.
+func loadGolden[T any](path string, examples int) ([]T, bool, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- golden files are configured by the test itself
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("[propx] reading golden file: %w", err)
+	}
+	var f goldenFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, false, fmt.Errorf("[propx] golden file %s is corrupt: %w", path, err)
+	}
+	if f.Version != goldenVersion {
+		return nil, false, fmt.Errorf("[propx] golden file %s has version %d, expected %d; delete it to record again",
+			path, f.Version, goldenVersion)
+	}
+	if len(f.Examples) != examples {
+		return nil, false, fmt.Errorf("[propx] golden file %s holds %d examples but Config.Examples=%d; delete it to record again",
+			path, len(f.Examples), examples)
+	}
+	out := make([]T, len(f.Examples))
+	for i, raw := range f.Examples {
+		if err := json.Unmarshal(raw, &out[i]); err != nil {
+			return nil, false, fmt.Errorf("[propx] golden file %s: example %d: %w", path, i+1, err)
+		}
+	}
+	return out, true, nil
+}
+
+// saveGolden records the examples of a run at path.
+func saveGolden[T any](path string, seed int64, examples []T) error {
+	f := goldenFile{Version: goldenVersion, Seed: seed, Examples: make([]json.RawMessage, len(examples))}
+	for i, v := range examples {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Errorf("[propx] example %d cannot be recorded in the golden file: %w", i+1, err)
+		}
+		f.Examples[i] = data
+	}
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o600)
+}
+
+// noShrinker is the shrinker of replayed golden examples: their generator
+// state is not recorded, so they are reported as is.
+func noShrinker[T any](bool) (T, bool) {
+	var zero T
+	return zero, false
+}
@@ -0,0 +1,90 @@
+package prop
+
+import (
+	"math/rand"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"arcsyn.io/propx/gen"
+)
+
+func TestGoldenFile_RecordThenReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "golden", "ints.json")
+	calls := 0
+	g := gen.From(func(r *rand.Rand, _ gen.Size) ([]int, gen.Shrinker[[]int]) {
+		calls++
+		return []int{r.Intn(1000), r.Intn(1000)}, nil
+	})
+
+	var recorded, replayed [][]int
+	cfg := Config{Seed: 1, Examples: 5, Parallelism: 1, GoldenFile: path}
+	ForAll(t, cfg, g)(func(t *testing.T, v []int) { recorded = append(recorded, v) })
+	if calls != 5 {
+		t.Fatalf("first run generated %d examples, expected 5", calls)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("golden file not written: %v", err)
+	}
+
+	// a different seed must not matter: the recorded inputs are replayed
+	ForAll(t, cfg.WithSeed(99), g)(func(t *testing.T, v []int) { replayed = append(replayed, v) })
+	if calls != 5 {
+		t.Errorf("replay generated %d more examples, expected none", calls-5)
+	}
+	if !reflect.DeepEqual(recorded, replayed) {
+		t.Errorf("replayed inputs %v, expected recorded %v", replayed, recorded)
+	}
+}
+
+func TestLoadGolden(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	if _, ok, err := loadGolden[int](filepath.Join(dir, "missing.json"), 3); ok || err != nil {
+		t.Errorf("loadGolden(missing) = (%v, %v), expected not found without error", ok, err)
+	}
+
+	path := filepath.Join(dir, "ok.json")
+	if err := saveGolden(path, 7, []string{"a", "b"}); err != nil {
+		t.Fatalf("saveGolden() error: %v", err)
+	}
+	got, ok, err := loadGolden[string](path, 2)
+	if err != nil || !ok || !reflect.DeepEqual(got, []string{"a", "b"}) {
+		t.Errorf("loadGolden() = (%v, %v, %v), expected [a b]", got, ok, err)
+	}
+
+	tests := []struct {
+		name, path string
+		examples   int
+		want       string
+	}{
+		{"newer version", write("v2.json", `{"version":2,"examples":[1]}`), 1, "version 2"},
+		{"wrong count", path, 3, "holds 2 examples but Config.Examples=3"},
+		{"wrong type", path, 2, "example 1"},
+		{"corrupt", write("bad.json", `{`), 1, "corrupt"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := loadGolden[int](tt.path, tt.examples)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("loadGolden() error = %v, expected it to mention %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestSaveGolden_UnencodableValue(t *testing.T) {
+	err := saveGolden(filepath.Join(t.TempDir(), "f.json"), 1, []func(){func() {}})
+	if err == nil || !strings.Contains(err.Error(), "example 1") {
+		t.Errorf("saveGolden(func) error = %v, expected an encoding error", err)
+	}
+}
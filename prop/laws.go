@@ -0,0 +1,110 @@
+package prop
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"arcsyn.io/propx/gen"
+)
+
+// Idempotent checks that applying f twice gives the same result as applying
+// it once, f(f(x)) == f(x), for every value of g: the law of normalization and
+// canonicalization functions. Results are compared with go-cmp; a failing
+// input is shrunk and reported with the diff between f(x) and f(f(x)).
+//
+// Example usage:
+//
+//	prop.Idempotent(t, prop.Default(), gen.String(" ab\t", gen.Size{}), strings.TrimSpace)
+func Idempotent[T any](t *testing.T, cfg Config, g gen.Generator[T], f func(T) T) {
+	t.Helper()
+	ForAll(t, cfg, g)(func(t *testing.T, x T) {
+		t.Helper()
+		once := f(x)
+		if diff := cmp.Diff(once, f(once)); diff != "" {
+			t.Errorf("f(f(x)) != f(x) (-f(x) +f(f(x))):\n%s", diff)
+		}
+	})
+}
+
+// Involutive checks that f is its own inverse, f(f(x)) == x, for every value
+// of g (negation, reversal, toggles). Results are compared with go-cmp; a
+// failing input is shrunk and reported with the diff between x and f(f(x)).
+//
+// Example usage:
+//
+//	prop.Involutive(t, prop.Default(), gen.SliceOf(gen.Int(gen.Size{}), gen.Size{}), reverse)
+func Involutive[T any](t *testing.T, cfg Config, g gen.Generator[T], f func(T) T) {
+	t.Helper()
+	ForAll(t, cfg, g)(func(t *testing.T, x T) {
+		t.Helper()
+		if diff := cmp.Diff(x, f(f(x))); diff != "" {
+			t.Errorf("f(f(x)) != x (-x +f(f(x))):\n%s", diff)
+		}
+	})
+}
+
+// RoundTrip checks that decoding an encoded value gives the value back,
+// decode(encode(x)) == x, for every value of g: the law of serializers (JSON,
+// gob, custom formats). A decode error fails the example; otherwise values
+// are compared with go-cmp. A failing input is shrunk and reported with the
+// encoded form and the error or the diff between x and the decoded value.
+//
+// Example usage:
+//
+//	prop.RoundTrip(t, prop.Default(), gen.StringAlpha(gen.Size{}),
+//	    func(s string) []byte { b, _ := json.Marshal(s); return b },
+//	    func(b []byte) (string, error) { var s string; err := json.Unmarshal(b, &s); return s, err })
+func RoundTrip[A, B any](t *testing.T, cfg Config, g gen.Generator[A], encode func(A) B, decode func(B) (A, error)) {
+	t.Helper()
+	ForAll(t, cfg, g)(func(t *testing.T, x A) {
+		t.Helper()
+		enc := encode(x)
+		back, err := decode(enc)
+		if err != nil {
+			t.Errorf("decode(encode(x)) failed: %v\nencoded: %#v", err, enc)
+			return
+		}
+		if diff := cmp.Diff(x, back); diff != "" {
+			t.Errorf("decode(encode(x)) != x (-x +decoded):\n%s\nencoded: %#v", diff, enc)
+		}
+	})
+}
+
+// Associative checks that op(op(a, b), c) == op(a, op(b, c)) for every three
+// operands drawn from g: the law of semigroups and monoids (concatenation,
+// merging, max, set union). Results are compared with go-cmp; failing
+// operands are shrunk and reported with the diff between both groupings.
+//
+// Example usage:
+//
+//	prop.Associative(t, prop.Default(), gen.StringAlpha(gen.Size{}), func(a, b string) string { return a + b })
+func Associative[T any](t *testing.T, cfg Config, g gen.Generator[T], op func(T, T) T) {
+	t.Helper()
+	ForAll(t, cfg, gen.PairOf(g, gen.PairOf(g, g)))(func(t *testing.T, x gen.Pair[T, gen.Pair[T, T]]) {
+		t.Helper()
+		a, b, c := x.First, x.Second.First, x.Second.Second
+		if diff := cmp.Diff(op(op(a, b), c), op(a, op(b, c))); diff != "" {
+			t.Errorf("op(op(a, b), c) != op(a, op(b, c)) (-op(op(a, b), c) +op(a, op(b, c))):\n%s\na: %#v\nb: %#v\nc: %#v", diff, a, b, c)
+		}
+	})
+}
+
+// Commutative checks that op(a, b) == op(b, a) for every two operands drawn
+// from g (addition, set union, merges that ignore order). Results are
+// compared with go-cmp; failing operands are shrunk and reported with the
+// diff between both orders.
+//
+// Example usage:
+//
+//	prop.Commutative(t, prop.Default(), gen.Int(gen.Size{}), func(a, b int) int { return a + b })
+func Commutative[T any](t *testing.T, cfg Config, g gen.Generator[T], op func(T, T) T) {
+	t.Helper()
+	ForAll(t, cfg, gen.PairOf(g, g))(func(t *testing.T, x gen.Pair[T, T]) {
+		t.Helper()
+		a, b := x.First, x.Second
+		if diff := cmp.Diff(op(a, b), op(b, a)); diff != "" {
+			t.Errorf("op(a, b) != op(b, a) (-op(a, b) +op(b, a)):\n%s\na: %#v\nb: %#v", diff, a, b)
+		}
+	})
+}
@@ -0,0 +1,115 @@
+package prop
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"testing"
+
+	"arcsyn.io/propx/gen"
+)
+
+func TestIdempotent_Passes(t *testing.T) {
+	config := Config{Seed: 12345, Examples: 50, MaxShrink: 10, ShrinkStrat: "bfs", Parallelism: 1}
+
+	calls := 0
+	Idempotent(t, config, gen.String(" ab\t", gen.Size{Max: 12}), func(s string) string {
+		calls++
+		return strings.TrimSpace(s)
+	})
+	// f runs twice per example: f(x) and f(f(x))
+	if calls != 100 {
+		t.Errorf("f ran %d times, expected 100", calls)
+	}
+}
+
+func TestInvolutive_Passes(t *testing.T) {
+	config := Config{Seed: 12345, Examples: 50, MaxShrink: 10, ShrinkStrat: "bfs", Parallelism: 1}
+
+	// go-cmp tells nil from empty slices: reversing keeps nil as nil
+	reverse := func(xs []int) []int {
+		out := append([]int(nil), xs...)
+		for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+			out[i], out[j] = out[j], out[i]
+		}
+		return out
+	}
+	Involutive(t, config, gen.SliceOf(gen.Int(gen.Size{}), gen.Size{Max: 10}), reverse)
+	Involutive(t, config, gen.Int(gen.Size{}), func(x int) int { return -x })
+}
+
+func TestRoundTrip_Passes(t *testing.T) {
+	config := Config{Seed: 12345, Examples: 50, MaxShrink: 10, ShrinkStrat: "bfs", Parallelism: 1}
+
+	decodes := 0
+	RoundTrip(t, config, gen.Int(gen.Size{}), strconv.Itoa, func(s string) (int, error) {
+		decodes++
+		return strconv.Atoi(s)
+	})
+	if decodes != 50 {
+		t.Errorf("decode ran %d times, expected 50", decodes)
+	}
+
+	type point struct{ X, Y int }
+	RoundTrip(t, config, gen.Map(gen.PairOf(gen.Int(gen.Size{}), gen.Int(gen.Size{})), func(p gen.Pair[int, int]) point {
+		return point{p.First, p.Second}
+	}), func(p point) []byte {
+		b, _ := json.Marshal(p)
+		return b
+	}, func(b []byte) (point, error) {
+		var p point
+		err := json.Unmarshal(b, &p)
+		return p, err
+	})
+}
+
+func TestAssociative_Passes(t *testing.T) {
+	config := Config{Seed: 12345, Examples: 50, MaxShrink: 10, ShrinkStrat: "bfs", Parallelism: 1}
+
+	calls := 0
+	Associative(t, config, gen.StringAlpha(gen.Size{Max: 8}), func(a, b string) string {
+		calls++
+		return a + b
+	})
+	// op runs four times per example, twice for each grouping
+	if calls != 200 {
+		t.Errorf("op ran %d times, expected 200", calls)
+	}
+
+	Associative(t, config, gen.SliceOf(gen.Int(gen.Size{}), gen.Size{Max: 5}), func(a, b []int) []int {
+		return append(append([]int(nil), a...), b...)
+	})
+	Associative(t, config, gen.Int(gen.Size{}), func(a, b int) int { return max(a, b) })
+}
+
+func TestCommutative_Passes(t *testing.T) {
+	config := Config{Seed: 12345, Examples: 50, MaxShrink: 10, ShrinkStrat: "bfs", Parallelism: 1}
+
+	calls := 0
+	Commutative(t, config, gen.Int(gen.Size{}), func(a, b int) int {
+		calls++
+		return a + b
+	})
+	if calls != 100 {
+		t.Errorf("op ran %d times, expected 100", calls)
+	}
+
+	union := func(a, b map[string]bool) map[string]bool {
+		out := map[string]bool{}
+		for k := range a {
+			out[k] = true
+		}
+		for k := range b {
+			out[k] = true
+		}
+		return out
+	}
+	sets := gen.Map(gen.SliceOf(gen.StringAlpha(gen.Size{Max: 3}), gen.Size{Max: 4}), func(xs []string) map[string]bool {
+		m := map[string]bool{}
+		for _, x := range xs {
+			m[x] = true
+		}
+		return m
+	})
+	Commutative(t, config, sets, union)
+}
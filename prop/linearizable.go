@@ -0,0 +1,191 @@
+package prop
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// DefaultMaxInterleavings bounds the linearizability search when
+// Config.MaxInterleavings is 0.
+const DefaultMaxInterleavings = 10_000
+
+// ParallelHistory records command branches run concurrently against a
+// system: Branches[i] holds the commands goroutine i ran, in order,
+// Results[i][j] the result it observed for Branches[i][j], and Calls[i][j]
+// when that command was invoked and when it returned.
+type ParallelHistory[C, R any] struct {
+	Branches [][]C
+	Results  [][]R
+	Calls    [][]CallSpan
+}
+
+// CallSpan is the real-time extent of one command of a ParallelHistory, on a
+// clock shared by all branches: any increasing count works, such as a shared
+// atomic counter ticked before and after each call, or time.Now().UnixNano().
+// A command whose Returned is before another's Invoked completed before that
+// one started, so it must come first in any linearization.
+type CallSpan struct {
+	Invoked, Returned int64
+}
+
+// HistoryStep identifies one command of a ParallelHistory.
+type HistoryStep[C any] struct {
+	// Branch and Index locate the command: Branches[Branch][Index].
+	Branch, Index int
+
+	// Command is the command itself.
+	Command C
+}
+
+// Linearization is the outcome of CheckLinearizable.
+type Linearization[C any] struct {
+	// Linearizable reports whether a serial order reproducing every observed
+	// result was found.
+	Linearizable bool
+
+	// Witness is that serial order (the sequential witness), set when
+	// Linearizable.
+	Witness []HistoryStep[C]
+
+	// Interleavings is the number of interleavings explored, each either
+	// complete or abandoned at the first result the model disagrees with.
+	Interleavings int
+
+	// Truncated reports that the search stopped at Config.MaxInterleavings
+	// without finding a witness nor ruling out every interleaving.
+	Truncated bool
+}
+
+// String renders the outcome for failure reports, listing the witness one
+// command per line as "b<branch>#<index>: <command>".
+func (l Linearization[C]) String() string {
+	switch {
+	case l.Linearizable:
+		var b strings.Builder
+		fmt.Fprintf(&b, "linearizable after %d interleavings; witness:", l.Interleavings)
+		for _, s := range l.Witness {
+			fmt.Fprintf(&b, "\n  b%d#%d: %#v", s.Branch, s.Index, s.Command)
+		}
+		return b.String()
+	case l.Truncated:
+		return fmt.Sprintf("no witness found within MaxInterleavings=%d", l.Interleavings)
+	default:
+		return fmt.Sprintf("not linearizable: none of %d interleavings respecting the real-time order matches the observed results", l.Interleavings)
+	}
+}
+
+// CheckLinearizable searches for a serial order of the history's commands
+// that respects their real-time order (a command that returned before another
+// was invoked comes first, per Calls, which also keeps the order within each
+// branch) and in which the sequential model (starting from init) produces
+// exactly the observed results (compared with reflect.DeepEqual). The number
+// of interleavings grows factorially with the history, so at most
+// cfg.MaxInterleavings are explored (DefaultMaxInterleavings when 0);
+// interleavings are abandoned at the first mismatching result.
+// Panics if Results or Calls does not have the shape of Branches.
+func CheckLinearizable[S, C, R any](cfg Config, model func(S, C) (S, R), init S, h ParallelHistory[C, R]) Linearization[C] {
+	if len(h.Results) != len(h.Branches) {
+		panic(fmt.Sprintf("prop.CheckLinearizable: %d result branches for %d command branches", len(h.Results), len(h.Branches)))
+	}
+	if len(h.Calls) != len(h.Branches) {
+		panic(fmt.Sprintf("prop.CheckLinearizable: %d call branches for %d command branches", len(h.Calls), len(h.Branches)))
+	}
+	total := 0
+	for i, b := range h.Branches {
+		if len(h.Results[i]) != len(b) {
+			panic(fmt.Sprintf("prop.CheckLinearizable: branch %d has %d results for %d commands", i, len(h.Results[i]), len(b)))
+		}
+		if len(h.Calls[i]) != len(b) {
+			panic(fmt.Sprintf("prop.CheckLinearizable: branch %d has %d calls for %d commands", i, len(h.Calls[i]), len(b)))
+		}
+		total += len(b)
+	}
+	limit := cfg.MaxInterleavings
+	if limit == 0 {
+		limit = DefaultMaxInterleavings
+	}
+
+	var out Linearization[C]
+	next := make([]int, len(h.Branches)) // next command of each branch
+	path := make([]HistoryStep[C], 0, total)
+
+	// ready reports whether command j of branch b may come next: no pending
+	// command of another branch returned before it was invoked (the later
+	// commands of a branch return later still)
+	ready := func(b, j int) bool {
+		for o, k := range next {
+			if o != b && k < len(h.Branches[o]) && h.Calls[o][k].Returned < h.Calls[b][j].Invoked {
+				return false
+			}
+		}
+		return true
+	}
+
+	// search extends path from state, depth first; it reports whether a
+	// witness was found
+	var search func(state S) bool
+	search = func(state S) bool {
+		if len(path) == total {
+			out.Interleavings++
+			return true
+		}
+		for b, cmds := range h.Branches {
+			j := next[b]
+			if j == len(cmds) || !ready(b, j) {
+				continue
+			}
+			if out.Interleavings >= limit {
+				out.Truncated = true
+				return false
+			}
+			ns, res := model(state, cmds[j])
+			if !reflect.DeepEqual(res, h.Results[b][j]) {
+				out.Interleavings++
+				continue
+			}
+			next[b]++
+			path = append(path, HistoryStep[C]{Branch: b, Index: j, Command: cmds[j]})
+			if search(ns) {
+				return true
+			}
+			path = path[:len(path)-1]
+			next[b]--
+			if out.Truncated {
+				return false
+			}
+		}
+		return false
+	}
+
+	if search(init) {
+		out.Linearizable = true
+		out.Witness = append([]HistoryStep[C](nil), path...)
+		out.Truncated = false
+	}
+	return out
+}
+
+// AssertLinearizable checks the history with CheckLinearizable. It logs the
+// sequential witness when one is found, and fails t otherwise, noting
+// whether the search was cut short by cfg.MaxInterleavings.
+//
+// Example usage:
+//
+//	h := runConcurrently(counter, branches) // user code recording results and calls
+//	prop.AssertLinearizable(t, cfg, func(n int, c Op) (int, int) {
+//	    if c.Inc {
+//	        n++
+//	    }
+//	    return n, n
+//	}, 0, h)
+func AssertLinearizable[S, C, R any](t *testing.T, cfg Config, model func(S, C) (S, R), init S, h ParallelHistory[C, R]) {
+	t.Helper()
+	l := CheckLinearizable(cfg, model, init, h)
+	if l.Linearizable {
+		t.Log("[propx] " + l.String())
+		return
+	}
+	t.Errorf("[propx] history %s\nbranches: %#v\nresults: %#v\ncalls: %v", l, h.Branches, h.Results, h.Calls)
+}
@@ -0,0 +1,160 @@
+package prop
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"arcsyn.io/propx/gen"
+)
+
+// counterModel is a sequential counter whose operations add their argument
+// and return the new total.
+func counterModel(n, add int) (int, int) {
+	return n + add, n + add
+}
+
+// overlapping returns calls for branches that all overlap in time, so that
+// only the order within each branch constrains the search.
+func overlapping[C any](branches [][]C) [][]CallSpan {
+	calls := make([][]CallSpan, len(branches))
+	for i, b := range branches {
+		calls[i] = make([]CallSpan, len(b))
+		for j := range b {
+			calls[i][j] = CallSpan{Invoked: 0, Returned: 1}
+		}
+	}
+	return calls
+}
+
+func TestCheckLinearizable_Witness(t *testing.T) {
+	// b0 saw 1 then 4; b1 saw 3: the only serial order is b0#0, b1#0, b0#1
+	h := ParallelHistory[int, int]{
+		Branches: [][]int{{1, 1}, {2}},
+		Results:  [][]int{{1, 4}, {3}},
+	}
+	h.Calls = overlapping(h.Branches)
+	l := CheckLinearizable(Config{}, counterModel, 0, h)
+	if !l.Linearizable || l.Truncated {
+		t.Fatalf("CheckLinearizable() = %+v, expected a witness", l)
+	}
+	want := []HistoryStep[int]{{0, 0, 1}, {1, 0, 2}, {0, 1, 1}}
+	if len(l.Witness) != len(want) {
+		t.Fatalf("witness = %v, expected %v", l.Witness, want)
+	}
+	for i := range want {
+		if l.Witness[i] != want[i] {
+			t.Errorf("witness[%d] = %v, expected %v", i, l.Witness[i], want[i])
+		}
+	}
+	if s := l.String(); !strings.Contains(s, "witness:\n  b0#0: 1\n  b1#0: 2\n  b0#1: 1") {
+		t.Errorf("String() = %q, expected the witness listed in order", s)
+	}
+}
+
+func TestCheckLinearizable_NotLinearizable(t *testing.T) {
+	// both branches observed the counter at 1: a lost update
+	h := ParallelHistory[int, int]{
+		Branches: [][]int{{1}, {1}},
+		Results:  [][]int{{1}, {1}},
+	}
+	h.Calls = overlapping(h.Branches)
+	l := CheckLinearizable(Config{}, counterModel, 0, h)
+	if l.Linearizable || l.Truncated || l.Witness != nil {
+		t.Errorf("CheckLinearizable() = %+v, expected not linearizable", l)
+	}
+	if l.Interleavings != 2 {
+		t.Errorf("explored %d interleavings, expected 2", l.Interleavings)
+	}
+}
+
+func TestCheckLinearizable_MaxInterleavings(t *testing.T) {
+	// six branches of adds with one impossible result at the very end: every
+	// interleaving must be explored before giving up
+	h := ParallelHistory[int, int]{}
+	for b := 0; b < 6; b++ {
+		h.Branches = append(h.Branches, []int{0, 0})
+		h.Results = append(h.Results, []int{0, 0})
+	}
+	h.Results[5][1] = 99
+	h.Calls = overlapping(h.Branches)
+
+	l := CheckLinearizable(Config{MaxInterleavings: 50}, counterModel, 0, h)
+	if l.Linearizable || !l.Truncated || l.Interleavings != 50 {
+		t.Errorf("CheckLinearizable() = %+v, expected truncated after 50", l)
+	}
+	if s := l.String(); !strings.Contains(s, "MaxInterleavings=50") {
+		t.Errorf("String() = %q, expected to mention the bound", s)
+	}
+}
+
+func TestCheckLinearizable_ShapeMismatchPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("CheckLinearizable() did not panic on a missing result")
+		}
+	}()
+	CheckLinearizable(Config{}, counterModel, 0, ParallelHistory[int, int]{
+		Branches: [][]int{{1, 2}},
+		Results:  [][]int{{1}},
+		Calls:    [][]CallSpan{{{0, 1}, {2, 3}}},
+	})
+}
+
+func TestCheckLinearizable_MissingCallsPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("CheckLinearizable() did not panic on missing calls")
+		}
+	}()
+	CheckLinearizable(Config{}, counterModel, 0, ParallelHistory[int, int]{
+		Branches: [][]int{{1}},
+		Results:  [][]int{{1}},
+	})
+}
+
+func TestCheckLinearizable_RealTimeOrder(t *testing.T) {
+	// b0 added 1 and returned before b1 was invoked, yet b1 read 0: placing
+	// b1 first reproduces the results (sequentially consistent), but it
+	// started after b0 completed, so the history is not linearizable
+	h := ParallelHistory[int, int]{
+		Branches: [][]int{{1}, {0}},
+		Results:  [][]int{{1}, {0}},
+		Calls:    [][]CallSpan{{{Invoked: 1, Returned: 2}}, {{Invoked: 3, Returned: 4}}},
+	}
+	if l := CheckLinearizable(Config{}, counterModel, 0, h); l.Linearizable {
+		t.Errorf("CheckLinearizable() = %v, expected the real-time order to rule out the witness", l)
+	}
+
+	// overlapping calls may be ordered either way
+	h.Calls = [][]CallSpan{{{Invoked: 1, Returned: 3}}, {{Invoked: 2, Returned: 4}}}
+	l := CheckLinearizable(Config{}, counterModel, 0, h)
+	if !l.Linearizable || len(l.Witness) != 2 || l.Witness[0].Branch != 1 {
+		t.Errorf("CheckLinearizable() = %v, expected the witness b1#0, b0#0", l)
+	}
+}
+
+func TestAssertLinearizable_AtomicCounter(t *testing.T) {
+	cfg := Config{Seed: 7, Examples: 30, MaxShrink: 10, Parallelism: 1}
+	branches := gen.SliceOf(gen.SliceOf(gen.IntRange(1, 5), gen.Size{Max: 4}), gen.Size{Min: 2, Max: 3})
+
+	ForAll(t, cfg, branches)(func(t *testing.T, bs [][]int) {
+		var counter, clock atomic.Int64
+		h := ParallelHistory[int, int]{Branches: bs, Results: make([][]int, len(bs)), Calls: make([][]CallSpan, len(bs))}
+		var wg sync.WaitGroup
+		for i, b := range bs {
+			wg.Add(1)
+			go func(i int, b []int) {
+				defer wg.Done()
+				for _, add := range b {
+					invoked := clock.Add(1)
+					h.Results[i] = append(h.Results[i], int(counter.Add(int64(add))))
+					h.Calls[i] = append(h.Calls[i], CallSpan{Invoked: invoked, Returned: clock.Add(1)})
+				}
+			}(i, b)
+		}
+		wg.Wait()
+		AssertLinearizable(t, cfg, counterModel, 0, h)
+	})
+}
@@ -0,0 +1,62 @@
+package prop
+
+import (
+	"fmt"
+	"testing"
+
+	"arcsyn.io/propx/gen"
+)
+
+// NamedGenerator pairs a generator with the name ForAllMatrix gives its
+// subtest.
+type NamedGenerator[T any] struct {
+	Name string
+	Gen  gen.Generator[T]
+}
+
+// ForAllMatrix checks the property against each generator in turn, running
+// ForAll with cfg in a subtest named after the generator, to cover several
+// input distributions in one call. Each run has its own seed line, shrinks
+// independently and reports the generator in its subtest path and replay
+// command. Names must be non-empty and unique.
+//
+// Example usage:
+//
+//	prop.ForAllMatrix(t, prop.Default(), []prop.NamedGenerator[int]{
+//	    {Name: "small", Gen: gen.IntRange(-10, 10)},
+//	    {Name: "large", Gen: gen.IntRange(1<<40, 1<<50)},
+//	    {Name: "edge", Gen: gen.OneOf(gen.Const(math.MinInt), gen.Const(0), gen.Const(math.MaxInt))},
+//	}, func(t *testing.T, n int) {
+//	    if Abs(n) < 0 && n != math.MinInt {
+//	        t.Errorf("Abs(%d) < 0", n)
+//	    }
+//	})
+func ForAllMatrix[T any](t *testing.T, cfg Config, gens []NamedGenerator[T], property func(*testing.T, T)) {
+	t.Helper()
+	if err := checkMatrixNames(gens); err != nil {
+		t.Fatalf("[propx] %v", err)
+	}
+	for _, ng := range gens {
+		t.Run(ng.Name, func(st *testing.T) {
+			ForAll(st, cfg, ng.Gen)(property)
+		})
+	}
+}
+
+// checkMatrixNames checks that every generator has a distinct, non-empty
+// name, so each subtest can be selected with -run.
+func checkMatrixNames[T any](gens []NamedGenerator[T]) error {
+	seen := make(map[string]bool, len(gens))
+	for i, ng := range gens {
+		switch {
+		case ng.Name == "":
+			return fmt.Errorf("ForAllMatrix: generator #%d has no name", i)
+		case seen[ng.Name]:
+			return fmt.Errorf("ForAllMatrix: duplicate generator name %q", ng.Name)
+		case ng.Gen == nil:
+			return fmt.Errorf("ForAllMatrix: generator %q is nil", ng.Name)
+		}
+		seen[ng.Name] = true
+	}
+	return nil
+}
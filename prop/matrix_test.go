@@ -0,0 +1,63 @@
+package prop
+
+import (
+	"strings"
+	"testing"
+
+	"arcsyn.io/propx/gen"
+)
+
+func TestForAllMatrix_RunsEachGenerator(t *testing.T) {
+	config := Config{Seed: 7, Examples: 20, MaxShrink: 10, ShrinkStrat: "bfs", Parallelism: 1}
+
+	counts := map[string]int{}
+	ForAllMatrix(t, config, []NamedGenerator[int]{
+		{Name: "small", Gen: gen.IntRange(0, 9)},
+		{Name: "large", Gen: gen.IntRange(1000, 9999)},
+		{Name: "edge", Gen: gen.Const(-1)},
+	}, func(t *testing.T, n int) {
+		name := t.Name()[strings.Index(t.Name(), "/")+1:]
+		name = name[:strings.Index(name, "/")]
+		counts[name]++
+		switch name {
+		case "small":
+			if n < 0 || n > 9 {
+				t.Errorf("small generated %d", n)
+			}
+		case "large":
+			if n < 1000 {
+				t.Errorf("large generated %d", n)
+			}
+		case "edge":
+			if n != -1 {
+				t.Errorf("edge generated %d", n)
+			}
+		}
+	})
+
+	for _, name := range []string{"small", "large", "edge"} {
+		if counts[name] != config.Examples {
+			t.Errorf("generator %q ran %d examples, expected %d", name, counts[name], config.Examples)
+		}
+	}
+}
+
+func TestCheckMatrixNames(t *testing.T) {
+	g := gen.IntRange(0, 1)
+	tests := []struct {
+		gens []NamedGenerator[int]
+		err  string
+	}{
+		{nil, ""},
+		{[]NamedGenerator[int]{{"a", g}, {"b", g}}, ""},
+		{[]NamedGenerator[int]{{"a", g}, {"", g}}, "generator #1 has no name"},
+		{[]NamedGenerator[int]{{"a", g}, {"a", g}}, `duplicate generator name "a"`},
+		{[]NamedGenerator[int]{{"a", nil}}, `generator "a" is nil`},
+	}
+	for _, tt := range tests {
+		err := checkMatrixNames(tt.gens)
+		if tt.err == "" && err != nil || tt.err != "" && (err == nil || !strings.Contains(err.Error(), tt.err)) {
+			t.Errorf("checkMatrixNames(%v) = %v, expected %q", tt.gens, err, tt.err)
+		}
+	}
+}
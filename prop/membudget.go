@@ -0,0 +1,53 @@
+package prop
+
+import (
+	"fmt"
+	"runtime/metrics"
+)
+
+// heapAllocsMetric is the cumulative number of bytes allocated on the heap,
+// the runtime/metrics counterpart of runtime.MemStats.TotalAlloc; reading it
+// does not stop the world.
+const heapAllocsMetric = "/gc/heap/allocs:bytes"
+
+// memBudget tracks the bytes allocated since a run started against
+// Config.MemoryBudgetBytes. A nil *memBudget (no budget) never fails.
+type memBudget struct {
+	limit int64
+	start uint64
+}
+
+// newMemBudget starts tracking the allocations of a run, or returns nil when
+// limit is 0.
+func newMemBudget(limit int64) *memBudget {
+	if limit == 0 {
+		return nil
+	}
+	return &memBudget{limit: limit, start: heapAllocs()}
+}
+
+// check returns an error carrying the seed once more than the budget has been
+// allocated, before the example at index is generated.
+func (b *memBudget) check(seed int64, index int) error {
+	if b == nil {
+		return nil
+	}
+	used := int64(heapAllocs() - b.start)
+	if used <= b.limit {
+		return nil
+	}
+	return fmt.Errorf("[propx] run exceeded Config.MemoryBudgetBytes=%d (%d bytes allocated since it started) before example %d; seed=%d\n"+
+		"lower the collection sizes (see MaxGeneratedSize) or raise MemoryBudgetBytes\nreplay: -propx.seed=%d",
+		b.limit, used, index+1, seed, seed)
+}
+
+// heapAllocs returns the bytes allocated on the heap by the process so far.
+func heapAllocs() uint64 {
+	s := []metrics.Sample{{Name: heapAllocsMetric}}
+	metrics.Read(s)
+	if s[0].Value.Kind() != metrics.KindUint64 {
+		// unsupported by this runtime: the budget never trips
+		return 0
+	}
+	return s[0].Value.Uint64()
+}
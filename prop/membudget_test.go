@@ -0,0 +1,57 @@
+package prop
+
+import (
+	"strings"
+	"testing"
+
+	"arcsyn.io/propx/gen"
+)
+
+var memSink [][]byte
+
+func TestMemBudget(t *testing.T) {
+	if b := newMemBudget(0); b != nil || b.check(42, 0) != nil {
+		t.Fatal("a zero MemoryBudgetBytes is not unlimited")
+	}
+	if heapAllocs() == 0 {
+		t.Skip("heap allocation metric unsupported")
+	}
+
+	b := newMemBudget(1 << 20)
+	if err := b.check(42, 0); err != nil {
+		t.Fatalf("check() before allocating = %v", err)
+	}
+	memSink = append(memSink, make([]byte, 2<<20))
+	memSink = nil
+	err := b.check(42, 6)
+	if err == nil {
+		t.Fatal("check() after allocating past the budget returned nil")
+	}
+	for _, want := range []string{"MemoryBudgetBytes=1048576", "before example 7", "seed=42", "-propx.seed=42"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("check() error %q lacks %q", err, want)
+		}
+	}
+}
+
+func TestExampleFeed_MemoryBudget(t *testing.T) {
+	if heapAllocs() == 0 {
+		t.Skip("heap allocation metric unsupported")
+	}
+	// every example allocates 1 MiB: a 4 MiB budget stops the run early
+	g := gen.Map(gen.IntRange(0, 9), func(n int) int {
+		memSink = append(memSink, make([]byte, 1<<20))
+		return n
+	})
+	defer func() { memSink = nil }()
+	next := exampleFeed(Config{Examples: 100, MemoryBudgetBytes: 4 << 20}, g, 1, nil)
+	for pos := 0; pos < 100; pos++ {
+		if _, _, _, err := next(pos); err != nil {
+			if pos < 4 {
+				t.Errorf("budget exceeded after %d examples of 1 MiB, expected at least 4", pos)
+			}
+			return
+		}
+	}
+	t.Error("100 MiB allocated without exceeding a 4 MiB budget")
+}
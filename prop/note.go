@@ -0,0 +1,99 @@
+package prop
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// noteLogs maps the *testing.T of each running example to its *noteLog.
+var noteLogs sync.Map
+
+// noteLog holds the notes of one example run.
+type noteLog struct {
+	mu    sync.Mutex
+	lines []string
+}
+
+// Note attaches a note to the current example from inside a property, like
+// t.Logf but printed only if the example ends up as the reported
+// counterexample: the notes of the minimal failing input follow it in the
+// failure report (under "notes" in ReportJSON), and those of passing examples
+// and rejected shrink candidates are dropped. Use it for context that only
+// matters when reading a failure, such as intermediate results.
+//
+// Note works in ForAll and everything built on it (ForAllN, ForAllErr,
+// ForAllRand and the law checks), with the *testing.T passed to the property;
+// it is safe to call from goroutines the property starts, as long as they end
+// before it returns. Elsewhere (another *testing.T, a nested t.Run) it falls
+// back to t.Logf.
+//
+// Example usage:
+//
+//	prop.ForAll(t, cfg, gen.SliceOf(gen.Int(gen.Size{}), gen.Size{}))(func(t *testing.T, xs []int) {
+//	    sorted := Sort(xs)
+//	    prop.Note(t, "sorted: %v", sorted)
+//	    if !slices.IsSorted(sorted) {
+//	        t.Errorf("Sort(%v) is not sorted", xs)
+//	    }
+//	})
+func Note(t *testing.T, format string, args ...any) {
+	t.Helper()
+	v, ok := noteLogs.Load(t)
+	if !ok {
+		t.Logf(format, args...)
+		return
+	}
+	l := v.(*noteLog)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.lines = append(l.lines, fmt.Sprintf(format, args...))
+}
+
+// captureNotes starts collecting the notes of t and returns a function that
+// stops and stores them in *dst (when dst is not nil).
+func captureNotes(t *testing.T, dst *[]string) func() {
+	l := &noteLog{}
+	noteLogs.Store(t, l)
+	return func() {
+		noteLogs.Delete(t)
+		if dst != nil {
+			l.mu.Lock()
+			*dst = l.lines
+			l.mu.Unlock()
+		}
+	}
+}
+
+// recordNotes returns a copy of cfg whose OnShrink keeps the notes of the
+// last accepted candidate, which the shrinking run stores in *last, and a
+// function returning the notes of the minimal counterexample (orig's when no
+// candidate was accepted).
+func recordNotes(cfg Config, orig []string, last *[]string) (Config, func() []string) {
+	notes := orig
+	onShrink := cfg.OnShrink
+	cfg.OnShrink = func(step int, candidate any, accepted bool) {
+		if accepted {
+			notes = *last
+		}
+		if onShrink != nil {
+			onShrink(step, candidate, accepted)
+		}
+	}
+	return cfg, func() []string { return notes }
+}
+
+// notesText renders the notes of a counterexample for the text report, one
+// per line, or "" when there are none.
+func notesText(notes []string) string {
+	if len(notes) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "\nnotes (%d):", len(notes))
+	for _, n := range notes {
+		fmt.Fprintf(&b, "\n  %s", n)
+	}
+	return b.String()
+}
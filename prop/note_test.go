@@ -0,0 +1,103 @@
+package prop
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"sync"
+	"testing"
+
+	"arcsyn.io/propx/gen"
+)
+
+func TestNote_CapturedPerExample(t *testing.T) {
+	var notes []string
+	var sub *testing.T
+	for _, v := range []int{1, 2} {
+		t.Run(fmt.Sprintf("ex#%d", v), func(st *testing.T) {
+			sub = st
+			runBody(st, func(t *testing.T, x int) {
+				Note(t, "x=%d", x)
+				var wg sync.WaitGroup
+				for i := 0; i < 4; i++ {
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						Note(t, "worker")
+					}()
+				}
+				wg.Wait()
+			}, v, nil, &notes)
+		})
+		if len(notes) != 5 || notes[0] != fmt.Sprintf("x=%d", v) {
+			t.Errorf("example %d: notes = %q, expected x=%d and 4 from the workers", v, notes, v)
+		}
+	}
+	if _, ok := noteLogs.Load(sub); ok {
+		t.Error("notes still captured after the example ended")
+	}
+
+	// a discarded example still hands over its notes
+	t.Run("discarded", func(st *testing.T) {
+		runBody(st, func(t *testing.T, _ int) { Note(t, "before"); Discard() }, 0, nil, &notes)
+	})
+	if len(notes) != 1 || notes[0] != "before" {
+		t.Errorf("discarded example: notes = %q, expected [before]", notes)
+	}
+}
+
+// TestRecordNotes checks that the reported notes are those of the minimal
+// counterexample, not of the last candidate run.
+func TestRecordNotes(t *testing.T) {
+	gen.SetShrinkStrategy("bfs")
+	val, shrink := gen.IntRange(100, 1000).Generate(rand.New(rand.NewSource(123)), gen.Size{})
+
+	var notes []string
+	run := func(_ string, v int) bool {
+		notes = []string{fmt.Sprintf("v=%d", v)}
+		return v < 10
+	}
+	run("ex#1", val)
+	calls := 0
+	cfg := Config{MaxShrink: 100, OnShrink: func(int, any, bool) { calls++ }}
+	scfg, minNotes := recordNotes(cfg, notes, &notes)
+	min, steps := shrinkCounterexample(scfg, "ex#1", val, shrink, run)
+	if calls != steps {
+		t.Errorf("OnShrink called %d times for %d steps", calls, steps)
+	}
+	if got, want := minNotes(), []string{fmt.Sprintf("v=%d", min)}; len(got) != 1 || got[0] != want[0] {
+		t.Errorf("notes = %q, expected %q", got, want)
+	}
+
+	// nothing accepted: the original's notes
+	_, minNotes = recordNotes(cfg, []string{"orig"}, &notes)
+	if got := minNotes(); len(got) != 1 || got[0] != "orig" {
+		t.Errorf("notes = %q, expected [orig]", got)
+	}
+}
+
+func TestNotesReport(t *testing.T) {
+	if got := notesText(nil); got != "" {
+		t.Errorf("notesText(nil) = %q, expected \"\"", got)
+	}
+	if got, want := notesText([]string{"a=1", "b"}), "\nnotes (2):\n  a=1\n  b"; got != want {
+		t.Errorf("notesText() = %q, expected %q", got, want)
+	}
+
+	f := failureResult{name: "ex#1", orig: 9, min: 1, notes: []string{"a=1"}}
+	var got map[string]any
+	if err := json.Unmarshal([]byte(jsonFailureReport(t, 1, 1, f, 0)), &got); err != nil {
+		t.Fatal(err)
+	}
+	if !jsonEqual(got["notes"], []any{"a=1"}) {
+		t.Errorf("notes = %v, expected [a=1]", got["notes"])
+	}
+	f.notes = nil
+	got = nil
+	if err := json.Unmarshal([]byte(jsonFailureReport(t, 1, 1, f, 0)), &got); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := got["notes"]; ok {
+		t.Error("notes reported for an example without any")
+	}
+}
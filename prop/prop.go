@@ -4,9 +4,13 @@
 package prop
 
 import (
+	crand "crypto/rand"
+	"encoding/binary"
 	"flag"
 	"fmt"
 	"math/rand"
+	"runtime"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -17,7 +21,9 @@ import (
 // Config holds the configuration for property-based testing.
 type Config struct {
 	// Seed is the random seed used for test case generation.
-	// If zero, a random seed will be generated based on the current time.
+	// If zero, each run draws a fresh non-zero seed from crypto/rand and logs
+	// it (marked "random"), so local runs explore new inputs while a failure
+	// stays reproducible with -propx.seed. Pin it (e.g. in CI) for fixed inputs.
 	Seed int64
 
 	// Examples is the number of test cases to generate and run.
@@ -36,13 +42,104 @@ type Config struct {
 	StopOnFirstFailure bool
 
 	// Parallelism specifies the number of parallel workers to use
-	// for running test cases. Must be at least 1.
+	// for running test cases:
+	//   - 0 means auto: runtime.GOMAXPROCS(0), capped at 8 (see AutoParallelism);
+	//   - 1 runs examples sequentially;
+	//   - n > 1 uses n workers (never more than Examples).
 	Parallelism int
+
+	// Verbose makes a passing ForAll log its elapsed time, throughput
+	// (examples/sec) and the split between generation and property time.
+	Verbose bool
+
+	// MaxGeneratedSize caps the total number of elements generated for a
+	// single example across nested collections (e.g. a slice of slices of
+	// strings). Exceeding it fails the test with a clear message instead of
+	// exhausting memory. 0 means unlimited.
+	MaxGeneratedSize int
+
+	// MemoryBudgetBytes caps the memory allocated during a whole run: before
+	// each example is generated, the bytes allocated on the heap since the
+	// run started (by generators, the property and shrinking alike, freed or
+	// not; runtime.MemStats.TotalAlloc) are sampled, and past the budget the
+	// test fails with the seed instead of being killed for running out of
+	// memory. It is best effort: the process's other goroutines (e.g.
+	// parallel tests) count too, and an example can overshoot it before the
+	// next check. 0 means unlimited.
+	MemoryBudgetBytes int64
+
+	// ShuffleExamples generates all examples up front and runs them in a
+	// seed-derived shuffled order, exposing ordering-dependent state shared
+	// across examples. Subtests keep their generation index in the name
+	// (ex#N), and the order is reproducible from the seed.
+	ShuffleExamples bool
+
+	// CorpusDir, when set, keeps a corpus of minimal counterexamples per test
+	// (as JSON files in CorpusDir/<TestName>/). When an example fails, the
+	// corpus entries are tried first while shrinking, since bugs tend to
+	// recur on the same inputs; new minimal counterexamples are added to it.
+	CorpusDir string
+
+	// ReportFormat selects how failures are reported: "text" (ReportText, the
+	// default) or "json" (ReportJSON), which logs a single JSON object with the
+	// seed, example index, original and shrunk values, shrink count and
+	// elapsed time, for tools that parse test output.
+	ReportFormat string
+
+	// GoldenFile, when set, freezes the inputs of the test: the first run
+	// records every generated example in this file (JSON, versioned), and
+	// later runs replay the recorded examples instead of generating them, so
+	// they stay the same even if math/rand changes across Go versions.
+	// Replayed examples are not shrunk. Delete the file to record again.
+	GoldenFile string
+
+	// MaxInterleavings bounds how many interleavings CheckLinearizable
+	// explores for one parallel history, since the full search is factorial
+	// in the number of commands. 0 selects DefaultMaxInterleavings.
+	MaxInterleavings int
+
+	// OnShrink, when set, is called after every shrink attempt with the step
+	// number (from 1), the candidate tried and whether it was accepted (it
+	// still fails the property and becomes the new minimum). Use it to log,
+	// visualize or assert the shrink trajectory. With Parallelism > 1 it may
+	// be called from several goroutines at once.
+	OnShrink func(step int, candidate any, accepted bool)
+
+	// RecordShrinkPath adds the shrink path to failure reports: the original
+	// counterexample and every accepted candidate after it, with its step
+	// number, ending at the minimal one. Use it to check that a shrinker
+	// minimizes sensibly; unlike OnShrink, rejected candidates are left out.
+	RecordShrinkPath bool
+
+	// MaxDiscardRatio is the largest fraction of the examples that the
+	// property may drop with Discard (0.9 allows 90 of 100): beyond it the
+	// test fails, since the property checks too few inputs. 0 selects
+	// DefaultMaxDiscardRatio; 1 never fails.
+	MaxDiscardRatio float64
+
+	// DiscardDiagnostics explains discard storms: the run records how many
+	// values each gen.Filter (named by gen.Label) and Discard rejected, with
+	// a sample of them, and adds the breakdown to the MaxDiscardRatio
+	// failure. The test also fails, with the breakdown, as soon as a filter
+	// finds no value within its maxTries, instead of the filter silently
+	// generating the zero value. Only the filters of the run's own generator
+	// are recorded (through gen.Size.Filters), not those of tests running in
+	// parallel.
+	DiscardDiagnostics bool
+
+	// Stringer, when set, renders values in text failure reports (the
+	// counterexample and the shrink path): it returns the text of the values
+	// it handles and false for the others, which keep their %#v rendering.
+	// The arguments of ForAll3 to ForAll5 are passed one by one. Use it to
+	// show domain values compactly (e.g. only their ID), notably third-party
+	// types that cannot implement fmt.Stringer. JSON reports still encode
+	// the values as JSON.
+	Stringer func(v any) (string, bool)
 }
 
 var (
 	// flagSeed sets the random seed for test case generation.
-	// Default: 0 (random seed based on current time).
+	// Default: 0 (a random seed from crypto/rand, logged for replay).
 	flagSeed = flag.Int64("propx.seed", 0, "Random seed for test case generation")
 
 	// flagExamples sets the number of test cases to generate.
@@ -58,30 +155,158 @@ var (
 	flagShrinkStrat = flag.String("propx.shrink.strategy", "bfs", "Shrinking strategy (bfs or dfs)")
 
 	// flagParallelism sets the number of parallel workers.
-	// Default: 1.
-	flagParallelism = flag.Int("propx.shrink.parallel", 1, "Number of parallel workers")
+	// Default: 0 (auto, based on GOMAXPROCS).
+	flagParallelism = flag.Int("propx.shrink.parallel", 0, "Number of parallel workers (0 = auto, 1 = sequential)")
+
+	// flagVerbose enables timing reports for passing properties.
+	// Default: false.
+	flagVerbose = flag.Bool("propx.verbose", false, "Log elapsed time and throughput of passing properties")
+
+	// flagReport sets the failure report format.
+	// Default: "text".
+	flagReport = flag.String("propx.report", ReportText, "Failure report format (text or json)")
 )
 
-// Default returns a Config with default values based on command-line flags.
-// This is the recommended way to create a configuration for property-based testing.
+// Default returns a Config with default values based on command-line flags,
+// including the flags bound to a custom flag set with BindFlags once it is
+// parsed. This is the recommended way to create a configuration for
+// property-based testing.
 func Default() Config {
-	return Config{
+	cfg := Config{
 		Seed:               *flagSeed,
 		Examples:           *flagExamples,
 		MaxShrink:          *flagMaxShrink,
 		ShrinkStrat:        *flagShrinkStrat,
 		StopOnFirstFailure: true,
-		Parallelism:        *flagParallelism,
+		Parallelism:        resolveParallelism(*flagParallelism),
+		Verbose:            *flagVerbose,
+		ReportFormat:       *flagReport,
+	}
+	applyBoundFlags(&cfg)
+	return cfg
+}
+
+// WithSeed returns a copy of c with Seed set to seed.
+func (c Config) WithSeed(seed int64) Config {
+	c.Seed = seed
+	return c
+}
+
+// WithExamples returns a copy of c with Examples set to n.
+func (c Config) WithExamples(n int) Config {
+	c.Examples = n
+	return c
+}
+
+// WithMaxShrink returns a copy of c with MaxShrink set to n.
+func (c Config) WithMaxShrink(n int) Config {
+	c.MaxShrink = n
+	return c
+}
+
+// Validate reports the first nonsensical setting in c: Examples <= 0,
+// MaxShrink < 0, Parallelism < 0, MaxGeneratedSize < 0, MaxInterleavings < 0,
+// MaxDiscardRatio outside [0, 1], or an unknown ShrinkStrat or ReportFormat (matching is exact: "BFS" is
+// rejected).
+// Empty ShrinkStrat and ReportFormat select the defaults ("bfs", "text").
+func (c Config) Validate() error {
+	switch {
+	case c.Examples <= 0:
+		return fmt.Errorf("[propx] invalid Config: Examples=%d, must be > 0", c.Examples)
+	case c.MaxShrink < 0:
+		return fmt.Errorf("[propx] invalid Config: MaxShrink=%d, must be >= 0", c.MaxShrink)
+	case c.Parallelism < 0:
+		return fmt.Errorf("[propx] invalid Config: Parallelism=%d, must be >= 0 (0 = auto)", c.Parallelism)
+	case c.MaxGeneratedSize < 0:
+		return fmt.Errorf("[propx] invalid Config: MaxGeneratedSize=%d, must be >= 0 (0 = unlimited)", c.MaxGeneratedSize)
+	case c.MemoryBudgetBytes < 0:
+		return fmt.Errorf("[propx] invalid Config: MemoryBudgetBytes=%d, must be >= 0 (0 = unlimited)", c.MemoryBudgetBytes)
+	case c.MaxInterleavings < 0:
+		return fmt.Errorf("[propx] invalid Config: MaxInterleavings=%d, must be >= 0 (0 = default)", c.MaxInterleavings)
+	case !(c.MaxDiscardRatio >= 0 && c.MaxDiscardRatio <= 1):
+		return fmt.Errorf("[propx] invalid Config: MaxDiscardRatio=%g, must be in [0, 1] (0 = default)", c.MaxDiscardRatio)
+	}
+	switch c.ShrinkStrat {
+	case "", gen.ShrinkStrategyBFS, gen.ShrinkStrategyDFS:
+	default:
+		return fmt.Errorf("[propx] invalid Config: unknown ShrinkStrat %q, must be %q or %q",
+			c.ShrinkStrat, gen.ShrinkStrategyBFS, gen.ShrinkStrategyDFS)
+	}
+	switch c.ReportFormat {
+	case "", ReportText, ReportJSON:
+	default:
+		return fmt.Errorf("[propx] invalid Config: unknown ReportFormat %q, must be %q or %q",
+			c.ReportFormat, ReportText, ReportJSON)
 	}
+	return nil
+}
+
+// maxAutoParallelism caps the number of workers chosen automatically.
+const maxAutoParallelism = 8
+
+// AutoParallelism returns the number of workers used when Parallelism is 0:
+// runtime.GOMAXPROCS(0), capped at 8.
+func AutoParallelism() int {
+	n := runtime.GOMAXPROCS(0)
+	if n > maxAutoParallelism {
+		n = maxAutoParallelism
+	}
+	if n < 1 {
+		n = 1
+	}
+	return n
+}
+
+// resolveParallelism turns the configured value into an effective worker
+// count: 0 (or negative) selects AutoParallelism.
+func resolveParallelism(p int) int {
+	if p <= 0 {
+		return AutoParallelism()
+	}
+	return p
+}
+
+// exampleSize returns the Size passed to the generator for one example,
+// carrying a fresh budget when MaxGeneratedSize is set and the filter
+// observer of diag, if any.
+func (c Config) exampleSize(diag *discardLog) gen.Size {
+	sz := gen.Size{Budget: gen.NewBudget(c.MaxGeneratedSize)}
+	if diag != nil {
+		sz.Filters = gen.NewFilterLog(diag.observeFilter)
+	}
+	return sz
 }
 
 // effectiveSeed returns the effective seed to use for random number generation.
-// If the configured seed is zero, it returns a random seed based on the current time.
+// If the configured seed is zero, it returns a random seed (see entropySeed).
 func (c Config) effectiveSeed() int64 {
 	if c.Seed != 0 {
 		return c.Seed
 	}
-	return time.Now().UnixNano()
+	return entropySeed()
+}
+
+// entropySeed draws a positive seed from crypto/rand, falling back to the
+// current time if the system source fails. It is never zero, since a zero
+// seed fed back with -propx.seed would pick a new random one.
+func entropySeed() int64 {
+	var b [8]byte
+	for {
+		if _, err := crand.Read(b[:]); err != nil {
+			return time.Now().UnixNano()
+		}
+		if seed := int64(binary.LittleEndian.Uint64(b[:]) >> 1); seed != 0 {
+			return seed
+		}
+	}
+}
+
+// seedSource labels a random seed in the run's log line.
+func (c Config) seedSource() string {
+	if c.Seed == 0 {
+		return " (random)"
+	}
+	return ""
 }
 
 // ForAll creates a property-based test that generates test cases using the provided generator
@@ -89,7 +314,8 @@ func (c Config) effectiveSeed() int64 {
 // body as a parameter.
 //
 // The test will generate cfg.Examples number of test cases, and if any fail, it will attempt
-// to shrink the counterexample to find a minimal failing case.
+// to shrink the counterexample to find a minimal failing case. An invalid cfg (see
+// Config.Validate) fails the test before any example runs.
 //
 // Example usage:
 //
@@ -101,70 +327,310 @@ func (c Config) effectiveSeed() int64 {
 //	})
 func ForAll[T any](t *testing.T, cfg Config, g gen.Generator[T]) func(func(*testing.T, T)) {
 	return func(body func(*testing.T, T)) {
-		seed := cfg.effectiveSeed()
-		r := rand.New(rand.NewSource(seed)) // #nosec G404 -- Using math/rand for deterministic property-based testing
-		gen.SetShrinkStrategy(cfg.ShrinkStrat)
-
-		t.Logf("[propx] seed=%d examples=%d maxshrink=%d strategy=%s parallelism=%d",
-			seed, cfg.Examples, cfg.MaxShrink, cfg.ShrinkStrat, cfg.Parallelism)
-
+		if err := cfg.Validate(); err != nil {
+			t.Fatal(err)
+		}
+		cfg, seed := startRun(t.Logf, cfg)
+		stats := newRunStats()
+		if cfg.DiscardDiagnostics {
+			stats.diagnostics = &discardLog{}
+		}
 		if cfg.Parallelism <= 1 {
-			runSequential(t, cfg, g, body, seed, r)
+			runSequential(t, cfg, g, body, seed, stats)
 		} else {
-			runParallel(t, cfg, g, body, seed, r)
+			runParallel(t, cfg, g, body, seed, stats)
+		}
+		checkDiscards(t, cfg, seed, stats)
+
+		// reached only when every example passed (failures call t.Fatal)
+		if cfg.Verbose {
+			t.Log(stats.report(stats.elapsed()))
 		}
 	}
 }
 
+// startRun resolves the seed and the worker count of cfg, selects its shrink
+// strategy and logs them for replay; it returns the resolved cfg and seed.
+func startRun(logf func(format string, args ...any), cfg Config) (Config, int64) {
+	seed := cfg.effectiveSeed()
+	gen.SetShrinkStrategy(cfg.ShrinkStrat)
+	cfg.Parallelism = resolveParallelism(cfg.Parallelism)
+	logf("[propx] seed=%d%s examples=%d maxshrink=%d strategy=%s parallelism=%d",
+		seed, cfg.seedSource(), cfg.Examples, cfg.MaxShrink, cfg.ShrinkStrat, cfg.Parallelism)
+	return cfg, seed
+}
+
+// ForAllN is shorthand for ForAll with Default() configuration but running n
+// examples. Shrinking and reporting behave exactly as in ForAll.
+//
+// Example usage:
+//
+//	prop.ForAllN(t, 10_000, gen.Int(gen.Size{}), func(t *testing.T, x int) {
+//	    if x+0 != x {
+//	        t.Errorf("addition identity failed for %d", x)
+//	    }
+//	})
+func ForAllN[T any](t *testing.T, n int, g gen.Generator[T], property func(*testing.T, T)) {
+	cfg := Default()
+	cfg.Examples = n
+	ForAll(t, cfg, g)(property)
+}
+
 // runSequential executes property-based tests sequentially (single-threaded).
 // It generates test cases one by one and runs them against the test function.
 // If a test fails, it attempts to shrink the counterexample.
-func runSequential[T any](t *testing.T, cfg Config, g gen.Generator[T], body func(*testing.T, T), seed int64, r *rand.Rand) {
-	for i := 0; i < cfg.Examples; i++ {
-		val, shrink := g.Generate(r, gen.Size{})
+func runSequential[T any](t *testing.T, cfg Config, g gen.Generator[T], body func(*testing.T, T), seed int64, stats *runStats) {
+	var notes []string // of the last example or candidate run
+	run := func(name string, v T) bool {
+		return t.Run(name, func(st *testing.T) { runBody(st, body, v, nil, &notes) })
+	}
+	next := exampleFeed(cfg, g, seed, stats)
+	corpus := loadCorpus[T](t, cfg)
+	for pos := 0; pos < cfg.Examples; pos++ {
+		i, val, shrink, err := next(pos)
+		if err != nil {
+			t.Fatal(err)
+		}
 		name := fmt.Sprintf("ex#%d", i+1)
 
-		passed := t.Run(name, func(st *testing.T) { body(st, val) })
+		start := time.Now()
+		passed := t.Run(name, func(st *testing.T) { runBody(st, body, val, stats, &notes) })
+		stats.addProperty(time.Since(start))
 		if passed {
 			continue
 		}
 
-		min := val
-		steps := 0
-		acceptedPrev := true
+		scfg, path := recordShrinkPath(cfg, val)
+		scfg, minNotes := recordNotes(scfg, notes, &notes)
+		min, steps := shrinkCounterexample(scfg, name, val, withCorpusHints(corpus, shrink), run)
+		if err := saveCorpus(t, cfg, min); err != nil {
+			t.Log(err)
+		}
+		reportFailure(t, cfg, seed, pos+1, failureResult{testIndex: i, name: name, orig: val, min: min, steps: steps, path: path(), notes: minNotes()}, stats.elapsed())
 
-		for steps < cfg.MaxShrink {
-			next, ok := shrink(acceptedPrev)
-			if !ok {
-				break
+		if cfg.StopOnFirstFailure {
+			return
+		}
+	}
+}
+
+// shuffleSalt derives the shuffling seed from the run seed, so the order does
+// not correlate with the generator's random stream.
+const shuffleSalt = 0x5f3759df
+
+// exampleFeed returns a function yielding, for each run position, the example
+// to check: its generation index, value and shrinker. Example i is generated
+// from its own source (see ExampleSeed), on demand, or all up front (on the
+// first call) when cfg.ShuffleExamples or cfg.GoldenFile is set: they are
+// then recorded in (or replayed from) the golden file, and shuffled. The
+// returned function is not safe for concurrent use.
+func exampleFeed[T any](cfg Config, g gen.Generator[T], seed int64, stats *runStats) func(pos int) (int, T, gen.Shrinker[T], error) {
+	mem := newMemBudget(cfg.MemoryBudgetBytes)
+	generateOne := func(i int) (T, gen.Shrinker[T], error) {
+		if err := mem.check(seed, i); err != nil {
+			var zero T
+			return zero, nil, err
+		}
+		diag := stats.discardDiagnostics()
+		diag.takeExhausted() // a filter that gave up while shrinking
+		start := time.Now()
+		val, shrink, err := generate(g, exampleRand(seed, i), cfg.exampleSize(diag), seed, i)
+		stats.addGenerate(time.Since(start))
+		if err == nil {
+			err = diag.stormError(seed, i)
+		}
+		return val, shrink, err
+	}
+	if !cfg.ShuffleExamples && cfg.GoldenFile == "" {
+		return func(pos int) (int, T, gen.Shrinker[T], error) {
+			val, shrink, err := generateOne(pos)
+			return pos, val, shrink, err
+		}
+	}
+
+	type example struct {
+		index  int
+		val    T
+		shrink gen.Shrinker[T]
+	}
+	var (
+		examples []example
+		genErr   error
+	)
+	// collect generates all examples, or replays them from the golden file
+	collect := func() ([]example, error) {
+		examples := make([]example, 0, cfg.Examples)
+		if cfg.GoldenFile != "" {
+			recorded, ok, err := loadGolden[T](cfg.GoldenFile, cfg.Examples)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				for i, val := range recorded {
+					examples = append(examples, example{index: i, val: val, shrink: noShrinker[T]})
+				}
+				return examples, nil
+			}
+		}
+		for i := 0; i < cfg.Examples; i++ {
+			val, shrink, err := generateOne(i)
+			if err != nil {
+				return nil, err
+			}
+			examples = append(examples, example{index: i, val: val, shrink: shrink})
+		}
+		if cfg.GoldenFile != "" {
+			vals := make([]T, len(examples))
+			for i, ex := range examples {
+				vals[i] = ex.val
+			}
+			if err := saveGolden(cfg.GoldenFile, seed, vals); err != nil {
+				return nil, err
+			}
+		}
+		return examples, nil
+	}
+	return func(pos int) (int, T, gen.Shrinker[T], error) {
+		if examples == nil && genErr == nil {
+			examples, genErr = collect()
+			if cfg.ShuffleExamples {
+				sr := rand.New(rand.NewSource(seed ^ shuffleSalt)) // #nosec G404 -- Using math/rand for deterministic property-based testing
+				sr.Shuffle(len(examples), func(a, b int) { examples[a], examples[b] = examples[b], examples[a] })
+			}
+		}
+		if genErr != nil {
+			var zero T
+			return pos, zero, nil, genErr
+		}
+		ex := examples[pos]
+		return ex.index, ex.val, ex.shrink, nil
+	}
+}
+
+// generate produces one example, converting a panic inside the generator into
+// an error that carries the seed, the example index and the generator label.
+// The size budget (if any) is stopped once the example is generated.
+func generate[T any](g gen.Generator[T], r *rand.Rand, sz gen.Size, seed int64, index int) (val T, shrink gen.Shrinker[T], err error) {
+	defer sz.Budget.Stop()
+	defer func() {
+		if p := recover(); p != nil {
+			label := gen.LabelOf(g)
+			if label == "" {
+				label = fmt.Sprintf("%T", g)
 			}
-			steps++
-			sname := fmt.Sprintf("%s/shrink#%d", name, steps)
-
-			stillFails := !t.Run(sname, func(st *testing.T) { body(st, next) })
-			if stillFails {
-				min = next
-				acceptedPrev = true
-			} else {
-				acceptedPrev = false
+			if be, ok := p.(*gen.BudgetExceededError); ok {
+				err = fmt.Errorf("[propx] generator %s exceeded Config.MaxGeneratedSize=%d (%d elements requested); seed=%d; example=%d\n"+
+					"lower the collection sizes or raise MaxGeneratedSize\nreplay: -propx.seed=%d\ncause: %w",
+					label, be.Limit, be.Used, seed, index+1, seed, be)
+				return
 			}
+			cause, ok := p.(error)
+			if !ok {
+				cause = fmt.Errorf("%v", p)
+			}
+			err = fmt.Errorf("[propx] generator %s panicked; seed=%d; example=%d\nreplay: -propx.seed=%d\npanic: %w",
+				label, seed, index+1, seed, cause)
 		}
+	}()
+	val, shrink = g.Generate(r, sz)
+	return val, shrink, nil
+}
 
-		full := fmt.Sprintf("^%s$/%s(/|$)", t.Name(), name)
-		t.Fatalf("[propx] property failed; seed=%d; examples_run=%d; shrunk_steps=%d\n"+
-			"counterexample (min): %#v\nreplay: go test -run '%s' -propx.seed=%d",
-			seed, i+1, steps, min, full, seed)
+// shrinkCounterexample drives the shrinker of a failing value and returns the
+// minimal failing value found and the number of shrinking steps performed.
+// run executes the property as a named subtest and reports whether it passed.
+func shrinkCounterexample[T any](cfg Config, name string, val T, shrink gen.Shrinker[T], run func(string, T) bool) (T, int) {
+	min := val
+	steps := 0
+	acceptedPrev := true
+
+	for steps < cfg.MaxShrink {
+		next, ok := shrink(acceptedPrev)
+		if !ok {
+			break
+		}
+		steps++
+		sname := fmt.Sprintf("%s/shrink#%d", name, steps)
 
-		if cfg.StopOnFirstFailure {
-			return
+		stillFails := !run(sname, next)
+		if cfg.OnShrink != nil {
+			cfg.OnShrink(steps, next, stillFails)
+		}
+		if stillFails {
+			min = next
+			acceptedPrev = true
+		} else {
+			acceptedPrev = false
+		}
+	}
+	return min, steps
+}
+
+// shrinkStep is an accepted step of a shrink path: the step number (0 for
+// the original counterexample) and the candidate.
+type shrinkStep struct {
+	Step  int `json:"step"`
+	Value any `json:"value"`
+}
+
+// recordShrinkPath returns a copy of cfg whose OnShrink also records the
+// accepted candidates after orig when cfg.RecordShrinkPath is set, and a
+// function returning the recorded path (nil when not recording).
+func recordShrinkPath(cfg Config, orig any) (Config, func() []shrinkStep) {
+	if !cfg.RecordShrinkPath {
+		return cfg, func() []shrinkStep { return nil }
+	}
+	path := []shrinkStep{{Step: 0, Value: orig}}
+	onShrink := cfg.OnShrink
+	cfg.OnShrink = func(step int, candidate any, accepted bool) {
+		if accepted {
+			path = append(path, shrinkStep{Step: step, Value: candidate})
+		}
+		if onShrink != nil {
+			onShrink(step, candidate, accepted)
 		}
 	}
+	return cfg, func() []shrinkStep { return path }
+}
+
+// failureMessage builds the failure report of a property, including the
+// command line needed to replay the failing example and the shrink path,
+// if recorded. Values are rendered with render (see valueRenderer).
+func failureMessage(t *testing.T, render func(any) string, seed int64, examplesRun int, name string, steps int, min any, path []shrinkStep) string {
+	return fmt.Sprintf("[propx] property failed; seed=%d; examples_run=%d; shrunk_steps=%d\n"+
+		"counterexample (min): %s\nreplay: %s%s",
+		seed, examplesRun, steps, render(min), replayCommand(t, name, seed), shrinkPathText(path, render))
+}
+
+// shrinkPathText renders a recorded shrink path for the text report, one
+// accepted step per line, or "" when there is none.
+func shrinkPathText(path []shrinkStep, render func(any) string) string {
+	if len(path) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "\nshrink path (%d accepted):", len(path)-1)
+	for _, s := range path {
+		if s.Step == 0 {
+			fmt.Fprintf(&b, "\n  original: %s", render(s.Value))
+		} else {
+			fmt.Fprintf(&b, "\n  step %d: %s", s.Step, render(s.Value))
+		}
+	}
+	return b.String()
+}
+
+// replayCommand returns the go test command that reruns the named example.
+func replayCommand(t *testing.T, name string, seed int64) string {
+	return fmt.Sprintf("go test -run '^%s$/%s(/|$)' -propx.seed=%d", t.Name(), name, seed)
 }
 
 // runParallel executes property-based tests in parallel using multiple goroutines.
 // It distributes test cases across multiple workers and collects failure results.
-// The random number generator is protected by a mutex to ensure thread safety.
-func runParallel[T any](t *testing.T, cfg Config, g gen.Generator[T], body func(*testing.T, T), seed int64, r *rand.Rand) {
+// The example feed is protected by a mutex to ensure thread safety; since each
+// example has its own random source, the order in which workers generate them
+// does not change their values.
+func runParallel[T any](t *testing.T, cfg Config, g gen.Generator[T], body func(*testing.T, T), seed int64, stats *runStats) {
 	// Create a channel to distribute test indices to workers
 	testChan := make(chan int, cfg.Examples)
 
@@ -177,61 +643,64 @@ func runParallel[T any](t *testing.T, cfg Config, g gen.Generator[T], body func(
 	// WaitGroup to coordinate worker goroutines
 	var wg sync.WaitGroup
 
-	// Mutex to protect the shared random number generator
+	// Mutex to protect the feed
 	var randMutex sync.Mutex
+	next := exampleFeed(cfg, g, seed, stats)
+	corpus := loadCorpus[T](t, cfg)
 
 	// Channel to collect failure results from workers
 	failureChan := make(chan failureResult, cfg.Examples)
 
-	// Start worker goroutines
-	for i := 0; i < cfg.Parallelism; i++ {
+	// Start worker goroutines (never more than there are examples)
+	workers := cfg.Parallelism
+	if workers > cfg.Examples {
+		workers = cfg.Examples
+	}
+	for i := 0; i < workers; i++ {
 		wg.Add(1)
 		go func(workerID int) {
 			defer wg.Done()
 
+			var notes []string // of the worker's last example or candidate run
+			run := func(name string, v T) bool {
+				return t.Run(name, func(st *testing.T) { runBody(st, body, v, nil, &notes) })
+			}
+
 			// Process test cases from the channel
-			for testIndex := range testChan {
+			for pos := range testChan {
 				// Generate test case (protected by mutex for thread safety)
 				randMutex.Lock()
-				val, shrink := g.Generate(r, gen.Size{})
+				testIndex, val, shrink, err := next(pos)
 				randMutex.Unlock()
+				if err != nil {
+					failureChan <- failureResult{testIndex: testIndex, err: err}
+					return
+				}
 
 				name := fmt.Sprintf("ex#%d", testIndex+1)
 
 				// Run the test case
-				passed := t.Run(name, func(st *testing.T) { body(st, val) })
+				start := time.Now()
+				passed := t.Run(name, func(st *testing.T) { runBody(st, body, val, stats, &notes) })
+				stats.addProperty(time.Since(start))
 				if passed {
 					continue
 				}
 
 				// Test failed, attempt to shrink the counterexample
-				min := val
-				steps := 0
-				acceptedPrev := true
-
-				for steps < cfg.MaxShrink {
-					next, ok := shrink(acceptedPrev)
-					if !ok {
-						break
-					}
-					steps++
-					sname := fmt.Sprintf("%s/shrink#%d", name, steps)
-
-					stillFails := !t.Run(sname, func(st *testing.T) { body(st, next) })
-					if stillFails {
-						min = next
-						acceptedPrev = true
-					} else {
-						acceptedPrev = false
-					}
-				}
+				scfg, path := recordShrinkPath(cfg, val)
+				scfg, minNotes := recordNotes(scfg, notes, &notes)
+				min, steps := shrinkCounterexample(scfg, name, val, withCorpusHints(corpus, shrink), run)
 
 				// Send failure result to the channel
 				failureChan <- failureResult{
 					testIndex: testIndex,
 					name:      name,
+					orig:      val,
 					min:       min,
 					steps:     steps,
+					path:      path(),
+					notes:     minNotes(),
 				}
 
 				if cfg.StopOnFirstFailure {
@@ -249,10 +718,13 @@ func runParallel[T any](t *testing.T, cfg Config, g gen.Generator[T], body func(
 
 	// Process failure results and report them
 	for failure := range failureChan {
-		full := fmt.Sprintf("^%s$/%s(/|$)", t.Name(), failure.name)
-		t.Fatalf("[propx] property failed; seed=%d; examples_run=%d; shrunk_steps=%d\n"+
-			"counterexample (min): %#v\nreplay: go test -run '%s' -propx.seed=%d",
-			seed, failure.testIndex+1, failure.steps, failure.min, full, seed)
+		if failure.err != nil {
+			t.Fatal(failure.err)
+		}
+		if err := saveCorpus(t, cfg, failure.min); err != nil {
+			t.Log(err)
+		}
+		reportFailure(t, cfg, seed, failure.testIndex+1, failure, stats.elapsed())
 
 		if cfg.StopOnFirstFailure {
 			return
@@ -268,11 +740,23 @@ type failureResult struct {
 	// name is the name of the test case.
 	name string
 
+	// orig is the failing value as generated, before shrinking.
+	orig interface{}
+
 	// min is the minimal counterexample found through shrinking.
 	min interface{}
 
 	// steps is the number of shrinking steps performed.
 	steps int
+
+	// path is the shrink path, when Config.RecordShrinkPath is set.
+	path []shrinkStep
+
+	// notes are the Notes of the minimal counterexample.
+	notes []string
+
+	// err is set when the example could not be generated (the generator panicked).
+	err error
 }
 
 // StateMachine represents a state machine for property-based testing.
@@ -293,6 +777,14 @@ type Command[S, C any] struct {
 	// Generator creates instances of the command.
 	Generator gen.Generator[C]
 
+	// GenArgs, if set, is used instead of Generator: it returns the generator
+	// for the command given the model state reached by the commands before it
+	// in the sequence (e.g. a withdrawal amount up to the current balance).
+	// To track that state, sequences are replayed through Precondition and
+	// Execute while they are generated, so Execute must only compute the
+	// model state.
+	GenArgs func(state S) gen.Generator[C]
+
 	// Execute applies the command to the current state and returns the new state.
 	// If an error is returned, the command execution is considered failed.
 	Execute func(S, C) (S, error)
@@ -362,6 +854,16 @@ func (g commandSequenceGenerator[S, C]) Generate(r *rand.Rand, sz gen.Size) (Com
 	commands := make([]C, length)
 	shrinkers := make([]gen.Shrinker[C], length)
 
+	// The model state is only tracked when some command depends on it
+	trackState := false
+	for _, cmd := range g.stateMachine.Commands {
+		if cmd.GenArgs != nil {
+			trackState = true
+			break
+		}
+	}
+	state := g.stateMachine.InitialState
+
 	// Generate each command in the sequence
 	for i := 0; i < length; i++ {
 		// Select a random command type
@@ -373,9 +875,17 @@ func (g commandSequenceGenerator[S, C]) Generate(r *rand.Rand, sz gen.Size) (Com
 		cmd := g.stateMachine.Commands[cmdIndex]
 
 		// Generate the command
-		cmdVal, cmdShrinker := cmd.Generator.Generate(r, sz)
+		argGen := cmd.Generator
+		if cmd.GenArgs != nil {
+			argGen = cmd.GenArgs(state)
+		}
+		cmdVal, cmdShrinker := argGen.Generate(r, sz)
 		commands[i] = cmdVal
 		shrinkers[i] = cmdShrinker
+
+		if trackState {
+			state = nextModelState(g.stateMachine, state, cmdVal)
+		}
 	}
 
 	// If no commands were generated (because no commands are available), create empty sequence
@@ -414,6 +924,22 @@ func (g commandSequenceGenerator[S, C]) Generate(r *rand.Rand, sz gen.Size) (Com
 	return sequence, shrinker
 }
 
+// nextModelState returns the state after cmd, as executeStateMachine would
+// compute it: unchanged when the precondition fails or Execute errors.
+func nextModelState[S, C any](sm StateMachine[S, C], state S, cmd C) S {
+	matchedCmd := findMatchingCommand(sm, cmd)
+	if matchedCmd == nil || matchedCmd.Execute == nil {
+		return state
+	}
+	if matchedCmd.Precondition != nil && !matchedCmd.Precondition(state, cmd) {
+		return state
+	}
+	if newState, err := matchedCmd.Execute(state, cmd); err == nil {
+		return newState
+	}
+	return state
+}
+
 // findMatchingCommand finds a command that can handle the given command.
 // This is a simplified implementation - in practice you'd want proper command type discrimination.
 func findMatchingCommand[S, C any](sm StateMachine[S, C], cmd C) *Command[S, C] {
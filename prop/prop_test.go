@@ -4,8 +4,14 @@
 package prop
 
 import (
+	"errors"
 	"fmt"
+	"math"
 	"math/rand"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -71,6 +77,45 @@ func TestConfig_effectiveSeed_Consistency(t *testing.T) {
 	}
 }
 
+func TestEntropySeed(t *testing.T) {
+	seen := map[int64]bool{}
+	for i := 0; i < 100; i++ {
+		seed := entropySeed()
+		if seed <= 0 {
+			t.Fatalf("entropySeed() = %d, expected a positive seed", seed)
+		}
+		if seen[seed] {
+			t.Fatalf("entropySeed() repeated %d", seed)
+		}
+		seen[seed] = true
+	}
+}
+
+// TestRandomSeed_Replays checks that a logged random seed, fed back as
+// Config.Seed, reproduces the same examples.
+func TestRandomSeed_Replays(t *testing.T) {
+	seed := Config{}.effectiveSeed()
+	if got := (Config{Seed: 0}).seedSource(); got != " (random)" {
+		t.Errorf("seedSource() for a zero seed = %q, expected \" (random)\"", got)
+	}
+	if got := (Config{Seed: seed}).seedSource(); got != "" {
+		t.Errorf("seedSource() for a pinned seed = %q, expected none", got)
+	}
+
+	run := func() []int {
+		var vals []int
+		cfg := Config{Seed: seed, Examples: 20, MaxShrink: 10, ShrinkStrat: "bfs", Parallelism: 1}
+		ForAll(t, cfg, gen.Int(gen.Size{Max: 1 << 20}))(func(t *testing.T, x int) {
+			vals = append(vals, x)
+		})
+		return vals
+	}
+	first, replay := run(), run()
+	if !reflect.DeepEqual(first, replay) {
+		t.Errorf("seed %d gave %v, then %v on replay", seed, first, replay)
+	}
+}
+
 // TestDefault tests that the Default() function returns a valid configuration
 // with all required fields set to reasonable values.
 func TestDefault(t *testing.T) {
@@ -259,7 +304,7 @@ func TestForAll_WithHighParallelism(t *testing.T) {
 }
 
 func TestForAll_WithZeroExamples(t *testing.T) {
-	// Test with zero examples
+	// Zero examples is a configuration mistake: ForAll rejects it up front
 	config := Config{
 		Seed:        12345,
 		Examples:    0,
@@ -268,17 +313,9 @@ func TestForAll_WithZeroExamples(t *testing.T) {
 		Parallelism: 1,
 	}
 
-	gen := gen.From(func(r *rand.Rand, sz gen.Size) (int, gen.Shrinker[int]) {
-		return 42, func(accept bool) (int, bool) {
-			return 0, false
-		}
-	})
-
-	ForAll(t, config, gen)(func(t *testing.T, val int) {
-		if val != 42 {
-			t.Errorf("Expected 42, got %d", val)
-		}
-	})
+	if err := config.Validate(); err == nil || !strings.Contains(err.Error(), "Examples=0") {
+		t.Errorf("Validate() = %v, expected an Examples error", err)
+	}
 }
 
 func TestForAll_WithZeroMaxShrink(t *testing.T) {
@@ -437,6 +474,7 @@ func TestForAll_WithDFSStrategy(t *testing.T) {
 }
 
 func TestForAll_WithInvalidStrategy(t *testing.T) {
+	// an unknown strategy used to fall back to bfs silently; ForAll now rejects it
 	config := Config{
 		Seed:        12345,
 		Examples:    3,
@@ -445,17 +483,52 @@ func TestForAll_WithInvalidStrategy(t *testing.T) {
 		Parallelism: 1,
 	}
 
-	gen := gen.From(func(r *rand.Rand, sz gen.Size) (int, gen.Shrinker[int]) {
-		return 42, func(accept bool) (int, bool) {
-			return 0, false
-		}
-	})
+	if err := config.Validate(); err == nil || !strings.Contains(err.Error(), `ShrinkStrat "invalid"`) {
+		t.Errorf("Validate() = %v, expected a ShrinkStrat error", err)
+	}
+}
 
-	ForAll(t, config, gen)(func(t *testing.T, val int) {
-		if val != 42 {
-			t.Errorf("Expected 42, got %d", val)
-		}
-	})
+func TestConfig_Validate(t *testing.T) {
+	valid := Config{Examples: 10, MaxShrink: 5, ShrinkStrat: "bfs", Parallelism: 1}
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("Validate() on a valid config = %v", err)
+	}
+	if err := (Config{Examples: 1}).Validate(); err != nil {
+		t.Errorf("Validate() with default (empty) strategy and format = %v", err)
+	}
+	if err := Default().Validate(); err != nil {
+		t.Errorf("Default().Validate() = %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(*Config)
+		want   string
+	}{
+		{"zero examples", func(c *Config) { c.Examples = 0 }, "Examples=0"},
+		{"negative examples", func(c *Config) { c.Examples = -5 }, "Examples=-5"},
+		{"negative max shrink", func(c *Config) { c.MaxShrink = -1 }, "MaxShrink=-1"},
+		{"negative parallelism", func(c *Config) { c.Parallelism = -2 }, "Parallelism=-2"},
+		{"negative max generated size", func(c *Config) { c.MaxGeneratedSize = -1 }, "MaxGeneratedSize=-1"},
+		{"negative memory budget", func(c *Config) { c.MemoryBudgetBytes = -1 }, "MemoryBudgetBytes=-1"},
+		{"negative max interleavings", func(c *Config) { c.MaxInterleavings = -1 }, "MaxInterleavings=-1"},
+		{"negative max discard ratio", func(c *Config) { c.MaxDiscardRatio = -0.5 }, "MaxDiscardRatio=-0.5"},
+		{"max discard ratio above 1", func(c *Config) { c.MaxDiscardRatio = 1.5 }, "MaxDiscardRatio=1.5"},
+		{"NaN max discard ratio", func(c *Config) { c.MaxDiscardRatio = math.NaN() }, "MaxDiscardRatio=NaN"},
+		{"uppercase strategy", func(c *Config) { c.ShrinkStrat = "BFS" }, `ShrinkStrat "BFS"`},
+		{"unknown strategy", func(c *Config) { c.ShrinkStrat = "random" }, `ShrinkStrat "random"`},
+		{"unknown report format", func(c *Config) { c.ReportFormat = "xml" }, `ReportFormat "xml"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := valid
+			tt.modify(&c)
+			err := c.Validate()
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Validate() = %v, expected error mentioning %q", err, tt.want)
+			}
+		})
+	}
 }
 
 // Test failureResult struct
@@ -655,8 +728,8 @@ func TestFlagVariables(t *testing.T) {
 		t.Errorf("flagShrinkStrat should not be empty, got %q", *flagShrinkStrat)
 	}
 
-	if *flagParallelism <= 0 {
-		t.Errorf("flagParallelism should be > 0, got %d", *flagParallelism)
+	if *flagParallelism < 0 {
+		t.Errorf("flagParallelism should be >= 0 (0 = auto), got %d", *flagParallelism)
 	}
 }
 
@@ -677,8 +750,16 @@ func TestDefault_UsesFlagValues(t *testing.T) {
 		t.Errorf("Default().ShrinkStrat = %q, expected %q", config.ShrinkStrat, *flagShrinkStrat)
 	}
 
-	if config.Parallelism != *flagParallelism {
-		t.Errorf("Default().Parallelism = %d, expected %d", config.Parallelism, *flagParallelism)
+	if config.Parallelism != resolveParallelism(*flagParallelism) {
+		t.Errorf("Default().Parallelism = %d, expected %d", config.Parallelism, resolveParallelism(*flagParallelism))
+	}
+
+	if config.Verbose != *flagVerbose {
+		t.Errorf("Default().Verbose = %v, expected %v", config.Verbose, *flagVerbose)
+	}
+
+	if config.ReportFormat != *flagReport {
+		t.Errorf("Default().ReportFormat = %q, expected %q", config.ReportFormat, *flagReport)
 	}
 }
 
@@ -825,7 +906,7 @@ func TestForAll_WithDifferentSeedValues(t *testing.T) {
 }
 
 func TestForAll_WithDifferentStrategies(t *testing.T) {
-	strategies := []string{"bfs", "dfs", "invalid", ""}
+	strategies := []string{"bfs", "dfs", ""}
 
 	for _, strategy := range strategies {
 		t.Run(fmt.Sprintf("strategy_%s", strategy), func(t *testing.T) {
@@ -1109,3 +1190,333 @@ func TestForAll_ParallelShrinkingBreakPath(t *testing.T) {
 		}
 	})
 }
+
+// TestShrinkCounterexample checks that the shared shrinking loop converges
+// to the minimal failing value.
+func TestShrinkCounterexample(t *testing.T) {
+	config := Config{MaxShrink: 100}
+	r := rand.New(rand.NewSource(123))
+	gen.SetShrinkStrategy("bfs")
+
+	val, shrink := gen.IntRange(0, 1000).Generate(r, gen.Size{})
+	if val < 10 {
+		t.Skip("generated value already minimal")
+	}
+
+	// property fails for every value >= 10; the minimum must be exactly 10
+	min, steps := shrinkCounterexample(config, "ex#1", val, shrink, func(_ string, v int) bool {
+		return v < 10
+	})
+	if min != 10 {
+		t.Errorf("shrinkCounterexample() = %d, expected 10", min)
+	}
+	if steps == 0 {
+		t.Error("shrinkCounterexample() performed no steps")
+	}
+}
+
+// TestRecordShrinkPath checks that the recorded path starts at the original
+// value, lists exactly the accepted candidates in order, ends at the minimum,
+// and still calls the user's OnShrink.
+func TestRecordShrinkPath(t *testing.T) {
+	var accepted []int
+	calls := 0
+	config := Config{MaxShrink: 100, RecordShrinkPath: true, OnShrink: func(step int, candidate any, ok bool) {
+		calls++
+		if ok {
+			accepted = append(accepted, step)
+		}
+	}}
+	gen.SetShrinkStrategy("bfs")
+	val, shrink := gen.IntRange(100, 1000).Generate(rand.New(rand.NewSource(123)), gen.Size{})
+
+	scfg, path := recordShrinkPath(config, val)
+	min, steps := shrinkCounterexample(scfg, "ex#1", val, shrink, func(_ string, v int) bool {
+		return v < 10
+	})
+	if calls != steps {
+		t.Errorf("OnShrink called %d times for %d steps", calls, steps)
+	}
+	got := path()
+	if len(got) != len(accepted)+1 {
+		t.Fatalf("path has %d entries for %d accepted steps: %v", len(got), len(accepted), got)
+	}
+	if got[0] != (shrinkStep{Step: 0, Value: val}) {
+		t.Errorf("path starts at %v, expected the original %d", got[0], val)
+	}
+	for i, s := range got[1:] {
+		if s.Step != accepted[i] {
+			t.Errorf("path entry %d has step %d, expected %d", i+1, s.Step, accepted[i])
+		}
+		if v := s.Value.(int); v < 10 {
+			t.Errorf("path entry %d holds passing value %d", i+1, v)
+		}
+	}
+	if last := got[len(got)-1].Value; last != min {
+		t.Errorf("path ends at %v, shrinkCounterexample() = %d", last, min)
+	}
+
+	text := shrinkPathText(got, goString)
+	if !strings.Contains(text, fmt.Sprintf("original: %d", val)) || !strings.HasSuffix(text, fmt.Sprint(min)) {
+		t.Errorf("shrinkPathText() = %q", text)
+	}
+}
+
+// TestRecordShrinkPath_Disabled checks that nothing is recorded by default.
+func TestRecordShrinkPath_Disabled(t *testing.T) {
+	scfg, path := recordShrinkPath(Config{MaxShrink: 100}, 500)
+	if scfg.OnShrink != nil {
+		t.Error("OnShrink installed without RecordShrinkPath")
+	}
+	if p := path(); p != nil {
+		t.Errorf("path() = %v, expected nil", p)
+	}
+	if text := shrinkPathText(nil, goString); text != "" {
+		t.Errorf("shrinkPathText(nil) = %q, expected \"\"", text)
+	}
+}
+
+// TestShrinkCounterexample_OnShrink checks that the callback sees every shrink
+// attempt, in order, with the verdict used by the loop.
+func TestShrinkCounterexample_OnShrink(t *testing.T) {
+	type call struct {
+		step      int
+		candidate int
+		accepted  bool
+	}
+	var calls []call
+	config := Config{MaxShrink: 100, OnShrink: func(step int, candidate any, accepted bool) {
+		calls = append(calls, call{step, candidate.(int), accepted})
+	}}
+	gen.SetShrinkStrategy("bfs")
+	val, shrink := gen.IntRange(0, 1000).Generate(rand.New(rand.NewSource(123)), gen.Size{})
+
+	min, steps := shrinkCounterexample(config, "ex#1", val, shrink, func(_ string, v int) bool {
+		return v < 10
+	})
+	if len(calls) != steps {
+		t.Fatalf("OnShrink called %d times for %d steps", len(calls), steps)
+	}
+	last := val
+	for i, c := range calls {
+		if c.step != i+1 {
+			t.Errorf("call %d has step %d, expected %d", i, c.step, i+1)
+		}
+		if c.accepted != (c.candidate >= 10) {
+			t.Errorf("step %d: candidate %d reported accepted=%v", c.step, c.candidate, c.accepted)
+		}
+		if c.accepted {
+			last = c.candidate
+		}
+	}
+	if last != min {
+		t.Errorf("last accepted candidate %d, shrinkCounterexample() = %d", last, min)
+	}
+}
+
+// TestGenerate_RecoversPanic verifies that a panicking generator is turned into
+// an error that identifies the generator, the seed and the example.
+func TestGenerate_RecoversPanic(t *testing.T) {
+	boom := errors.New("boom")
+	g := gen.Label(gen.From(func(r *rand.Rand, sz gen.Size) (int, gen.Shrinker[int]) {
+		panic(boom)
+	}), "exploding")
+
+	r := rand.New(rand.NewSource(1))
+	_, _, err := generate(g, r, gen.Size{}, 777, 4)
+	if err == nil {
+		t.Fatal("generate() returned nil error for panicking generator")
+	}
+	if !errors.Is(err, boom) {
+		t.Errorf("generate() error does not wrap the panic value: %v", err)
+	}
+	for _, want := range []string{"exploding", "seed=777", "example=5"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("generate() error %q does not mention %q", err, want)
+		}
+	}
+}
+
+// TestGenerate_NonErrorPanic verifies that non-error panic values are reported.
+func TestGenerate_NonErrorPanic(t *testing.T) {
+	g := gen.From(func(r *rand.Rand, sz gen.Size) (int, gen.Shrinker[int]) {
+		var s []int
+		return s[3], nil
+	})
+
+	r := rand.New(rand.NewSource(1))
+	_, _, err := generate(g, r, gen.Size{}, 1, 0)
+	if err == nil {
+		t.Fatal("generate() returned nil error for panicking generator")
+	}
+	if !strings.Contains(err.Error(), "index out of range") {
+		t.Errorf("generate() error %q does not include the panic message", err)
+	}
+}
+
+// TestGenerate_NoPanic verifies that well-behaved generators pass through.
+func TestGenerate_NoPanic(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	val, shrink, err := generate(gen.Const(5), r, gen.Size{}, 1, 0)
+	if err != nil {
+		t.Fatalf("generate() returned unexpected error: %v", err)
+	}
+	if val != 5 || shrink == nil {
+		t.Errorf("generate() = (%d, %v), expected (5, non-nil shrinker)", val, shrink != nil)
+	}
+}
+
+// TestForAllN verifies that ForAllN runs exactly n examples.
+func TestForAllN(t *testing.T) {
+	var calls atomic.Int64
+	ForAllN(t, 7, gen.IntRange(0, 10), func(t *testing.T, x int) {
+		calls.Add(1)
+		if x < 0 || x > 10 {
+			t.Errorf("Value %d is outside expected range", x)
+		}
+	})
+	if got := calls.Load(); got != 7 {
+		t.Errorf("ForAllN() ran %d examples, expected 7", got)
+	}
+}
+
+// TestAutoParallelism verifies that the automatic worker count follows
+// GOMAXPROCS, capped at maxAutoParallelism.
+func TestAutoParallelism(t *testing.T) {
+	want := runtime.GOMAXPROCS(0)
+	if want > maxAutoParallelism {
+		want = maxAutoParallelism
+	}
+	if got := AutoParallelism(); got != want {
+		t.Errorf("AutoParallelism() = %d, expected %d", got, want)
+	}
+
+	if got := resolveParallelism(0); got != want {
+		t.Errorf("resolveParallelism(0) = %d, expected %d", got, want)
+	}
+	if got := resolveParallelism(1); got != 1 {
+		t.Errorf("resolveParallelism(1) = %d, expected 1", got)
+	}
+	if got := resolveParallelism(3); got != 3 {
+		t.Errorf("resolveParallelism(3) = %d, expected 3", got)
+	}
+
+	// the run logs the resolved worker count, not 0
+	var logged string
+	cfg, _ := startRun(func(format string, args ...any) { logged = fmt.Sprintf(format, args...) }, Config{Seed: 1, ShrinkStrat: "bfs"})
+	if cfg.Parallelism != want {
+		t.Errorf("startRun(Parallelism=0) resolved %d workers, expected %d", cfg.Parallelism, want)
+	}
+	if suffix := fmt.Sprintf(" parallelism=%d", want); !strings.HasSuffix(logged, suffix) {
+		t.Errorf("startRun(Parallelism=0) logged %q, expected it to end with %q", logged, suffix)
+	}
+
+	prev := runtime.GOMAXPROCS(64)
+	defer runtime.GOMAXPROCS(prev)
+	if got := AutoParallelism(); got != maxAutoParallelism {
+		t.Errorf("AutoParallelism() with GOMAXPROCS=64 = %d, expected %d", got, maxAutoParallelism)
+	}
+}
+
+func TestConfig_With(t *testing.T) {
+	base := Config{Seed: 1, Examples: 10, MaxShrink: 20, ShrinkStrat: "dfs", Parallelism: 2}
+
+	got := base.WithSeed(42).WithExamples(7).WithMaxShrink(3)
+
+	if got.Seed != 42 || got.Examples != 7 || got.MaxShrink != 3 {
+		t.Errorf("WithSeed/WithExamples/WithMaxShrink = %+v, expected seed=42 examples=7 maxshrink=3", got)
+	}
+	if got.ShrinkStrat != "dfs" || got.Parallelism != 2 {
+		t.Errorf("other fields not preserved: %+v", got)
+	}
+	// the receiver is a copy: base must be unchanged
+	if base.Seed != 1 || base.Examples != 10 || base.MaxShrink != 20 {
+		t.Errorf("base config modified: %+v", base)
+	}
+}
+
+func TestGenerate_MaxGeneratedSize(t *testing.T) {
+	cfg := Config{MaxGeneratedSize: 100}
+	g := gen.SliceOf(gen.StringAlpha(gen.Size{Min: 20, Max: 20}), gen.Size{Min: 10, Max: 10})
+	r := rand.New(rand.NewSource(1))
+
+	_, _, err := generate(g, r, cfg.exampleSize(nil), 42, 0)
+	if err == nil {
+		t.Fatal("generate() over MaxGeneratedSize returned nil error")
+	}
+	var be *gen.BudgetExceededError
+	if !errors.As(err, &be) {
+		t.Errorf("generate() error does not wrap *gen.BudgetExceededError: %v", err)
+	}
+	if !strings.Contains(err.Error(), "MaxGeneratedSize=100") || !strings.Contains(err.Error(), "-propx.seed=42") {
+		t.Errorf("generate() error %q lacks the limit or replay seed", err)
+	}
+
+	// within the limit, and unlimited by default
+	if _, _, err := generate(g, r, Config{MaxGeneratedSize: 1000}.exampleSize(nil), 42, 0); err != nil {
+		t.Errorf("generate() within MaxGeneratedSize returned %v", err)
+	}
+	if _, _, err := generate(g, r, Config{}.exampleSize(nil), 42, 0); err != nil {
+		t.Errorf("generate() without MaxGeneratedSize returned %v", err)
+	}
+}
+
+func TestExampleFeed_Shuffle(t *testing.T) {
+	g := gen.IntRange(0, 1_000_000)
+	order := func(shuffle bool, seed int64) (idx []int, vals []int) {
+		cfg := Config{Examples: 20, ShuffleExamples: shuffle}
+		next := exampleFeed(cfg, g, seed, nil)
+		for pos := 0; pos < cfg.Examples; pos++ {
+			i, v, _, err := next(pos)
+			if err != nil {
+				t.Fatalf("exampleFeed() error: %v", err)
+			}
+			idx, vals = append(idx, i), append(vals, v)
+		}
+		return idx, vals
+	}
+
+	plainIdx, plainVals := order(false, 7)
+	shufIdx, shufVals := order(true, 7)
+
+	identity := true
+	for pos, i := range shufIdx {
+		identity = identity && i == pos
+		// the same generation index yields the same value as without shuffling
+		if shufVals[pos] != plainVals[i] {
+			t.Errorf("shuffled example %d = %d, expected %d", i, shufVals[pos], plainVals[i])
+		}
+		if plainIdx[pos] != pos {
+			t.Errorf("unshuffled position %d has index %d", pos, plainIdx[pos])
+		}
+	}
+	if identity {
+		t.Error("ShuffleExamples did not change the order")
+	}
+
+	again, _ := order(true, 7)
+	if fmt.Sprint(again) != fmt.Sprint(shufIdx) {
+		t.Errorf("shuffled order not reproducible: %v vs %v", again, shufIdx)
+	}
+}
+
+func TestExampleFeed_ShuffleGeneratorPanic(t *testing.T) {
+	g := gen.From(func(*rand.Rand, gen.Size) (int, gen.Shrinker[int]) { panic("boom") })
+	next := exampleFeed(Config{Examples: 3, ShuffleExamples: true}, g, 1, nil)
+	if _, _, _, err := next(0); err == nil {
+		t.Error("exampleFeed() returned nil error for a panicking generator")
+	}
+}
+
+func TestForAll_ShuffleExamples(t *testing.T) {
+	for _, parallelism := range []int{1, 4} {
+		var count atomic.Int64
+		cfg := Config{Seed: 3, Examples: 30, MaxShrink: 10, ShrinkStrat: "bfs", Parallelism: parallelism, ShuffleExamples: true}
+		ForAll(t, cfg, gen.IntRange(0, 100))(func(t *testing.T, x int) {
+			count.Add(1)
+		})
+		if count.Load() != 30 {
+			t.Errorf("ForAll(ShuffleExamples, parallelism=%d) ran %d examples, expected 30", parallelism, count.Load())
+		}
+	}
+}
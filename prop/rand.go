@@ -0,0 +1,58 @@
+package prop
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"arcsyn.io/propx/gen"
+)
+
+// randExample pairs a generated value with the seed of its auxiliary random source.
+type randExample[T any] struct {
+	Value T
+	Seed  int64
+}
+
+// GoString renders the value followed by its auxiliary seed in failure reports.
+func (e randExample[T]) GoString() string { return e.renderValues(goString) }
+
+// renderValues renders the value with value, followed by the seed.
+func (e randExample[T]) renderValues(value func(any) string) string {
+	return fmt.Sprintf("%s (rand seed %d)", value(e.Value), e.Seed)
+}
+
+// ForAllRand is like ForAll, but the property also receives a *rand.Rand for
+// auxiliary random choices (e.g. picking a sub-operation). The source is
+// derived from the run's seed and is specific to each example: every run of the
+// same example (including its shrink attempts) receives a fresh source with the
+// same seed, so failures stay reproducible with -propx.seed.
+//
+// Example usage:
+//
+//	prop.ForAllRand(t, prop.Default(), gen.SliceOf(gen.Int(gen.Size{}), gen.Size{}))(
+//	    func(t *testing.T, xs []int, r *rand.Rand) {
+//	        r.Shuffle(len(xs), func(i, j int) { xs[i], xs[j] = xs[j], xs[i] })
+//	        // ...
+//	    })
+func ForAllRand[T any](t *testing.T, cfg Config, g gen.Generator[T]) func(func(*testing.T, T, *rand.Rand)) {
+	withSeed := gen.From(func(r *rand.Rand, sz gen.Size) (randExample[T], gen.Shrinker[randExample[T]]) {
+		v, s := g.Generate(r, sz)
+		seed := r.Int63()
+		return randExample[T]{Value: v, Seed: seed}, func(accept bool) (randExample[T], bool) {
+			if s == nil {
+				return randExample[T]{}, false
+			}
+			nv, ok := s(accept)
+			if !ok {
+				return randExample[T]{}, false
+			}
+			return randExample[T]{Value: nv, Seed: seed}, true
+		}
+	})
+	return func(body func(*testing.T, T, *rand.Rand)) {
+		ForAll(t, cfg, withSeed)(func(t *testing.T, e randExample[T]) {
+			body(t, e.Value, rand.New(rand.NewSource(e.Seed))) // #nosec G404 -- Using math/rand for deterministic property-based testing
+		})
+	}
+}
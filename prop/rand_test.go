@@ -0,0 +1,59 @@
+package prop
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"arcsyn.io/propx/gen"
+)
+
+func TestForAllRand_ProvidesSource(t *testing.T) {
+	config := Config{
+		Seed:        12345,
+		Examples:    5,
+		MaxShrink:   10,
+		ShrinkStrat: "bfs",
+		Parallelism: 1,
+	}
+
+	ForAllRand(t, config, gen.IntRange(0, 10))(func(t *testing.T, x int, r *rand.Rand) {
+		if r == nil {
+			t.Fatal("ForAllRand() passed a nil *rand.Rand")
+		}
+		if x < 0 || x > 10 {
+			t.Errorf("Value %d is outside expected range", x)
+		}
+	})
+}
+
+func TestForAllRand_Reproducible(t *testing.T) {
+	config := Config{
+		Seed:        777,
+		Examples:    5,
+		MaxShrink:   10,
+		ShrinkStrat: "bfs",
+		Parallelism: 1,
+	}
+
+	record := func() []string {
+		var draws []string
+		ForAllRand(t, config, gen.IntRange(0, 100))(func(t *testing.T, x int, r *rand.Rand) {
+			draws = append(draws, fmt.Sprintf("%d:%d", x, r.Int63()))
+		})
+		return draws
+	}
+
+	first, second := record(), record()
+	if strings.Join(first, ",") != strings.Join(second, ",") {
+		t.Errorf("ForAllRand() with the same seed drew %v then %v", first, second)
+	}
+}
+
+func TestRandExample_GoString(t *testing.T) {
+	e := randExample[int]{Value: 42, Seed: 9}
+	if got := fmt.Sprintf("%#v", e); got != "42 (rand seed 9)" {
+		t.Errorf("randExample GoString = %q, expected '42 (rand seed 9)'", got)
+	}
+}
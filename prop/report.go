@@ -0,0 +1,117 @@
+package prop
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// Failure report formats accepted by Config.ReportFormat.
+const (
+	// ReportText reports failures as a human-readable message (the default).
+	ReportText = "text"
+
+	// ReportJSON reports each failure as a single JSON object on one log line.
+	ReportJSON = "json"
+)
+
+// jsonFailure is the JSON failure report. Values that cannot be encoded as
+// JSON are reported as their %#v string.
+type jsonFailure struct {
+	Test        string           `json:"test"`
+	Seed        int64            `json:"seed"`
+	Example     int              `json:"example"`
+	ExamplesRun int              `json:"examples_run"`
+	Original    json.RawMessage  `json:"original"`
+	Shrunk      json.RawMessage  `json:"shrunk"`
+	ShrinkSteps int              `json:"shrink_steps"`
+	ShrinkPath  []jsonShrinkStep `json:"shrink_path,omitempty"`
+	Notes       []string         `json:"notes,omitempty"`
+	ElapsedMS   float64          `json:"elapsed_ms"`
+	Replay      string           `json:"replay"`
+}
+
+// jsonShrinkStep is an accepted step of the shrink path in the JSON report.
+type jsonShrinkStep struct {
+	Step  int             `json:"step"`
+	Value json.RawMessage `json:"value"`
+}
+
+// reportFailure fails t with the report of a shrunk counterexample, in the
+// format selected by cfg.ReportFormat.
+func reportFailure(t *testing.T, cfg Config, seed int64, examplesRun int, f failureResult, elapsed time.Duration) {
+	t.Helper()
+	if cfg.ReportFormat != ReportJSON {
+		t.Fatal(failureMessage(t, valueRenderer(cfg.Stringer), seed, examplesRun, f.name, f.steps, f.min, f.path) + notesText(f.notes))
+		return
+	}
+	t.Log(jsonFailureReport(t, seed, examplesRun, f, elapsed))
+	t.FailNow()
+}
+
+// jsonFailureReport encodes the failure as a single-line JSON object.
+func jsonFailureReport(t *testing.T, seed int64, examplesRun int, f failureResult, elapsed time.Duration) string {
+	var path []jsonShrinkStep
+	for _, s := range f.path {
+		path = append(path, jsonShrinkStep{Step: s.Step, Value: jsonValue(s.Value)})
+	}
+	data, err := json.Marshal(jsonFailure{
+		Test:        t.Name(),
+		Seed:        seed,
+		Example:     f.testIndex + 1,
+		ExamplesRun: examplesRun,
+		Original:    jsonValue(f.orig),
+		Shrunk:      jsonValue(f.min),
+		ShrinkSteps: f.steps,
+		ShrinkPath:  path,
+		Notes:       f.notes,
+		ElapsedMS:   float64(elapsed.Microseconds()) / 1000,
+		Replay:      replayCommand(t, f.name, seed),
+	})
+	if err != nil {
+		// unreachable: every field is encodable
+		return fmt.Sprintf(`{"error":%q}`, err.Error())
+	}
+	return string(data)
+}
+
+// reportValues is implemented by the examples holding several values
+// (the arguments of ForAll3 to ForAll5, the examples of ForAllErr and
+// ForAllRand), which render each of their values with value.
+type reportValues interface {
+	renderValues(value func(any) string) string
+}
+
+// valueRenderer returns the function rendering values in text failure
+// reports: with stringer (Config.Stringer) when it handles them, with %#v
+// otherwise.
+func valueRenderer(stringer func(any) (string, bool)) func(any) string {
+	var render func(any) string
+	render = func(v any) string {
+		if rv, ok := v.(reportValues); ok {
+			return rv.renderValues(render)
+		}
+		if stringer != nil {
+			if s, ok := stringer(v); ok {
+				return s
+			}
+		}
+		return goString(v)
+	}
+	return render
+}
+
+// goString renders v with %#v.
+func goString(v any) string {
+	return fmt.Sprintf("%#v", v)
+}
+
+// jsonValue encodes v as JSON, falling back to its %#v string.
+func jsonValue(v any) json.RawMessage {
+	if data, err := json.Marshal(v); err == nil {
+		return data
+	}
+	data, _ := json.Marshal(goString(v))
+	return data
+}
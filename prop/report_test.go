@@ -0,0 +1,139 @@
+package prop
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestJSONFailureReport(t *testing.T) {
+	f := failureResult{testIndex: 4, name: "ex#5", orig: []int{9, 8, 7}, min: []int{1}, steps: 12}
+	line := jsonFailureReport(t, 42, 5, f, 1500*time.Microsecond)
+
+	if strings.Contains(line, "\n") {
+		t.Errorf("report spans several lines: %q", line)
+	}
+	var got map[string]any
+	if err := json.Unmarshal([]byte(line), &got); err != nil {
+		t.Fatalf("report is not valid JSON: %v\n%s", err, line)
+	}
+	want := map[string]any{
+		"test":         t.Name(),
+		"seed":         42.0,
+		"example":      5.0,
+		"examples_run": 5.0,
+		"original":     []any{9.0, 8.0, 7.0},
+		"shrunk":       []any{1.0},
+		"shrink_steps": 12.0,
+		"elapsed_ms":   1.5,
+		"replay":       "go test -run '^TestJSONFailureReport$/ex#5(/|$)' -propx.seed=42",
+	}
+	for k, w := range want {
+		if g, ok := got[k]; !ok || !jsonEqual(g, w) {
+			t.Errorf("report[%q] = %v, expected %v", k, got[k], w)
+		}
+	}
+}
+
+func TestJSONFailureReport_UnencodableValues(t *testing.T) {
+	type node struct{ Next func() }
+	f := failureResult{name: "ex#1", orig: node{}, min: make(chan int), steps: 0}
+	line := jsonFailureReport(t, 1, 1, f, 0)
+
+	var got struct {
+		Original string `json:"original"`
+		Shrunk   string `json:"shrunk"`
+	}
+	if err := json.Unmarshal([]byte(line), &got); err != nil {
+		t.Fatalf("report is not valid JSON: %v\n%s", err, line)
+	}
+	if !strings.Contains(got.Original, "prop.node{Next:") {
+		t.Errorf("original = %q, expected %%#v fallback", got.Original)
+	}
+	if !strings.HasPrefix(got.Shrunk, "(chan int)") {
+		t.Errorf("shrunk = %q, expected %%#v fallback", got.Shrunk)
+	}
+}
+
+func TestJSONFailureReport_ShrinkPath(t *testing.T) {
+	f := failureResult{name: "ex#1", orig: 90, min: 10, steps: 7,
+		path: []shrinkStep{{Step: 0, Value: 90}, {Step: 2, Value: 45}, {Step: 7, Value: 10}}}
+	var got map[string]any
+	if err := json.Unmarshal([]byte(jsonFailureReport(t, 1, 1, f, 0)), &got); err != nil {
+		t.Fatal(err)
+	}
+	want := []any{
+		map[string]any{"step": 0.0, "value": 90.0},
+		map[string]any{"step": 2.0, "value": 45.0},
+		map[string]any{"step": 7.0, "value": 10.0},
+	}
+	if !jsonEqual(got["shrink_path"], want) {
+		t.Errorf("shrink_path = %v, expected %v", got["shrink_path"], want)
+	}
+
+	f.path = nil
+	got = nil
+	if err := json.Unmarshal([]byte(jsonFailureReport(t, 1, 1, f, 0)), &got); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := got["shrink_path"]; ok {
+		t.Error("shrink_path reported without RecordShrinkPath")
+	}
+}
+
+func jsonEqual(a, b any) bool {
+	da, _ := json.Marshal(a)
+	db, _ := json.Marshal(b)
+	return string(da) == string(db)
+}
+
+func TestFailureMessage_Stringer(t *testing.T) {
+	type user struct {
+		ID    int
+		Email string
+	}
+	stringer := func(v any) (string, bool) {
+		if u, ok := v.(user); ok {
+			return fmt.Sprintf("user#%d", u.ID), true
+		}
+		return "", false
+	}
+	render := valueRenderer(stringer)
+
+	msg := failureMessage(t, render, 1, 1, "ex#1", 2, user{ID: 7, Email: "a@b.c"},
+		[]shrinkStep{{Step: 0, Value: user{ID: 9}}, {Step: 2, Value: user{ID: 7}}})
+	for _, want := range []string{"counterexample (min): user#7\n", "original: user#9", "step 2: user#7"} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("failureMessage() = %q, expected it to contain %q", msg, want)
+		}
+	}
+	if strings.Contains(msg, "a@b.c") {
+		t.Errorf("failureMessage() = %q, rendered the value with %%#v", msg)
+	}
+
+	// values the stringer declines, and the values inside examples, one by one
+	err := errors.New("boom")
+	tests := []struct {
+		v    any
+		want string
+	}{
+		{[]int{1}, "[]int{1}"},
+		{args3[user, int, string]{user{ID: 1}, 2, "x"}, `(user#1, 2, "x")`},
+		{args5[int, user, int, int, user]{args4[int, user, int, int]{args3[int, user, int]{1, user{ID: 2}, 3}, 4}, user{ID: 5}}, "(1, user#2, 3, 4, user#5)"},
+		{errExample[user]{Value: user{ID: 3}, err: &err}, "user#3 (error: boom)"},
+		{randExample[user]{Value: user{ID: 4}, Seed: 9}, "user#4 (rand seed 9)"},
+	}
+	for _, tt := range tests {
+		if got := render(tt.v); got != tt.want {
+			t.Errorf("render(%#v) = %q, expected %q", tt.v, got, tt.want)
+		}
+	}
+
+	// without a stringer, values keep their %#v rendering
+	if got, want := valueRenderer(nil)(user{ID: 7}), fmt.Sprintf("%#v", user{ID: 7}); got != want {
+		t.Errorf("render without Stringer = %q, expected %q", got, want)
+	}
+}
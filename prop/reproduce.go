@@ -0,0 +1,49 @@
+package prop
+
+import (
+	"math/rand"
+
+	"arcsyn.io/propx/gen"
+)
+
+// ExampleSeed returns the seed of the random source that generates the
+// example at index (from 0) of a run with the given seed. Every example has
+// its own source, so its value depends only on the run seed and its index:
+// not on the examples before it, the shrinking of earlier failures, or the
+// order in which parallel workers generate examples.
+func ExampleSeed(seed int64, index int) int64 {
+	// splitmix64 finalizer, so neighboring indexes get unrelated seeds
+	z := uint64(seed) + uint64(index+1)*0x9e3779b97f4a7c15
+	z = (z ^ z>>30) * 0xbf58476d1ce4e5b9
+	z = (z ^ z>>27) * 0x94d049bb133111eb
+	return int64(z ^ z>>31)
+}
+
+// exampleRand returns the random source of the example at index.
+func exampleRand(seed int64, index int) *rand.Rand {
+	return rand.New(rand.NewSource(ExampleSeed(seed, index))) // #nosec G404 -- Using math/rand for deterministic property-based testing
+}
+
+// Reproduce regenerates the original (unshrunk) input of example number n of
+// a ForAll run with the given seed: the N of the failing subtest ex#N and the
+// seed of the failure report (also "example" and "seed" in ReportJSON). Use it
+// to step through a failure in a debugger, from a focused test or a
+// standalone main, without the runner:
+//
+//	func TestCheckoutExample(t *testing.T) {
+//	    order := prop.Reproduce(1712345, 37, orderGen)
+//	    checkout(order) // set a breakpoint here
+//	}
+//
+// The replay command of the report (-propx.seed with -run selecting ex#N)
+// reruns the same example through ForAll, shrinking included; Reproduce only
+// regenerates it. g must be the generator given to ForAll. Examples replayed
+// from Config.GoldenFile were recorded, not generated from the seed, so
+// Reproduce does not apply to them. Panics if n < 1.
+func Reproduce[T any](seed int64, n int, g gen.Generator[T]) T {
+	if n < 1 {
+		panic("prop.Reproduce: example numbers start at 1")
+	}
+	v, _ := g.Generate(exampleRand(seed, n-1), gen.Size{})
+	return v
+}
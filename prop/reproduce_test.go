@@ -0,0 +1,61 @@
+package prop
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"arcsyn.io/propx/gen"
+)
+
+func TestReproduce(t *testing.T) {
+	g := gen.SliceOf(gen.IntRange(-100, 100), gen.Size{Max: 8})
+	for _, parallelism := range []int{1, 4} {
+		t.Run(fmt.Sprintf("parallelism=%d", parallelism), func(t *testing.T) {
+			var mu sync.Mutex
+			seen := map[int][]int{}
+			cfg := Config{Seed: 42, Examples: 30, MaxShrink: 10, ShrinkStrat: "bfs", Parallelism: parallelism}
+			ForAll(t, cfg, g)(func(t *testing.T, xs []int) {
+				var n int
+				if _, err := fmt.Sscanf(t.Name()[strings.LastIndex(t.Name(), "#")+1:], "%d", &n); err != nil {
+					t.Fatal(err)
+				}
+				mu.Lock()
+				seen[n] = xs
+				mu.Unlock()
+			})
+			if len(seen) != cfg.Examples {
+				t.Fatalf("recorded %d examples, expected %d", len(seen), cfg.Examples)
+			}
+			for n, xs := range seen {
+				if got := Reproduce(42, n, g); fmt.Sprint(got) != fmt.Sprint(xs) {
+					t.Errorf("Reproduce(42, %d) = %v, ForAll ran %v", n, got, xs)
+				}
+			}
+		})
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Reproduce(42, 0, g) did not panic")
+		}
+	}()
+	Reproduce(42, 0, g)
+}
+
+func TestExampleSeed(t *testing.T) {
+	seeds := map[int64]bool{}
+	for _, seed := range []int64{0, 1, 2, -1} {
+		for i := 0; i < 100; i++ {
+			s := ExampleSeed(seed, i)
+			if s != ExampleSeed(seed, i) {
+				t.Fatalf("ExampleSeed(%d, %d) is not deterministic", seed, i)
+			}
+			seeds[s] = true
+		}
+	}
+	if len(seeds) != 400 {
+		t.Errorf("ExampleSeed gave %d distinct seeds for 400 (seed, index) pairs", len(seeds))
+	}
+}
@@ -424,3 +424,62 @@ func TestCommandSequenceGeneratorSizeConstraints(t *testing.T) {
 		}
 	}
 }
+
+// TestCommandGenArgs tests that GenArgs draws commands from the model state
+// reached by the commands before them.
+func TestCommandGenArgs(t *testing.T) {
+	// positive amounts are deposits, negative ones withdrawals
+	execute := func(balance, amount int) (int, error) {
+		if balance+amount < 0 {
+			return balance, errors.New("insufficient funds")
+		}
+		return balance + amount, nil
+	}
+	precondition := func(balance, amount int) bool { return balance+amount >= 0 }
+
+	sm := StateMachine[int, int]{
+		InitialState: 50,
+		Commands: []Command[int, int]{
+			{
+				Name:         "deposit",
+				Generator:    gen.IntRange(1, 100),
+				Execute:      execute,
+				Precondition: precondition,
+			},
+			{
+				Name: "withdraw",
+				GenArgs: func(balance int) gen.Generator[int] {
+					return gen.IntRange(-balance, 0)
+				},
+				Execute:      execute,
+				Precondition: precondition,
+			},
+		},
+	}
+
+	cmd := commandSequenceGenerator[int, int]{
+		stateMachine: sm,
+		maxLength:    20,
+	}
+
+	r := rand.New(rand.NewSource(12345))
+	withdrawals := 0
+	for i := 0; i < 100; i++ {
+		sequence, _ := cmd.Generate(r, gen.Size{})
+		result := executeStateMachine(sm, sequence)
+		if len(result.SkippedCommands) != 0 {
+			t.Fatalf("commands %v skipped in sequence %v", result.SkippedCommands, sequence.Commands)
+		}
+		for _, tr := range result.ExecutionHistory {
+			if tr.Error != nil {
+				t.Fatalf("unexpected error for command %d from %d: %v", tr.Command, tr.FromState, tr.Error)
+			}
+			if tr.Command < 0 {
+				withdrawals++
+			}
+		}
+	}
+	if withdrawals == 0 {
+		t.Error("expected some withdrawals to be generated")
+	}
+}
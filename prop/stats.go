@@ -0,0 +1,94 @@
+package prop
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// runStats accumulates timing information for a ForAll run. Durations are
+// summed across workers, so with Parallelism > 1 generation + property time
+// can exceed the elapsed wall-clock time. All methods are safe for concurrent
+// use and a nil *runStats ignores every call.
+type runStats struct {
+	start    time.Time
+	examples atomic.Int64
+	genNanos atomic.Int64
+	runNanos atomic.Int64
+	discards atomic.Int64
+
+	// diagnostics records the discards by source with
+	// Config.DiscardDiagnostics, nil otherwise
+	diagnostics *discardLog
+}
+
+// newRunStats starts the wall clock of a run.
+func newRunStats() *runStats {
+	return &runStats{start: time.Now()}
+}
+
+// addGenerate records the time spent generating one example.
+func (s *runStats) addGenerate(d time.Duration) {
+	if s == nil {
+		return
+	}
+	s.genNanos.Add(int64(d))
+}
+
+// addProperty records the time spent evaluating the property on one example.
+func (s *runStats) addProperty(d time.Duration) {
+	if s == nil {
+		return
+	}
+	s.examples.Add(1)
+	s.runNanos.Add(int64(d))
+}
+
+// addDiscard records an example dropped with Discard.
+func (s *runStats) addDiscard() {
+	if s == nil {
+		return
+	}
+	s.discards.Add(1)
+}
+
+// discardDiagnostics returns the run's discard log, nil without
+// Config.DiscardDiagnostics.
+func (s *runStats) discardDiagnostics() *discardLog {
+	if s == nil {
+		return nil
+	}
+	return s.diagnostics
+}
+
+// elapsed returns the wall-clock time since the run started.
+func (s *runStats) elapsed() time.Duration {
+	if s == nil {
+		return 0
+	}
+	return time.Since(s.start)
+}
+
+// report formats elapsed time, throughput and the generation vs. property
+// evaluation breakdown.
+func (s *runStats) report(elapsed time.Duration) string {
+	n := s.examples.Load()
+	gen := time.Duration(s.genNanos.Load())
+	run := time.Duration(s.runNanos.Load())
+
+	rate := 0.0
+	if elapsed > 0 {
+		rate = float64(n) / elapsed.Seconds()
+	}
+	genPct, runPct := 0.0, 0.0
+	if total := gen + run; total > 0 {
+		genPct = 100 * float64(gen) / float64(total)
+		runPct = 100 * float64(run) / float64(total)
+	}
+	discarded := ""
+	if d := s.discards.Load(); d > 0 {
+		discarded = fmt.Sprintf(" discarded=%d", d)
+	}
+	return fmt.Sprintf("[propx] passed; examples=%d%s elapsed=%s rate=%.1f/s generate=%s (%.0f%%) property=%s (%.0f%%)",
+		n, discarded, elapsed.Round(time.Microsecond), rate, gen.Round(time.Microsecond), genPct, run.Round(time.Microsecond), runPct)
+}
@@ -0,0 +1,49 @@
+package prop
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"arcsyn.io/propx/gen"
+)
+
+func TestRunStats_Report(t *testing.T) {
+	s := newRunStats()
+	for i := 0; i < 4; i++ {
+		s.addGenerate(10 * time.Millisecond)
+		s.addProperty(30 * time.Millisecond)
+	}
+
+	got := s.report(2 * time.Second)
+
+	for _, want := range []string{"examples=4", "elapsed=2s", "rate=2.0/s", "generate=40ms (25%)", "property=120ms (75%)"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("report() = %q, expected it to contain %q", got, want)
+		}
+	}
+}
+
+func TestRunStats_NilIsNoop(t *testing.T) {
+	var s *runStats
+	s.addGenerate(time.Second)
+	s.addProperty(time.Second)
+}
+
+func TestRunStats_EmptyReport(t *testing.T) {
+	got := newRunStats().report(0)
+	if !strings.Contains(got, "examples=0") || !strings.Contains(got, "rate=0.0/s") {
+		t.Errorf("report() = %q, expected zero examples and rate", got)
+	}
+}
+
+func TestForAll_Verbose(t *testing.T) {
+	for _, parallelism := range []int{1, 4} {
+		cfg := Config{Seed: 1, Examples: 20, MaxShrink: 10, ShrinkStrat: "bfs", Parallelism: parallelism, Verbose: true}
+		ForAll(t, cfg, gen.Int(gen.Size{Max: 100}))(func(t *testing.T, x int) {
+			if x+0 != x {
+				t.Errorf("identity failed for %d", x)
+			}
+		})
+	}
+}
@@ -23,13 +23,17 @@
 package propx
 
 import (
+	"flag"
+	"iter"
 	"math/rand"
 	"testing"
+	"time"
 
 	"arcsyn.io/propx/gen"
 	"arcsyn.io/propx/gen/domain"
 	"arcsyn.io/propx/prop"
 	"arcsyn.io/propx/quick"
+	"github.com/google/go-cmp/cmp"
 )
 
 // =============================================================================
@@ -46,6 +50,12 @@ func Default() Config {
 	return prop.Default()
 }
 
+// BindFlags registers the propx flags on a custom flag set; Default applies
+// the ones set explicitly once fs is parsed.
+func BindFlags(fs *flag.FlagSet) {
+	prop.BindFlags(fs)
+}
+
 // ForAll runs a property-based test with the given configuration and generator.
 // It generates test cases using the provided generator and runs the property
 // function for each generated value. If a counterexample is found, it will
@@ -68,6 +78,109 @@ func ForAll[T any](t *testing.T, cfg Config, g gen.Generator[T]) func(func(*test
 	return prop.ForAll(t, cfg, g)
 }
 
+// ForAllN is shorthand for ForAll with Default() configuration and n examples.
+func ForAllN[T any](t *testing.T, n int, g gen.Generator[T], property func(*testing.T, T)) {
+	prop.ForAllN(t, n, g, property)
+}
+
+// NamedGenerator pairs a generator with the subtest name ForAllMatrix gives it.
+type NamedGenerator[T any] = prop.NamedGenerator[T]
+
+// ForAllMatrix runs ForAll once per generator, each in a subtest named after
+// it, to check a property across several input distributions.
+func ForAllMatrix[T any](t *testing.T, cfg Config, gens []NamedGenerator[T], property func(*testing.T, T)) {
+	t.Helper()
+	prop.ForAllMatrix(t, cfg, gens, property)
+}
+
+// ForAll3 is ForAll for properties of three independently generated
+// arguments, reported together as "(a, b, c)".
+func ForAll3[A, B, C any](t *testing.T, cfg Config, ga gen.Generator[A], gb gen.Generator[B], gc gen.Generator[C]) func(func(*testing.T, A, B, C)) {
+	return prop.ForAll3(t, cfg, ga, gb, gc)
+}
+
+// ForAll4 is ForAll3 for properties of four arguments.
+func ForAll4[A, B, C, D any](t *testing.T, cfg Config, ga gen.Generator[A], gb gen.Generator[B], gc gen.Generator[C], gd gen.Generator[D]) func(func(*testing.T, A, B, C, D)) {
+	return prop.ForAll4(t, cfg, ga, gb, gc, gd)
+}
+
+// ForAll5 is ForAll3 for properties of five arguments.
+func ForAll5[A, B, C, D, E any](t *testing.T, cfg Config, ga gen.Generator[A], gb gen.Generator[B], gc gen.Generator[C], gd gen.Generator[D], ge gen.Generator[E]) func(func(*testing.T, A, B, C, D, E)) {
+	return prop.ForAll5(t, cfg, ga, gb, gc, gd, ge)
+}
+
+// Reproduce regenerates the original input of example n (ex#N) of a ForAll
+// run with the given seed, for debugging a failure outside the runner.
+func Reproduce[T any](seed int64, n int, g gen.Generator[T]) T {
+	return prop.Reproduce(seed, n, g)
+}
+
+// ForAllConcurrent runs the property for each generated value from several
+// goroutines at once, to surface data races when running under -race.
+func ForAllConcurrent[T any](t *testing.T, cfg Config, g gen.Generator[T], property func(*testing.T, T)) {
+	prop.ForAllConcurrent(t, cfg, g, property)
+}
+
+// ForAllRand is like ForAll, but the property also receives a per-example
+// *rand.Rand derived from the seed, for reproducible auxiliary randomness.
+func ForAllRand[T any](t *testing.T, cfg Config, g gen.Generator[T]) func(func(*testing.T, T, *rand.Rand)) {
+	return prop.ForAllRand(t, cfg, g)
+}
+
+// ForAllErr is like ForAll for properties that return an error: a non-nil
+// error fails the example and is included in the failure report.
+func ForAllErr[T any](t *testing.T, cfg Config, g gen.Generator[T], property func(T) error) {
+	prop.ForAllErr(t, cfg, g, property)
+}
+
+// Discard drops the current example from inside a property: it counts as
+// discarded rather than passed, up to Config.MaxDiscardRatio of the examples.
+func Discard() {
+	prop.Discard()
+}
+
+// Note attaches a note to the current example from inside a property, printed
+// only if the example ends up as the reported counterexample.
+func Note(t *testing.T, format string, args ...any) {
+	t.Helper()
+	prop.Note(t, format, args...)
+}
+
+// Idempotent checks f(f(x)) == f(x) for every generated x, comparing with go-cmp.
+func Idempotent[T any](t *testing.T, cfg Config, g gen.Generator[T], f func(T) T) {
+	t.Helper()
+	prop.Idempotent(t, cfg, g, f)
+}
+
+// Involutive checks f(f(x)) == x for every generated x, comparing with go-cmp.
+func Involutive[T any](t *testing.T, cfg Config, g gen.Generator[T], f func(T) T) {
+	t.Helper()
+	prop.Involutive(t, cfg, g, f)
+}
+
+// RoundTrip checks decode(encode(x)) == x for every generated x, comparing with go-cmp.
+func RoundTrip[A, B any](t *testing.T, cfg Config, g gen.Generator[A], encode func(A) B, decode func(B) (A, error)) {
+	t.Helper()
+	prop.RoundTrip(t, cfg, g, encode, decode)
+}
+
+// Associative checks op(op(a, b), c) == op(a, op(b, c)) for generated operands, comparing with go-cmp.
+func Associative[T any](t *testing.T, cfg Config, g gen.Generator[T], op func(T, T) T) {
+	t.Helper()
+	prop.Associative(t, cfg, g, op)
+}
+
+// Commutative checks op(a, b) == op(b, a) for generated operands, comparing with go-cmp.
+func Commutative[T any](t *testing.T, cfg Config, g gen.Generator[T], op func(T, T) T) {
+	t.Helper()
+	prop.Commutative(t, cfg, g, op)
+}
+
+// ForEach runs the property over an explicit list of inputs with ForAll-style reporting.
+func ForEach[T any](t *testing.T, cfg Config, inputs []T, property func(*testing.T, T)) {
+	prop.ForEach(t, cfg, inputs, property)
+}
+
 // =============================================================================
 // STATE MACHINE TESTING
 // =============================================================================
@@ -102,6 +215,33 @@ func TestStateMachine[S, C any](t *testing.T, sm StateMachine[S, C], cfg Config)
 	prop.TestStateMachine(t, sm, cfg)
 }
 
+// ParallelHistory records command branches run concurrently, their results
+// and when each command was invoked and returned.
+type ParallelHistory[C, R any] = prop.ParallelHistory[C, R]
+
+// CallSpan is when a command of a ParallelHistory was invoked and returned.
+type CallSpan = prop.CallSpan
+
+// HistoryStep identifies one command of a ParallelHistory.
+type HistoryStep[C any] = prop.HistoryStep[C]
+
+// Linearization is the outcome of CheckLinearizable, with the sequential witness.
+type Linearization[C any] = prop.Linearization[C]
+
+// CheckLinearizable searches, up to cfg.MaxInterleavings interleavings, for a
+// serial order of the history, respecting its real-time order, in which the
+// model reproduces every result.
+func CheckLinearizable[S, C, R any](cfg Config, model func(S, C) (S, R), init S, h ParallelHistory[C, R]) Linearization[C] {
+	return prop.CheckLinearizable(cfg, model, init, h)
+}
+
+// AssertLinearizable logs the sequential witness of a linearizable history and
+// fails t otherwise.
+func AssertLinearizable[S, C, R any](t *testing.T, cfg Config, model func(S, C) (S, R), init S, h ParallelHistory[C, R]) {
+	t.Helper()
+	prop.AssertLinearizable(t, cfg, model, init, h)
+}
+
 // =============================================================================
 // GENERATORS
 // =============================================================================
@@ -109,7 +249,8 @@ func TestStateMachine[S, C any](t *testing.T, sm StateMachine[S, C], cfg Config)
 // Generator is the interface that all generators must implement.
 type Generator[T any] = gen.Generator[T]
 
-// Size controls the scale and limits of generators.
+// Size controls the scale and limits of generators; the zero Size selects each
+// generator's default range.
 type Size = gen.Size
 
 // Shrinker proposes "smaller" candidates during the shrinking process.
@@ -180,11 +321,76 @@ func StringASCII(size gen.Size) gen.Generator[string] {
 	return gen.StringASCII(size)
 }
 
+// StringUnicodeTricky generates strings with NFC/NFD variants, combining marks,
+// bidi controls and zero-width characters; it shrinks toward ASCII.
+func StringUnicodeTricky(size gen.Size) gen.Generator[string] {
+	return gen.StringUnicodeTricky(size)
+}
+
+// LineEnding selects the line terminators of Text.
+type LineEnding = gen.LineEnding
+
+// Line endings for Text.
+const (
+	LineLF    = gen.LineLF    // "\n"
+	LineCRLF  = gen.LineCRLF  // "\r\n"
+	LineMixed = gen.LineMixed // "\n" or "\r\n" per line
+)
+
+// Text generates multi-line text with the given line endings, sometimes
+// without a final terminator.
+func Text(lines gen.Size, lineLen gen.Size, ending gen.LineEnding) gen.Generator[string] {
+	return gen.Text(lines, lineLen, ending)
+}
+
+// BalancedParens generates correctly matched strings of (), [] and {} pairs.
+func BalancedParens(size gen.Size) gen.Generator[string] {
+	return gen.BalancedParens(size)
+}
+
+// UnbalancedParens generates bracket strings that are not correctly matched.
+func UnbalancedParens(size gen.Size) gen.Generator[string] {
+	return gen.UnbalancedParens(size)
+}
+
 // Bool generates random boolean values.
 func Bool() gen.Generator[bool] {
 	return gen.Bool()
 }
 
+// Flags generates bitmasks ORing a random subset of the given flag constants.
+func Flags[T ~uint | ~uint32 | ~uint64](flags ...T) gen.Generator[T] {
+	return gen.Flags(flags...)
+}
+
+// Money represents a monetary amount stored as integer cents plus a currency code.
+type Money = gen.Money
+
+// MoneyOf generates Money values with cents in [min, max] and a fixed currency.
+func MoneyOf(min, max int64, currency string) gen.Generator[Money] {
+	return gen.MoneyOf(min, max, currency)
+}
+
+// Decimal generates decimal strings fitting DECIMAL(precision, scale), e.g. "123.45".
+func Decimal(precision, scale int) gen.Generator[string] {
+	return gen.Decimal(precision, scale)
+}
+
+// FloatBoundary generates finite float64 values where the representation is lossy.
+func FloatBoundary() gen.Generator[float64] {
+	return gen.FloatBoundary()
+}
+
+// HumanDuration generates durations in [0, 24h] biased toward round, human-scale values.
+func HumanDuration() gen.Generator[time.Duration] {
+	return gen.HumanDuration()
+}
+
+// TimeLayout generates valid layouts for time.Format and time.Parse.
+func TimeLayout() gen.Generator[string] {
+	return gen.TimeLayout()
+}
+
 // =============================================================================
 // SLICE GENERATORS
 // =============================================================================
@@ -194,6 +400,112 @@ func SliceOf[T any](g gen.Generator[T], size gen.Size) gen.Generator[[]T] {
 	return gen.SliceOf(g, size)
 }
 
+// SliceOfIndexed generates slices where element i is drawn from f(i).
+func SliceOfIndexed[T any](f func(i int) gen.Generator[T], size gen.Size) gen.Generator[[]T] {
+	return gen.SliceOfIndexed(f, size)
+}
+
+// SliceOfLen generates slices whose length is drawn from lenGen.
+func SliceOfLen[T any](g gen.Generator[T], lenGen gen.Generator[int]) gen.Generator[[]T] {
+	return gen.SliceOfLen(g, lenGen)
+}
+
+// NearlySorted generates slices sorted by less except for at most inversions
+// out-of-order pairs, for testing adaptive sorting algorithms.
+func NearlySorted[T any](g gen.Generator[T], less func(a, b T) bool, inversions int, size gen.Size) gen.Generator[[]T] {
+	return gen.NearlySorted(g, less, inversions, size)
+}
+
+// SliceLenBias generates lengths for SliceOfLen: 0, 1 or 2..32 with the given
+// relative weights.
+func SliceLenBias(empty, single, many float64) gen.Generator[int] {
+	return gen.SliceLenBias(empty, single, many)
+}
+
+// BinaryHeap generates slices satisfying the binary min-heap property for less.
+func BinaryHeap[T any](g gen.Generator[T], less func(a, b T) bool, size gen.Size) gen.Generator[[]T] {
+	return gen.BinaryHeap(g, less, size)
+}
+
+// Partition generates groups sub-slices whose concatenation is a generated slice.
+func Partition[T any](g gen.Generator[T], groups int, size gen.Size) gen.Generator[[][]T] {
+	return gen.Partition(g, groups, size)
+}
+
+// SliceWithDuplicates generates slices where some elements deliberately repeat,
+// with dupRatio controlling how often a position copies an earlier element.
+func SliceWithDuplicates[T comparable](g gen.Generator[T], size gen.Size, dupRatio float64) gen.Generator[[]T] {
+	return gen.SliceWithDuplicates(g, size, dupRatio)
+}
+
+// SliceContaining generates slices from g that always hold must at least once.
+func SliceContaining[T any](g gen.Generator[T], must T, size gen.Size) gen.Generator[[]T] {
+	return gen.SliceContaining(g, must, size)
+}
+
+// TimedEvent is an event of an EventLog with its timestamp.
+type TimedEvent[E any] = gen.TimedEvent[E]
+
+// EventLog generates event logs with non-decreasing timestamps.
+func EventLog[E any](eventGen gen.Generator[E], timeGen gen.Generator[time.Time], size gen.Size) gen.Generator[[]TimedEvent[E]] {
+	return gen.EventLog(eventGen, timeGen, size)
+}
+
+// Graph is a generated graph over the nodes 0..Nodes-1.
+type Graph = gen.Graph
+
+// Edge is an edge of a Graph.
+type Edge = gen.Edge
+
+// GraphOf generates graphs where each possible edge is present with probability edgeProb.
+func GraphOf(nodes int, edgeProb float64, directed bool) gen.Generator[Graph] {
+	return gen.GraphOf(nodes, edgeProb, directed)
+}
+
+// DAG generates directed acyclic graphs where each allowed edge is present with probability edgeProb.
+func DAG(nodes int, edgeProb float64) gen.Generator[Graph] {
+	return gen.DAG(nodes, edgeProb)
+}
+
+// TreeNode is a node of a binary tree; a nil *TreeNode is the empty tree.
+type TreeNode[T any] = gen.TreeNode[T]
+
+// BinaryTree generates degenerate, balanced and random binary trees up to maxDepth levels.
+func BinaryTree[T any](g gen.Generator[T], maxDepth int) gen.Generator[*TreeNode[T]] {
+	return gen.BinaryTree(g, maxDepth)
+}
+
+// BalancedBinaryTree generates height-balanced binary trees up to maxDepth levels.
+func BalancedBinaryTree[T any](g gen.Generator[T], maxDepth int) gen.Generator[*TreeNode[T]] {
+	return gen.BalancedBinaryTree(g, maxDepth)
+}
+
+// Node is a node of a tree of any arity, carrying a label.
+type Node[L any] = gen.Node[L]
+
+// LabeledTree generates trees of labels where each node has up to
+// branchingFactor children, up to maxDepth levels.
+func LabeledTree[L any](labelGen gen.Generator[L], branchingFactor, maxDepth int) gen.Generator[*Node[L]] {
+	return gen.LabeledTree(labelGen, branchingFactor, maxDepth)
+}
+
+// EnvMap generates environment-style maps with valid variable names as keys.
+func EnvMap(size gen.Size) gen.Generator[map[string]string] {
+	return gen.EnvMap(size)
+}
+
+// JSONMatching generates JSON values conforming to a JSON Schema (a practical
+// subset), returning an error for unsupported keywords.
+func JSONMatching(schema string) (gen.Generator[any], error) {
+	return gen.JSONMatching(schema)
+}
+
+// MatchesJSONSchema reports whether v matches a JSON Schema, in the subset
+// JSONMatching supports.
+func MatchesJSONSchema(schema string, v any) (bool, error) {
+	return gen.MatchesJSONSchema(schema, v)
+}
+
 // =============================================================================
 // COMBINATOR GENERATORS
 // =============================================================================
@@ -203,6 +515,23 @@ func OneOf[T any](generators ...gen.Generator[T]) gen.Generator[T] {
 	return gen.OneOf(generators...)
 }
 
+// Freq is a generator with its relative weight, for Frequency.
+type Freq[T any] = gen.Freq[T]
+
+// FrequencyOption configures Frequency.
+type FrequencyOption = gen.FrequencyOption
+
+// Frequency chooses a generator with probability proportional to its weight.
+func Frequency[T any](choices []Freq[T], opts ...FrequencyOption) gen.Generator[T] {
+	return gen.Frequency(choices, opts...)
+}
+
+// PreferFrequent makes Frequency shrink a counterexample toward the more
+// frequent branches that still fail.
+func PreferFrequent() FrequencyOption {
+	return gen.PreferFrequent()
+}
+
 // Const always returns the same value (without shrinking).
 func Const[T any](v T) gen.Generator[T] {
 	return gen.Const(v)
@@ -223,6 +552,61 @@ func Bind[A, B any](ga gen.Generator[A], f func(A) gen.Generator[B]) gen.Generat
 	return gen.Bind(ga, f)
 }
 
+// Draw records the values pulled inside a Do body.
+type Draw = gen.Draw
+
+// Do builds a generator imperatively, pulling values with DrawFrom.
+func Do[T any](f func(d *Draw) T) gen.Generator[T] {
+	return gen.Do(f)
+}
+
+// Decoder is a byte stream that Decode functions read their choices from.
+type Decoder = gen.Decoder
+
+// NewDecoder returns a Decoder reading data, then zeros (e.g. a fuzzing input).
+func NewDecoder(data []byte) *Decoder {
+	return gen.NewDecoder(data)
+}
+
+// Decode builds a generator from a function decoding a seeded byte stream;
+// values shrink by shrinking the stream (prototype).
+func Decode[T any](f func(d *Decoder) T) gen.Generator[T] {
+	return gen.Decode(f)
+}
+
+// DrawFrom pulls a value from g inside a Do body and records it for shrinking.
+func DrawFrom[T any](d *Draw, g gen.Generator[T]) T {
+	return gen.DrawFrom(d, g)
+}
+
+// ToAny lifts a typed generator into a Generator[any], preserving shrinking.
+func ToAny[T any](g gen.Generator[T]) gen.Generator[any] {
+	return gen.ToAny(g)
+}
+
+// AnyOf chooses uniformly among type-erased generators.
+func AnyOf(generators ...gen.Generator[any]) gen.Generator[any] {
+	return gen.AnyOf(generators...)
+}
+
+// Freeze caches the first value generated by g and never shrinks it.
+func Freeze[T any](g gen.Generator[T]) gen.Generator[T] {
+	return gen.Freeze(g)
+}
+
+// EncodedPair holds a generated value with its encoding and decoding.
+type EncodedPair[T any] = gen.EncodedPair[T]
+
+// Encoded generates values together with their string encoding for round-trip tests.
+func Encoded[T any](g gen.Generator[T], encode func(T) string, decode func(string) (T, error)) gen.Generator[EncodedPair[T]] {
+	return gen.Encoded(g, encode, decode)
+}
+
+// Label attaches a name to a generator, used by the runner in diagnostics.
+func Label[T any](g gen.Generator[T], name string) gen.Generator[T] {
+	return gen.Label(g, name)
+}
+
 // =============================================================================
 // PAIR GENERATORS
 // =============================================================================
@@ -258,6 +642,54 @@ func TupleOf[A, B any](ga gen.Generator[A], gb gen.Generator[B]) gen.Generator[T
 	return gen.TupleOf(ga, gb)
 }
 
+// Coupled generates pairs of a value from ga and derive's result for it;
+// shrinking shrinks the value and re-derives the second component.
+func Coupled[A, B any](ga gen.Generator[A], derive func(A) B) gen.Generator[Pair[A, B]] {
+	return gen.Coupled(ga, derive)
+}
+
+// Interval is a range of values from Start to End, with Start not after End.
+type Interval[T any] = gen.Interval[T]
+
+// IntervalOf generates intervals of two values from g ordered by less;
+// shrinking tries collapsing to a point, then shrinks both ends in turns.
+func IntervalOf[T any](g gen.Generator[T], less func(a, b T) bool) gen.Generator[Interval[T]] {
+	return gen.IntervalOf(g, less)
+}
+
+// Either is a tagged union holding a Left value of type L or a Right value of type R.
+type Either[L, R any] = gen.Either[L, R]
+
+// EitherOf generates Left values from gl with probability leftProb, otherwise Right values from gr.
+func EitherOf[L, R any](gl gen.Generator[L], gr gen.Generator[R], leftProb float64) gen.Generator[Either[L, R]] {
+	return gen.EitherOf(gl, gr, leftProb)
+}
+
+// =============================================================================
+// STRUCT GENERATORS
+// =============================================================================
+
+// Fields maps exported struct field names to type-erased field generators (see ToAny).
+type Fields = gen.Fields
+
+// StructOption configures Struct.
+type StructOption = gen.StructOption
+
+// Struct generates values of the struct type T, filling each named field from its generator.
+func Struct[T any](fields Fields, opts ...StructOption) gen.Generator[T] {
+	return gen.Struct[T](fields, opts...)
+}
+
+// ShrinkOrder makes Struct shrink the named fields first, in the given order.
+func ShrinkOrder(fieldNames ...string) StructOption {
+	return gen.ShrinkOrder(fieldNames...)
+}
+
+// PartialStruct generates values of the struct type T with a random subset of fields set.
+func PartialStruct[T any]() gen.Generator[T] {
+	return gen.PartialStruct[T]()
+}
+
 // =============================================================================
 // CUSTOM GENERATORS
 // =============================================================================
@@ -267,6 +699,54 @@ func From[T any](fn func(*rand.Rand, Size) (T, Shrinker[T])) gen.Generator[T] {
 	return gen.From(fn)
 }
 
+// FromIter creates a Generator from a function returning a value and its
+// progressively simpler shrink candidates as a lazy sequence.
+func FromIter[T any](fn func(*rand.Rand, Size) (T, iter.Seq[T])) gen.Generator[T] {
+	return gen.FromIter(fn)
+}
+
+// ShrinkBool returns the shrinker Bool uses for v, for custom generators.
+func ShrinkBool(v bool) Shrinker[bool] {
+	return gen.ShrinkBool(v)
+}
+
+// ShrinkInt returns a shrinker for v toward 0, for custom generators.
+func ShrinkInt(v int) Shrinker[int] {
+	return gen.ShrinkInt(v)
+}
+
+// ShrinkIntRange returns the shrinker IntRange uses for v, staying in [min, max].
+func ShrinkIntRange(v, min, max int) Shrinker[int] {
+	return gen.ShrinkIntRange(v, min, max)
+}
+
+// ShrinkInt64 returns a shrinker for v toward 0, for custom generators.
+func ShrinkInt64(v int64) Shrinker[int64] {
+	return gen.ShrinkInt64(v)
+}
+
+// ShrinkFloat64 returns a shrinker for v toward 0, never proposing NaN or ±Inf.
+func ShrinkFloat64(v float64) Shrinker[float64] {
+	return gen.ShrinkFloat64(v)
+}
+
+// ShrinkString returns a shrinker for s toward "", for custom generators.
+func ShrinkString(s string) Shrinker[string] {
+	return gen.ShrinkString(s)
+}
+
+// ShrinkSlice returns a shrinker for s that removes elements, then shrinks
+// the remaining ones with elem (nil skips element shrinking).
+func ShrinkSlice[T any](s []T, elem func(T) Shrinker[T]) Shrinker[[]T] {
+	return gen.ShrinkSlice(s, elem)
+}
+
+// ShrinkNeighbors returns a shrinker for start that proposes the candidates
+// pushed by neighbors, rebasing on each accepted one, for custom generators.
+func ShrinkNeighbors[S any, K comparable, T any](start S, key func(S) K, render func(S) T, neighbors func(base S, push func(S))) Shrinker[T] {
+	return gen.ShrinkNeighbors(start, key, render, neighbors)
+}
+
 // =============================================================================
 // DOMAIN-SPECIFIC GENERATORS
 // =============================================================================
@@ -298,6 +778,51 @@ func UnmaskCPF(s string) string {
 	return domain.UnmaskCPF(s)
 }
 
+// Boleto generates valid Brazilian bank slip lines (linha digitável), unmasked.
+func Boleto() gen.Generator[string] {
+	return domain.Boleto()
+}
+
+// BoletoInvalid generates boleto lines with one digit wrong, so a check digit fails.
+func BoletoInvalid() gen.Generator[string] {
+	return domain.BoletoInvalid()
+}
+
+// ValidBoleto validates if a string is a valid boleto line, masked or not.
+func ValidBoleto(s string) bool {
+	return domain.ValidBoleto(s)
+}
+
+// MaskBoleto formats a raw boleto line with dots and spaces.
+func MaskBoleto(raw string) string {
+	return domain.MaskBoleto(raw)
+}
+
+// UnmaskBoleto removes formatting from a boleto line.
+func UnmaskBoleto(s string) string {
+	return domain.UnmaskBoleto(s)
+}
+
+// LatLng is a geographic coordinate in decimal degrees.
+type LatLng = domain.LatLng
+
+// BoundingBox restricts generated coordinates to a rectangle (inclusive).
+type BoundingBox = domain.BoundingBox
+
+// LatLngOptions controls LatLngOf.
+type LatLngOptions = domain.LatLngOptions
+
+// LatLngOf generates valid coordinates rounded to the configured precision,
+// favoring the poles, the antimeridian and the equator.
+func LatLngOf(opts LatLngOptions) gen.Generator[LatLng] {
+	return domain.LatLngOf(opts)
+}
+
+// ValidLatLng reports whether c is within the valid coordinate ranges.
+func ValidLatLng(c LatLng) bool {
+	return domain.ValidLatLng(c)
+}
+
 // =============================================================================
 // TESTING UTILITIES
 // =============================================================================
@@ -307,3 +832,9 @@ func UnmaskCPF(s string) string {
 func Equal[T any](t *testing.T, got, want T) {
 	quick.Equal(t, got, want)
 }
+
+// EqualOpts is like Equal but passes go-cmp options (e.g. cmpopts.EquateApprox).
+func EqualOpts[T any](t *testing.T, got, want T, opts ...cmp.Option) {
+	t.Helper()
+	quick.EqualOpts(t, got, want, opts...)
+}
@@ -25,7 +25,19 @@ import (
 //	quick.Equal(t, map[string]int{"a": 1}, map[string]int{"a": 1})
 func Equal[T any](t *testing.T, got, want T) {
 	t.Helper()
-	if diff := cmp.Diff(want, got); diff != "" {
+	EqualOpts(t, got, want)
+}
+
+// EqualOpts is like Equal but passes opts to go-cmp, e.g. to ignore fields or
+// compare floats approximately.
+//
+// Example usage:
+//
+//	quick.EqualOpts(t, got, want, cmpopts.EquateApprox(0, 1e-9))
+//	quick.EqualOpts(t, got, want, cmpopts.IgnoreFields(User{}, "UpdatedAt"))
+func EqualOpts[T any](t *testing.T, got, want T, opts ...cmp.Option) {
+	t.Helper()
+	if diff := cmp.Diff(want, got, opts...); diff != "" {
 		t.Fatalf("mismatch (-want +got):\n%s", diff)
 	}
 }
@@ -5,6 +5,8 @@ package quick
 
 import (
 	"testing"
+
+	"github.com/google/go-cmp/cmp/cmpopts"
 )
 
 // TestEqual tests the Equal function with various data types to ensure
@@ -103,3 +105,23 @@ func TestEqual_WithDifferentTypes(t *testing.T) {
 		Equal(t, []int{1, 2, 3}, []int{1, 2, 4})
 	})
 }
+
+// TestEqualOpts tests that EqualOpts forwards go-cmp options.
+func TestEqualOpts(t *testing.T) {
+	type Point struct {
+		X, Y float64
+		Name string
+	}
+
+	t.Run("approximate floats", func(t *testing.T) {
+		EqualOpts(t, Point{X: 0.1 + 0.2, Y: 1}, Point{X: 0.3, Y: 1}, cmpopts.EquateApprox(0, 1e-9))
+	})
+
+	t.Run("ignored fields", func(t *testing.T) {
+		EqualOpts(t, Point{X: 1, Name: "a"}, Point{X: 1, Name: "b"}, cmpopts.IgnoreFields(Point{}, "Name"))
+	})
+
+	t.Run("no options", func(t *testing.T) {
+		EqualOpts(t, []int{1, 2}, []int{1, 2})
+	})
+}
@@ -7,6 +7,8 @@
 package demo
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 
 	"arcsyn.io/propx"
@@ -39,3 +41,73 @@ func Test_CPF_Invalid(t *testing.T) {
 		}
 	})
 }
+
+// Test_ForAllErr_Falha demonstrates a failing property that returns an error
+// instead of using t. The error of the shrunk counterexample is shown next to
+// it in the failure report.
+func Test_ForAllErr_Falha(t *testing.T) {
+	propx.ForAllErr(t, propx.Default(), propx.IntRange(0, 1000), func(n int) error {
+		if n >= 100 {
+			return fmt.Errorf("value %d exceeds the limit of 100", n)
+		}
+		return nil
+	})
+}
+
+// Test_Idempotent_Falha demonstrates a normalization that is not idempotent:
+// collapsing "--" into "-" once leaves "--" behind for "---". The failure shows
+// the shrunk input and the diff between f(x) and f(f(x)).
+func Test_Idempotent_Falha(t *testing.T) {
+	collapse := func(s string) string { return strings.ReplaceAll(s, "--", "-") }
+	propx.Idempotent(t, propx.Default(), propx.String("a-", propx.Size{Max: 16}), collapse)
+}
+
+// Test_Associative_Falha demonstrates an operation that is commutative but
+// not associative: the average of two integers. The failure shows the shrunk
+// operands and the diff between both groupings.
+func Test_Associative_Falha(t *testing.T) {
+	propx.Associative(t, propx.Default(), propx.IntRange(-100, 100), func(a, b int) int { return (a + b) / 2 })
+}
+
+// Test_Commutative_Falha demonstrates an operation that depends on operand
+// order: subtraction.
+func Test_Commutative_Falha(t *testing.T) {
+	propx.Commutative(t, propx.Default(), propx.IntRange(-100, 100), func(a, b int) int { return a - b })
+}
+
+// Test_Discard_Falha demonstrates Config.MaxDiscardRatio: a property that
+// only cares about multiples of 20 discards most examples, and the run gives
+// up since it checks too few inputs.
+func Test_Discard_Falha(t *testing.T) {
+	propx.ForAll(t, propx.Default(), propx.IntRange(0, 1000))(func(t *testing.T, n int) {
+		if n%20 != 0 {
+			propx.Discard()
+		}
+		if n%5 != 0 {
+			t.Errorf("%d is not a multiple of 5", n)
+		}
+	})
+}
+
+// Test_RoundTrip_Falha demonstrates a lossy encoding: joining words with
+// commas cannot tell a word containing a comma from two words, nor no words
+// from a single empty one. The failure
+// shows the shrunk input, its encoded form and the diff after decoding.
+func Test_RoundTrip_Falha(t *testing.T) {
+	encode := func(words []string) string { return strings.Join(words, ",") }
+	decode := func(s string) ([]string, error) { return strings.Split(s, ","), nil }
+	propx.RoundTrip(t, propx.Default(), propx.SliceOf(propx.String("ab,", propx.Size{Max: 4}), propx.Size{Min: 1, Max: 4}), encode, decode)
+}
+
+// Test_ShrinkPath_Falha demonstrates Config.RecordShrinkPath: the failure
+// report lists the original counterexample and every accepted shrink step
+// down to the minimal one.
+func Test_ShrinkPath_Falha(t *testing.T) {
+	cfg := propx.Default()
+	cfg.RecordShrinkPath = true
+	propx.ForAll(t, cfg, propx.IntRange(0, 1000))(func(t *testing.T, n int) {
+		if n >= 100 {
+			t.Fatalf("value %d exceeds the limit of 100", n)
+		}
+	})
+}